@@ -43,8 +43,11 @@ webhooks:
     - UPDATE
     resources:
     - kongconsumers
+    - kongconsumergroups
     - kongplugins
     - kongclusterplugins
+    - kongvaults
+    - gatewayconfigurations
   - apiGroups:
     - ''
     apiVersions:
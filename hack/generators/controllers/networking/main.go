@@ -73,6 +73,20 @@ var inputControllersNeeded = &typesNeeded{
 		AcceptsIngressClassNameSpec:       false,
 		RBACVerbs:                         []string{"list", "watch"},
 	},
+	typeNeeded{
+		Group:                             "\"\"",
+		Version:                           "v1",
+		Kind:                              "Namespace",
+		PackageImportAlias:                "corev1",
+		PackageAlias:                      "CoreV1",
+		Package:                           corev1,
+		Plural:                            "namespaces",
+		CacheType:                         "Namespace",
+		NeedsStatusPermissions:            false,
+		AcceptsIngressClassNameAnnotation: false,
+		AcceptsIngressClassNameSpec:       false,
+		RBACVerbs:                         []string{"get", "list", "watch"},
+	},
 	typeNeeded{
 		Group:                             "networking.k8s.io",
 		Version:                           "v1",
@@ -156,6 +170,7 @@ var inputControllersNeeded = &typesNeeded{
 		Plural:                            "kongplugins",
 		CacheType:                         "Plugin",
 		NeedsStatusPermissions:            true,
+		ReportsStatusConditions:           true,
 		AcceptsIngressClassNameAnnotation: false,
 		AcceptsIngressClassNameSpec:       false,
 		RBACVerbs:                         []string{"get", "list", "watch"},
@@ -170,6 +185,7 @@ var inputControllersNeeded = &typesNeeded{
 		Plural:                            "kongclusterplugins",
 		CacheType:                         "ClusterPlugin",
 		NeedsStatusPermissions:            true,
+		ReportsStatusConditions:           true,
 		AcceptsIngressClassNameAnnotation: true,
 		AcceptsIngressClassNameSpec:       false,
 		RBACVerbs:                         []string{"get", "list", "watch"},
@@ -184,6 +200,65 @@ var inputControllersNeeded = &typesNeeded{
 		Plural:                            "kongconsumers",
 		CacheType:                         "Consumer",
 		NeedsStatusPermissions:            true,
+		ReportsStatusConditions:           true,
+		AcceptsIngressClassNameAnnotation: true,
+		AcceptsIngressClassNameSpec:       false,
+		RBACVerbs:                         []string{"get", "list", "watch"},
+	},
+	typeNeeded{
+		Group:                             "configuration.konghq.com",
+		Version:                           "v1beta1",
+		Kind:                              "KongConsumerGroup",
+		PackageImportAlias:                "kongv1beta1",
+		PackageAlias:                      "KongV1Beta1",
+		Package:                           kongv1beta1,
+		Plural:                            "kongconsumergroups",
+		CacheType:                         "ConsumerGroup",
+		NeedsStatusPermissions:            false,
+		AcceptsIngressClassNameAnnotation: true,
+		AcceptsIngressClassNameSpec:       false,
+		RBACVerbs:                         []string{"get", "list", "watch"},
+	},
+	typeNeeded{
+		Group:                             "configuration.konghq.com",
+		Version:                           "v1beta1",
+		Kind:                              "KongUpstreamPolicy",
+		PackageImportAlias:                "kongv1beta1",
+		PackageAlias:                      "KongV1Beta1",
+		Package:                           kongv1beta1,
+		Plural:                            "kongupstreampolicies",
+		CacheType:                         "UpstreamPolicy",
+		NeedsStatusPermissions:            true,
+		ReportsStatusConditions:           true,
+		AcceptsIngressClassNameAnnotation: false,
+		AcceptsIngressClassNameSpec:       false,
+		RBACVerbs:                         []string{"get", "list", "watch"},
+	},
+	typeNeeded{
+		Group:                             "configuration.konghq.com",
+		Version:                           "v1beta1",
+		Kind:                              "KongServicePolicy",
+		PackageImportAlias:                "kongv1beta1",
+		PackageAlias:                      "KongV1Beta1",
+		Package:                           kongv1beta1,
+		Plural:                            "kongservicepolicies",
+		CacheType:                         "ServicePolicy",
+		NeedsStatusPermissions:            true,
+		ReportsStatusConditions:           true,
+		AcceptsIngressClassNameAnnotation: false,
+		AcceptsIngressClassNameSpec:       false,
+		RBACVerbs:                         []string{"get", "list", "watch"},
+	},
+	typeNeeded{
+		Group:                             "configuration.konghq.com",
+		Version:                           "v1beta1",
+		Kind:                              "KongVault",
+		PackageImportAlias:                "kongv1beta1",
+		PackageAlias:                      "KongV1Beta1",
+		Package:                           kongv1beta1,
+		Plural:                            "kongvaults",
+		CacheType:                         "Vault",
+		NeedsStatusPermissions:            false,
 		AcceptsIngressClassNameAnnotation: true,
 		AcceptsIngressClassNameSpec:       false,
 		RBACVerbs:                         []string{"get", "list", "watch"},
@@ -355,6 +430,12 @@ type typeNeeded struct {
 	// CapableOfStatusUpdates indicates that the controllers should manage status
 	// updates for the resource.
 	CapableOfStatusUpdates bool
+
+	// ReportsStatusConditions indicates that the controllers should update the
+	// status conditions of the resource once the data-plane has been configured,
+	// using an updateStatus() method of the reconciler which is implemented
+	// alongside the generated controllers.
+	ReportsStatusConditions bool
 }
 
 func (t *typeNeeded) generate(contents *bytes.Buffer) error {
@@ -446,6 +527,9 @@ type {{.PackageAlias}}{{.Kind}}Reconciler struct {
 
 	DataplaneAddressFinder *dataplane.AddressFinder
 	StatusQueue            *status.Queue
+{{- else if .ReportsStatusConditions }}
+
+	StatusQueue *status.Queue
 {{- end}}
 {{- if or .AcceptsIngressClassNameSpec .AcceptsIngressClassNameAnnotation}}
 
@@ -466,7 +550,7 @@ func (r *{{.PackageAlias}}{{.Kind}}Reconciler) SetupWithManager(mgr ctrl.Manager
 		return err
 	}
 
-{{- if .CapableOfStatusUpdates}}
+{{- if or .CapableOfStatusUpdates .ReportsStatusConditions}}
 	// if configured, start the status updater controller
 	if r.StatusQueue != nil {
 		if err := c.Watch(
@@ -621,6 +705,13 @@ func (r *{{.PackageAlias}}{{.Kind}}Reconciler) Reconcile(ctx context.Context, re
 		}
 	}
 {{- end}}
+{{- if .ReportsStatusConditions}}
+	// if status updates are enabled report the status for the object
+	if r.DataplaneClient.AreKubernetesObjectReportsEnabled() {
+		log.V(util.DebugLevel).Info("updating the status conditions of the object", "namespace", req.Namespace, "name", req.Name)
+		return r.updateStatus(ctx, obj)
+	}
+{{- end}}
 
 	return ctrl.Result{}, nil
 }
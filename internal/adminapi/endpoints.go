@@ -0,0 +1,64 @@
+package adminapi
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// DefaultAdminAPIServicePortNames are the names of the ports on the Kong Admin
+// API Service which are considered to be serving the Admin API when discovering
+// Admin API endpoints, unless a different set of names is provided.
+var DefaultAdminAPIServicePortNames = []string{"admin", "admin-tls", "kong-admin", "kong-admin-tls"}
+
+// GetURLsForEndpoints produces the Admin API URLs of all the ready addresses in
+// the provided Endpoints for ports which have one of the provided names. Ports
+// whose names include "tls" are expected to serve the Admin API over HTTPS.
+// Each address is a single proxy instance, so only one URL is produced per
+// address even when it serves the Admin API on several ports, preferring the
+// ports which use HTTPS. The resulting URLs are sorted.
+func GetURLsForEndpoints(endpoints *corev1.Endpoints, portNames sets.String) []string {
+	urlsByAddress := make(map[string]string)
+	for _, subset := range endpoints.Subsets {
+		for _, port := range subset.Ports {
+			if !portNames.Has(port.Name) {
+				continue
+			}
+
+			scheme := "http"
+			if strings.Contains(port.Name, "tls") {
+				scheme = "https"
+			}
+
+			// only the ready addresses are considered, as proxy instances which
+			// are not yet ready aren't expected to accept configuration.
+			for _, address := range subset.Addresses {
+				url := fmt.Sprintf("%s://%s", scheme, net.JoinHostPort(address.IP, strconv.Itoa(int(port.Port))))
+				if existing, ok := urlsByAddress[address.IP]; !ok || preferURL(url, existing) {
+					urlsByAddress[address.IP] = url
+				}
+			}
+		}
+	}
+
+	urls := sets.NewString()
+	for _, url := range urlsByAddress {
+		urls.Insert(url)
+	}
+	return urls.List()
+}
+
+// preferURL indicates whether the Admin API URL a should be used rather than
+// b for the same address: HTTPS is preferred, then the lowest URL so that the
+// choice is stable.
+func preferURL(a, b string) bool {
+	aTLS, bTLS := strings.HasPrefix(a, "https://"), strings.HasPrefix(b, "https://")
+	if aTLS != bTLS {
+		return aTLS
+	}
+	return a < b
+}
@@ -0,0 +1,100 @@
+package adminapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestGetURLsForEndpoints(t *testing.T) {
+	portNames := sets.NewString(DefaultAdminAPIServicePortNames...)
+
+	for _, tt := range []struct {
+		name      string
+		endpoints *corev1.Endpoints
+		want      []string
+	}{
+		{
+			name:      "no subsets produces no urls",
+			endpoints: &corev1.Endpoints{},
+			want:      []string{},
+		},
+		{
+			name: "only ready addresses on admin ports are used",
+			endpoints: &corev1.Endpoints{
+				Subsets: []corev1.EndpointSubset{
+					{
+						Addresses: []corev1.EndpointAddress{
+							{IP: "10.0.0.2"},
+							{IP: "10.0.0.1"},
+						},
+						NotReadyAddresses: []corev1.EndpointAddress{
+							{IP: "10.0.0.3"},
+						},
+						Ports: []corev1.EndpointPort{
+							{Name: "admin", Port: 8001},
+							{Name: "proxy", Port: 8000},
+						},
+					},
+				},
+			},
+			want: []string{
+				"http://10.0.0.1:8001",
+				"http://10.0.0.2:8001",
+			},
+		},
+		{
+			name: "tls ports use https and ipv6 addresses are bracketed",
+			endpoints: &corev1.Endpoints{
+				Subsets: []corev1.EndpointSubset{
+					{
+						Addresses: []corev1.EndpointAddress{
+							{IP: "fd00::1"},
+						},
+						Ports: []corev1.EndpointPort{
+							{Name: "admin-tls", Port: 8444},
+						},
+					},
+				},
+			},
+			want: []string{
+				"https://[fd00::1]:8444",
+			},
+		},
+		{
+			name: "addresses serving the admin api on several ports are used once, preferring https",
+			endpoints: &corev1.Endpoints{
+				Subsets: []corev1.EndpointSubset{
+					{
+						Addresses: []corev1.EndpointAddress{
+							{IP: "10.0.0.1"},
+						},
+						Ports: []corev1.EndpointPort{
+							{Name: "admin", Port: 8001},
+							{Name: "admin-tls", Port: 8444},
+						},
+					},
+					{
+						Addresses: []corev1.EndpointAddress{
+							{IP: "10.0.0.1"},
+							{IP: "10.0.0.2"},
+						},
+						Ports: []corev1.EndpointPort{
+							{Name: "kong-admin", Port: 8001},
+						},
+					},
+				},
+			},
+			want: []string{
+				"http://10.0.0.2:8001",
+				"https://10.0.0.1:8444",
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, GetURLsForEndpoints(tt.endpoints, portNames))
+		})
+	}
+}
@@ -6,6 +6,9 @@ const (
 	ErrTextConsumerExists                     = "consumer already exists"
 	ErrTextConsumerUnretrievable              = "failed to fetch consumer from kong"
 	ErrTextConsumerUsernameEmpty              = "username cannot be empty"
+	ErrTextConsumerGroupNameConflict          = "consumer group name %q is already used by KongConsumerGroup %s/%s"
+	ErrTextConsumerGroupUnretrievable         = "failed to fetch consumer groups from the kubernetes API"
+	ErrTextConsumerGroupsUnsupported          = "consumer groups are only supported by DB-less data-planes"
 	ErrTextFailedToRetrieveSecret             = "could not retrieve secrets from the kubernets API" //nolint:gosec
 	ErrTextPluginConfigInvalid                = "could not parse plugin configuration"
 	ErrTextPluginConfigValidationFailed       = "unable to validate plugin schema"
@@ -13,6 +16,9 @@ const (
 	ErrTextPluginNameEmpty                    = "plugin name cannot be empty"
 	ErrTextPluginSecretConfigUnretrievable    = "could not load secret plugin configuration"
 	ErrTextPluginUsesBothConfigTypes          = "plugin cannot use both Config and ConfigFrom"
+	ErrTextVaultConfigInvalid                 = "could not parse vault configuration"
+	ErrTextVaultPrefixConflict                = "vault prefix %q is already used by KongVault %s"
+	ErrTextVaultUnretrievable                 = "failed to fetch vaults from the kubernetes API"
 )
 
 const (
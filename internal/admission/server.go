@@ -18,6 +18,7 @@ import (
 	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	configuration "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+	configurationv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
 )
 
 var (
@@ -170,6 +171,31 @@ var (
 		Version:  configuration.SchemeGroupVersion.Version,
 		Resource: "kongclusterplugins",
 	}
+	consumerGroupGVResource = meta.GroupVersionResource{
+		Group:    configurationv1beta1.SchemeGroupVersion.Group,
+		Version:  configurationv1beta1.SchemeGroupVersion.Version,
+		Resource: "kongconsumergroups",
+	}
+	vaultGVResource = meta.GroupVersionResource{
+		Group:    configurationv1beta1.SchemeGroupVersion.Group,
+		Version:  configurationv1beta1.SchemeGroupVersion.Version,
+		Resource: "kongvaults",
+	}
+	upstreamPolicyGVResource = meta.GroupVersionResource{
+		Group:    configurationv1beta1.SchemeGroupVersion.Group,
+		Version:  configurationv1beta1.SchemeGroupVersion.Version,
+		Resource: "kongupstreampolicies",
+	}
+	servicePolicyGVResource = meta.GroupVersionResource{
+		Group:    configurationv1beta1.SchemeGroupVersion.Group,
+		Version:  configurationv1beta1.SchemeGroupVersion.Version,
+		Resource: "kongservicepolicies",
+	}
+	gatewayConfigurationGVResource = meta.GroupVersionResource{
+		Group:    configurationv1beta1.SchemeGroupVersion.Group,
+		Version:  configurationv1beta1.SchemeGroupVersion.Version,
+		Resource: "gatewayconfigurations",
+	}
 	secretGVResource = meta.GroupVersionResource{
 		Group:    corev1.SchemeGroupVersion.Group,
 		Version:  corev1.SchemeGroupVersion.Version,
@@ -257,6 +283,71 @@ func (a RequestHandler) handleValidation(ctx context.Context, request admission.
 		if err != nil {
 			return nil, err
 		}
+	case consumerGroupGVResource:
+		group := configurationv1beta1.KongConsumerGroup{}
+		deserializer := codecs.UniversalDeserializer()
+		_, _, err = deserializer.Decode(request.Object.Raw,
+			nil, &group)
+		if err != nil {
+			return nil, err
+		}
+
+		ok, message, err = a.Validator.ValidateConsumerGroup(ctx, group)
+		if err != nil {
+			return nil, err
+		}
+	case vaultGVResource:
+		vault := configurationv1beta1.KongVault{}
+		deserializer := codecs.UniversalDeserializer()
+		_, _, err = deserializer.Decode(request.Object.Raw,
+			nil, &vault)
+		if err != nil {
+			return nil, err
+		}
+
+		ok, message, err = a.Validator.ValidateVault(ctx, vault)
+		if err != nil {
+			return nil, err
+		}
+	case upstreamPolicyGVResource:
+		policy := configurationv1beta1.KongUpstreamPolicy{}
+		deserializer := codecs.UniversalDeserializer()
+		_, _, err = deserializer.Decode(request.Object.Raw,
+			nil, &policy)
+		if err != nil {
+			return nil, err
+		}
+
+		ok, message, err = a.Validator.ValidateUpstreamPolicy(ctx, policy)
+		if err != nil {
+			return nil, err
+		}
+	case servicePolicyGVResource:
+		policy := configurationv1beta1.KongServicePolicy{}
+		deserializer := codecs.UniversalDeserializer()
+		_, _, err = deserializer.Decode(request.Object.Raw,
+			nil, &policy)
+		if err != nil {
+			return nil, err
+		}
+
+		ok, message, err = a.Validator.ValidateServicePolicy(ctx, policy)
+		if err != nil {
+			return nil, err
+		}
+	case gatewayConfigurationGVResource:
+		config := configurationv1beta1.GatewayConfiguration{}
+		deserializer := codecs.UniversalDeserializer()
+		_, _, err = deserializer.Decode(request.Object.Raw,
+			nil, &config)
+		if err != nil {
+			return nil, err
+		}
+
+		ok, message, err = a.Validator.ValidateGatewayConfiguration(ctx, config)
+		if err != nil {
+			return nil, err
+		}
 	case secretGVResource:
 		secret := corev1.Secret{}
 		deserializer := codecs.UniversalDeserializer()
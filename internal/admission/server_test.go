@@ -18,6 +18,7 @@ import (
 	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	configuration "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+	configurationv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
 )
 
 var decoder = codecs.UniversalDeserializer()
@@ -55,6 +56,26 @@ func (v KongFakeValidator) ValidateHTTPRoute(ctx context.Context, gateway gatewa
 	return v.Result, v.Message, v.Error
 }
 
+func (v KongFakeValidator) ValidateConsumerGroup(ctx context.Context, group configurationv1beta1.KongConsumerGroup) (bool, string, error) {
+	return v.Result, v.Message, v.Error
+}
+
+func (v KongFakeValidator) ValidateVault(ctx context.Context, vault configurationv1beta1.KongVault) (bool, string, error) {
+	return v.Result, v.Message, v.Error
+}
+
+func (v KongFakeValidator) ValidateUpstreamPolicy(ctx context.Context, policy configurationv1beta1.KongUpstreamPolicy) (bool, string, error) {
+	return v.Result, v.Message, v.Error
+}
+
+func (v KongFakeValidator) ValidateServicePolicy(ctx context.Context, policy configurationv1beta1.KongServicePolicy) (bool, string, error) {
+	return v.Result, v.Message, v.Error
+}
+
+func (v KongFakeValidator) ValidateGatewayConfiguration(ctx context.Context, config configurationv1beta1.GatewayConfiguration) (bool, string, error) {
+	return v.Result, v.Message, v.Error
+}
+
 func TestServeHTTPBasic(t *testing.T) {
 	assert := assert.New(t)
 	res := httptest.NewRecorder()
@@ -324,6 +345,65 @@ func TestValidationWebhook(t *testing.T) {
 					Result:  &metav1.Status{},
 				},
 			},
+			{
+				name: "validate kong upstream policy",
+				reqBody: dedent.Dedent(`
+					{
+						"kind": "AdmissionReview",
+						"apiVersion": "` + apiVersion + `",
+						"request": {
+							"uid": "b2df61dd-ab5b-4cb4-9be0-878533c83892",
+							"resource": {
+								"group": "configuration.konghq.com",
+								"version": "v1beta1",
+								"resource": "kongupstreampolicies"
+							},
+							"object": {
+								"apiVersion": "configuration.konghq.com/v1beta1",
+								"kind": "KongUpstreamPolicy"
+							},
+						"operation": "CREATE"
+						}
+					}`),
+				validator:    KongFakeValidator{Result: true},
+				wantRespCode: http.StatusOK,
+				wantSuccessResponse: admission.AdmissionResponse{
+					UID:     "b2df61dd-ab5b-4cb4-9be0-878533c83892",
+					Allowed: true,
+					Result:  &metav1.Status{},
+				},
+			},
+			{
+				name: "validate kong service policy invalid",
+				reqBody: dedent.Dedent(`
+					{
+						"kind": "AdmissionReview",
+						"apiVersion": "` + apiVersion + `",
+						"request": {
+							"uid": "b2df61dd-ab5b-4cb4-9be0-878533c83892",
+							"resource": {
+								"group": "configuration.konghq.com",
+								"version": "v1beta1",
+								"resource": "kongservicepolicies"
+							},
+							"object": {
+								"apiVersion": "configuration.konghq.com/v1beta1",
+								"kind": "KongServicePolicy"
+							},
+						"operation": "UPDATE"
+						}
+					}`),
+				validator:    KongFakeValidator{Result: false, Message: "policy is not valid"},
+				wantRespCode: http.StatusOK,
+				wantSuccessResponse: admission.AdmissionResponse{
+					UID:     "b2df61dd-ab5b-4cb4-9be0-878533c83892",
+					Allowed: false,
+					Result: &metav1.Status{
+						Code:    http.StatusBadRequest,
+						Message: "policy is not valid",
+					},
+				},
+			},
 		} {
 			t.Run(fmt.Sprintf("%s/%s", apiVersion, tt.name), func(t *testing.T) {
 				// arrange
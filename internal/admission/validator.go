@@ -18,17 +18,24 @@ import (
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
 	credsvalidation "github.com/kong/kubernetes-ingress-controller/v2/internal/validation/consumers/credentials"
 	gatewayvalidators "github.com/kong/kubernetes-ingress-controller/v2/internal/validation/gateway"
+	policyvalidators "github.com/kong/kubernetes-ingress-controller/v2/internal/validation/policies"
 	kongv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+	kongv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
 )
 
 // KongValidator validates Kong entities.
 type KongValidator interface {
 	ValidateConsumer(ctx context.Context, consumer kongv1.KongConsumer) (bool, string, error)
+	ValidateConsumerGroup(ctx context.Context, group kongv1beta1.KongConsumerGroup) (bool, string, error)
+	ValidateVault(ctx context.Context, vault kongv1beta1.KongVault) (bool, string, error)
+	ValidateUpstreamPolicy(ctx context.Context, policy kongv1beta1.KongUpstreamPolicy) (bool, string, error)
+	ValidateServicePolicy(ctx context.Context, policy kongv1beta1.KongServicePolicy) (bool, string, error)
 	ValidatePlugin(ctx context.Context, plugin kongv1.KongPlugin) (bool, string, error)
 	ValidateClusterPlugin(ctx context.Context, plugin kongv1.KongClusterPlugin) (bool, string, error)
 	ValidateCredential(ctx context.Context, secret corev1.Secret) (bool, string, error)
 	ValidateGateway(ctx context.Context, gateway gatewayv1alpha2.Gateway) (bool, string, error)
 	ValidateHTTPRoute(ctx context.Context, httproute gatewayv1alpha2.HTTPRoute) (bool, string, error)
+	ValidateGatewayConfiguration(ctx context.Context, config kongv1beta1.GatewayConfiguration) (bool, string, error)
 }
 
 // KongHTTPValidator implements KongValidator interface to validate Kong
@@ -41,18 +48,21 @@ type KongHTTPValidator struct {
 	ManagerClient client.Client
 
 	ingressClassMatcher func(*metav1.ObjectMeta, string, annotations.ClassMatching) bool
+	inMemory            bool
 }
 
 // NewKongHTTPValidator provides a new KongHTTPValidator object provided a
 // controller-runtime client which will be used to retrieve reference objects
 // such as consumer credentials secrets. If you do not pass a cached client
 // here, the performance of this validator can get very poor at high scales.
+// The database mode of Kong determines which entities can be configured.
 func NewKongHTTPValidator(
 	consumerSvc kong.AbstractConsumerService,
 	pluginSvc kong.AbstractPluginService,
 	logger logrus.FieldLogger,
 	managerClient client.Client,
 	ingressClass string,
+	dbmode string,
 ) KongHTTPValidator {
 	matcher := annotations.IngressClassValidatorFuncFromObjectMeta(ingressClass)
 	return KongHTTPValidator{
@@ -63,6 +73,7 @@ func NewKongHTTPValidator(
 		ManagerClient: managerClient,
 
 		ingressClassMatcher: matcher,
+		inMemory:            dbmode == "off" || dbmode == "",
 	}
 }
 
@@ -365,6 +376,104 @@ func (validator KongHTTPValidator) ValidateHTTPRoute(
 	return gatewayvalidators.ValidateHTTPRoute(&httproute, managedGateways...)
 }
 
+// ValidateConsumerGroup checks that Kong runs without a database, as consumer
+// groups can only be configured on DB-less data-planes, and that the name of
+// the consumer group in Kong isn't already used by another KongConsumerGroup
+// managed by the controller, as consumer group names are global in Kong.
+func (validator KongHTTPValidator) ValidateConsumerGroup(
+	ctx context.Context,
+	group kongv1beta1.KongConsumerGroup,
+) (bool, string, error) {
+	// ignore consumer groups that are being managed by another controller
+	if !validator.ingressClassMatcher(&group.ObjectMeta, annotations.IngressClassKey, annotations.ExactClassMatch) {
+		return true, "", nil
+	}
+	if !validator.inMemory {
+		return false, ErrTextConsumerGroupsUnsupported, nil
+	}
+
+	groups := &kongv1beta1.KongConsumerGroupList{}
+	if err := validator.ManagerClient.List(ctx, groups, &client.ListOptions{
+		Namespace: corev1.NamespaceAll,
+	}); err != nil {
+		return false, ErrTextConsumerGroupUnretrievable, err
+	}
+	for _, other := range groups.Items {
+		if other.Namespace == group.Namespace && other.Name == group.Name {
+			continue
+		}
+		if !validator.ingressClassMatcher(&other.ObjectMeta, annotations.IngressClassKey, annotations.ExactClassMatch) {
+			continue
+		}
+		if other.KongName() == group.KongName() {
+			return false, fmt.Sprintf(ErrTextConsumerGroupNameConflict, group.KongName(), other.Namespace, other.Name), nil
+		}
+	}
+	return true, "", nil
+}
+
+// ValidateVault checks that the configuration of the vault can be parsed and
+// that its prefix isn't already used by another KongVault managed by the
+// controller, as plugin configurations refer to vaults by prefix.
+func (validator KongHTTPValidator) ValidateVault(
+	ctx context.Context,
+	vault kongv1beta1.KongVault,
+) (bool, string, error) {
+	// ignore vaults that are being managed by another controller
+	if !validator.ingressClassMatcher(&vault.ObjectMeta, annotations.IngressClassKey, annotations.ExactClassMatch) {
+		return true, "", nil
+	}
+
+	if _, err := kongstate.RawConfigToConfiguration(vault.Spec.Config); err != nil {
+		return false, ErrTextVaultConfigInvalid, nil
+	}
+
+	vaults := &kongv1beta1.KongVaultList{}
+	if err := validator.ManagerClient.List(ctx, vaults); err != nil {
+		return false, ErrTextVaultUnretrievable, err
+	}
+	for _, other := range vaults.Items {
+		if other.Name == vault.Name {
+			continue
+		}
+		if !validator.ingressClassMatcher(&other.ObjectMeta, annotations.IngressClassKey, annotations.ExactClassMatch) {
+			continue
+		}
+		if other.Spec.Prefix == vault.Spec.Prefix {
+			return false, fmt.Sprintf(ErrTextVaultPrefixConflict, vault.Spec.Prefix, other.Name), nil
+		}
+	}
+	return true, "", nil
+}
+
+// ValidateUpstreamPolicy checks the settings of a KongUpstreamPolicy which its
+// schema can't express. Like KongIngresses, policies apply to the Services
+// attaching them regardless of the ingress class, so all are validated.
+func (validator KongHTTPValidator) ValidateUpstreamPolicy(
+	_ context.Context, policy kongv1beta1.KongUpstreamPolicy,
+) (bool, string, error) {
+	ok, message := policyvalidators.ValidateKongUpstreamPolicy(&policy)
+	return ok, message, nil
+}
+
+// ValidateServicePolicy checks the settings of a KongServicePolicy which its
+// schema can't express.
+func (validator KongHTTPValidator) ValidateServicePolicy(
+	_ context.Context, policy kongv1beta1.KongServicePolicy,
+) (bool, string, error) {
+	ok, message := policyvalidators.ValidateKongServicePolicy(&policy)
+	return ok, message, nil
+}
+
+// ValidateGatewayConfiguration checks that the settings of a GatewayConfiguration
+// can be applied to the Gateways and routes of the classes referencing it.
+func (validator KongHTTPValidator) ValidateGatewayConfiguration(
+	_ context.Context, config kongv1beta1.GatewayConfiguration,
+) (bool, string, error) {
+	ok, message := gatewayvalidators.ValidateGatewayConfiguration(&config)
+	return ok, message, nil
+}
+
 // -----------------------------------------------------------------------------
 // KongHTTPValidator - Private Methods
 // -----------------------------------------------------------------------------
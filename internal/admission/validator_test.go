@@ -6,12 +6,18 @@ import (
 	"testing"
 
 	"github.com/kong/go-kong/kong"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
 	configurationv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+	configurationv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
 )
 
 type fakePluginSvc struct {
@@ -289,3 +295,197 @@ func TestKongHTTPValidator_ValidateClusterPlugin(t *testing.T) {
 }
 
 func fakeClassMatcher(*metav1.ObjectMeta, string, annotations.ClassMatching) bool { return true }
+
+func TestKongHTTPValidator_ValidateConsumerGroup(t *testing.T) {
+	objectMeta := func(namespace, name, class string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Namespace:   namespace,
+			Name:        name,
+			Annotations: map[string]string{annotations.IngressClassKey: class},
+		}
+	}
+	scheme := runtime.NewScheme()
+	require.NoError(t, configurationv1beta1.AddToScheme(scheme))
+	managerClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&configurationv1beta1.KongConsumerGroup{
+			ObjectMeta: objectMeta("default", "gold", annotations.DefaultIngressClass),
+		},
+		&configurationv1beta1.KongConsumerGroup{
+			ObjectMeta: objectMeta("default", "other-class", "other"),
+			Spec:       configurationv1beta1.KongConsumerGroupSpec{Name: "silver"},
+		},
+	).Build()
+	validator := NewKongHTTPValidator(nil, nil, logrus.New(), managerClient, annotations.DefaultIngressClass, "off")
+
+	for _, tt := range []struct {
+		name        string
+		group       configurationv1beta1.KongConsumerGroup
+		wantOK      bool
+		wantMessage string
+	}{
+		{
+			name:   "updating a consumer group keeps its name",
+			group:  configurationv1beta1.KongConsumerGroup{ObjectMeta: objectMeta("default", "gold", annotations.DefaultIngressClass)},
+			wantOK: true,
+		},
+		{
+			name: "a consumer group name used by another consumer group is rejected",
+			group: configurationv1beta1.KongConsumerGroup{
+				ObjectMeta: objectMeta("other", "premium", annotations.DefaultIngressClass),
+				Spec:       configurationv1beta1.KongConsumerGroupSpec{Name: "gold"},
+			},
+			wantMessage: fmt.Sprintf(ErrTextConsumerGroupNameConflict, "gold", "default", "gold"),
+		},
+		{
+			name: "consumer groups of other classes are ignored",
+			group: configurationv1beta1.KongConsumerGroup{
+				ObjectMeta: objectMeta("other", "premium", annotations.DefaultIngressClass),
+				Spec:       configurationv1beta1.KongConsumerGroupSpec{Name: "silver"},
+			},
+			wantOK: true,
+		},
+		{
+			name:   "consumer groups managed by other controllers are not validated",
+			group:  configurationv1beta1.KongConsumerGroup{ObjectMeta: objectMeta("other", "gold", "other")},
+			wantOK: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, message, err := validator.ValidateConsumerGroup(context.Background(), tt.group)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantMessage, message)
+		})
+	}
+	t.Run("consumer groups are rejected when Kong runs with a database", func(t *testing.T) {
+		validator := NewKongHTTPValidator(nil, nil, logrus.New(), managerClient, annotations.DefaultIngressClass, "postgres")
+		ok, message, err := validator.ValidateConsumerGroup(context.Background(), configurationv1beta1.KongConsumerGroup{
+			ObjectMeta: objectMeta("default", "gold", annotations.DefaultIngressClass),
+		})
+		require.NoError(t, err)
+		assert.False(t, ok)
+		assert.Equal(t, ErrTextConsumerGroupsUnsupported, message)
+	})
+}
+
+func TestKongHTTPValidator_ValidateVault(t *testing.T) {
+	objectMeta := func(name, class string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Name:        name,
+			Annotations: map[string]string{annotations.IngressClassKey: class},
+		}
+	}
+	scheme := runtime.NewScheme()
+	require.NoError(t, configurationv1beta1.AddToScheme(scheme))
+	managerClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&configurationv1beta1.KongVault{
+			ObjectMeta: objectMeta("env", annotations.DefaultIngressClass),
+			Spec:       configurationv1beta1.KongVaultSpec{Backend: "env", Prefix: "my-env"},
+		},
+		&configurationv1beta1.KongVault{
+			ObjectMeta: objectMeta("other-class", "other"),
+			Spec:       configurationv1beta1.KongVaultSpec{Backend: "env", Prefix: "other-env"},
+		},
+	).Build()
+	validator := NewKongHTTPValidator(nil, nil, logrus.New(), managerClient, annotations.DefaultIngressClass, "off")
+
+	for _, tt := range []struct {
+		name        string
+		vault       configurationv1beta1.KongVault
+		wantOK      bool
+		wantMessage string
+	}{
+		{
+			name: "updating a vault keeps its prefix",
+			vault: configurationv1beta1.KongVault{
+				ObjectMeta: objectMeta("env", annotations.DefaultIngressClass),
+				Spec:       configurationv1beta1.KongVaultSpec{Backend: "env", Prefix: "my-env"},
+			},
+			wantOK: true,
+		},
+		{
+			name: "a prefix used by another vault is rejected",
+			vault: configurationv1beta1.KongVault{
+				ObjectMeta: objectMeta("env-copy", annotations.DefaultIngressClass),
+				Spec:       configurationv1beta1.KongVaultSpec{Backend: "env", Prefix: "my-env"},
+			},
+			wantMessage: fmt.Sprintf(ErrTextVaultPrefixConflict, "my-env", "env"),
+		},
+		{
+			name: "vaults of other classes are ignored",
+			vault: configurationv1beta1.KongVault{
+				ObjectMeta: objectMeta("env-copy", annotations.DefaultIngressClass),
+				Spec:       configurationv1beta1.KongVaultSpec{Backend: "env", Prefix: "other-env"},
+			},
+			wantOK: true,
+		},
+		{
+			name: "a config which isn't an object is rejected",
+			vault: configurationv1beta1.KongVault{
+				ObjectMeta: objectMeta("aws", annotations.DefaultIngressClass),
+				Spec: configurationv1beta1.KongVaultSpec{
+					Backend: "aws",
+					Prefix:  "my-aws",
+					Config:  apiextensionsv1.JSON{Raw: []byte(`["region"]`)},
+				},
+			},
+			wantMessage: ErrTextVaultConfigInvalid,
+		},
+		{
+			name: "vaults managed by other controllers are not validated",
+			vault: configurationv1beta1.KongVault{
+				ObjectMeta: objectMeta("env-copy", "other"),
+				Spec:       configurationv1beta1.KongVaultSpec{Backend: "env", Prefix: "my-env"},
+			},
+			wantOK: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, message, err := validator.ValidateVault(context.Background(), tt.vault)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantMessage, message)
+		})
+	}
+}
+
+func TestKongHTTPValidator_ValidatePolicies(t *testing.T) {
+	validator := NewKongHTTPValidator(nil, nil, logrus.New(), nil, annotations.DefaultIngressClass, "off")
+	ctx := context.Background()
+
+	t.Log("verifying that upstream policies are checked beyond their schema")
+	ok, message, err := validator.ValidateUpstreamPolicy(ctx, configurationv1beta1.KongUpstreamPolicy{
+		Spec: configurationv1beta1.KongUpstreamPolicySpec{
+			Algorithm: kong.String("consistent-hashing"),
+			HashOn:    &configurationv1beta1.KongUpstreamHash{Header: kong.String("x-user")},
+		},
+	})
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Empty(t, message)
+
+	ok, message, err = validator.ValidateUpstreamPolicy(ctx, configurationv1beta1.KongUpstreamPolicy{
+		Spec: configurationv1beta1.KongUpstreamPolicySpec{
+			Algorithm: kong.String("round-robin"),
+			HashOn:    &configurationv1beta1.KongUpstreamHash{Header: kong.String("x-user")},
+		},
+	})
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, "hashOn and hashOnFallback require the consistent-hashing algorithm", message)
+
+	t.Log("verifying that service policies are checked beyond their schema")
+	ok, message, err = validator.ValidateServicePolicy(ctx, configurationv1beta1.KongServicePolicy{
+		Spec: configurationv1beta1.KongServicePolicySpec{Protocol: kong.String("https"), Path: kong.String("/api")},
+	})
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Empty(t, message)
+
+	ok, message, err = validator.ValidateServicePolicy(ctx, configurationv1beta1.KongServicePolicy{
+		Spec: configurationv1beta1.KongServicePolicySpec{Protocol: kong.String("tcp"), Path: kong.String("/api")},
+	})
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, "path can't be set for the tcp protocol", message)
+}
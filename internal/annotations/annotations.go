@@ -54,13 +54,16 @@ const (
 	RequestBuffering     = "/request-buffering"
 	ResponseBuffering    = "/response-buffering"
 	HostAliasesKey       = "/host-aliases"
+	UpstreamPolicyKey    = "/upstream-policy"
+	ServicePolicyKey     = "/service-policy"
 
 	// GatewayUnmanagedAnnotation is an annotation used on a Gateway resource to
 	// indicate that the Gateway should be reconciled according to unmanaged
 	// mode.
 	//
-	// NOTE: it's currently required that this annotation be present on all Gateway
-	// resources: "unmanaged" mode is the only supported mode at this time.
+	// Gateways without this annotation are reconciled in managed mode if their
+	// GatewayClass references managed gateway parameters, otherwise the annotation
+	// is added to them with the default proxy Service as its value.
 	GatewayUnmanagedAnnotation = "/gateway-unmanaged"
 
 	// DefaultIngressClass defines the default class used
@@ -243,6 +246,18 @@ func ExtractHostAliases(anns map[string]string) ([]string, bool) {
 	return strings.Split(val, ","), true
 }
 
+// ExtractUpstreamPolicy extracts the name of the KongUpstreamPolicy attached
+// to a Service by the upstream-policy annotation.
+func ExtractUpstreamPolicy(anns map[string]string) string {
+	return anns[AnnotationPrefix+UpstreamPolicyKey]
+}
+
+// ExtractServicePolicy extracts the name of the KongServicePolicy attached to
+// a Service by the service-policy annotation.
+func ExtractServicePolicy(anns map[string]string) string {
+	return anns[AnnotationPrefix+ServicePolicyKey]
+}
+
 // ExtractUnmanagedGatewayMode extracts the value of the unmanaged gateway
 // mode annotation.
 func ExtractUnmanagedGatewayMode(anns map[string]string) (string, bool) {
@@ -684,3 +684,67 @@ func TestExtractHostAliases(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractUpstreamPolicy(t *testing.T) {
+	type args struct {
+		anns map[string]string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "empty",
+			want: "",
+		},
+		{
+			name: "non-empty",
+			args: args{
+				anns: map[string]string{
+					"konghq.com/upstream-policy": "sticky-sessions",
+				},
+			},
+			want: "sticky-sessions",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractUpstreamPolicy(tt.args.anns); got != tt.want {
+				t.Errorf("ExtractUpstreamPolicy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractServicePolicy(t *testing.T) {
+	type args struct {
+		anns map[string]string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "empty",
+			want: "",
+		},
+		{
+			name: "non-empty",
+			args: args{
+				anns: map[string]string{
+					"konghq.com/service-policy": "long-timeouts",
+				},
+			},
+			want: "long-timeouts",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractServicePolicy(tt.args.anns); got != tt.want {
+				t.Errorf("ExtractServicePolicy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
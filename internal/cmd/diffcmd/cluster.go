@@ -0,0 +1,158 @@
+package diffcmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	knativev1alpha1 "knative.dev/networking/pkg/apis/networking/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/manager"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+	konghqcomv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+	configurationv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
+)
+
+// newScheme provides a scheme with all the APIs which objects are translated from.
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(konghqcomv1.AddToScheme(scheme))
+	utilruntime.Must(configurationv1beta1.AddToScheme(scheme))
+	utilruntime.Must(knativev1alpha1.AddToScheme(scheme))
+	utilruntime.Must(gatewayv1alpha2.AddToScheme(scheme))
+	return scheme
+}
+
+// loadCacheStores lists the objects which the controller configured as the
+// provided one would translate from the cluster. APIs which are not installed
+// in the cluster are skipped with a warning.
+func loadCacheStores(
+	ctx context.Context,
+	cl client.Client,
+	c *manager.Config,
+	featureGates map[string]bool,
+	warnings io.Writer,
+) (store.CacheStores, error) {
+	cs := store.NewCacheStores()
+
+	namespacedLists := []client.ObjectList{
+		&corev1.ServiceList{},
+		&corev1.EndpointsList{},
+		&corev1.SecretList{},
+		&konghqcomv1.KongPluginList{},
+		&konghqcomv1.KongConsumerList{},
+		&konghqcomv1.KongIngressList{},
+		&configurationv1beta1.KongConsumerGroupList{},
+		&configurationv1beta1.KongUpstreamPolicyList{},
+		&configurationv1beta1.KongServicePolicyList{},
+		&configurationv1beta1.TCPIngressList{},
+		&configurationv1beta1.UDPIngressList{},
+	}
+	clusterLists := []client.ObjectList{
+		&networkingv1.IngressClassList{},
+		&konghqcomv1.KongClusterPluginList{},
+		&configurationv1beta1.KongVaultList{},
+	}
+	if featureGates[manager.KnativeFeature] {
+		namespacedLists = append(namespacedLists, &knativev1alpha1.IngressList{})
+	}
+	if featureGates[manager.GatewayFeature] {
+		namespacedLists = append(namespacedLists,
+			&gatewayv1alpha2.GatewayList{},
+			&configurationv1beta1.GatewayConfigurationList{},
+			&gatewayv1alpha2.HTTPRouteList{},
+			&gatewayv1alpha2.UDPRouteList{},
+			&gatewayv1alpha2.TCPRouteList{},
+			&gatewayv1alpha2.TLSRouteList{},
+			&gatewayv1alpha2.ReferencePolicyList{},
+		)
+		clusterLists = append(clusterLists, &gatewayv1alpha2.GatewayClassList{}, &corev1.NamespaceList{})
+	}
+
+	namespaces := c.WatchNamespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{corev1.NamespaceAll}
+	}
+
+	// the same Ingresses are served by all the Ingress APIs, so only the
+	// first available one is listed, as the controller does.
+	var ingressLists []client.ObjectList
+	if c.IngressNetV1Enabled {
+		ingressLists = append(ingressLists, &networkingv1.IngressList{})
+	}
+	if c.IngressNetV1beta1Enabled {
+		ingressLists = append(ingressLists, &networkingv1beta1.IngressList{})
+	}
+	for _, list := range ingressLists {
+		if err := listInto(ctx, cl, cs, list, namespaces); err == nil {
+			break
+		} else if !meta.IsNoMatchError(err) {
+			return store.CacheStores{}, err
+		}
+	}
+
+	for _, list := range namespacedLists {
+		if err := listInto(ctx, cl, cs, list, namespaces); err != nil {
+			if meta.IsNoMatchError(err) {
+				fmt.Fprintf(warnings, "warning: skipping %T: %v\n", list, err)
+				continue
+			}
+			return store.CacheStores{}, err
+		}
+	}
+	for _, list := range clusterLists {
+		if err := listInto(ctx, cl, cs, list, []string{corev1.NamespaceAll}); err != nil {
+			if meta.IsNoMatchError(err) {
+				fmt.Fprintf(warnings, "warning: skipping %T: %v\n", list, err)
+				continue
+			}
+			return store.CacheStores{}, err
+		}
+	}
+	return cs, nil
+}
+
+// listInto lists the objects of the type of the provided list in the provided
+// namespaces and adds them to the provided cache stores. The errors for APIs
+// which are not installed in the cluster are returned as is.
+func listInto(ctx context.Context, cl client.Client, cs store.CacheStores, list client.ObjectList, namespaces []string) error {
+	for _, namespace := range namespaces {
+		// the items are added to the stores as is, so every namespace is listed
+		// into a new list.
+		list := list.DeepCopyObject().(client.ObjectList)
+		if err := cl.List(ctx, list, client.InNamespace(namespace)); err != nil {
+			if meta.IsNoMatchError(err) {
+				return err
+			}
+			return fmt.Errorf("listing %T: %w", list, err)
+		}
+		objs, err := meta.ExtractList(list)
+		if err != nil {
+			return err
+		}
+		for _, obj := range objs {
+			// typed lists don't set the kinds of their items, which are used to
+			// report problems with them.
+			gvk, err := apiutil.GVKForObject(obj, cl.Scheme())
+			if err != nil {
+				return err
+			}
+			obj.GetObjectKind().SetGroupVersionKind(gvk)
+			if err := cs.Add(obj); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+// Package diffcmd implements the cobra.Command which previews the changes that
+// the controller would make to the configuration of Kong.
+package diffcmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/bombsimon/logrusr/v2"
+	"github.com/kong/go-kong/kong"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/cmd/translatecmd"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/sendconfig"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/manager"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/metrics"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+const (
+	// OutputFormatText renders the changes as a human-readable diff.
+	OutputFormatText = "text"
+
+	// OutputFormatJSON renders the changes as JSON.
+	OutputFormatJSON = "json"
+)
+
+// Summary is the machine-readable description of the changes.
+type Summary struct {
+	// Created is the number of entities which would be created.
+	Created int `json:"created"`
+
+	// Updated is the number of entities which would be updated.
+	Updated int `json:"updated"`
+
+	// Deleted is the number of entities which would be deleted.
+	Deleted int `json:"deleted"`
+
+	// Changes are the individual changes.
+	Changes []util.ConfigChange `json:"changes"`
+
+	// Excluded are the entities which would be configured as well, but which
+	// aren't compared with the configuration of Kong.
+	Excluded []string `json:"excluded,omitempty"`
+}
+
+// New provides the diff command.
+func New() *cobra.Command {
+	var (
+		c            manager.Config
+		outputFormat string
+	)
+	cmd := &cobra.Command{
+		Use:   "diff [PATH...]",
+		Short: "Preview the changes the controller would make to the configuration of Kong",
+		Long: "Builds the Kong configuration from the Kubernetes objects in the cluster, or from the manifests at the " +
+			"provided paths (\"-\" reads from stdin), and compares it with the configuration of the Kong instance at " +
+			"--kong-admin-url, without changing it. The controller flags and CONTROLLER_* environment variables are " +
+			"supported, so that e.g. the effect of enabling a feature gate can be previewed.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return Run(cmd, &c, outputFormat, args)
+		},
+		SilenceUsage: true,
+		// errors are printed by the caller of the root command.
+		SilenceErrors: true,
+	}
+	cmd.Flags().AddFlagSet(c.FlagSet())
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", OutputFormatText,
+		fmt.Sprintf("Format of the changes, either %q or %q.", OutputFormatText, OutputFormatJSON))
+	return cmd
+}
+
+// Run compares the configuration built from the manifests at the provided
+// paths, or from the cluster if there are none, with the configuration of Kong
+// and prints the changes.
+func Run(cmd *cobra.Command, c *manager.Config, outputFormat string, paths []string) error {
+	if outputFormat != OutputFormatText && outputFormat != OutputFormatJSON {
+		return fmt.Errorf("unsupported output format %q", outputFormat)
+	}
+	ctx := cmd.Context()
+
+	logger, err := util.MakeLogger(c.LogLevel, c.LogFormat)
+	if err != nil {
+		return err
+	}
+	featureGates, err := manager.SetupFeatureGates(logrusr.New(logger), c)
+	if err != nil {
+		return fmt.Errorf("failed to configure feature gates: %w", err)
+	}
+
+	kongClient, err := c.GetKongClient(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to build kong api client: %w", err)
+	}
+	kongRoot, err := kongClient.Root(ctx)
+	if err != nil {
+		return fmt.Errorf("could not retrieve Kong admin root: %w", err)
+	}
+	kongConfig := sendconfig.Kong{
+		URL:               c.KongAdminURL,
+		Concurrency:       c.Concurrency,
+		Client:            kongClient,
+		PluginSchemaStore: util.NewPluginSchemaStore(kongClient),
+	}
+	if kongVersion, err := kong.ParseSemanticVersion(kong.VersionFromInfo(kongRoot)); err == nil {
+		util.SetKongVersion(kongVersion)
+		kongConfig.Version = kongVersion
+	}
+	kongRootConfig, ok := kongRoot["configuration"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("invalid root configuration, expected a map[string]interface{} got %T", kongRoot["configuration"])
+	}
+	kongConfig.InMemory = kongRootConfig["database"] == "off"
+	if ok, err := kongClient.Tags.Exists(ctx); err == nil && ok {
+		kongConfig.FilterTags = c.FilterTags
+	}
+
+	var cs store.CacheStores
+	if len(paths) > 0 {
+		manifests, warnings, err := translatecmd.ReadManifests(paths, cmd.InOrStdin())
+		if err != nil {
+			return err
+		}
+		for _, warning := range warnings {
+			fmt.Fprintf(cmd.ErrOrStderr(), "warning: %s\n", warning)
+		}
+		if cs, err = store.NewCacheStoresFromObjYAML(manifests...); err != nil {
+			return fmt.Errorf("loading objects: %w", err)
+		}
+	} else {
+		kubeconfig, err := c.GetKubeconfig()
+		if err != nil {
+			return fmt.Errorf("get kubeconfig from file %q: %w", c.KubeconfigPath, err)
+		}
+		cl, err := client.New(kubeconfig, client.Options{Scheme: newScheme()})
+		if err != nil {
+			return err
+		}
+		if cs, err = loadCacheStores(ctx, cl, c, featureGates, cmd.ErrOrStderr()); err != nil {
+			return err
+		}
+	}
+
+	// the consumer groups and vaults are translated so that they are reported
+	// like by the controller, but the comparison doesn't support them.
+	content, _, failures, err := translatecmd.Translate(ctx, logger, cs, translatecmd.Options{
+		IngressClass:                c.IngressClassName,
+		EnableCombinedServiceRoutes: featureGates[manager.CombinedRoutesFeature],
+		PluginSchemas:               kongConfig.PluginSchemaStore,
+		SelectorTags:                kongConfig.FilterTags,
+		InMemory:                    kongConfig.InMemory,
+		KongVersion:                 kongConfig.Version,
+	})
+	if err != nil {
+		return err
+	}
+	translatecmd.PrintTranslationFailures(cmd.ErrOrStderr(), failures)
+
+	changes, err := sendconfig.Diff(ctx, &kongConfig, content, kongConfig.FilterTags, c.SkipCACertificates)
+	if err != nil {
+		return fmt.Errorf("comparing with the configuration of kong: %w", err)
+	}
+	return writeChanges(cmd.OutOrStdout(), changes, excludedEntities(c, &kongConfig, cs), outputFormat)
+}
+
+// excludedEntities describes the entities which the controller would merge
+// into the configuration, but which the comparison doesn't support.
+func excludedEntities(c *manager.Config, kongConfig *sendconfig.Kong, cs store.CacheStores) []string {
+	var excluded []string
+	if kongConfig.InMemory && c.KongCustomEntitiesSecret != "" {
+		excluded = append(excluded, fmt.Sprintf("custom entities of Secret %s", c.KongCustomEntitiesSecret))
+	}
+	if len(cs.ConsumerGroup.List()) > 0 {
+		excluded = append(excluded, "consumer groups of KongConsumerGroups")
+	}
+	if len(cs.Vault.List()) > 0 {
+		excluded = append(excluded, "vaults of KongVaults")
+	}
+	return excluded
+}
+
+// writeChanges renders the provided changes in the provided format, along
+// with the provided entities which weren't compared.
+func writeChanges(w io.Writer, changes []util.ConfigChange, excluded []string, format string) error {
+	summary := Summary{Changes: changes, Excluded: excluded}
+	if summary.Changes == nil {
+		summary.Changes = []util.ConfigChange{}
+	}
+	for _, change := range changes {
+		switch change.Action {
+		case metrics.ActionCreate:
+			summary.Created++
+		case metrics.ActionUpdate:
+			summary.Updated++
+		case metrics.ActionDelete:
+			summary.Deleted++
+		}
+	}
+
+	if format == OutputFormatJSON {
+		b, err := json.MarshalIndent(summary, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(b))
+		return err
+	}
+
+	var out strings.Builder
+	for _, change := range changes {
+		fmt.Fprintf(&out, "%s %s %s\n", change.Action, change.Kind, change.Entity)
+		for _, line := range strings.SplitAfter(change.Diff, "\n") {
+			if line != "" {
+				out.WriteString("    " + line)
+			}
+		}
+	}
+	fmt.Fprintf(&out, "Summary:\n  Created: %d\n  Updated: %d\n  Deleted: %d\n", summary.Created, summary.Updated, summary.Deleted)
+	if len(excluded) > 0 {
+		out.WriteString("Not compared:\n")
+		for _, e := range excluded {
+			fmt.Fprintf(&out, "  %s\n", e)
+		}
+	}
+	_, err := io.WriteString(w, out.String())
+	return err
+}
@@ -0,0 +1,127 @@
+package diffcmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/manager"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+	kongv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+	kongv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
+)
+
+func TestWriteChanges(t *testing.T) {
+	changes := []util.ConfigChange{
+		{Action: "create", Kind: "route", Entity: "default.new.00"},
+		{Action: "delete", Kind: "route", Entity: "default.old.00"},
+		{Action: "update", Kind: "service", Entity: "default.echo.80", Diff: "--- current\n+++ target\n-  \"port\": 80,\n+  \"port\": 8080,\n"},
+	}
+
+	t.Run("text", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, writeChanges(&out, changes, nil, OutputFormatText))
+		assert.Equal(t, `create route default.new.00
+delete route default.old.00
+update service default.echo.80
+    --- current
+    +++ target
+    -  "port": 80,
+    +  "port": 8080,
+Summary:
+  Created: 1
+  Updated: 1
+  Deleted: 1
+`, out.String())
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, writeChanges(&out, changes, nil, OutputFormatJSON))
+		var summary Summary
+		require.NoError(t, json.Unmarshal(out.Bytes(), &summary))
+		assert.Equal(t, Summary{Created: 1, Updated: 1, Deleted: 1, Changes: changes}, summary)
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, writeChanges(&out, nil, nil, OutputFormatJSON))
+		assert.JSONEq(t, `{"created":0,"updated":0,"deleted":0,"changes":[]}`, out.String())
+	})
+
+	t.Run("excluded entities", func(t *testing.T) {
+		excluded := []string{"vaults of KongVaults"}
+
+		var out bytes.Buffer
+		require.NoError(t, writeChanges(&out, nil, excluded, OutputFormatText))
+		assert.Equal(t, `Summary:
+  Created: 0
+  Updated: 0
+  Deleted: 0
+Not compared:
+  vaults of KongVaults
+`, out.String())
+
+		out.Reset()
+		require.NoError(t, writeChanges(&out, nil, excluded, OutputFormatJSON))
+		assert.JSONEq(t, `{"created":0,"updated":0,"deleted":0,"changes":[],"excluded":["vaults of KongVaults"]}`, out.String())
+	})
+}
+
+func TestLoadCacheStores(t *testing.T) {
+	objs := []runtime.Object{
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "watched", Name: "echo"}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "echo"}},
+		&networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "watched", Name: "echo"}},
+		&networkingv1.IngressClass{ObjectMeta: metav1.ObjectMeta{Name: "kong"}},
+		&kongv1.KongClusterPlugin{ObjectMeta: metav1.ObjectMeta{Name: "plugin"}},
+		&kongv1beta1.KongConsumerGroup{ObjectMeta: metav1.ObjectMeta{Namespace: "watched", Name: "group"}},
+		&kongv1beta1.KongUpstreamPolicy{ObjectMeta: metav1.ObjectMeta{Namespace: "watched", Name: "upstream"}},
+		&kongv1beta1.KongServicePolicy{ObjectMeta: metav1.ObjectMeta{Namespace: "watched", Name: "service"}},
+		&kongv1beta1.KongVault{ObjectMeta: metav1.ObjectMeta{Name: "env"}},
+		&kongv1beta1.GatewayConfiguration{ObjectMeta: metav1.ObjectMeta{Namespace: "watched", Name: "config"}},
+		&gatewayv1alpha2.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "watched", Name: "kong"}},
+		&gatewayv1alpha2.GatewayClass{ObjectMeta: metav1.ObjectMeta{Name: "kong"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "watched"}},
+	}
+	cl := fake.NewClientBuilder().WithScheme(newScheme()).WithRuntimeObjects(objs...).Build()
+
+	c := &manager.Config{
+		WatchNamespaces:          []string{"watched"},
+		IngressNetV1Enabled:      true,
+		IngressNetV1beta1Enabled: true,
+	}
+	var warnings bytes.Buffer
+	cs, err := loadCacheStores(context.Background(), cl, c, map[string]bool{manager.GatewayFeature: true}, &warnings)
+	require.NoError(t, err)
+	assert.Empty(t, warnings.String())
+
+	services := cs.Service.List()
+	require.Len(t, services, 1)
+	service := services[0].(*corev1.Service)
+	assert.Equal(t, "watched", service.Namespace)
+	assert.Equal(t, "Service", service.Kind, "the kinds of listed objects should be set")
+
+	assert.Len(t, cs.IngressV1.List(), 1)
+	assert.Len(t, cs.IngressV1beta1.List(), 0, "Ingresses should only be listed through a single API")
+	assert.Len(t, cs.IngressClassV1.List(), 1)
+	assert.Len(t, cs.ClusterPlugin.List(), 1)
+	assert.Len(t, cs.ConsumerGroup.List(), 1)
+	assert.Len(t, cs.UpstreamPolicy.List(), 1)
+	assert.Len(t, cs.ServicePolicy.List(), 1)
+	assert.Len(t, cs.Vault.List(), 1)
+	assert.Len(t, cs.GatewayConfiguration.List(), 1)
+	assert.Len(t, cs.Gateway.List(), 1)
+	assert.Len(t, cs.GatewayClass.List(), 1)
+	assert.Len(t, cs.Namespace.List(), 1)
+}
@@ -4,6 +4,8 @@ package rootcmd
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/cmd/diffcmd"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/cmd/translatecmd"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/manager"
 )
 
@@ -11,6 +13,8 @@ var cfg manager.Config
 
 func init() {
 	rootCmd.Flags().AddFlagSet(cfg.FlagSet())
+	rootCmd.AddCommand(translatecmd.New())
+	rootCmd.AddCommand(diffcmd.New())
 }
 
 var rootCmd = &cobra.Command{
@@ -0,0 +1,73 @@
+package rootcmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// executeArgsEnv holds the newline-separated arguments Execute() is run with
+// by TestExecuteHelper.
+const executeArgsEnv = "TEST_ROOTCMD_EXECUTE_ARGS"
+
+// TestExecuteHelper runs Execute() as if the binary was invoked with the
+// arguments of executeArgsEnv, so that the exit status of subcommands can be
+// checked from another process. It does nothing when run as a regular test.
+func TestExecuteHelper(t *testing.T) {
+	args, ok := os.LookupEnv(executeArgsEnv)
+	if !ok {
+		return
+	}
+	os.Args = append([]string{"kong-ingress-controller"}, strings.Split(args, "\n")...)
+	Execute()
+	os.Exit(0)
+}
+
+func TestTranslateExitStatus(t *testing.T) {
+	manifest := filepath.Join(t.TempDir(), "ingress.yaml")
+	require.NoError(t, os.WriteFile(manifest, []byte(`apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: missing-service
+  namespace: default
+  annotations:
+    kubernetes.io/ingress.class: kong
+spec:
+  rules:
+  - http:
+      paths:
+      - path: /missing
+        pathType: Prefix
+        backend:
+          service:
+            name: missing
+            port:
+              number: 80
+`), 0o600))
+
+	var stderr bytes.Buffer
+	execute := func(args ...string) error {
+		stderr.Reset()
+		cmd := exec.Command(os.Args[0], "-test.run=^TestExecuteHelper$") //nolint:gosec
+		cmd.Env = append(os.Environ(), executeArgsEnv+"="+strings.Join(args, "\n"))
+		cmd.Stderr = &stderr
+		return cmd.Run()
+	}
+
+	t.Log("verifying that translation failures make the translate command exit with a non-zero status")
+	err := execute("translate", manifest)
+	var exitErr *exec.ExitError
+	require.True(t, errors.As(err, &exitErr), "unexpected error: %v", err)
+	assert.NotEqual(t, 0, exitErr.ExitCode())
+	assert.Equal(t, 1, strings.Count(stderr.String(), "Error:"), "the error should be printed once: %s", stderr.String())
+
+	t.Log("verifying that translation failures can be allowed")
+	assert.NoError(t, execute("translate", "--allow-failures", manifest))
+}
@@ -27,7 +27,7 @@ func StartDiagnosticsServer(ctx context.Context, port int, c *manager.Config) (d
 	}
 	logger := logrusr.New(deprecatedLogger)
 
-	if !c.EnableProfiling && !c.EnableConfigDumps {
+	if !c.EnableProfiling && !c.EnableConfigDumps && !c.DryRun {
 		logger.Info("diagnostics server disabled")
 		return diagnostics.Server{}, nil
 	}
@@ -37,11 +37,13 @@ func StartDiagnosticsServer(ctx context.Context, port int, c *manager.Config) (d
 		ProfilingEnabled: c.EnableProfiling,
 		ConfigLock:       &sync.RWMutex{},
 	}
+	s.ConfigDumps.DumpsIncludeSensitive = c.DumpSensitiveConfig
+	s.ConfigDumps.CircuitBreaker = make(chan util.CircuitBreakerStatus, DiagnosticConfigBufferDepth)
 	if c.EnableConfigDumps {
-		s.ConfigDumps = util.ConfigDumpDiagnostic{
-			DumpsIncludeSensitive: c.DumpSensitiveConfig,
-			Configs:               make(chan util.ConfigDump, DiagnosticConfigBufferDepth),
-		}
+		s.ConfigDumps.Configs = make(chan util.ConfigDump, DiagnosticConfigBufferDepth)
+	}
+	if c.DryRun {
+		s.ConfigDumps.DryRunReports = make(chan util.DryRunReport, DiagnosticConfigBufferDepth)
 	}
 	go func() {
 		if err := s.Listen(ctx, port); err != nil {
@@ -0,0 +1,120 @@
+package translatecmd
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+)
+
+// stdinPath is the path which reads manifests from stdin.
+const stdinPath = "-"
+
+// manifestExtensions are the extensions of the files read from directories.
+var manifestExtensions = map[string]struct{}{
+	".yaml": {},
+	".yml":  {},
+	".json": {},
+}
+
+// ReadManifests reads the Kubernetes objects from the YAML or JSON manifests
+// at the provided paths, one document per object. Directories are walked for
+// manifest files, and stdinPath reads from the provided reader. Documents which
+// are not objects the controller translates are skipped with a warning.
+func ReadManifests(paths []string, stdin io.Reader) ([][]byte, []string, error) {
+	var (
+		manifests [][]byte
+		warnings  []string
+	)
+	read := func(source string, r io.Reader) error {
+		docs, docWarnings, err := splitManifest(source, r)
+		if err != nil {
+			return err
+		}
+		manifests = append(manifests, docs...)
+		warnings = append(warnings, docWarnings...)
+		return nil
+	}
+
+	for _, path := range paths {
+		if path == stdinPath {
+			if err := read("stdin", stdin); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+		err := filepath.WalkDir(path, func(file string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			// files named explicitly are read regardless of their extension.
+			if _, ok := manifestExtensions[strings.ToLower(filepath.Ext(file))]; !ok && file != path {
+				return nil
+			}
+			f, err := os.Open(file)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			return read(file, f)
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading manifests from %s: %w", path, err)
+		}
+	}
+	return manifests, warnings, nil
+}
+
+// splitManifest splits the provided manifest in its documents and keeps the
+// ones which are supported objects.
+func splitManifest(source string, r io.Reader) ([][]byte, []string, error) {
+	var (
+		docs     [][]byte
+		warnings []string
+	)
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(r))
+	for i := 0; ; i++ {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return docs, warnings, nil
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading %s: %w", source, err)
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		var obj struct {
+			APIVersion string `json:"apiVersion"`
+			Kind       string `json:"kind"`
+		}
+		if err := yaml.Unmarshal(doc, &obj); err != nil {
+			return nil, nil, fmt.Errorf("parsing document %d of %s: %w", i+1, source, err)
+		}
+		if obj.APIVersion == "" && obj.Kind == "" {
+			// documents made of comments only.
+			continue
+		}
+		gvk := schema.FromAPIVersionAndKind(obj.APIVersion, obj.Kind)
+		if !store.IsObjectSupported(gvk) {
+			warnings = append(warnings, fmt.Sprintf("skipping document %d of %s: unsupported kind %s", i+1, source, gvk))
+			continue
+		}
+		docs = append(docs, doc)
+	}
+}
@@ -0,0 +1,88 @@
+package translatecmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/blang/semver/v4"
+	"github.com/kong/deck/file"
+	"github.com/sirupsen/logrus"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/deckgen"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/parser"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+// Options are the settings of the translation which the controller would
+// otherwise get from its configuration.
+type Options struct {
+	// IngressClass is the ingress class objects have to match to be translated.
+	IngressClass string
+
+	// EnableCombinedServiceRoutes enables the CombinedRoutes feature.
+	EnableCombinedServiceRoutes bool
+
+	// EnableRequestMirrorPlugin indicates that the request-mirror plugin is
+	// available, unless PluginSchemas are set to check it.
+	EnableRequestMirrorPlugin bool
+
+	// PluginSchemas, when set, are used to fill the defaults of the
+	// configurations of plugins and to check which plugins are available.
+	PluginSchemas *util.PluginSchemaStore
+
+	// SelectorTags are the tags added to all entities.
+	SelectorTags []string
+
+	// InMemory indicates that the configuration is translated for a DB-less
+	// data-plane, which is the only kind supporting consumer groups and vaults.
+	InMemory bool
+
+	// KongVersion is the version of Kong the configuration is translated for.
+	KongVersion semver.Version
+}
+
+// Translate generates the Kong declarative configuration for the Kubernetes
+// objects in the provided cache stores, as the controller would for a cluster
+// containing them. The entities which the decK configuration can't hold, e.g.
+// consumer groups and vaults, are returned as JSON custom entities to merge
+// into it, and the problems found with individual objects along with the
+// configuration.
+func Translate(
+	ctx context.Context,
+	logger logrus.FieldLogger,
+	cs store.CacheStores,
+	opts Options,
+) (*file.Content, []byte, []parser.TranslationFailure, error) {
+	storer := store.New(cs, opts.IngressClass, false, false, false, logger)
+	var err error
+
+	p := parser.NewParser(logger, storer)
+	if opts.EnableCombinedServiceRoutes {
+		p.EnableCombinedServiceRoutes()
+	}
+	enableRequestMirrorPlugin := opts.EnableRequestMirrorPlugin
+	if opts.PluginSchemas != nil {
+		if enableRequestMirrorPlugin, err = opts.PluginSchemas.IsAvailable(ctx, parser.RequestMirrorPluginName); err != nil {
+			return nil, nil, nil, fmt.Errorf("checking whether the %s plugin is available: %w", parser.RequestMirrorPluginName, err)
+		}
+	}
+	if enableRequestMirrorPlugin {
+		p.EnableRequestMirrorPlugin()
+	}
+	if opts.InMemory {
+		p.EnableConsumerGroups()
+		if opts.KongVersion.GTE(parser.MinVaultKongVersion) {
+			p.EnableVaults()
+		}
+	}
+	state, err := p.Build()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("translating objects: %w", err)
+	}
+
+	content := deckgen.ToDeckContent(ctx, logger, state, opts.PluginSchemas, opts.SelectorTags)
+	entities := dataplane.WithGeneratedEntities(ctx, logger, state, opts.PluginSchemas, opts.KongVersion, nil)
+	return content, entities, p.PopTranslationFailures(), nil
+}
@@ -0,0 +1,237 @@
+// Package translatecmd implements the cobra.Command which translates Kubernetes
+// manifests into Kong declarative configuration without a cluster.
+package translatecmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/blang/semver/v4"
+	"github.com/bombsimon/logrusr/v2"
+	"github.com/kong/deck/file"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	cliflag "k8s.io/component-base/cli/flag"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/parser"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/manager"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+const (
+	// OutputFormatYAML renders the configuration as YAML.
+	OutputFormatYAML = "yaml"
+
+	// OutputFormatJSON renders the configuration as JSON.
+	OutputFormatJSON = "json"
+)
+
+// Config is the configuration of the translate command.
+type Config struct {
+	// OutputFormat is the format the configuration is rendered in.
+	OutputFormat string
+
+	// IngressClass is the ingress class objects have to match to be translated.
+	IngressClass string
+
+	// KongVersion is the version of Kong the configuration is translated for.
+	KongVersion string
+
+	// FeatureGates are the overrides of the default states of gated features.
+	FeatureGates map[string]bool
+
+	// LogLevel is the level of the logs of the translation process.
+	LogLevel string
+
+	// EnableRequestMirrorPlugin indicates that the request-mirror plugin, which
+	// Kong doesn't bundle, is installed in the data-plane.
+	EnableRequestMirrorPlugin bool
+
+	// DBLess indicates that Kong runs without a database, which is the only
+	// mode supporting consumer groups and vaults.
+	DBLess bool
+
+	// AllowFailures indicates that objects which can't be translated are only
+	// reported as warnings rather than failing the command.
+	AllowFailures bool
+}
+
+// FlagSet binds the provided Config to command-line flags.
+func (c *Config) FlagSet() *pflag.FlagSet {
+	flagSet := pflag.NewFlagSet("", pflag.ExitOnError)
+	flagSet.StringVarP(&c.OutputFormat, "output", "o", OutputFormatYAML,
+		fmt.Sprintf("Format of the generated configuration, either %q or %q.", OutputFormatYAML, OutputFormatJSON))
+	flagSet.StringVar(&c.IngressClass, "ingress-class", annotations.DefaultIngressClass,
+		"Name of the ingress class to translate objects for.")
+	flagSet.StringVar(&c.KongVersion, "kong-version", "",
+		"Version of Kong to translate the objects for (e.g. 2.8.0). By default the oldest supported version is assumed.")
+	flagSet.Var(cliflag.NewMapStringBool(&c.FeatureGates), "feature-gates",
+		"A set of key=value pairs that describe feature gates for alpha/beta/experimental features, as for the controller.")
+	flagSet.StringVar(&c.LogLevel, "log-level", "fatal",
+		`Level of logging of the translation process. Supported levels are "trace", "debug", "info", "warn", "error", "fatal" and "panic".`)
+	flagSet.BoolVar(&c.EnableRequestMirrorPlugin, "enable-request-mirror-plugin", false,
+		"Translate HTTPRoute RequestMirror filters, which requires the request-mirror plugin to be installed in Kong.")
+	flagSet.BoolVar(&c.DBLess, "db-less", true,
+		"Translate for a DB-less Kong, which is required by KongConsumerGroups and KongVaults. Set to false for a Kong with a database.")
+	flagSet.BoolVar(&c.AllowFailures, "allow-failures", false,
+		"Exit successfully even if some objects can't be translated, which are still reported as warnings.")
+	return flagSet
+}
+
+// New provides the translate command.
+func New() *cobra.Command {
+	var c Config
+	cmd := &cobra.Command{
+		Use:   "translate PATH...",
+		Short: "Translate Kubernetes manifests into Kong declarative configuration",
+		Long: "Reads the Kubernetes objects (e.g. Ingresses, Gateway API routes and Kong custom resources) " +
+			"from the YAML or JSON manifests at the provided paths, and prints the Kong declarative configuration " +
+			"the controller would generate from them. Directories are read recursively, and \"-\" reads from stdin. " +
+			"Problems with individual objects are reported as warnings on stderr, and the command fails when " +
+			"any object can't be translated unless --allow-failures is set.",
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return Run(cmd, &c, args)
+		},
+		SilenceUsage: true,
+		// errors are printed by the caller of the root command.
+		SilenceErrors: true,
+	}
+	cmd.Flags().AddFlagSet(c.FlagSet())
+	return cmd
+}
+
+// Run translates the manifests at the provided paths and prints the resulting
+// configuration to the command's output and warnings to its error output. The
+// configuration is printed even when some objects can't be translated, but an
+// error is returned then unless failures are allowed.
+func Run(cmd *cobra.Command, c *Config, paths []string) error {
+	if c.OutputFormat != OutputFormatYAML && c.OutputFormat != OutputFormatJSON {
+		return fmt.Errorf("unsupported output format %q", c.OutputFormat)
+	}
+
+	logger, err := util.MakeLogger(c.LogLevel, "text")
+	if err != nil {
+		return err
+	}
+
+	featureGates, err := manager.SetupFeatureGates(logrusr.New(logger), &manager.Config{FeatureGates: c.FeatureGates})
+	if err != nil {
+		return err
+	}
+
+	kongVersion := util.GetKongVersion()
+	if c.KongVersion != "" {
+		if kongVersion, err = semver.Parse(c.KongVersion); err != nil {
+			return fmt.Errorf("invalid Kong version %q: %w", c.KongVersion, err)
+		}
+		util.SetKongVersion(kongVersion)
+	}
+
+	manifests, warnings, err := ReadManifests(paths, cmd.InOrStdin())
+	if err != nil {
+		return err
+	}
+	for _, warning := range warnings {
+		fmt.Fprintf(cmd.ErrOrStderr(), "warning: %s\n", warning)
+	}
+
+	cs, err := store.NewCacheStoresFromObjYAML(manifests...)
+	if err != nil {
+		return fmt.Errorf("loading objects: %w", err)
+	}
+	// as plugin schemas can't be retrieved without a data-plane, plugin
+	// configurations are not filled with their defaults.
+	content, entities, failures, err := Translate(cmd.Context(), logger, cs, Options{
+		IngressClass:                c.IngressClass,
+		EnableCombinedServiceRoutes: featureGates[manager.CombinedRoutesFeature],
+		EnableRequestMirrorPlugin:   c.EnableRequestMirrorPlugin,
+		InMemory:                    c.DBLess,
+		KongVersion:                 kongVersion,
+	})
+	if err != nil {
+		return err
+	}
+	PrintTranslationFailures(cmd.ErrOrStderr(), failures)
+
+	if err := writeContent(cmd.OutOrStdout(), content, entities, c.OutputFormat); err != nil {
+		return err
+	}
+	if len(failures) > 0 && !c.AllowFailures {
+		return fmt.Errorf("some objects could not be translated (%d failures), see the warnings above", len(failures))
+	}
+	return nil
+}
+
+// PrintTranslationFailures prints the provided translation failures as warnings,
+// one per object which caused them. Cluster-scoped objects are only named.
+func PrintTranslationFailures(w io.Writer, failures []parser.TranslationFailure) {
+	for _, failure := range failures {
+		for _, obj := range failure.CausingObjects {
+			name := obj.GetName()
+			if obj.GetNamespace() != "" {
+				name = obj.GetNamespace() + "/" + name
+			}
+			fmt.Fprintf(w, "warning: %s %s: %s\n", obj.GetObjectKind().GroupVersionKind().Kind, name, failure.Reason)
+		}
+	}
+}
+
+// writeContent renders the provided configuration, merged with the provided
+// JSON custom entities, in the provided format. Like for the controller, the
+// custom entities don't replace the entities of the configuration.
+func writeContent(w io.Writer, content *file.Content, customEntities []byte, format string) error {
+	var config interface{} = content
+	if len(customEntities) > 0 {
+		merged, err := mergeCustomEntities(content, customEntities)
+		if err != nil {
+			return fmt.Errorf("rendering configuration: %w", err)
+		}
+		config = merged
+	}
+
+	var (
+		b   []byte
+		err error
+	)
+	switch format {
+	case OutputFormatJSON:
+		b, err = json.MarshalIndent(config, "", "  ")
+		b = append(b, '\n')
+	default:
+		b, err = yaml.Marshal(config)
+	}
+	if err != nil {
+		return fmt.Errorf("rendering configuration: %w", err)
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// mergeCustomEntities adds the provided JSON custom entities to the top level
+// of the provided configuration, except for the keys the configuration has.
+func mergeCustomEntities(content *file.Content, customEntities []byte) (map[string]interface{}, error) {
+	b, err := json.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling configuration: %w", err)
+	}
+	config := map[string]interface{}{}
+	if err := json.Unmarshal(b, &config); err != nil {
+		return nil, fmt.Errorf("unmarshaling configuration: %w", err)
+	}
+
+	var entities map[string]interface{}
+	if err := json.Unmarshal(customEntities, &entities); err != nil {
+		return nil, fmt.Errorf("unmarshaling custom entities: %w", err)
+	}
+	for k, v := range entities {
+		if _, exists := config[k]; !exists {
+			config[k] = v
+		}
+	}
+	return config, nil
+}
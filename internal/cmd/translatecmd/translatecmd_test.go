@@ -0,0 +1,217 @@
+package translatecmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kong/deck/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+const manifests = `apiVersion: v1
+kind: Service
+metadata:
+  name: echo
+  namespace: default
+spec:
+  ports:
+  - port: 80
+    protocol: TCP
+---
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: echo
+  namespace: default
+  annotations:
+    kubernetes.io/ingress.class: kong
+spec:
+  rules:
+  - http:
+      paths:
+      - path: /echo
+        pathType: Prefix
+        backend:
+          service:
+            name: echo
+            port:
+              number: 80
+---
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: missing-service
+  namespace: default
+  annotations:
+    kubernetes.io/ingress.class: kong
+spec:
+  rules:
+  - http:
+      paths:
+      - path: /missing
+        pathType: Prefix
+        backend:
+          service:
+            name: missing
+            port:
+              number: 80
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: echo
+  namespace: default
+`
+
+const vaultManifests = `apiVersion: configuration.konghq.com/v1beta1
+kind: KongVault
+metadata:
+  name: env
+  annotations:
+    kubernetes.io/ingress.class: kong
+spec:
+  backend: env
+  prefix: env-vault
+  config:
+    prefix: SECRET_
+---
+apiVersion: configuration.konghq.com/v1beta1
+kind: KongConsumerGroup
+metadata:
+  name: gold
+  namespace: default
+  annotations:
+    kubernetes.io/ingress.class: kong
+---
+apiVersion: configuration.konghq.com/v1
+kind: KongConsumer
+metadata:
+  name: alice
+  namespace: default
+  annotations:
+    kubernetes.io/ingress.class: kong
+username: alice
+consumerGroups:
+- gold
+`
+
+func runTranslate(t *testing.T, args ...string) (string, string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := New()
+	cmd.SetArgs(args)
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	err := cmd.Execute()
+	return stdout.String(), stderr.String(), err
+}
+
+func routeNames(content file.Content) []string {
+	var names []string
+	for _, service := range content.Services {
+		for _, route := range service.Routes {
+			names = append(names, *route.Name)
+		}
+	}
+	return names
+}
+
+func TestTranslate(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "manifests.yaml"), []byte(manifests), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a manifest"), 0o600))
+
+	t.Run("yaml", func(t *testing.T) {
+		stdout, stderr, err := runTranslate(t, dir)
+		require.Error(t, err, "translation failures should fail the command")
+
+		var content file.Content
+		require.NoError(t, yaml.Unmarshal([]byte(stdout), &content))
+		assert.ElementsMatch(t, []string{"default.echo.00", "default.missing-service.00"}, routeNames(content))
+		require.Len(t, content.Upstreams, 2)
+
+		assert.Contains(t, stderr, "skipping document 4 of "+filepath.Join(dir, "manifests.yaml")+": unsupported kind apps/v1, Kind=Deployment")
+		assert.Contains(t, stderr, "warning: Ingress default/missing-service: ")
+		assert.NotContains(t, stderr, "default/echo:")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		stdout, _, err := runTranslate(t, "-o", "json", "--allow-failures", filepath.Join(dir, "manifests.yaml"))
+		require.NoError(t, err)
+
+		var content file.Content
+		require.NoError(t, json.Unmarshal([]byte(stdout), &content))
+		assert.ElementsMatch(t, []string{"default.echo.00", "default.missing-service.00"}, routeNames(content))
+	})
+
+	t.Run("combined routes", func(t *testing.T) {
+		stdout, _, err := runTranslate(t, "--feature-gates", "CombinedRoutes=true", "--allow-failures", dir)
+		require.NoError(t, err)
+
+		var content file.Content
+		require.NoError(t, yaml.Unmarshal([]byte(stdout), &content))
+		assert.ElementsMatch(t, []string{"default.echo.echo..80", "default.missing-service.missing..80"}, routeNames(content))
+	})
+
+	t.Run("stdin", func(t *testing.T) {
+		var stdout bytes.Buffer
+		cmd := New()
+		cmd.SetArgs([]string{"--allow-failures", "-"})
+		cmd.SetIn(bytes.NewBufferString(manifests))
+		cmd.SetOut(&stdout)
+		cmd.SetErr(&bytes.Buffer{})
+		require.NoError(t, cmd.Execute())
+
+		var content file.Content
+		require.NoError(t, yaml.Unmarshal(stdout.Bytes(), &content))
+		assert.Len(t, routeNames(content), 2)
+	})
+
+	t.Run("consumer groups and vaults", func(t *testing.T) {
+		vaults := filepath.Join(t.TempDir(), "vaults.yaml")
+		require.NoError(t, os.WriteFile(vaults, []byte(vaultManifests), 0o600))
+
+		stdout, stderr, err := runTranslate(t, "-o", "json", "--kong-version", "3.0.0", vaults)
+		require.NoError(t, err)
+		assert.Empty(t, stderr)
+		var config struct {
+			file.Content
+			ConsumerGroups []map[string]interface{} `json:"consumer_groups"`
+			Vaults         []map[string]interface{} `json:"vaults"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(stdout), &config))
+		require.Len(t, config.Consumers, 1)
+		assert.Equal(t, "alice", *config.Consumers[0].Username)
+		require.Len(t, config.ConsumerGroups, 1)
+		assert.Equal(t, "gold", config.ConsumerGroups[0]["name"])
+		assert.Equal(t, []interface{}{map[string]interface{}{"username": "alice"}}, config.ConsumerGroups[0]["consumers"])
+		require.Len(t, config.Vaults, 1)
+		assert.Equal(t, "env-vault", config.Vaults[0]["prefix"])
+
+		_, stderr, err = runTranslate(t, "--db-less=false", vaults)
+		require.Error(t, err)
+		assert.Contains(t, stderr, "warning: KongVault env: ", "cluster-scoped objects should only be named")
+		assert.Contains(t, stderr, "warning: KongConsumerGroup default/gold: ")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, _, err := runTranslate(t, filepath.Join(dir, "missing.yaml"))
+		assert.Error(t, err)
+
+		_, _, err = runTranslate(t, "-o", "toml", dir)
+		assert.Error(t, err)
+
+		_, _, err = runTranslate(t, "--feature-gates", "NoSuchFeature=true", dir)
+		assert.Error(t, err)
+
+		invalid := filepath.Join(dir, "invalid", "invalid.yaml")
+		require.NoError(t, os.MkdirAll(filepath.Dir(invalid), 0o700))
+		require.NoError(t, os.WriteFile(invalid, []byte("kind: [\n"), 0o600))
+		_, _, err = runTranslate(t, invalid)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,97 @@
+package configuration
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+// -----------------------------------------------------------------------------
+// Kong Admin API Service - Reconciler
+// -----------------------------------------------------------------------------
+
+// KongAdminAPIServiceReconciler watches the Endpoints of the Kong Admin API
+// Service and keeps the set of proxy instances which the dataplane client sends
+// configuration to in sync with the ready Admin API endpoints.
+type KongAdminAPIServiceReconciler struct {
+	client.Client
+
+	Log             logr.Logger
+	DataplaneClient *dataplane.KongClient
+
+	// ServiceNN is the namespace and name of the Kong Admin API Service.
+	ServiceNN types.NamespacedName
+
+	// PortNames are the names of the Service ports which serve the Admin API.
+	PortNames []string
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *KongAdminAPIServiceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	c, err := controller.New("KongAdminAPIService", mgr, controller.Options{
+		Reconciler: r,
+		LogConstructor: func(_ *reconcile.Request) logr.Logger {
+			return r.Log
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return c.Watch(
+		&source.Kind{Type: &corev1.Endpoints{}},
+		&handler.EnqueueRequestForObject{},
+		predicate.NewPredicateFuncs(r.isAdminAPIServiceEndpoints),
+	)
+}
+
+// isAdminAPIServiceEndpoints filters out any Endpoints which don't belong to
+// the Kong Admin API Service.
+func (r *KongAdminAPIServiceReconciler) isAdminAPIServiceEndpoints(obj client.Object) bool {
+	return obj.GetNamespace() == r.ServiceNN.Namespace && obj.GetName() == r.ServiceNN.Name
+}
+
+//+kubebuilder:rbac:groups="",resources=endpoints,verbs=get;list;watch
+
+// Reconcile processes the watched objects
+func (r *KongAdminAPIServiceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("KongAdminAPIService", req.NamespacedName)
+
+	endpoints := new(corev1.Endpoints)
+	if err := r.Get(ctx, req.NamespacedName, endpoints); err != nil {
+		if !errors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		// with the Endpoints gone there are no proxy instances left to configure,
+		// but the current set is retained so that it can recover if they return.
+		log.Info("kong admin api service endpoints not found, keeping the current proxy instances")
+		return ctrl.Result{}, nil
+	}
+
+	urls := adminapi.GetURLsForEndpoints(endpoints, sets.NewString(r.PortNames...))
+	if len(urls) == 0 {
+		log.Info("no ready kong admin api endpoints found, keeping the current proxy instances")
+		return ctrl.Result{}, nil
+	}
+
+	log.V(util.DebugLevel).Info("updating kong proxy instances", "urls", urls)
+	if err := r.DataplaneClient.UpdateProxies(ctx, urls); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
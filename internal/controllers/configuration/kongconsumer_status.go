@@ -0,0 +1,84 @@
+package configuration
+
+import (
+	"context"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kongv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+)
+
+// -----------------------------------------------------------------------------
+// KongV1 KongConsumer - Status
+// -----------------------------------------------------------------------------
+
+// updateStatus updates the status of the KongConsumer with the outcome of the
+// most recent configuration of the data-plane.
+func (r *KongV1KongConsumerReconciler) updateStatus(ctx context.Context, obj *kongv1.KongConsumer) (ctrl.Result, error) {
+	// objects retrieved from the cache are not guaranteed to include their kind,
+	// which the data-plane client identifies objects with.
+	obj.SetGroupVersionKind(kongv1.SchemeGroupVersion.WithKind("KongConsumer"))
+	changed, configured := ensureConsumerStatus(r.DataplaneClient, obj)
+	if changed {
+		return ctrl.Result{}, r.Status().Update(ctx, obj)
+	}
+	return ctrl.Result{Requeue: !configured}, nil
+}
+
+// -----------------------------------------------------------------------------
+// Consumer Status - Helpers
+// -----------------------------------------------------------------------------
+
+// consumerStatusReporter provides the outcome of the most recent configuration
+// of the data-plane for KongConsumers.
+type consumerStatusReporter interface {
+	KubernetesObjectIsConfigured(obj client.Object) bool
+	KubernetesObjectTranslationFailure(obj client.Object) (string, bool)
+	KubernetesObjectConfigurationError(obj client.Object) (string, bool)
+	KubernetesObjectConsumerCredentials(obj client.Object) []kongv1.ConsumerCredentialStatus
+	KubernetesObjectConsumerID(obj client.Object) string
+}
+
+// ensureConsumerStatus updates the status of the provided KongConsumer with
+// the outcome of the most recent configuration of the data-plane. It returns
+// whether the status changed, and whether the consumer is configured on the
+// data-plane. Consumers which aren't configured yet keep their status until
+// they are.
+func ensureConsumerStatus(reporter consumerStatusReporter, obj *kongv1.KongConsumer) (bool, bool) {
+	previous := obj.Status.DeepCopy()
+	status := &obj.Status
+	setProgrammed := func(conditionStatus metav1.ConditionStatus, reason, message string) {
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               kongv1.ConsumerConditionProgrammed,
+			Status:             conditionStatus,
+			ObservedGeneration: obj.Generation,
+			Reason:             reason,
+			Message:            message,
+		})
+	}
+
+	configured := reporter.KubernetesObjectIsConfigured(obj)
+	if msg, failed := reporter.KubernetesObjectTranslationFailure(obj); failed {
+		setProgrammed(metav1.ConditionFalse, kongv1.ConsumerReasonInvalid, msg)
+		status.ConsumerID = ""
+		status.Credentials = nil
+	} else if msg, rejected := reporter.KubernetesObjectConfigurationError(obj); rejected {
+		setProgrammed(metav1.ConditionFalse, kongv1.ConsumerReasonConfigurationRejected, msg)
+		status.Credentials = reporter.KubernetesObjectConsumerCredentials(obj)
+	} else if configured {
+		setProgrammed(metav1.ConditionTrue, kongv1.ConsumerReasonProgrammed, "")
+		status.Credentials = reporter.KubernetesObjectConsumerCredentials(obj)
+		if id := reporter.KubernetesObjectConsumerID(obj); id != "" {
+			status.ConsumerID = id
+		}
+	} else {
+		return false, false
+	}
+	status.ObservedGeneration = obj.Generation
+
+	return !reflect.DeepEqual(previous, status), configured
+}
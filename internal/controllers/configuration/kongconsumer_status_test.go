@@ -0,0 +1,95 @@
+package configuration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kongv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+)
+
+type fakeConsumerStatusReporter struct {
+	configured         bool
+	translationFailure string
+	configError        string
+	credentials        []kongv1.ConsumerCredentialStatus
+	id                 string
+}
+
+func (f fakeConsumerStatusReporter) KubernetesObjectIsConfigured(client.Object) bool {
+	return f.configured
+}
+
+func (f fakeConsumerStatusReporter) KubernetesObjectTranslationFailure(client.Object) (string, bool) {
+	return f.translationFailure, f.translationFailure != ""
+}
+
+func (f fakeConsumerStatusReporter) KubernetesObjectConfigurationError(client.Object) (string, bool) {
+	return f.configError, f.configError != ""
+}
+
+func (f fakeConsumerStatusReporter) KubernetesObjectConsumerCredentials(client.Object) []kongv1.ConsumerCredentialStatus {
+	return f.credentials
+}
+
+func (f fakeConsumerStatusReporter) KubernetesObjectConsumerID(client.Object) string {
+	return f.id
+}
+
+func Test_ensureConsumerStatus(t *testing.T) {
+	consumer := &kongv1.KongConsumer{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "alice", Generation: 3},
+		Username:   "alice",
+	}
+	credentials := []kongv1.ConsumerCredentialStatus{
+		{SecretName: "alice-key", Type: "key-auth", Reason: kongv1.CredentialReasonFound},
+		{SecretName: "alice-basic", Reason: kongv1.CredentialReasonSecretNotFound, Message: `Secret default/alice-basic not found`},
+	}
+
+	t.Log("verifying that consumers which aren't configured yet keep their status")
+	changed, configured := ensureConsumerStatus(fakeConsumerStatusReporter{}, consumer)
+	assert.False(t, changed)
+	assert.False(t, configured)
+	assert.Empty(t, consumer.Status.Conditions)
+
+	t.Log("verifying that configured consumers are programmed and report their credentials and ID")
+	reporter := fakeConsumerStatusReporter{configured: true, credentials: credentials, id: "8f3ad0a6-4ba9-4a6c-b1a0-a9a4d3cc5a0e"}
+	changed, configured = ensureConsumerStatus(reporter, consumer)
+	assert.True(t, changed)
+	assert.True(t, configured)
+	assert.Equal(t, int64(3), consumer.Status.ObservedGeneration)
+	assert.True(t, meta.IsStatusConditionTrue(consumer.Status.Conditions, kongv1.ConsumerConditionProgrammed))
+	assert.Equal(t, credentials, consumer.Status.Credentials)
+	assert.Equal(t, "8f3ad0a6-4ba9-4a6c-b1a0-a9a4d3cc5a0e", consumer.Status.ConsumerID)
+
+	t.Log("verifying that the status is only changed once")
+	changed, _ = ensureConsumerStatus(reporter, consumer)
+	assert.False(t, changed)
+
+	t.Log("verifying that the ID is kept while it's unknown")
+	reporter.id = ""
+	changed, _ = ensureConsumerStatus(reporter, consumer)
+	assert.False(t, changed)
+	assert.Equal(t, "8f3ad0a6-4ba9-4a6c-b1a0-a9a4d3cc5a0e", consumer.Status.ConsumerID)
+
+	t.Log("verifying that consumers whose configuration was rejected are not programmed")
+	changed, configured = ensureConsumerStatus(fakeConsumerStatusReporter{configError: "uniqueness violation: key", credentials: credentials}, consumer)
+	assert.True(t, changed)
+	assert.False(t, configured)
+	programmed := meta.FindStatusCondition(consumer.Status.Conditions, kongv1.ConsumerConditionProgrammed)
+	assert.Equal(t, metav1.ConditionFalse, programmed.Status)
+	assert.Equal(t, kongv1.ConsumerReasonConfigurationRejected, programmed.Reason)
+	assert.Equal(t, "uniqueness violation: key", programmed.Message)
+	assert.Equal(t, credentials, consumer.Status.Credentials)
+
+	t.Log("verifying that invalid consumers have neither credentials nor ID")
+	changed, _ = ensureConsumerStatus(fakeConsumerStatusReporter{translationFailure: "KongConsumer must have a username or a custom_id"}, consumer)
+	assert.True(t, changed)
+	programmed = meta.FindStatusCondition(consumer.Status.Conditions, kongv1.ConsumerConditionProgrammed)
+	assert.Equal(t, kongv1.ConsumerReasonInvalid, programmed.Reason)
+	assert.Empty(t, consumer.Status.Credentials)
+	assert.Empty(t, consumer.Status.ConsumerID)
+}
@@ -0,0 +1,119 @@
+package configuration
+
+import (
+	"context"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+	kongv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+)
+
+// -----------------------------------------------------------------------------
+// KongV1 KongPlugin - Status
+// -----------------------------------------------------------------------------
+
+// updateStatus updates the status of the KongPlugin with the outcome of the
+// most recent configuration of the data-plane.
+func (r *KongV1KongPluginReconciler) updateStatus(ctx context.Context, obj *kongv1.KongPlugin) (ctrl.Result, error) {
+	// objects retrieved from the cache are not guaranteed to include their kind,
+	// which the data-plane client identifies objects with.
+	obj.SetGroupVersionKind(kongv1.SchemeGroupVersion.WithKind("KongPlugin"))
+	if ensurePluginStatus(r.DataplaneClient, obj, &obj.Status) {
+		return ctrl.Result{}, r.Status().Update(ctx, obj)
+	}
+	return ctrl.Result{}, nil
+}
+
+// -----------------------------------------------------------------------------
+// KongV1 KongClusterPlugin - Status
+// -----------------------------------------------------------------------------
+
+// updateStatus updates the status of the KongClusterPlugin with the outcome of
+// the most recent configuration of the data-plane.
+func (r *KongV1KongClusterPluginReconciler) updateStatus(ctx context.Context, obj *kongv1.KongClusterPlugin) (ctrl.Result, error) {
+	// objects retrieved from the cache are not guaranteed to include their kind,
+	// which the data-plane client identifies objects with.
+	obj.SetGroupVersionKind(kongv1.SchemeGroupVersion.WithKind("KongClusterPlugin"))
+	if ensurePluginStatus(r.DataplaneClient, obj, &obj.Status) {
+		return ctrl.Result{}, r.Status().Update(ctx, obj)
+	}
+	return ctrl.Result{}, nil
+}
+
+// -----------------------------------------------------------------------------
+// Plugin Status - Helpers
+// -----------------------------------------------------------------------------
+
+// pluginStatusReporter provides the outcome of the most recent configuration
+// of the data-plane for KongPlugins and KongClusterPlugins.
+type pluginStatusReporter interface {
+	KubernetesObjectIsConfigured(obj client.Object) bool
+	KubernetesObjectTranslationFailure(obj client.Object) (string, bool)
+	KubernetesObjectConfigurationError(obj client.Object) (string, bool)
+	KubernetesObjectPluginParents(obj client.Object) []util.K8sObjectInfo
+}
+
+// ensurePluginStatus updates the provided status of a KongPlugin or
+// KongClusterPlugin with the outcome of the most recent configuration of the
+// data-plane, and returns whether it changed. Plugins which aren't configured
+// and have no problem to report are not programmed because no object refers
+// to them: the data-plane client publishes the plugins whose attachments
+// change, so their status is updated once objects refer to them again.
+func ensurePluginStatus(reporter pluginStatusReporter, obj client.Object, status *kongv1.KongPluginStatus) bool {
+	previous := status.DeepCopy()
+	generation := obj.GetGeneration()
+	setCondition := func(conditionType string, conditionStatus metav1.ConditionStatus, reason, message string) {
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               conditionType,
+			Status:             conditionStatus,
+			ObservedGeneration: generation,
+			Reason:             reason,
+			Message:            message,
+		})
+	}
+
+	if msg, failed := reporter.KubernetesObjectTranslationFailure(obj); failed {
+		setCondition(kongv1.PluginConditionAccepted, metav1.ConditionFalse, kongv1.PluginReasonInvalid, msg)
+		setCondition(kongv1.PluginConditionProgrammed, metav1.ConditionFalse, kongv1.PluginReasonInvalid,
+			"no configuration could be generated from the plugin")
+		status.Parents = nil
+	} else if msg, rejected := reporter.KubernetesObjectConfigurationError(obj); rejected {
+		setCondition(kongv1.PluginConditionAccepted, metav1.ConditionTrue, kongv1.PluginReasonAccepted, "")
+		setCondition(kongv1.PluginConditionProgrammed, metav1.ConditionFalse, kongv1.PluginReasonConfigurationRejected, msg)
+	} else if reporter.KubernetesObjectIsConfigured(obj) {
+		setCondition(kongv1.PluginConditionAccepted, metav1.ConditionTrue, kongv1.PluginReasonAccepted, "")
+		setCondition(kongv1.PluginConditionProgrammed, metav1.ConditionTrue, kongv1.PluginReasonProgrammed, "")
+		status.Parents = pluginParentReferences(reporter.KubernetesObjectPluginParents(obj))
+	} else {
+		setCondition(kongv1.PluginConditionAccepted, metav1.ConditionTrue, kongv1.PluginReasonAccepted, "")
+		setCondition(kongv1.PluginConditionProgrammed, metav1.ConditionFalse, kongv1.PluginReasonNoParents,
+			"no object refers to the plugin, so it isn't configured on the data-plane")
+		status.Parents = nil
+	}
+	status.ObservedGeneration = generation
+
+	return !reflect.DeepEqual(previous, status)
+}
+
+// pluginParentReferences converts the provided parents of a plugin to the
+// references of its status.
+func pluginParentReferences(parents []util.K8sObjectInfo) []kongv1.PluginParentReference {
+	if len(parents) == 0 {
+		return nil
+	}
+	refs := make([]kongv1.PluginParentReference, 0, len(parents))
+	for _, parent := range parents {
+		refs = append(refs, kongv1.PluginParentReference{
+			Group:     parent.GroupVersionKind.Group,
+			Kind:      parent.GroupVersionKind.Kind,
+			Namespace: parent.Namespace,
+			Name:      parent.Name,
+		})
+	}
+	return refs
+}
@@ -0,0 +1,85 @@
+package configuration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	netv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+	kongv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+)
+
+type fakePluginStatusReporter struct {
+	configured         bool
+	translationFailure string
+	configError        string
+	parents            []util.K8sObjectInfo
+}
+
+func (f fakePluginStatusReporter) KubernetesObjectIsConfigured(client.Object) bool {
+	return f.configured
+}
+
+func (f fakePluginStatusReporter) KubernetesObjectTranslationFailure(client.Object) (string, bool) {
+	return f.translationFailure, f.translationFailure != ""
+}
+
+func (f fakePluginStatusReporter) KubernetesObjectConfigurationError(client.Object) (string, bool) {
+	return f.configError, f.configError != ""
+}
+
+func (f fakePluginStatusReporter) KubernetesObjectPluginParents(client.Object) []util.K8sObjectInfo {
+	return f.parents
+}
+
+func Test_ensurePluginStatus(t *testing.T) {
+	plugin := &kongv1.KongPlugin{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "rate-limiting", Generation: 2},
+	}
+	ingress := util.K8sObjectInfo{
+		Namespace:        "default",
+		Name:             "ingress",
+		GroupVersionKind: netv1.SchemeGroupVersion.WithKind("Ingress"),
+	}
+
+	t.Log("verifying that configured plugins are programmed and list their parents")
+	reporter := fakePluginStatusReporter{configured: true, parents: []util.K8sObjectInfo{ingress}}
+	assert.True(t, ensurePluginStatus(reporter, plugin, &plugin.Status))
+	assert.Equal(t, int64(2), plugin.Status.ObservedGeneration)
+	assert.True(t, meta.IsStatusConditionTrue(plugin.Status.Conditions, kongv1.PluginConditionAccepted))
+	assert.True(t, meta.IsStatusConditionTrue(plugin.Status.Conditions, kongv1.PluginConditionProgrammed))
+	assert.Equal(t, []kongv1.PluginParentReference{
+		{Group: "networking.k8s.io", Kind: "Ingress", Namespace: "default", Name: "ingress"},
+	}, plugin.Status.Parents)
+
+	t.Log("verifying that the status is only changed once")
+	assert.False(t, ensurePluginStatus(reporter, plugin, &plugin.Status))
+
+	t.Log("verifying that plugins which no object refers to anymore are not programmed and have no parents")
+	assert.True(t, ensurePluginStatus(fakePluginStatusReporter{}, plugin, &plugin.Status))
+	assert.True(t, meta.IsStatusConditionTrue(plugin.Status.Conditions, kongv1.PluginConditionAccepted))
+	programmed := meta.FindStatusCondition(plugin.Status.Conditions, kongv1.PluginConditionProgrammed)
+	assert.Equal(t, metav1.ConditionFalse, programmed.Status)
+	assert.Equal(t, kongv1.PluginReasonNoParents, programmed.Reason)
+	assert.Empty(t, plugin.Status.Parents)
+
+	t.Log("verifying that plugins whose configuration was rejected are not programmed")
+	assert.True(t, ensurePluginStatus(fakePluginStatusReporter{configError: "invalid config.minute"}, plugin, &plugin.Status))
+	assert.True(t, meta.IsStatusConditionTrue(plugin.Status.Conditions, kongv1.PluginConditionAccepted))
+	programmed = meta.FindStatusCondition(plugin.Status.Conditions, kongv1.PluginConditionProgrammed)
+	assert.Equal(t, metav1.ConditionFalse, programmed.Status)
+	assert.Equal(t, kongv1.PluginReasonConfigurationRejected, programmed.Reason)
+	assert.Equal(t, "invalid config.minute", programmed.Message)
+
+	t.Log("verifying that invalid plugins are not accepted and have no parents")
+	assert.True(t, ensurePluginStatus(fakePluginStatusReporter{translationFailure: "invalid empty 'plugin' property"}, plugin, &plugin.Status))
+	accepted := meta.FindStatusCondition(plugin.Status.Conditions, kongv1.PluginConditionAccepted)
+	assert.Equal(t, metav1.ConditionFalse, accepted.Status)
+	assert.Equal(t, kongv1.PluginReasonInvalid, accepted.Reason)
+	assert.False(t, meta.IsStatusConditionTrue(plugin.Status.Conditions, kongv1.PluginConditionProgrammed))
+	assert.Empty(t, plugin.Status.Parents)
+}
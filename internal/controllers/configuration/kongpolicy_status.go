@@ -0,0 +1,97 @@
+package configuration
+
+import (
+	"context"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kongv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
+)
+
+// -----------------------------------------------------------------------------
+// KongV1Beta1 KongUpstreamPolicy - Status
+// -----------------------------------------------------------------------------
+
+// updateStatus updates the status of the KongUpstreamPolicy with the outcome
+// of the most recent configuration of the data-plane.
+func (r *KongV1Beta1KongUpstreamPolicyReconciler) updateStatus(ctx context.Context, obj *kongv1beta1.KongUpstreamPolicy) (ctrl.Result, error) {
+	// objects retrieved from the cache are not guaranteed to include their kind,
+	// which the data-plane client identifies objects with.
+	obj.SetGroupVersionKind(kongv1beta1.SchemeGroupVersion.WithKind("KongUpstreamPolicy"))
+	changed, configured := ensurePolicyStatus(r.DataplaneClient, obj, &obj.Status)
+	if changed {
+		return ctrl.Result{}, r.Status().Update(ctx, obj)
+	}
+	return ctrl.Result{Requeue: !configured}, nil
+}
+
+// -----------------------------------------------------------------------------
+// KongV1Beta1 KongServicePolicy - Status
+// -----------------------------------------------------------------------------
+
+// updateStatus updates the status of the KongServicePolicy with the outcome
+// of the most recent configuration of the data-plane.
+func (r *KongV1Beta1KongServicePolicyReconciler) updateStatus(ctx context.Context, obj *kongv1beta1.KongServicePolicy) (ctrl.Result, error) {
+	// objects retrieved from the cache are not guaranteed to include their kind,
+	// which the data-plane client identifies objects with.
+	obj.SetGroupVersionKind(kongv1beta1.SchemeGroupVersion.WithKind("KongServicePolicy"))
+	changed, configured := ensurePolicyStatus(r.DataplaneClient, obj, &obj.Status)
+	if changed {
+		return ctrl.Result{}, r.Status().Update(ctx, obj)
+	}
+	return ctrl.Result{Requeue: !configured}, nil
+}
+
+// -----------------------------------------------------------------------------
+// Policy Status - Helpers
+// -----------------------------------------------------------------------------
+
+// policyStatusReporter provides the outcome of the most recent configuration
+// of the data-plane for KongUpstreamPolicies and KongServicePolicies.
+type policyStatusReporter interface {
+	KubernetesObjectIsConfigured(obj client.Object) bool
+	KubernetesObjectTranslationFailure(obj client.Object) (string, bool)
+	KubernetesObjectConfigurationError(obj client.Object) (string, bool)
+}
+
+// ensurePolicyStatus updates the provided status of a KongUpstreamPolicy or
+// KongServicePolicy with the outcome of the most recent configuration of the
+// data-plane. It returns whether the status changed, and whether the policy is
+// configured on the data-plane. Policies which aren't configured yet, e.g.
+// because they aren't attached to any Service in use, keep their status until
+// they are.
+func ensurePolicyStatus(reporter policyStatusReporter, obj client.Object, status *kongv1beta1.KongPolicyStatus) (bool, bool) {
+	previous := status.DeepCopy()
+	generation := obj.GetGeneration()
+	setCondition := func(conditionType string, conditionStatus metav1.ConditionStatus, reason, message string) {
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               conditionType,
+			Status:             conditionStatus,
+			ObservedGeneration: generation,
+			Reason:             reason,
+			Message:            message,
+		})
+	}
+
+	configured := reporter.KubernetesObjectIsConfigured(obj)
+	if msg, failed := reporter.KubernetesObjectTranslationFailure(obj); failed {
+		setCondition(kongv1beta1.PolicyConditionAccepted, metav1.ConditionFalse, kongv1beta1.PolicyReasonInvalid, msg)
+		setCondition(kongv1beta1.PolicyConditionProgrammed, metav1.ConditionFalse, kongv1beta1.PolicyReasonInvalid,
+			"no configuration could be generated from the policy")
+	} else if msg, rejected := reporter.KubernetesObjectConfigurationError(obj); rejected {
+		setCondition(kongv1beta1.PolicyConditionAccepted, metav1.ConditionTrue, kongv1beta1.PolicyReasonAccepted, "")
+		setCondition(kongv1beta1.PolicyConditionProgrammed, metav1.ConditionFalse, kongv1beta1.PolicyReasonConfigurationRejected, msg)
+	} else if configured {
+		setCondition(kongv1beta1.PolicyConditionAccepted, metav1.ConditionTrue, kongv1beta1.PolicyReasonAccepted, "")
+		setCondition(kongv1beta1.PolicyConditionProgrammed, metav1.ConditionTrue, kongv1beta1.PolicyReasonProgrammed, "")
+	} else {
+		return false, false
+	}
+	status.ObservedGeneration = generation
+
+	return !reflect.DeepEqual(previous, status), configured
+}
@@ -0,0 +1,55 @@
+package configuration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kongv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
+)
+
+func Test_ensurePolicyStatus(t *testing.T) {
+	policy := &kongv1beta1.KongUpstreamPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "sticky", Generation: 3},
+	}
+
+	t.Log("verifying that policies which aren't configured yet keep their status")
+	changed, configured := ensurePolicyStatus(fakePluginStatusReporter{}, policy, &policy.Status)
+	assert.False(t, changed)
+	assert.False(t, configured)
+	assert.Empty(t, policy.Status.Conditions)
+
+	t.Log("verifying that configured policies are accepted and programmed")
+	reporter := fakePluginStatusReporter{configured: true}
+	changed, configured = ensurePolicyStatus(reporter, policy, &policy.Status)
+	assert.True(t, changed)
+	assert.True(t, configured)
+	assert.Equal(t, int64(3), policy.Status.ObservedGeneration)
+	assert.True(t, meta.IsStatusConditionTrue(policy.Status.Conditions, kongv1beta1.PolicyConditionAccepted))
+	assert.True(t, meta.IsStatusConditionTrue(policy.Status.Conditions, kongv1beta1.PolicyConditionProgrammed))
+
+	t.Log("verifying that the status is only changed once")
+	changed, _ = ensurePolicyStatus(reporter, policy, &policy.Status)
+	assert.False(t, changed)
+
+	t.Log("verifying that policies whose configuration was rejected are not programmed")
+	changed, configured = ensurePolicyStatus(fakePluginStatusReporter{configError: "invalid healthchecks"}, policy, &policy.Status)
+	assert.True(t, changed)
+	assert.False(t, configured)
+	assert.True(t, meta.IsStatusConditionTrue(policy.Status.Conditions, kongv1beta1.PolicyConditionAccepted))
+	programmed := meta.FindStatusCondition(policy.Status.Conditions, kongv1beta1.PolicyConditionProgrammed)
+	assert.Equal(t, metav1.ConditionFalse, programmed.Status)
+	assert.Equal(t, kongv1beta1.PolicyReasonConfigurationRejected, programmed.Reason)
+	assert.Equal(t, "invalid healthchecks", programmed.Message)
+
+	t.Log("verifying that policies which couldn't be applied are not accepted")
+	changed, _ = ensurePolicyStatus(fakePluginStatusReporter{translationFailure: "conflicting policies"}, policy, &policy.Status)
+	assert.True(t, changed)
+	accepted := meta.FindStatusCondition(policy.Status.Conditions, kongv1beta1.PolicyConditionAccepted)
+	assert.Equal(t, metav1.ConditionFalse, accepted.Status)
+	assert.Equal(t, kongv1beta1.PolicyReasonInvalid, accepted.Reason)
+	assert.Equal(t, "conflicting policies", accepted.Message)
+	assert.False(t, meta.IsStatusConditionTrue(policy.Status.Conditions, kongv1beta1.PolicyConditionProgrammed))
+}
@@ -7,8 +7,11 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/kong/go-kong/kong"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -22,19 +25,16 @@ import (
 	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
+	ctrlutils "github.com/kong/kubernetes-ingress-controller/v2/internal/controllers/utils"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane"
+	configurationv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
 )
 
 // -----------------------------------------------------------------------------
 // Vars & Consts
 // -----------------------------------------------------------------------------
 
-var (
-	// ManagedGatewaysUnsupported is an error used whenever a failure occurs
-	// due to a Gateway that is not properly configured for unmanaged mode.
-	ManagedGatewaysUnsupported = fmt.Errorf("invalid gateway spec: managed gateways are not currently supported") //nolint:revive
-	gatewayV1alpha2Group       = gatewayv1alpha2.Group(gatewayv1alpha2.GroupName)
-)
+var gatewayV1alpha2Group = gatewayv1alpha2.Group(gatewayv1alpha2.GroupName)
 
 // -----------------------------------------------------------------------------
 // Gateway Controller - GatewayReconciler
@@ -51,15 +51,16 @@ type GatewayReconciler struct { //nolint:revive
 	PublishService  string
 	WatchNamespaces []string
 
-	publishServiceRef types.NamespacedName
+	// ControllerPodPeer selects the pods of the controller, which are the only
+	// clients the NetworkPolicies of managed Gateways let reach the Admin API
+	// of their proxies. No NetworkPolicy is provisioned when it's not set.
+	ControllerPodPeer *networkingv1.NetworkPolicyPeer
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *GatewayReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	// verify that the PublishService was configured properly
-	var err error
-	r.publishServiceRef, err = getRefFromPublishService(r.PublishService)
-	if err != nil {
+	if _, err := getRefFromPublishService(r.PublishService); err != nil {
 		return err
 	}
 
@@ -84,6 +85,27 @@ func (r *GatewayReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
+	// managed gateways own the objects provisioned for their proxies, changes to those objects
+	// (e.g. the deployment becoming available, or the service being assigned addresses) need
+	// to be reflected in the status of the gateway.
+	for _, owned := range []client.Object{&appsv1.Deployment{}, &corev1.Service{}} {
+		if err := c.Watch(
+			&source.Kind{Type: owned},
+			&handler.EnqueueRequestForOwner{OwnerType: &gatewayv1alpha2.Gateway{}, IsController: true},
+		); err != nil {
+			return err
+		}
+	}
+
+	// the endpoints of the admin service of a managed gateway list the proxy instances which
+	// need to be configured with the routes attached to the gateway.
+	if err := c.Watch(
+		&source.Kind{Type: &corev1.Endpoints{}},
+		handler.EnqueueRequestsFromMapFunc(r.listGatewaysForEndpoints),
+	); err != nil {
+		return err
+	}
+
 	// watch for updates to gatewayclasses, if any gateway classes change, enqueue
 	// reconciliation for all supported gateway objects which reference it.
 	if err := c.Watch(
@@ -105,11 +127,32 @@ func (r *GatewayReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
+	// if a Secret referenced by the listeners of a Gateway changes, the
+	// ResolvedRefs conditions of the listeners need to be updated.
+	if err := c.Watch(
+		&source.Kind{Type: &corev1.Secret{}},
+		handler.EnqueueRequestsFromMapFunc(r.listGatewaysForSecret),
+	); err != nil {
+		return err
+	}
+
+	// the GatewayConfiguration referenced by a gatewayclass may provide the publish service
+	// of its unmanaged gateways, which need to be updated when it changes.
+	if ctrlutils.CRDExists(r.Client, configurationv1beta1.GroupVersion.WithResource("gatewayconfigurations")) {
+		if err := c.Watch(
+			&source.Kind{Type: &configurationv1beta1.GatewayConfiguration{}},
+			handler.EnqueueRequestsFromMapFunc(r.listGatewaysForGatewayConfiguration),
+		); err != nil {
+			return err
+		}
+	}
+
 	// start the required gatewayclass controller as well
 	gwcCTRL := &GatewayClassReconciler{
-		Client: r.Client,
-		Log:    r.Log.WithName("V1Alpha2GatewayClass"),
-		Scheme: r.Scheme,
+		Client:          r.Client,
+		Log:             r.Log.WithName("V1Alpha2GatewayClass"),
+		Scheme:          r.Scheme,
+		DataplaneClient: r.DataplaneClient,
 	}
 	return gwcCTRL.SetupWithManager(mgr)
 }
@@ -157,6 +200,29 @@ func (r *GatewayReconciler) listGatewaysForGatewayClass(gatewayClass client.Obje
 	return reconcileGatewaysIfClassMatches(gatewayClass, gateways.Items)
 }
 
+// listGatewaysForGatewayConfiguration is a watch predicate which finds all the gateway objects
+// using a GatewayClass which references the given GatewayConfiguration, and enqueues them for
+// reconciliation.
+func (r *GatewayReconciler) listGatewaysForGatewayConfiguration(config client.Object) (recs []reconcile.Request) {
+	gatewayClasses := &gatewayv1alpha2.GatewayClassList{}
+	if err := r.Client.List(context.Background(), gatewayClasses); err != nil {
+		r.Log.Error(err, "failed to list gatewayclasses for gatewayconfiguration in watch", "gatewayconfiguration", client.ObjectKeyFromObject(config))
+		return
+	}
+	gateways := &gatewayv1alpha2.GatewayList{}
+	if err := r.Client.List(context.Background(), gateways); err != nil {
+		r.Log.Error(err, "failed to list gateways for gatewayconfiguration in watch", "gatewayconfiguration", client.ObjectKeyFromObject(config))
+		return
+	}
+	for i := range gatewayClasses.Items {
+		gatewayClass := &gatewayClasses.Items[i]
+		if ref, ok := getGatewayConfigurationRef(gatewayClass); ok && ref == client.ObjectKeyFromObject(config) {
+			recs = append(recs, reconcileGatewaysIfClassMatches(gatewayClass, gateways.Items)...)
+		}
+	}
+	return
+}
+
 // listGatewaysForService is a watch predicate which finds all the gateway objects which use
 // GatewayClasses supported by this controller and are configured for the same service via
 // unmanaged mode and enqueues them for reconciliation. This is generally used to ensure
@@ -173,7 +239,7 @@ func (r *GatewayReconciler) listGatewaysForService(svc client.Object) (recs []re
 			r.Log.Error(err, "failed to retrieve gateway class in watch predicates", "gatewayclass", gateway.Spec.GatewayClassName)
 			return
 		}
-		if isGatewayInClassAndUnmanaged(gatewayClass, gateway) {
+		if isGatewayInClassAndUnmanaged(gatewayClass, gateway) && isGatewayUsingService(gateway, svc) {
 			recs = append(recs, reconcile.Request{
 				NamespacedName: types.NamespacedName{
 					Namespace: gateway.Namespace,
@@ -186,9 +252,22 @@ func (r *GatewayReconciler) listGatewaysForService(svc client.Object) (recs []re
 }
 
 // isGatewayService is a watch predicate that filters out events for objects that aren't
-// the gateway service referenced by --publish-service.
+// the gateway service referenced by --publish-service, or by unmanaged gateways.
 func (r *GatewayReconciler) isGatewayService(obj client.Object) bool {
-	return fmt.Sprintf("%s/%s", obj.GetNamespace(), obj.GetName()) == r.PublishService
+	if fmt.Sprintf("%s/%s", obj.GetNamespace(), obj.GetName()) == r.PublishService {
+		return true
+	}
+	gateways := &gatewayv1alpha2.GatewayList{}
+	if err := r.Client.List(context.Background(), gateways); err != nil {
+		r.Log.Error(err, "failed to list gateways for service in watch predicates", "service", client.ObjectKeyFromObject(obj))
+		return false
+	}
+	for _, gateway := range gateways.Items {
+		if isGatewayUsingService(gateway, obj) {
+			return true
+		}
+	}
+	return false
 }
 
 // -----------------------------------------------------------------------------
@@ -208,8 +287,11 @@ func (r *GatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	gateway := new(gatewayv1alpha2.Gateway)
 	if err := r.Get(ctx, req.NamespacedName, gateway); err != nil {
 		if errors.IsNotFound(err) {
-			debug(log, gateway, "reconciliation triggered but gateway does not exist, ignoring")
-			return ctrl.Result{Requeue: false}, nil
+			debug(log, gateway, "reconciliation triggered but gateway does not exist, ensuring it is not present in the data-plane cache")
+			gateway.Namespace = req.Namespace
+			gateway.Name = req.Name
+			r.DataplaneClient.DeleteGatewayProxies(req.NamespacedName)
+			return ctrl.Result{Requeue: false}, r.DataplaneClient.DeleteObject(gateway)
 		}
 		return ctrl.Result{Requeue: true}, err
 	}
@@ -227,27 +309,42 @@ func (r *GatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	}
 	if gwc.Spec.ControllerName != ControllerName {
 		debug(log, gateway, "unsupported gatewayclass controllername, ignoring", "gatewayclass", gwc.Name, "controllername", gwc.Spec.ControllerName)
-		return ctrl.Result{}, nil
+		r.DataplaneClient.DeleteGatewayProxies(req.NamespacedName)
+		return ctrl.Result{}, r.DataplaneClient.DeleteObject(gateway)
 	}
 
-	// if there's any deletion timestamp on the object, we can simply ignore it. At this point
-	// with unmanaged mode being the only option supported there are no finalizers and
-	// the object should be cleaned up by GC promptly.
+	// if there's any deletion timestamp on the object, we can simply ignore it. There are no
+	// finalizers: the objects provisioned for managed gateways are owned by them, so the
+	// gateway and those objects should be cleaned up by GC promptly.
 	debug(log, gateway, "checking deletion timestamp")
 	if gateway.DeletionTimestamp != nil {
-		debug(log, gateway, "gateway is being deleted, ignoring")
-		return ctrl.Result{Requeue: false}, nil
+		debug(log, gateway, "gateway is being deleted, ensuring it is not present in the data-plane cache")
+		r.DataplaneClient.DeleteGatewayProxies(req.NamespacedName)
+		return ctrl.Result{Requeue: false}, r.DataplaneClient.DeleteObject(gateway)
 	}
 
-	// reconciliation assumes unmanaged mode, in the future we may have a slot here for
-	// other gateway management modes.
-	return r.reconcileUnmanagedGateway(ctx, log, gateway)
+	// gateways of classes with managed gateway parameters get their own proxies provisioned,
+	// other gateways are attached to the pre-existing proxies in unmanaged mode.
+	config, err := r.getGatewayConfiguration(ctx, gwc)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if isGatewayManaged(gwc, config, gateway) {
+		return r.reconcileManagedGateway(ctx, log, gateway, gwc, config)
+	}
+	r.DataplaneClient.DeleteGatewayProxies(req.NamespacedName)
+	return r.reconcileUnmanagedGateway(ctx, log, gateway, gwc)
 }
 
 // reconcileUnmanagedGateway reconciles a Gateway that is configured for unmanaged mode,
 // this mode will extract the Addresses and Listeners for the Gateway from the Kubernetes Service
 // used for the Kong Gateway in the pre-existing deployment.
-func (r *GatewayReconciler) reconcileUnmanagedGateway(ctx context.Context, log logr.Logger, gateway *gatewayv1alpha2.Gateway) (ctrl.Result, error) {
+func (r *GatewayReconciler) reconcileUnmanagedGateway(
+	ctx context.Context,
+	log logr.Logger,
+	gateway *gatewayv1alpha2.Gateway,
+	gwc *gatewayv1alpha2.GatewayClass,
+) (ctrl.Result, error) {
 	// currently this controller supports only unmanaged gateway mode, we need to verify
 	// any Gateway object that comes to us is configured appropriately, and if not reject it
 	// with a clear status condition and message.
@@ -255,23 +352,30 @@ func (r *GatewayReconciler) reconcileUnmanagedGateway(ctx context.Context, log l
 	unmanagedAnnotation := annotations.AnnotationPrefix + annotations.GatewayUnmanagedAnnotation
 	existingGatewayEnabled, ok := annotations.ExtractUnmanagedGatewayMode(gateway.GetAnnotations())
 
+	// the publish service of the gateways of a class can be overridden by the GatewayConfiguration
+	// the class references, and otherwise defaults to the one provided via --publish-service.
+	publishService, err := r.getPublishServiceForGatewayClass(ctx, gwc)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// allow for Gateway resources to be configured with "true" in place of the publish service
 	// reference as a placeholder to automatically populate the annotation with the namespace/name
-	// that was provided to the controller manager via --publish-service.
+	// of the publish service of the gateway class.
 	debug(log, gateway, "initializing admin service annotation if unset")
 	if !ok || existingGatewayEnabled == "true" { // true is a placeholder which triggers auto-initialization of the ref
-		debug(log, gateway, fmt.Sprintf("a placeholder value was provided for %s, adding the default service ref %s", unmanagedAnnotation, r.PublishService))
+		debug(log, gateway, fmt.Sprintf("a placeholder value was provided for %s, adding the default service ref %s", unmanagedAnnotation, publishService))
 		if gateway.Annotations == nil {
 			gateway.Annotations = make(map[string]string)
 		}
-		gateway.Annotations[unmanagedAnnotation] = r.PublishService
+		gateway.Annotations[unmanagedAnnotation] = publishService
 		return ctrl.Result{}, r.Update(ctx, gateway)
 	}
 
 	// validation check of the Gateway to ensure that the publish service is actually available
 	// in the cluster. If it is not the object will be requeued until it exists (or is otherwise retrievable).
 	debug(log, gateway, "gathering the gateway publish service") // this will also be done by the validating webhook, this is a fallback
-	svc, err := r.determineServiceForGateway(ctx, existingGatewayEnabled)
+	svc, err := r.determineServiceForGateway(ctx, existingGatewayEnabled, publishService)
 	if err != nil {
 		log.Error(err, "could not determine service for gateway", "namespace", gateway.Namespace, "name", gateway.Name)
 		return ctrl.Result{Requeue: true}, err
@@ -314,6 +418,7 @@ func (r *GatewayReconciler) reconcileUnmanagedGateway(ctx context.Context, log l
 	}
 
 	gatewayListeners = mergeAllowedRoutes(gateway.Spec.Listeners, gatewayListeners)
+	gatewayListeners = mergeListenerTLS(gateway.Spec.Listeners, gatewayListeners)
 
 	debug(log, gateway, "updating the gateway if any changes to listeners occurred")
 	isChanged, err := r.updateAddressesAndListenersSpec(ctx, gateway, gatewayAddresses, gatewayListeners)
@@ -329,11 +434,25 @@ func (r *GatewayReconciler) reconcileUnmanagedGateway(ctx context.Context, log l
 		return ctrl.Result{}, nil // dont requeue here because spec update will trigger new reconciliation
 	}
 
+	// the TLS configuration of the listeners is translated into Kong certificates,
+	// so the Gateway needs to be known to the data-plane once its spec is final.
+	debug(log, gateway, "ensuring the gateway is present in the data-plane cache")
+	if err := r.DataplaneClient.UpdateObject(gateway); err != nil {
+		debug(log, gateway, "failed to update object in data-plane, requeueing")
+		return ctrl.Result{}, err
+	}
+
 	// once specification matches the reference Service, all that's left to do is ensure that the
 	// Gateway status reflects the spec. As the status is simply a mirror of the Service, this is
-	// a given and we can simply update spec to status.
+	// a given and we can simply update spec to status, along with the resolution of the
+	// certificateRefs of the listeners.
+	debug(log, gateway, "resolving the certificateRefs of the gateway listeners")
+	resolvedRefs, err := r.resolveListenerCertificateRefs(ctx, gateway)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
 	debug(log, gateway, "updating the gateway status if necessary")
-	isChanged, err = r.updateAddressesAndListenersStatus(ctx, gateway)
+	isChanged, err = r.updateAddressesAndListenersStatus(ctx, gateway, gateway.Spec.Addresses, resolvedRefs)
 	if err != nil {
 		if errors.IsConflict(err) {
 			// if there's a conflict that's normal just requeue to retry, no need to make noise.
@@ -375,19 +494,51 @@ func init() {
 	}
 }
 
+// getPublishServiceForGatewayClass provides the "namespace/name" of the publish service of the
+// unmanaged gateways of a class, which is set by the GatewayConfiguration referenced by the class
+// and otherwise defaults to the --publish-service provided to the controller manager.
+func (r *GatewayReconciler) getPublishServiceForGatewayClass(ctx context.Context, gwc *gatewayv1alpha2.GatewayClass) (string, error) {
+	config, err := r.getGatewayConfiguration(ctx, gwc)
+	if err != nil {
+		return "", err
+	}
+	if config == nil || config.Spec.PublishService == "" {
+		return r.PublishService, nil
+	}
+	return config.Spec.PublishService, nil
+}
+
+// getGatewayConfiguration provides the GatewayConfiguration referenced by the parametersRef of a
+// gatewayclass, or nil if it doesn't reference one.
+func (r *GatewayReconciler) getGatewayConfiguration(ctx context.Context, gwc *gatewayv1alpha2.GatewayClass) (*configurationv1beta1.GatewayConfiguration, error) {
+	ref, ok := getGatewayConfigurationRef(gwc)
+	if !ok {
+		return nil, nil
+	}
+	config := &configurationv1beta1.GatewayConfiguration{}
+	if err := r.Client.Get(ctx, ref, config); err != nil {
+		return nil, fmt.Errorf("failed to get GatewayConfiguration %s of gatewayclass %s: %w", ref, gwc.Name, err)
+	}
+	return config, nil
+}
+
 // determineServiceForGateway provides the "publish service" (aka the proxy Service) object which
 // will be used to populate unmanaged gateways.
-func (r *GatewayReconciler) determineServiceForGateway(ctx context.Context, ref string) (*corev1.Service, error) {
-	// currently the gateway controller ONLY supports service references that correspond with the --publish-service
-	// provided to the controller manager via flags when operating on unmanaged gateways. This constraint may
-	// be loosened in later iterations if there is need.
-	if ref != r.PublishService {
-		return nil, fmt.Errorf("service ref %s did not match controller manager ref %s", ref, r.PublishService)
+func (r *GatewayReconciler) determineServiceForGateway(ctx context.Context, ref, publishService string) (*corev1.Service, error) {
+	// currently the gateway controller ONLY supports service references that correspond with the publish
+	// service of the gateway class when operating on unmanaged gateways. This constraint may be loosened
+	// in later iterations if there is need.
+	if ref != publishService {
+		return nil, fmt.Errorf("service ref %s did not match gatewayclass publish service ref %s", ref, publishService)
+	}
+	publishServiceRef, err := getRefFromPublishService(publishService)
+	if err != nil {
+		return nil, err
 	}
 
 	// retrieve the service for the kong gateway
 	svc := &corev1.Service{}
-	return svc, r.Client.Get(ctx, r.publishServiceRef, svc)
+	return svc, r.Client.Get(ctx, publishServiceRef, svc)
 }
 
 // determineL4ListenersFromService generates L4 addresses and listeners for a
@@ -533,6 +684,122 @@ func (r *GatewayReconciler) determineListenersFromDataPlane(ctx context.Context,
 	return upgradedListeners, nil
 }
 
+// -----------------------------------------------------------------------------
+// Gateway Controller - Listener Certificate Resolution Methods
+// -----------------------------------------------------------------------------
+
+// resolveListenerCertificateRefs determines the ResolvedRefs conditions of the listeners of the provided
+// Gateway which terminate TLS, indexed by listener name. A listener resolves its refs when all its
+// certificateRefs are Secrets which exist, contain a certificate and key, and, when they are in another
+// namespace than the Gateway, are permitted by a ReferencePolicy. As Kong serves certificates for SNIs,
+// the refs of listeners without a hostname are never resolved.
+func (r *GatewayReconciler) resolveListenerCertificateRefs(
+	ctx context.Context,
+	gateway *gatewayv1alpha2.Gateway,
+) (map[gatewayv1alpha2.SectionName]metav1.Condition, error) {
+	policies := &gatewayv1alpha2.ReferencePolicyList{}
+	if err := r.Client.List(ctx, policies); err != nil && !meta.IsNoMatchError(err) {
+		return nil, fmt.Errorf("could not retrieve ReferencePolicies: %w", err)
+	}
+
+	conditions := make(map[gatewayv1alpha2.SectionName]metav1.Condition)
+	for _, listener := range gateway.Spec.Listeners {
+		if listener.TLS == nil || (listener.TLS.Mode != nil && *listener.TLS.Mode == gatewayv1alpha2.TLSModePassthrough) {
+			continue
+		}
+
+		condition := metav1.Condition{
+			Type:               string(gatewayv1alpha2.ListenerConditionResolvedRefs),
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: gateway.Generation,
+			LastTransitionTime: metav1.Now(),
+			Reason:             string(gatewayv1alpha2.ListenerReasonResolvedRefs),
+			Message:            "all certificateRefs of the listener were resolved",
+		}
+		for _, ref := range listener.TLS.CertificateRefs {
+			reason, message, err := r.resolveCertificateRef(ctx, gateway, ref, policies.Items)
+			if err != nil {
+				return nil, err
+			}
+			if reason != "" {
+				condition.Status = metav1.ConditionFalse
+				condition.Reason = string(reason)
+				condition.Message = message
+				break
+			}
+		}
+		if condition.Status == metav1.ConditionTrue && (listener.Hostname == nil || *listener.Hostname == "") {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = string(gatewayv1alpha2.ListenerReasonInvalidCertificateRef)
+			condition.Message = "certificates can only be served for listeners with a hostname, Kong serves its default certificate instead"
+		}
+		conditions[listener.Name] = condition
+	}
+	return conditions, nil
+}
+
+// resolveCertificateRef provides the reason and a message why the provided certificateRef of a listener of
+// the provided Gateway can't be resolved, or an empty reason if it can.
+func (r *GatewayReconciler) resolveCertificateRef(
+	ctx context.Context,
+	gateway *gatewayv1alpha2.Gateway,
+	ref *gatewayv1alpha2.SecretObjectReference,
+	policies []gatewayv1alpha2.ReferencePolicy,
+) (gatewayv1alpha2.ListenerConditionReason, string, error) {
+	if ref == nil {
+		return gatewayv1alpha2.ListenerReasonInvalidCertificateRef, "a certificateRef is empty", nil
+	}
+	if (ref.Group != nil && *ref.Group != "") || (ref.Kind != nil && *ref.Kind != "Secret") {
+		return gatewayv1alpha2.ListenerReasonInvalidCertificateRef,
+			fmt.Sprintf("certificateRef %s refers to an unsupported kind, only Secrets are supported", ref.Name), nil
+	}
+
+	namespace := gateway.Namespace
+	if ref.Namespace != nil && string(*ref.Namespace) != gateway.Namespace {
+		namespace = string(*ref.Namespace)
+		if !isSecretRefPermittedByPolicies(gateway.Namespace, *ref, policies) {
+			return gatewayv1alpha2.ListenerReasonRefNotPermitted,
+				fmt.Sprintf("certificateRef to Secret %s/%s is not permitted by any ReferencePolicy", namespace, ref.Name), nil
+		}
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: string(ref.Name)}, secret); err != nil {
+		if errors.IsNotFound(err) {
+			return gatewayv1alpha2.ListenerReasonInvalidCertificateRef,
+				fmt.Sprintf("Secret %s/%s does not exist", namespace, ref.Name), nil
+		}
+		return "", "", err
+	}
+	if len(secret.Data[corev1.TLSCertKey]) == 0 || len(secret.Data[corev1.TLSPrivateKeyKey]) == 0 {
+		return gatewayv1alpha2.ListenerReasonInvalidCertificateRef,
+			fmt.Sprintf("Secret %s/%s does not contain a %s and a %s", namespace, ref.Name, corev1.TLSCertKey, corev1.TLSPrivateKeyKey), nil
+	}
+	return "", "", nil
+}
+
+// listGatewaysForSecret is a watch predicate which finds all the gateway objects with listeners
+// which reference a Secret in their certificateRefs, to update their listener status when the
+// Secret changes.
+func (r *GatewayReconciler) listGatewaysForSecret(secret client.Object) (recs []reconcile.Request) {
+	gateways := &gatewayv1alpha2.GatewayList{}
+	if err := r.Client.List(context.Background(), gateways); err != nil {
+		r.Log.Error(err, "failed to list gateways for secret in watch predicates", "secret", secret.GetName())
+		return
+	}
+	for _, gateway := range gateways.Items {
+		if isSecretReferencedByGateway(gateway, secret.GetNamespace(), secret.GetName()) {
+			recs = append(recs, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: gateway.Namespace,
+					Name:      gateway.Name,
+				},
+			})
+		}
+	}
+	return
+}
+
 // -----------------------------------------------------------------------------
 // Gateway Controller - Private Object Update Methods
 // -----------------------------------------------------------------------------
@@ -553,15 +820,26 @@ func (r *GatewayReconciler) updateAddressesAndListenersSpec(ctx context.Context,
 	return true, r.Update(ctx, gateway)
 }
 
-// updateAddressesAndListenersStatus updates a unmanaged gateway's status with new addresses and listeners.
+// updateAddressesAndListenersStatus updates a gateway's status with new addresses and the listeners of its spec.
 // If the addresses and listeners provided are the same as what exists, it is assumed that reconciliation is complete and a Ready condition is posted.
+// The ResolvedRefs conditions of the listeners are updated whenever they change, as they depend on
+// Secrets and ReferencePolicies rather than on the Gateway itself.
 func (r *GatewayReconciler) updateAddressesAndListenersStatus(
 	ctx context.Context,
 	gateway *gatewayv1alpha2.Gateway,
+	addresses []gatewayv1alpha2.GatewayAddress,
+	resolvedRefs map[gatewayv1alpha2.SectionName]metav1.Condition,
 ) (bool, error) {
+	listenerStatuses := convertListenersToListenerStatuses(gateway, resolvedRefs)
+	if isGatewayReady(gateway) &&
+		areAddressesEqual(gateway.Status.Addresses, addresses) &&
+		areListenerStatusesEqual(gateway.Status.Listeners, listenerStatuses) {
+		return false, nil
+	}
+
+	gateway.Status.Listeners = listenerStatuses
+	gateway.Status.Addresses = addresses
 	if !isGatewayReady(gateway) {
-		gateway.Status.Listeners = convertListenersToListenerStatuses(gateway)
-		gateway.Status.Addresses = gateway.Spec.Addresses
 		gateway.Status.Conditions = append(gateway.Status.Conditions, metav1.Condition{
 			Type:               string(gatewayv1alpha2.GatewayConditionReady),
 			Status:             metav1.ConditionTrue,
@@ -570,9 +848,8 @@ func (r *GatewayReconciler) updateAddressesAndListenersStatus(
 			Reason:             string(gatewayv1alpha2.GatewayReasonReady),
 			Message:            "addresses and listeners for the Gateway resource were successfully updated",
 		})
-		return true, r.Status().Update(ctx, pruneGatewayStatusConds(gateway))
 	}
-	return false, nil
+	return true, r.Status().Update(ctx, pruneGatewayStatusConds(gateway))
 }
 
 // mergeAllowedRoutes takes two sets of listeners, scans the source for AllowedRoutes filters, and copies those into
@@ -597,6 +874,23 @@ func mergeAllowedRoutes(source, target []gatewayv1alpha2.Listener) (merged []gat
 	return merged
 }
 
+// mergeListenerTLS takes two sets of listeners and copies the hostname and TLS configuration of the source listeners
+// into the target listeners with the same port and protocol. The target listeners are derived from the proxy Service,
+// which has a single port per listener, so only the first source listener configured with TLS for a port is used.
+func mergeListenerTLS(source, target []gatewayv1alpha2.Listener) (merged []gatewayv1alpha2.Listener) {
+	for _, listener := range target {
+		for _, sourceListener := range source {
+			if sourceListener.TLS != nil && sourceListener.Port == listener.Port && sourceListener.Protocol == listener.Protocol {
+				listener.Hostname = sourceListener.Hostname
+				listener.TLS = sourceListener.TLS
+				break
+			}
+		}
+		merged = append(merged, listener)
+	}
+	return merged
+}
+
 // areAllowedRoutesConsistentByProtocol returns an error if a set of listeners includes multiple listeners for the same
 // protocol that do not use the same AllowedRoutes filters. Kong does not support limiting routes to a specific listen:
 // all routes are always served on all listens compatible with their protocol. As such, while we can filter the routes
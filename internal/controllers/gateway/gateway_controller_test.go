@@ -1,15 +1,22 @@
 package gateway
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
+	configurationv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
 )
 
 func Test_readyConditionExistsForObservedGeneration(t *testing.T) {
@@ -1021,3 +1028,162 @@ func Test_areAllowedRoutesConsistentByProtocol(t *testing.T) {
 		assert.Equal(t, input.expected, areAllowedRoutesConsistentByProtocol(input.l), input.message)
 	}
 }
+
+func Test_mergeListenerTLS(t *testing.T) {
+	hostname := gatewayv1alpha2.Hostname("konghq.com")
+	tls := &gatewayv1alpha2.GatewayTLSConfig{
+		CertificateRefs: []*gatewayv1alpha2.SecretObjectReference{{Name: "cert"}},
+	}
+	source := []gatewayv1alpha2.Listener{
+		{Name: "user-https", Hostname: &hostname, Port: 443, Protocol: gatewayv1alpha2.HTTPSProtocolType, TLS: tls},
+		{Name: "user-http", Port: 80, Protocol: gatewayv1alpha2.HTTPProtocolType},
+	}
+	target := []gatewayv1alpha2.Listener{
+		{Name: "proxy-ssl", Port: 443, Protocol: gatewayv1alpha2.HTTPSProtocolType},
+		{Name: "proxy", Port: 80, Protocol: gatewayv1alpha2.HTTPProtocolType},
+		{Name: "proxy-tls", Port: 8443, Protocol: gatewayv1alpha2.TLSProtocolType},
+	}
+	assert.Equal(t, []gatewayv1alpha2.Listener{
+		{Name: "proxy-ssl", Hostname: &hostname, Port: 443, Protocol: gatewayv1alpha2.HTTPSProtocolType, TLS: tls},
+		{Name: "proxy", Port: 80, Protocol: gatewayv1alpha2.HTTPProtocolType},
+		{Name: "proxy-tls", Port: 8443, Protocol: gatewayv1alpha2.TLSProtocolType},
+	}, mergeListenerTLS(source, target))
+}
+
+func TestGatewayReconciler_resolveListenerCertificateRefs(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, gatewayv1alpha2.AddToScheme(scheme))
+
+	certsNamespace := gatewayv1alpha2.Namespace("certs")
+	configMap := gatewayv1alpha2.Kind("ConfigMap")
+	passthrough := gatewayv1alpha2.TLSModePassthrough
+	hostname := gatewayv1alpha2.Hostname("konghq.com")
+	listenerWithRef := func(name string, ref gatewayv1alpha2.SecretObjectReference) gatewayv1alpha2.Listener {
+		return gatewayv1alpha2.Listener{
+			Name:     gatewayv1alpha2.SectionName(name),
+			Hostname: &hostname,
+			Port:     443,
+			Protocol: gatewayv1alpha2.HTTPSProtocolType,
+			TLS:      &gatewayv1alpha2.GatewayTLSConfig{CertificateRefs: []*gatewayv1alpha2.SecretObjectReference{&ref}},
+		}
+	}
+	gateway := &gatewayv1alpha2.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "kong"},
+		Spec: gatewayv1alpha2.GatewaySpec{
+			Listeners: []gatewayv1alpha2.Listener{
+				listenerWithRef("valid", gatewayv1alpha2.SecretObjectReference{Name: "cert"}),
+				listenerWithRef("missing", gatewayv1alpha2.SecretObjectReference{Name: "missing"}),
+				listenerWithRef("invalid", gatewayv1alpha2.SecretObjectReference{Name: "opaque"}),
+				listenerWithRef("unsupported-kind", gatewayv1alpha2.SecretObjectReference{Name: "cert", Kind: &configMap}),
+				listenerWithRef("permitted", gatewayv1alpha2.SecretObjectReference{Name: "cert", Namespace: &certsNamespace}),
+				listenerWithRef("not-permitted", gatewayv1alpha2.SecretObjectReference{Name: "other-cert", Namespace: &certsNamespace}),
+				{Name: "http", Port: 80, Protocol: gatewayv1alpha2.HTTPProtocolType},
+				{Name: "passthrough", Port: 8443, Protocol: gatewayv1alpha2.TLSProtocolType, TLS: &gatewayv1alpha2.GatewayTLSConfig{Mode: &passthrough}},
+				{
+					Name:     "no-hostname",
+					Port:     443,
+					Protocol: gatewayv1alpha2.HTTPSProtocolType,
+					TLS:      &gatewayv1alpha2.GatewayTLSConfig{CertificateRefs: []*gatewayv1alpha2.SecretObjectReference{{Name: "cert"}}},
+				},
+			},
+		},
+	}
+	tlsData := map[string][]byte{corev1.TLSCertKey: []byte("cert"), corev1.TLSPrivateKeyKey: []byte("key")}
+	certName := gatewayv1alpha2.ObjectName("cert")
+	r := &GatewayReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cert"}, Data: tlsData},
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "opaque"}, Data: map[string][]byte{"foo": []byte("bar")}},
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "certs", Name: "cert"}, Data: tlsData},
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "certs", Name: "other-cert"}, Data: tlsData},
+			&gatewayv1alpha2.ReferencePolicy{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "certs", Name: "allow-cert"},
+				Spec: gatewayv1alpha2.ReferencePolicySpec{
+					From: []gatewayv1alpha2.ReferencePolicyFrom{{Group: gatewayV1alpha2Group, Kind: "Gateway", Namespace: "default"}},
+					To:   []gatewayv1alpha2.ReferencePolicyTo{{Group: "", Kind: "Secret", Name: &certName}},
+				},
+			},
+		).Build(),
+	}
+
+	conditions, err := r.resolveListenerCertificateRefs(context.Background(), gateway)
+	require.NoError(t, err)
+	reasons := make(map[gatewayv1alpha2.SectionName]string, len(conditions))
+	for name, condition := range conditions {
+		reasons[name] = condition.Reason
+	}
+	assert.Equal(t, map[gatewayv1alpha2.SectionName]string{
+		"valid":            string(gatewayv1alpha2.ListenerReasonResolvedRefs),
+		"missing":          string(gatewayv1alpha2.ListenerReasonInvalidCertificateRef),
+		"invalid":          string(gatewayv1alpha2.ListenerReasonInvalidCertificateRef),
+		"unsupported-kind": string(gatewayv1alpha2.ListenerReasonInvalidCertificateRef),
+		"permitted":        string(gatewayv1alpha2.ListenerReasonResolvedRefs),
+		"not-permitted":    string(gatewayv1alpha2.ListenerReasonRefNotPermitted),
+		"no-hostname":      string(gatewayv1alpha2.ListenerReasonInvalidCertificateRef),
+	}, reasons)
+	assert.Equal(t, metav1.ConditionFalse, conditions["missing"].Status)
+	assert.Equal(t, "Secret default/missing does not exist", conditions["missing"].Message)
+
+	t.Log("verifying that listeners with unresolved refs are not ready")
+	statuses := convertListenersToListenerStatuses(gateway, conditions)
+	require.Len(t, statuses, len(gateway.Spec.Listeners))
+	assert.Equal(t, []metav1.ConditionStatus{metav1.ConditionTrue, metav1.ConditionTrue},
+		[]metav1.ConditionStatus{statuses[0].Conditions[0].Status, statuses[0].Conditions[1].Status})
+	assert.Equal(t, string(gatewayv1alpha2.ListenerReasonInvalid), statuses[1].Conditions[1].Reason)
+	assert.Len(t, statuses[6].Conditions, 1)
+	assert.True(t, areListenerStatusesEqual(statuses, convertListenersToListenerStatuses(gateway, conditions)))
+}
+
+func TestGatewayReconciler_getPublishServiceForGatewayClass(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, gatewayv1alpha2.AddToScheme(scheme))
+	require.NoError(t, configurationv1beta1.AddToScheme(scheme))
+
+	configNamespace := gatewayv1alpha2.Namespace("kong")
+	gatewayClass := func(config string) *gatewayv1alpha2.GatewayClass {
+		return &gatewayv1alpha2.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "kong"},
+			Spec: gatewayv1alpha2.GatewayClassSpec{
+				ControllerName: ControllerName,
+				ParametersRef: &gatewayv1alpha2.ParametersReference{
+					Group:     gatewayv1alpha2.Group(configurationv1beta1.GroupVersion.Group),
+					Kind:      configurationv1beta1.GatewayConfigurationKind,
+					Name:      config,
+					Namespace: &configNamespace,
+				},
+			},
+		}
+	}
+	r := &GatewayReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&configurationv1beta1.GatewayConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "kong", Name: "internal"},
+				Spec:       configurationv1beta1.GatewayConfigurationSpec{PublishService: "kong/kong-internal-proxy"},
+			},
+			&configurationv1beta1.GatewayConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "kong", Name: "plugins-only"},
+				Spec:       configurationv1beta1.GatewayConfigurationSpec{Plugins: []string{"rate-limit"}},
+			},
+		).Build(),
+		PublishService: "kong/kong-proxy",
+	}
+
+	t.Log("verifying that the publish service of the GatewayConfiguration of the class is used")
+	publishService, err := r.getPublishServiceForGatewayClass(context.Background(), gatewayClass("internal"))
+	require.NoError(t, err)
+	assert.Equal(t, "kong/kong-internal-proxy", publishService)
+
+	t.Log("verifying that the --publish-service is used by default")
+	publishService, err = r.getPublishServiceForGatewayClass(context.Background(), gatewayClass("plugins-only"))
+	require.NoError(t, err)
+	assert.Equal(t, "kong/kong-proxy", publishService)
+	publishService, err = r.getPublishServiceForGatewayClass(context.Background(), &gatewayv1alpha2.GatewayClass{})
+	require.NoError(t, err)
+	assert.Equal(t, "kong/kong-proxy", publishService)
+
+	t.Log("verifying that a missing GatewayConfiguration is an error")
+	_, err = r.getPublishServiceForGatewayClass(context.Background(), gatewayClass("missing"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,754 @@
+package gateway
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kong/go-kong/kong"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
+	configurationv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
+)
+
+// -----------------------------------------------------------------------------
+// Gateway Controller - Managed Gateways - Vars & Consts
+// -----------------------------------------------------------------------------
+
+const (
+	// ManagedGatewayLabel is the label set on the objects provisioned for a
+	// managed Gateway, with the name of the Gateway as its value.
+	ManagedGatewayLabel = "konghq.com/managed-gateway"
+
+	// ManagedGatewayParamsImageKey is the key of the ConfigMap referenced by
+	// the parametersRef of a GatewayClass which holds the Kong image of the
+	// proxies provisioned for managed Gateways.
+	//
+	// Deprecated: use the managed image of a GatewayConfiguration instead.
+	ManagedGatewayParamsImageKey = "image"
+
+	// ManagedGatewayParamsReplicasKey is the key of the ConfigMap referenced by
+	// the parametersRef of a GatewayClass which holds the number of proxy
+	// replicas provisioned for each managed Gateway.
+	//
+	// Deprecated: use the managed replicas of a GatewayConfiguration instead.
+	ManagedGatewayParamsReplicasKey = "replicas"
+
+	// ManagedGatewayParamsServiceTypeKey is the key of the ConfigMap referenced
+	// by the parametersRef of a GatewayClass which holds the type of the proxy
+	// Service provisioned for each managed Gateway.
+	//
+	// Deprecated: use the managed serviceType of a GatewayConfiguration instead.
+	ManagedGatewayParamsServiceTypeKey = "serviceType"
+
+	// DefaultManagedGatewayImage is the Kong image used for the proxies of
+	// managed Gateways when the GatewayClass parameters don't provide one.
+	DefaultManagedGatewayImage = "kong:2.8"
+
+	// managedGatewayAdminPort is the port the proxies of managed Gateways serve
+	// the Admin API on, over TLS.
+	managedGatewayAdminPort = 8444
+
+	// managedGatewayAdminPortName is the name of the Admin API port of the
+	// admin Service of managed Gateways, which is used to discover the Admin
+	// API endpoints of the proxies.
+	managedGatewayAdminPortName = "admin-tls"
+
+	// managedGatewayStatusPort is the port the proxies of managed Gateways
+	// serve the status API on, which is used for readiness checks.
+	managedGatewayStatusPort = 8100
+
+	// managedGatewayProxyPortBase is the container port the first listener of
+	// a managed Gateway is served on, the following listeners being served on
+	// the following ports.
+	managedGatewayProxyPortBase = 8000
+
+	// managedGatewayMaxListeners is the maximum number of listeners of a
+	// Gateway, as validated by the Gateway API CRDs, which keeps the container
+	// ports of the listeners below the Admin API and status ports.
+	managedGatewayMaxListeners = 64
+
+	// managedGatewayAdminCertDir is where the Admin API certificate of the
+	// proxies of managed Gateways is mounted.
+	managedGatewayAdminCertDir = "/etc/kong-admin"
+
+	// managedGatewayAdminClientCADir is where the certificate the proxies of
+	// managed Gateways verify Admin API clients with is mounted.
+	managedGatewayAdminClientCADir = "/etc/kong-admin-client"
+
+	// managedGatewayAdminClientCommonName is the common name of the client
+	// certificate the controller authenticates to the Admin API of the proxies
+	// of managed Gateways with.
+	managedGatewayAdminClientCommonName = "kong-ingress-controller"
+
+	// managedGatewayAdminCertValidity is how long the generated Admin API
+	// certificates of managed Gateways are valid for.
+	managedGatewayAdminCertValidity = 10 * 365 * 24 * time.Hour
+)
+
+// -----------------------------------------------------------------------------
+// Gateway Controller - Managed Gateways - Types
+// -----------------------------------------------------------------------------
+
+// managedGatewayParameters are the parameters the proxies of managed Gateways
+// are provisioned with, which are read from the GatewayConfiguration, or the
+// deprecated ConfigMap, referenced by the parametersRef of their GatewayClass.
+type managedGatewayParameters struct {
+	image       string
+	replicas    int32
+	serviceType corev1.ServiceType
+}
+
+// -----------------------------------------------------------------------------
+// Gateway Controller - Managed Gateways - Reconciliation
+// -----------------------------------------------------------------------------
+
+//+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch
+//+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch
+
+// reconcileManagedGateway reconciles a Gateway that is configured for managed mode. In this mode a
+// Kong proxy Deployment, with its Service and Admin API credentials, is provisioned for the Gateway and
+// owned by it. The listeners of the Gateway become the ports of the proxy Service, and the routes attached
+// to the Gateway are only configured on its own proxies. The Admin API of the proxies only accepts the
+// client certificate issued to the controller, and a NetworkPolicy restricts it to the controller pods.
+func (r *GatewayReconciler) reconcileManagedGateway(
+	ctx context.Context,
+	log logr.Logger,
+	gateway *gatewayv1alpha2.Gateway,
+	gwc *gatewayv1alpha2.GatewayClass,
+	config *configurationv1beta1.GatewayConfiguration,
+) (ctrl.Result, error) {
+	debug(log, gateway, "gathering the managed gateway parameters from the gatewayclass")
+	params, err := r.getManagedGatewayParameters(ctx, log, gwc, config)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	info(log, gateway, "marking gateway as scheduled")
+	if !isGatewayScheduled(gateway) {
+		gateway.Status.Conditions = append(gateway.Status.Conditions, metav1.Condition{
+			Type:               string(gatewayv1alpha2.GatewayConditionScheduled),
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: gateway.Generation,
+			LastTransitionTime: metav1.Now(),
+			Reason:             string(gatewayv1alpha2.GatewayReasonScheduled),
+			Message:            "this managed gateway has been picked up by the controller and its proxy will be provisioned",
+		})
+		return ctrl.Result{}, r.Status().Update(ctx, pruneGatewayStatusConds(gateway))
+	}
+
+	if len(gateway.Spec.Listeners) > managedGatewayMaxListeners {
+		return ctrl.Result{}, fmt.Errorf("managed gateways support at most %d listeners", managedGatewayMaxListeners)
+	}
+	if !areAllowedRoutesConsistentByProtocol(gateway.Spec.Listeners) {
+		return ctrl.Result{}, fmt.Errorf("all listeners for a protocol must use the same AllowedRoutes")
+	}
+
+	debug(log, gateway, "provisioning the admin api credentials of the gateway")
+	adminSecret, err := r.ensureManagedGatewayAdminSecret(ctx, gateway)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	adminClientSecret, err := r.ensureManagedGatewayAdminClientSecret(ctx, gateway)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	debug(log, gateway, "provisioning the proxy deployment of the gateway")
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: gateway.Namespace, Name: managedGatewayProxyName(gateway)}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, deployment, func() error {
+		deployment.Labels = managedGatewayLabels(gateway)
+		deployment.Spec = managedGatewayDeploymentSpec(gateway, params, adminSecret.Name, adminClientSecret.Name)
+		return controllerutil.SetControllerReference(gateway, deployment, r.Scheme)
+	}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("could not provision the proxy deployment of the gateway: %w", err)
+	}
+
+	debug(log, gateway, "provisioning the proxy service of the gateway")
+	proxyService := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: gateway.Namespace, Name: managedGatewayProxyName(gateway)}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, proxyService, func() error {
+		// the Service spec is only partially updated, as some of its fields
+		// (e.g. the clusterIP) are assigned by the API server.
+		proxyService.Labels = managedGatewayLabels(gateway)
+		proxyService.Spec.Type = params.serviceType
+		proxyService.Spec.Selector = managedGatewayLabels(gateway)
+		proxyService.Spec.Ports = managedGatewayProxyServicePorts(gateway.Spec.Listeners, proxyService.Spec.Ports)
+		return controllerutil.SetControllerReference(gateway, proxyService, r.Scheme)
+	}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("could not provision the proxy service of the gateway: %w", err)
+	}
+
+	debug(log, gateway, "provisioning the admin service of the gateway")
+	adminService := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: gateway.Namespace, Name: managedGatewayAdminName(gateway)}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, adminService, func() error {
+		adminService.Labels = managedGatewayLabels(gateway)
+		adminService.Spec.ClusterIP = corev1.ClusterIPNone
+		adminService.Spec.Selector = managedGatewayLabels(gateway)
+		adminService.Spec.Ports = []corev1.ServicePort{{
+			Name:       managedGatewayAdminPortName,
+			Protocol:   corev1.ProtocolTCP,
+			Port:       managedGatewayAdminPort,
+			TargetPort: intstr.FromInt(managedGatewayAdminPort),
+		}}
+		return controllerutil.SetControllerReference(gateway, adminService, r.Scheme)
+	}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("could not provision the admin service of the gateway: %w", err)
+	}
+
+	if r.ControllerPodPeer != nil {
+		debug(log, gateway, "restricting the admin api of the gateway to the controller")
+		networkPolicy := &networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Namespace: gateway.Namespace, Name: managedGatewayAdminName(gateway)}}
+		if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, networkPolicy, func() error {
+			networkPolicy.Labels = managedGatewayLabels(gateway)
+			networkPolicy.Spec = managedGatewayNetworkPolicySpec(gateway, *r.ControllerPodPeer)
+			return controllerutil.SetControllerReference(gateway, networkPolicy, r.Scheme)
+		}); err != nil {
+			return ctrl.Result{}, fmt.Errorf("could not provision the network policy of the gateway: %w", err)
+		}
+	} else {
+		debug(log, gateway, "the controller pod is unknown, the admin api of the gateway is only protected by client authentication")
+	}
+
+	debug(log, gateway, "discovering the proxy instances of the gateway")
+	if err := r.updateManagedGatewayProxies(ctx, gateway, adminService, adminSecret, adminClientSecret); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	debug(log, gateway, "ensuring the gateway is present in the data-plane cache")
+	if err := r.DataplaneClient.UpdateObject(gateway); err != nil {
+		debug(log, gateway, "failed to update object in data-plane, requeueing")
+		return ctrl.Result{}, err
+	}
+
+	// the addresses of the Gateway are those of its proxy Service. The listeners derived from the Service
+	// are not needed, as the Service ports are derived from the listeners in the first place.
+	debug(log, gateway, "determining the gateway addresses from the proxy service")
+	gatewayAddresses, _, err := r.determineL4ListenersFromService(log, proxyService)
+	if err != nil {
+		debug(log, gateway, "proxy service is not ready yet, requeueing")
+		return ctrl.Result{Requeue: true}, nil
+	}
+	if deployment.Status.AvailableReplicas < 1 {
+		debug(log, gateway, "no proxy replicas of the gateway are available yet, waiting for the deployment to become available")
+		return ctrl.Result{}, nil
+	}
+
+	debug(log, gateway, "resolving the certificateRefs of the gateway listeners")
+	resolvedRefs, err := r.resolveListenerCertificateRefs(ctx, gateway)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	debug(log, gateway, "updating the gateway status if necessary")
+	isChanged, err := r.updateAddressesAndListenersStatus(ctx, gateway, gatewayAddresses, resolvedRefs)
+	if err != nil {
+		if errors.IsConflict(err) {
+			// if there's a conflict that's normal just requeue to retry, no need to make noise.
+			return ctrl.Result{Requeue: true}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if isChanged {
+		debug(log, gateway, "gateways listeners and/or addresses were updated in the status to match the provisioned proxy")
+		return ctrl.Result{}, nil
+	}
+
+	info(log, gateway, "gateway provisioning complete")
+	return ctrl.Result{}, nil
+}
+
+// getManagedGatewayParameters reads the parameters of the proxies of the managed Gateways of the
+// provided GatewayClass from its GatewayConfiguration, or from the deprecated ConfigMap its
+// parametersRef refers to. Missing parameters are defaulted.
+func (r *GatewayReconciler) getManagedGatewayParameters(
+	ctx context.Context,
+	log logr.Logger,
+	gwc *gatewayv1alpha2.GatewayClass,
+	config *configurationv1beta1.GatewayConfiguration,
+) (managedGatewayParameters, error) {
+	if config != nil && config.Spec.Managed != nil {
+		return managedGatewayParametersFromConfiguration(config.Spec.Managed), nil
+	}
+
+	log.Info("the ConfigMap parameters of managed gateways are deprecated, set them in a GatewayConfiguration instead", "gatewayclass", gwc.Name)
+	ref := gwc.Spec.ParametersRef
+	configMap := &corev1.ConfigMap{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: string(*ref.Namespace), Name: ref.Name}, configMap); err != nil {
+		return managedGatewayParameters{}, fmt.Errorf("could not retrieve the parameters of gatewayclass %s: %w", gwc.Name, err)
+	}
+	params, err := parseManagedGatewayParameters(configMap.Data)
+	if err != nil {
+		return managedGatewayParameters{}, fmt.Errorf("invalid parameters for gatewayclass %s in configmap %s/%s: %w", gwc.Name, configMap.Namespace, configMap.Name, err)
+	}
+	return params, nil
+}
+
+// ensureManagedGatewayAdminSecret provides the Secret holding the certificate the proxies of the
+// provided managed Gateway serve their Admin API with, generating it if it doesn't exist yet. The
+// certificate is self-signed and also used by the controller to verify the proxies.
+func (r *GatewayReconciler) ensureManagedGatewayAdminSecret(ctx context.Context, gateway *gatewayv1alpha2.Gateway) (*corev1.Secret, error) {
+	return r.ensureManagedGatewayCertificateSecret(ctx, gateway, managedGatewayAdminName(gateway),
+		managedGatewayAdminServerName(gateway), x509.ExtKeyUsageServerAuth)
+}
+
+// ensureManagedGatewayAdminClientSecret provides the Secret holding the client certificate the
+// controller authenticates to the Admin API of the proxies of the provided managed Gateway with,
+// generating it if it doesn't exist yet. The certificate is self-signed, and only the certificate
+// itself is mounted in the proxies to verify the controller.
+func (r *GatewayReconciler) ensureManagedGatewayAdminClientSecret(ctx context.Context, gateway *gatewayv1alpha2.Gateway) (*corev1.Secret, error) {
+	return r.ensureManagedGatewayCertificateSecret(ctx, gateway, managedGatewayAdminClientName(gateway),
+		managedGatewayAdminClientCommonName, x509.ExtKeyUsageClientAuth)
+}
+
+// ensureManagedGatewayCertificateSecret provides the TLS Secret by the provided name, owned by the
+// provided managed Gateway, generating a self-signed certificate for the provided name and usage if
+// it doesn't exist yet.
+func (r *GatewayReconciler) ensureManagedGatewayCertificateSecret(
+	ctx context.Context,
+	gateway *gatewayv1alpha2.Gateway,
+	secretName string,
+	certName string,
+	usage x509.ExtKeyUsage,
+) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	key := client.ObjectKey{Namespace: gateway.Namespace, Name: secretName}
+	err := r.Client.Get(ctx, key, secret)
+	if err == nil {
+		return secret, nil
+	}
+	if !errors.IsNotFound(err) {
+		return nil, err
+	}
+
+	cert, privateKey, err := generateAdminCertificate(certName, usage)
+	if err != nil {
+		return nil, fmt.Errorf("could not generate the admin api certificate %s of the gateway: %w", secretName, err)
+	}
+	secret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: key.Namespace,
+			Name:      key.Name,
+			Labels:    managedGatewayLabels(gateway),
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       cert,
+			corev1.TLSPrivateKeyKey: privateKey,
+		},
+	}
+	if err := controllerutil.SetControllerReference(gateway, secret, r.Scheme); err != nil {
+		return nil, err
+	}
+	return secret, r.Client.Create(ctx, secret)
+}
+
+// updateManagedGatewayProxies configures the data-plane client with the ready proxy instances of the
+// provided managed Gateway, as found in the Endpoints of its admin Service.
+func (r *GatewayReconciler) updateManagedGatewayProxies(
+	ctx context.Context,
+	gateway *gatewayv1alpha2.Gateway,
+	adminService *corev1.Service,
+	adminSecret *corev1.Secret,
+	adminClientSecret *corev1.Secret,
+) error {
+	endpoints := &corev1.Endpoints{}
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(adminService), endpoints); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		// the Endpoints are created asynchronously, their creation triggers a new reconciliation.
+	}
+
+	httpClient, err := adminapi.MakeHTTPClient(&adminapi.HTTPClientOpts{
+		CACert:        string(adminSecret.Data[corev1.TLSCertKey]),
+		TLSServerName: managedGatewayAdminServerName(gateway),
+		TLSClientCert: string(adminClientSecret.Data[corev1.TLSCertKey]),
+		TLSClientKey:  string(adminClientSecret.Data[corev1.TLSPrivateKeyKey]),
+	})
+	if err != nil {
+		return fmt.Errorf("could not build the admin api client of the gateway: %w", err)
+	}
+	clients := make(map[string]*kong.Client)
+	for _, url := range adminapi.GetURLsForEndpoints(endpoints, sets.NewString(managedGatewayAdminPortName)) {
+		kongClient, err := adminapi.GetKongClientForWorkspace(ctx, url, "", httpClient)
+		if err != nil {
+			return err
+		}
+		clients[url] = kongClient
+	}
+
+	r.DataplaneClient.UpdateGatewayProxies(client.ObjectKeyFromObject(gateway), clients)
+	return nil
+}
+
+// listGatewaysForEndpoints is a watch predicate which finds the managed gateway the admin Service
+// Endpoints were provisioned for, to update the proxy instances of the gateway when they change.
+func (r *GatewayReconciler) listGatewaysForEndpoints(endpoints client.Object) []reconcile.Request {
+	name, ok := endpoints.GetLabels()[ManagedGatewayLabel]
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{
+		NamespacedName: types.NamespacedName{
+			Namespace: endpoints.GetNamespace(),
+			Name:      name,
+		},
+	}}
+}
+
+// -----------------------------------------------------------------------------
+// Gateway Controller - Managed Gateways - Utilities
+// -----------------------------------------------------------------------------
+
+// isGatewayManaged returns boolean whether the provided gateway is configured for managed mode, which
+// is the case when the GatewayConfiguration of its class sets managed proxy parameters, or its class
+// references a deprecated ConfigMap of parameters, and it's not configured for unmanaged mode. Gateways
+// of classes without parameters keep defaulting to unmanaged mode.
+func isGatewayManaged(
+	gatewayClass *gatewayv1alpha2.GatewayClass,
+	config *configurationv1beta1.GatewayConfiguration,
+	gateway *gatewayv1alpha2.Gateway,
+) bool {
+	if _, ok := annotations.ExtractUnmanagedGatewayMode(gateway.GetAnnotations()); ok {
+		return false
+	}
+	if config != nil {
+		return config.Spec.Managed != nil
+	}
+	ref := gatewayClass.Spec.ParametersRef
+	return ref != nil && ref.Group == "" && ref.Kind == "ConfigMap" && ref.Namespace != nil
+}
+
+// managedGatewayParametersFromConfiguration provides the parameters of the proxies of managed
+// Gateways set by a GatewayConfiguration, which are validated by its CRD. Missing parameters are
+// defaulted.
+func managedGatewayParametersFromConfiguration(managed *configurationv1beta1.ManagedGatewayProxy) managedGatewayParameters {
+	params := managedGatewayParameters{
+		image:       DefaultManagedGatewayImage,
+		replicas:    1,
+		serviceType: corev1.ServiceTypeLoadBalancer,
+	}
+	if managed.Image != "" {
+		params.image = managed.Image
+	}
+	if managed.Replicas != nil {
+		params.replicas = *managed.Replicas
+	}
+	if managed.ServiceType != "" {
+		params.serviceType = managed.ServiceType
+	}
+	return params
+}
+
+// parseManagedGatewayParameters parses the data of a ConfigMap holding the parameters of the proxies
+// of managed Gateways.
+func parseManagedGatewayParameters(data map[string]string) (managedGatewayParameters, error) {
+	params := managedGatewayParameters{
+		image:       DefaultManagedGatewayImage,
+		replicas:    1,
+		serviceType: corev1.ServiceTypeLoadBalancer,
+	}
+	if image, ok := data[ManagedGatewayParamsImageKey]; ok && image != "" {
+		params.image = image
+	}
+	if replicas, ok := data[ManagedGatewayParamsReplicasKey]; ok {
+		n, err := strconv.ParseInt(replicas, 10, 32)
+		if err != nil || n < 0 {
+			return managedGatewayParameters{}, fmt.Errorf("%s must be a non-negative integer, got %q", ManagedGatewayParamsReplicasKey, replicas)
+		}
+		params.replicas = int32(n)
+	}
+	if serviceType, ok := data[ManagedGatewayParamsServiceTypeKey]; ok {
+		switch corev1.ServiceType(serviceType) {
+		case corev1.ServiceTypeClusterIP, corev1.ServiceTypeNodePort, corev1.ServiceTypeLoadBalancer:
+			params.serviceType = corev1.ServiceType(serviceType)
+		default:
+			return managedGatewayParameters{}, fmt.Errorf("%s must be one of %s, %s or %s, got %q", ManagedGatewayParamsServiceTypeKey,
+				corev1.ServiceTypeClusterIP, corev1.ServiceTypeNodePort, corev1.ServiceTypeLoadBalancer, serviceType)
+		}
+	}
+	return params, nil
+}
+
+// managedGatewayProxyName provides the name of the proxy Deployment and Service of a managed Gateway.
+func managedGatewayProxyName(gateway *gatewayv1alpha2.Gateway) string {
+	return gateway.Name + "-kong"
+}
+
+// managedGatewayAdminName provides the name of the admin Service and Secret of a managed Gateway.
+func managedGatewayAdminName(gateway *gatewayv1alpha2.Gateway) string {
+	return gateway.Name + "-kong-admin"
+}
+
+// managedGatewayAdminClientName provides the name of the Secret holding the client certificate of the
+// controller for the Admin API of a managed Gateway.
+func managedGatewayAdminClientName(gateway *gatewayv1alpha2.Gateway) string {
+	return gateway.Name + "-kong-admin-client"
+}
+
+// managedGatewayAdminServerName provides the name the Admin API certificate of a managed Gateway is
+// issued for, which is verified by the controller.
+func managedGatewayAdminServerName(gateway *gatewayv1alpha2.Gateway) string {
+	return fmt.Sprintf("%s.%s.svc", managedGatewayAdminName(gateway), gateway.Namespace)
+}
+
+// managedGatewayLabels provides the labels of the objects provisioned for a managed Gateway, which
+// also select its proxy pods.
+func managedGatewayLabels(gateway *gatewayv1alpha2.Gateway) map[string]string {
+	return map[string]string{ManagedGatewayLabel: gateway.Name}
+}
+
+// managedGatewayListenConfig generates the proxy and stream listens of Kong for the provided Gateway
+// listeners, along with the matching port maps. Each listener is served on its own container port.
+func managedGatewayListenConfig(listeners []gatewayv1alpha2.Listener) (proxyListen, streamListen, portMaps string) {
+	var proxyListens, streamListens, maps []string
+	for i, listener := range listeners {
+		containerPort := managedGatewayProxyPortBase + i
+		listen := fmt.Sprintf("0.0.0.0:%d", containerPort)
+		switch listener.Protocol {
+		case gatewayv1alpha2.HTTPProtocolType:
+			proxyListens = append(proxyListens, listen)
+		case gatewayv1alpha2.HTTPSProtocolType:
+			proxyListens = append(proxyListens, listen+" ssl")
+		case gatewayv1alpha2.TCPProtocolType:
+			streamListens = append(streamListens, listen)
+		case gatewayv1alpha2.TLSProtocolType:
+			streamListens = append(streamListens, listen+" ssl")
+		case gatewayv1alpha2.UDPProtocolType:
+			streamListens = append(streamListens, listen+" udp")
+		default:
+			continue
+		}
+		maps = append(maps, fmt.Sprintf("%d:%d", listener.Port, containerPort))
+	}
+
+	proxyListen, streamListen = "off", "off"
+	if len(proxyListens) > 0 {
+		proxyListen = strings.Join(proxyListens, ", ")
+	}
+	if len(streamListens) > 0 {
+		streamListen = strings.Join(streamListens, ", ")
+	}
+	return proxyListen, streamListen, strings.Join(maps, ", ")
+}
+
+// managedGatewayProxyServicePorts generates the ports of the proxy Service of a managed Gateway from
+// its listeners. The node ports already allocated to the existing ports are kept.
+func managedGatewayProxyServicePorts(listeners []gatewayv1alpha2.Listener, existing []corev1.ServicePort) []corev1.ServicePort {
+	nodePorts := make(map[string]int32, len(existing))
+	for _, port := range existing {
+		nodePorts[port.Name] = port.NodePort
+	}
+
+	ports := make([]corev1.ServicePort, 0, len(listeners))
+	for i, listener := range listeners {
+		protocol := corev1.ProtocolTCP
+		if listener.Protocol == gatewayv1alpha2.UDPProtocolType {
+			protocol = corev1.ProtocolUDP
+		}
+		// listener names can be longer than what Service port names allow.
+		name := fmt.Sprintf("listener-%d", i)
+		ports = append(ports, corev1.ServicePort{
+			Name:       name,
+			Protocol:   protocol,
+			Port:       int32(listener.Port),
+			TargetPort: intstr.FromInt(managedGatewayProxyPortBase + i),
+			NodePort:   nodePorts[name],
+		})
+	}
+	return ports
+}
+
+// managedGatewayDeploymentSpec generates the spec of the proxy Deployment of a managed Gateway. The Admin
+// API is served over TLS and only accepts clients presenting the certificate of the admin client Secret,
+// which Kong configures through injected nginx directives.
+func managedGatewayDeploymentSpec(
+	gateway *gatewayv1alpha2.Gateway,
+	params managedGatewayParameters,
+	adminSecretName string,
+	adminClientSecretName string,
+) appsv1.DeploymentSpec {
+	proxyListen, streamListen, portMaps := managedGatewayListenConfig(gateway.Spec.Listeners)
+	env := []corev1.EnvVar{
+		{Name: "KONG_DATABASE", Value: "off"},
+		{Name: "KONG_PROXY_LISTEN", Value: proxyListen},
+		{Name: "KONG_STREAM_LISTEN", Value: streamListen},
+		{Name: "KONG_PORT_MAPS", Value: portMaps},
+		{Name: "KONG_ADMIN_LISTEN", Value: fmt.Sprintf("0.0.0.0:%d ssl", managedGatewayAdminPort)},
+		{Name: "KONG_ADMIN_SSL_CERT", Value: managedGatewayAdminCertDir + "/" + corev1.TLSCertKey},
+		{Name: "KONG_ADMIN_SSL_CERT_KEY", Value: managedGatewayAdminCertDir + "/" + corev1.TLSPrivateKeyKey},
+		{Name: "KONG_NGINX_ADMIN_SSL_VERIFY_CLIENT", Value: "on"},
+		{Name: "KONG_NGINX_ADMIN_SSL_CLIENT_CERTIFICATE", Value: managedGatewayAdminClientCADir + "/" + corev1.TLSCertKey},
+		{Name: "KONG_STATUS_LISTEN", Value: fmt.Sprintf("0.0.0.0:%d", managedGatewayStatusPort)},
+		{Name: "KONG_PROXY_ACCESS_LOG", Value: "/dev/stdout"},
+		{Name: "KONG_ADMIN_ACCESS_LOG", Value: "/dev/stdout"},
+		{Name: "KONG_PROXY_ERROR_LOG", Value: "/dev/stderr"},
+		{Name: "KONG_ADMIN_ERROR_LOG", Value: "/dev/stderr"},
+	}
+
+	ports := make([]corev1.ContainerPort, 0, len(gateway.Spec.Listeners)+2)
+	for i, listener := range gateway.Spec.Listeners {
+		protocol := corev1.ProtocolTCP
+		if listener.Protocol == gatewayv1alpha2.UDPProtocolType {
+			protocol = corev1.ProtocolUDP
+		}
+		ports = append(ports, corev1.ContainerPort{ContainerPort: int32(managedGatewayProxyPortBase + i), Protocol: protocol})
+	}
+	ports = append(ports,
+		corev1.ContainerPort{Name: managedGatewayAdminPortName, ContainerPort: managedGatewayAdminPort, Protocol: corev1.ProtocolTCP},
+		corev1.ContainerPort{Name: "status", ContainerPort: managedGatewayStatusPort, Protocol: corev1.ProtocolTCP},
+	)
+
+	labels := managedGatewayLabels(gateway)
+	replicas := params.replicas
+	return appsv1.DeploymentSpec{
+		Replicas: &replicas,
+		Selector: &metav1.LabelSelector{MatchLabels: labels},
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{Labels: labels},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name:  "proxy",
+					Image: params.image,
+					Env:   env,
+					Ports: ports,
+					VolumeMounts: []corev1.VolumeMount{
+						{
+							Name:      "admin-tls",
+							MountPath: managedGatewayAdminCertDir,
+							ReadOnly:  true,
+						},
+						{
+							Name:      "admin-client-ca",
+							MountPath: managedGatewayAdminClientCADir,
+							ReadOnly:  true,
+						},
+					},
+					ReadinessProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{
+							HTTPGet: &corev1.HTTPGetAction{
+								Path: "/status",
+								Port: intstr.FromInt(managedGatewayStatusPort),
+							},
+						},
+					},
+				}},
+				Volumes: []corev1.Volume{
+					{
+						Name: "admin-tls",
+						VolumeSource: corev1.VolumeSource{
+							Secret: &corev1.SecretVolumeSource{SecretName: adminSecretName},
+						},
+					},
+					{
+						// the private key of the controller is not mounted in the proxies.
+						Name: "admin-client-ca",
+						VolumeSource: corev1.VolumeSource{
+							Secret: &corev1.SecretVolumeSource{
+								SecretName: adminClientSecretName,
+								Items:      []corev1.KeyToPath{{Key: corev1.TLSCertKey, Path: corev1.TLSCertKey}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// managedGatewayNetworkPolicySpec generates the spec of the NetworkPolicy of the proxies of a managed
+// Gateway, which only lets the provided peer reach their Admin API. The ports of the listeners and of
+// the status API remain open to any client.
+func managedGatewayNetworkPolicySpec(gateway *gatewayv1alpha2.Gateway, controller networkingv1.NetworkPolicyPeer) networkingv1.NetworkPolicySpec {
+	tcp, udp := corev1.ProtocolTCP, corev1.ProtocolUDP
+	port := func(protocol *corev1.Protocol, number int) networkingv1.NetworkPolicyPort {
+		portNumber := intstr.FromInt(number)
+		return networkingv1.NetworkPolicyPort{Protocol: protocol, Port: &portNumber}
+	}
+
+	publicPorts := make([]networkingv1.NetworkPolicyPort, 0, len(gateway.Spec.Listeners)+1)
+	for i, listener := range gateway.Spec.Listeners {
+		protocol := &tcp
+		if listener.Protocol == gatewayv1alpha2.UDPProtocolType {
+			protocol = &udp
+		}
+		publicPorts = append(publicPorts, port(protocol, managedGatewayProxyPortBase+i))
+	}
+	publicPorts = append(publicPorts, port(&tcp, managedGatewayStatusPort))
+
+	return networkingv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{MatchLabels: managedGatewayLabels(gateway)},
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		Ingress: []networkingv1.NetworkPolicyIngressRule{
+			{Ports: publicPorts},
+			{
+				Ports: []networkingv1.NetworkPolicyPort{port(&tcp, managedGatewayAdminPort)},
+				From:  []networkingv1.NetworkPolicyPeer{controller},
+			},
+		},
+	}
+}
+
+// generateAdminCertificate generates a self-signed certificate and private key, both PEM-encoded, for
+// the provided DNS name and usage.
+func generateAdminCertificate(dnsName string, usage x509.ExtKeyUsage) ([]byte, []byte, error) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               pkix.Name{CommonName: dnsName},
+		DNSNames:              []string{dnsName},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(managedGatewayAdminCertValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{usage},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	key := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return cert, key, nil
+}
@@ -0,0 +1,245 @@
+package gateway
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
+	configurationv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
+)
+
+func Test_isGatewayManaged(t *testing.T) {
+	namespace := gatewayv1alpha2.Namespace("kong")
+	managedClass := &gatewayv1alpha2.GatewayClass{
+		Spec: gatewayv1alpha2.GatewayClassSpec{
+			ControllerName: ControllerName,
+			ParametersRef: &gatewayv1alpha2.ParametersReference{
+				Kind:      "ConfigMap",
+				Name:      "managed-gateways",
+				Namespace: &namespace,
+			},
+		},
+	}
+	unmanagedClass := &gatewayv1alpha2.GatewayClass{
+		Spec: gatewayv1alpha2.GatewayClassSpec{ControllerName: ControllerName},
+	}
+	gateway := &gatewayv1alpha2.Gateway{}
+	unmanagedGateway := &gatewayv1alpha2.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				annotations.AnnotationPrefix + annotations.GatewayUnmanagedAnnotation: "kong/kong-proxy",
+			},
+		},
+	}
+
+	managedConfig := &configurationv1beta1.GatewayConfiguration{
+		Spec: configurationv1beta1.GatewayConfigurationSpec{Managed: &configurationv1beta1.ManagedGatewayProxy{}},
+	}
+	unmanagedConfig := &configurationv1beta1.GatewayConfiguration{}
+
+	assert.True(t, isGatewayManaged(managedClass, nil, gateway), "the deprecated ConfigMap parameters should still be supported")
+	assert.False(t, isGatewayManaged(managedClass, nil, unmanagedGateway))
+	assert.False(t, isGatewayManaged(unmanagedClass, nil, gateway))
+	assert.True(t, isGatewayManaged(unmanagedClass, managedConfig, gateway))
+	assert.False(t, isGatewayManaged(unmanagedClass, managedConfig, unmanagedGateway))
+	assert.False(t, isGatewayManaged(unmanagedClass, unmanagedConfig, gateway))
+}
+
+func Test_managedGatewayParametersFromConfiguration(t *testing.T) {
+	assert.Equal(t, managedGatewayParameters{
+		image:       DefaultManagedGatewayImage,
+		replicas:    1,
+		serviceType: corev1.ServiceTypeLoadBalancer,
+	}, managedGatewayParametersFromConfiguration(&configurationv1beta1.ManagedGatewayProxy{}))
+
+	replicas := int32(0)
+	assert.Equal(t, managedGatewayParameters{
+		image:       "kong:2.8.1",
+		replicas:    0,
+		serviceType: corev1.ServiceTypeNodePort,
+	}, managedGatewayParametersFromConfiguration(&configurationv1beta1.ManagedGatewayProxy{
+		Image:       "kong:2.8.1",
+		Replicas:    &replicas,
+		ServiceType: corev1.ServiceTypeNodePort,
+	}))
+}
+
+func Test_parseManagedGatewayParameters(t *testing.T) {
+	t.Log("verifying that missing parameters are defaulted")
+	params, err := parseManagedGatewayParameters(nil)
+	require.NoError(t, err)
+	assert.Equal(t, managedGatewayParameters{
+		image:       DefaultManagedGatewayImage,
+		replicas:    1,
+		serviceType: corev1.ServiceTypeLoadBalancer,
+	}, params)
+
+	t.Log("verifying that provided parameters are used")
+	params, err = parseManagedGatewayParameters(map[string]string{
+		ManagedGatewayParamsImageKey:       "kong:2.8.1",
+		ManagedGatewayParamsReplicasKey:    "3",
+		ManagedGatewayParamsServiceTypeKey: "NodePort",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, managedGatewayParameters{
+		image:       "kong:2.8.1",
+		replicas:    3,
+		serviceType: corev1.ServiceTypeNodePort,
+	}, params)
+
+	t.Log("verifying that invalid parameters are refused")
+	_, err = parseManagedGatewayParameters(map[string]string{ManagedGatewayParamsReplicasKey: "many"})
+	assert.Error(t, err)
+	_, err = parseManagedGatewayParameters(map[string]string{ManagedGatewayParamsServiceTypeKey: "ExternalName"})
+	assert.Error(t, err)
+}
+
+func Test_managedGatewayListenConfig(t *testing.T) {
+	proxyListen, streamListen, portMaps := managedGatewayListenConfig([]gatewayv1alpha2.Listener{
+		{Name: "http", Port: 80, Protocol: gatewayv1alpha2.HTTPProtocolType},
+		{Name: "https", Port: 443, Protocol: gatewayv1alpha2.HTTPSProtocolType},
+		{Name: "tcp", Port: 9000, Protocol: gatewayv1alpha2.TCPProtocolType},
+		{Name: "udp", Port: 9001, Protocol: gatewayv1alpha2.UDPProtocolType},
+	})
+	assert.Equal(t, "0.0.0.0:8000, 0.0.0.0:8001 ssl", proxyListen)
+	assert.Equal(t, "0.0.0.0:8002, 0.0.0.0:8003 udp", streamListen)
+	assert.Equal(t, "80:8000, 443:8001, 9000:8002, 9001:8003", portMaps)
+
+	proxyListen, streamListen, _ = managedGatewayListenConfig([]gatewayv1alpha2.Listener{
+		{Name: "tls", Port: 8443, Protocol: gatewayv1alpha2.TLSProtocolType},
+	})
+	assert.Equal(t, "off", proxyListen)
+	assert.Equal(t, "0.0.0.0:8000 ssl", streamListen)
+}
+
+func Test_managedGatewayProxyServicePorts(t *testing.T) {
+	listeners := []gatewayv1alpha2.Listener{
+		{Name: "http", Port: 80, Protocol: gatewayv1alpha2.HTTPProtocolType},
+		{Name: "udp", Port: 53, Protocol: gatewayv1alpha2.UDPProtocolType},
+	}
+	existing := []corev1.ServicePort{{Name: "listener-0", Port: 80, NodePort: 30080}}
+	assert.Equal(t, []corev1.ServicePort{
+		{Name: "listener-0", Protocol: corev1.ProtocolTCP, Port: 80, TargetPort: intstr.FromInt(8000), NodePort: 30080},
+		{Name: "listener-1", Protocol: corev1.ProtocolUDP, Port: 53, TargetPort: intstr.FromInt(8001)},
+	}, managedGatewayProxyServicePorts(listeners, existing))
+}
+
+func TestGatewayReconciler_ensureManagedGatewayAdminSecret(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, gatewayv1alpha2.AddToScheme(scheme))
+	gateway := &gatewayv1alpha2.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-a", Namespace: "team-a", UID: "1234"},
+	}
+	r := &GatewayReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Scheme: scheme,
+	}
+
+	t.Log("verifying that admin api credentials are generated for the gateway")
+	secret, err := r.ensureManagedGatewayAdminSecret(context.Background(), gateway)
+	require.NoError(t, err)
+	assert.Equal(t, "team-a-kong-admin", secret.Name)
+	require.Len(t, secret.OwnerReferences, 1)
+	assert.Equal(t, gateway.Name, secret.OwnerReferences[0].Name)
+	block, _ := pem.Decode(secret.Data[corev1.TLSCertKey])
+	require.NotNil(t, block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"team-a-kong-admin.team-a.svc"}, cert.DNSNames)
+	assert.Equal(t, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}, cert.ExtKeyUsage)
+	require.NotEmpty(t, secret.Data[corev1.TLSPrivateKeyKey])
+
+	t.Log("verifying that existing admin api credentials are kept")
+	again, err := r.ensureManagedGatewayAdminSecret(context.Background(), gateway)
+	require.NoError(t, err)
+	assert.Equal(t, secret.Data, again.Data)
+	stored := &corev1.Secret{}
+	require.NoError(t, r.Client.Get(context.Background(), client.ObjectKeyFromObject(secret), stored))
+	assert.Equal(t, secret.Data, stored.Data)
+
+	t.Log("verifying that an admin api client certificate is generated for the controller")
+	clientSecret, err := r.ensureManagedGatewayAdminClientSecret(context.Background(), gateway)
+	require.NoError(t, err)
+	assert.Equal(t, "team-a-kong-admin-client", clientSecret.Name)
+	block, _ = pem.Decode(clientSecret.Data[corev1.TLSCertKey])
+	require.NotNil(t, block)
+	clientCert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	assert.Equal(t, []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}, clientCert.ExtKeyUsage)
+	require.NotEmpty(t, clientSecret.Data[corev1.TLSPrivateKeyKey])
+}
+
+func Test_managedGatewayDeploymentSpec(t *testing.T) {
+	gateway := &gatewayv1alpha2.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-a", Namespace: "team-a"},
+		Spec: gatewayv1alpha2.GatewaySpec{
+			Listeners: []gatewayv1alpha2.Listener{{Name: "http", Port: 80, Protocol: gatewayv1alpha2.HTTPProtocolType}},
+		},
+	}
+	spec := managedGatewayDeploymentSpec(gateway, managedGatewayParameters{image: DefaultManagedGatewayImage, replicas: 1},
+		"team-a-kong-admin", "team-a-kong-admin-client")
+
+	t.Log("verifying that the admin api only accepts the client certificate of the controller")
+	container := spec.Template.Spec.Containers[0]
+	env := make(map[string]string, len(container.Env))
+	for _, e := range container.Env {
+		env[e.Name] = e.Value
+	}
+	assert.Equal(t, "on", env["KONG_NGINX_ADMIN_SSL_VERIFY_CLIENT"])
+	assert.Equal(t, "/etc/kong-admin-client/tls.crt", env["KONG_NGINX_ADMIN_SSL_CLIENT_CERTIFICATE"])
+
+	t.Log("verifying that the private key of the controller is not mounted in the proxies")
+	require.Len(t, spec.Template.Spec.Volumes, 2)
+	clientVolume := spec.Template.Spec.Volumes[1].Secret
+	require.NotNil(t, clientVolume)
+	assert.Equal(t, "team-a-kong-admin-client", clientVolume.SecretName)
+	assert.Equal(t, []corev1.KeyToPath{{Key: corev1.TLSCertKey, Path: corev1.TLSCertKey}}, clientVolume.Items)
+}
+
+func Test_managedGatewayNetworkPolicySpec(t *testing.T) {
+	gateway := &gatewayv1alpha2.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-a", Namespace: "team-a"},
+		Spec: gatewayv1alpha2.GatewaySpec{
+			Listeners: []gatewayv1alpha2.Listener{
+				{Name: "http", Port: 80, Protocol: gatewayv1alpha2.HTTPProtocolType},
+				{Name: "dns", Port: 53, Protocol: gatewayv1alpha2.UDPProtocolType},
+			},
+		},
+	}
+	controller := networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{corev1.LabelMetadataName: "kong"}},
+		PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "ingress-kong"}},
+	}
+	tcp, udp := corev1.ProtocolTCP, corev1.ProtocolUDP
+	port := func(protocol *corev1.Protocol, number int) networkingv1.NetworkPolicyPort {
+		portNumber := intstr.FromInt(number)
+		return networkingv1.NetworkPolicyPort{Protocol: protocol, Port: &portNumber}
+	}
+
+	assert.Equal(t, networkingv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{ManagedGatewayLabel: "team-a"}},
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		Ingress: []networkingv1.NetworkPolicyIngressRule{
+			{Ports: []networkingv1.NetworkPolicyPort{port(&tcp, 8000), port(&udp, 8001), port(&tcp, 8100)}},
+			{
+				Ports: []networkingv1.NetworkPolicyPort{port(&tcp, 8444)},
+				From:  []networkingv1.NetworkPolicyPeer{controller},
+			},
+		},
+	}, managedGatewayNetworkPolicySpec(gateway, controller))
+}
@@ -14,6 +14,7 @@ import (
 	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
+	configurationv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
 )
 
 // -----------------------------------------------------------------------------
@@ -54,9 +55,21 @@ func isGatewayInClassAndUnmanaged(gatewayClass *gatewayv1alpha2.GatewayClass, ga
 	return ok && gatewayClass.Spec.ControllerName == ControllerName
 }
 
+// isGatewayUsingService returns boolean if the provided gateway is in unmanaged mode and
+// attached to the provided publish service.
+func isGatewayUsingService(gateway gatewayv1alpha2.Gateway, svc client.Object) bool {
+	ref, ok := annotations.ExtractUnmanagedGatewayMode(gateway.Annotations)
+	return ok && ref == fmt.Sprintf("%s/%s", svc.GetNamespace(), svc.GetName())
+}
+
 // convertListenersToListenerStatuses converts all the listeners from the given gateway
-// object into ListenerStatus objects.
-func convertListenersToListenerStatuses(gateway *gatewayv1alpha2.Gateway) (listenerStatuses []gatewayv1alpha2.ListenerStatus) {
+// object into ListenerStatus objects. The provided ResolvedRefs conditions, indexed by
+// listener name, are added to the statuses of the listeners, which are only ready
+// when their refs are resolved.
+func convertListenersToListenerStatuses(
+	gateway *gatewayv1alpha2.Gateway,
+	resolvedRefs map[gatewayv1alpha2.SectionName]metav1.Condition,
+) (listenerStatuses []gatewayv1alpha2.ListenerStatus) {
 	existingListenerStatuses := make(map[gatewayv1alpha2.SectionName]gatewayv1alpha2.ListenerStatus, len(gateway.Status.Listeners))
 	for _, listenerStatus := range gateway.Status.Listeners {
 		existingListenerStatuses[listenerStatus.Name] = listenerStatus
@@ -69,24 +82,104 @@ func convertListenersToListenerStatuses(gateway *gatewayv1alpha2.Gateway) (liste
 			attachedRoutes = existingListenerStatus.AttachedRoutes
 		}
 
+		readyCondition := metav1.Condition{
+			Type:               string(gatewayv1alpha2.ListenerConditionReady),
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: gateway.Generation,
+			LastTransitionTime: metav1.Now(),
+			Reason:             string(gatewayv1alpha2.ListenerReasonReady),
+			Message:            "the listener is ready and available for routing",
+		}
+		if resolvedRefsCondition, ok := resolvedRefs[listener.Name]; ok {
+			conditions = append(conditions, resolvedRefsCondition)
+			if resolvedRefsCondition.Status != metav1.ConditionTrue {
+				readyCondition.Status = metav1.ConditionFalse
+				readyCondition.Reason = string(gatewayv1alpha2.ListenerReasonInvalid)
+				readyCondition.Message = "the listener has certificateRefs which can't be resolved"
+			}
+		}
+
 		listenerStatuses = append(listenerStatuses, gatewayv1alpha2.ListenerStatus{
 			Name:           listener.Name,
 			SupportedKinds: supportedRouteGroupKinds,
 			AttachedRoutes: attachedRoutes,
-			Conditions: append(conditions, metav1.Condition{
-				Type:               string(gatewayv1alpha2.ListenerConditionReady),
-				Status:             metav1.ConditionTrue,
-				ObservedGeneration: gateway.Generation,
-				LastTransitionTime: metav1.Now(),
-				Reason:             string(gatewayv1alpha2.ListenerReasonReady),
-				Message:            "the listener is ready and available for routing",
-			}),
+			Conditions:     append(conditions, readyCondition),
 		})
 	}
 
 	return
 }
 
+// areListenerStatusesEqual determines if two lists of listener statuses have the same contents,
+// regardless of when their conditions last transitioned.
+func areListenerStatusesEqual(l1, l2 []gatewayv1alpha2.ListenerStatus) bool {
+	withoutTransitionTimes := func(statuses []gatewayv1alpha2.ListenerStatus) []gatewayv1alpha2.ListenerStatus {
+		res := make([]gatewayv1alpha2.ListenerStatus, 0, len(statuses))
+		for _, status := range statuses {
+			status.Conditions = append([]metav1.Condition{}, status.Conditions...)
+			for i := range status.Conditions {
+				status.Conditions[i].LastTransitionTime = metav1.Time{}
+			}
+			res = append(res, status)
+		}
+		return res
+	}
+	return reflect.DeepEqual(withoutTransitionTimes(l1), withoutTransitionTimes(l2))
+}
+
+// isSecretReferencedByGateway indicates whether any listener of the provided gateway
+// references the Secret with the provided namespace and name in its certificateRefs.
+func isSecretReferencedByGateway(gateway gatewayv1alpha2.Gateway, namespace, name string) bool {
+	for _, listener := range gateway.Spec.Listeners {
+		if listener.TLS == nil {
+			continue
+		}
+		for _, ref := range listener.TLS.CertificateRefs {
+			if ref == nil || string(ref.Name) != name {
+				continue
+			}
+			refNamespace := gateway.Namespace
+			if ref.Namespace != nil {
+				refNamespace = string(*ref.Namespace)
+			}
+			if refNamespace == namespace {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isSecretRefPermittedByPolicies indicates whether any of the provided ReferencePolicies permits
+// Gateways in the provided namespace to reference the Secret of the provided cross-namespace ref.
+func isSecretRefPermittedByPolicies(
+	gatewayNamespace string,
+	ref gatewayv1alpha2.SecretObjectReference,
+	policies []gatewayv1alpha2.ReferencePolicy,
+) bool {
+	from := gatewayv1alpha2.ReferencePolicyFrom{
+		Group:     gatewayV1alpha2Group,
+		Kind:      gatewayv1alpha2.Kind("Gateway"),
+		Namespace: gatewayv1alpha2.Namespace(gatewayNamespace),
+	}
+	for _, policy := range policies {
+		if policy.Namespace != string(*ref.Namespace) {
+			continue
+		}
+		for _, policyFrom := range policy.Spec.From {
+			if !reflect.DeepEqual(from, policyFrom) {
+				continue
+			}
+			for _, to := range policy.Spec.To {
+				if to.Group == "" && to.Kind == "Secret" && (to.Name == nil || *to.Name == ref.Name) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
 // getRefFromPublishService splits a publish service string in the format namespace/name into a types.NamespacedName
 // and verifies the contents producing an error if they don't match namespace/name format.
 func getRefFromPublishService(publishService string) (types.NamespacedName, error) {
@@ -100,6 +193,19 @@ func getRefFromPublishService(publishService string) (types.NamespacedName, erro
 	}, nil
 }
 
+// getGatewayConfigurationRef provides the namespace and name of the GatewayConfiguration
+// referenced by the parametersRef of a gatewayclass, if it references one.
+func getGatewayConfigurationRef(gatewayClass *gatewayv1alpha2.GatewayClass) (types.NamespacedName, bool) {
+	ref := gatewayClass.Spec.ParametersRef
+	if ref == nil ||
+		string(ref.Group) != configurationv1beta1.GroupVersion.Group ||
+		string(ref.Kind) != configurationv1beta1.GatewayConfigurationKind ||
+		ref.Namespace == nil {
+		return types.NamespacedName{}, false
+	}
+	return types.NamespacedName{Namespace: string(*ref.Namespace), Name: ref.Name}, true
+}
+
 // pruneGatewayStatusConds cleans out old status conditions if the Gateway currently has more
 // status conditions set than the 8 maximum allowed by the Kubernetes API.
 func pruneGatewayStatusConds(gateway *gatewayv1alpha2.Gateway) *gatewayv1alpha2.Gateway {
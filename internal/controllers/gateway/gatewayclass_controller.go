@@ -15,6 +15,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
 )
 
@@ -36,8 +37,9 @@ const (
 // GatewayClassReconciler reconciles a GatewayClass object
 type GatewayClassReconciler struct { //nolint:revive
 	client.Client
-	Log    logr.Logger
-	Scheme *runtime.Scheme
+	Log             logr.Logger
+	Scheme          *runtime.Scheme
+	DataplaneClient *dataplane.KongClient
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -72,34 +74,42 @@ func (r *GatewayClassReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	gwc := new(gatewayv1alpha2.GatewayClass)
 	if err := r.Client.Get(ctx, req.NamespacedName, gwc); err != nil {
 		if errors.IsNotFound(err) {
-			log.V(util.DebugLevel).Info("object enqueued no longer exists, skipping", "name", req.Name)
-			return ctrl.Result{}, nil
+			log.V(util.DebugLevel).Info("object enqueued no longer exists, ensuring it is not present in the proxy cache", "name", req.Name)
+			gwc.Name = req.Name
+			return ctrl.Result{}, r.DataplaneClient.DeleteObject(gwc)
 		}
 		return ctrl.Result{}, err
 	}
 	log.V(util.DebugLevel).Info("processing gatewayclass", "name", req.Name)
 
-	if gwc.Spec.ControllerName == ControllerName {
-		alreadyAccepted := false
-		for _, cond := range gwc.Status.Conditions {
-			if cond.Reason == string(gatewayv1alpha2.GatewayClassConditionStatusAccepted) {
-				if cond.ObservedGeneration == gwc.Generation {
-					alreadyAccepted = true
-				}
+	// the parser needs the gatewayclasses of the controller to find the
+	// GatewayConfigurations they reference.
+	if gwc.Spec.ControllerName != ControllerName {
+		return ctrl.Result{}, r.DataplaneClient.DeleteObject(gwc)
+	}
+	if err := r.DataplaneClient.UpdateObject(gwc); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	alreadyAccepted := false
+	for _, cond := range gwc.Status.Conditions {
+		if cond.Reason == string(gatewayv1alpha2.GatewayClassConditionStatusAccepted) {
+			if cond.ObservedGeneration == gwc.Generation {
+				alreadyAccepted = true
 			}
 		}
+	}
 
-		if !alreadyAccepted {
-			gwc.Status.Conditions = append(gwc.Status.Conditions, metav1.Condition{
-				Type:               string(gatewayv1alpha2.GatewayClassConditionStatusAccepted),
-				Status:             metav1.ConditionTrue,
-				ObservedGeneration: gwc.Generation,
-				LastTransitionTime: metav1.Now(),
-				Reason:             string(gatewayv1alpha2.GatewayClassReasonAccepted),
-				Message:            "the gatewayclass has been accepted by the controller",
-			})
-			return ctrl.Result{}, r.Status().Update(ctx, pruneGatewayClassStatusConds(gwc))
-		}
+	if !alreadyAccepted {
+		gwc.Status.Conditions = append(gwc.Status.Conditions, metav1.Condition{
+			Type:               string(gatewayv1alpha2.GatewayClassConditionStatusAccepted),
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: gwc.Generation,
+			LastTransitionTime: metav1.Now(),
+			Reason:             string(gatewayv1alpha2.GatewayClassReasonAccepted),
+			Message:            "the gatewayclass has been accepted by the controller",
+		})
+		return ctrl.Result{}, r.Status().Update(ctx, pruneGatewayClassStatusConds(gwc))
 	}
 
 	return ctrl.Result{}, nil
@@ -0,0 +1,101 @@
+/*
+Copyright 2022 Kong, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane"
+	configurationv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
+)
+
+// GatewayConfigurationReconciler reconciles a GatewayConfiguration object
+type GatewayConfigurationReconciler struct {
+	client.Client
+	Log             logr.Logger
+	Scheme          *runtime.Scheme
+	DataplaneClient *dataplane.KongClient
+}
+
+//+kubebuilder:rbac:groups=configuration.konghq.com,resources=gatewayconfigurations,verbs=get;list;watch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *GatewayConfigurationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("V1Beta1GatewayConfiguration", req.NamespacedName)
+	config := new(configurationv1beta1.GatewayConfiguration)
+	if err := r.Get(ctx, req.NamespacedName, config); err != nil {
+		// if the queued object is no longer present in the proxy cache we need
+		// to ensure that if it was ever added to the cache, it gets removed.
+		if errors.IsNotFound(err) {
+			debug(log, config, "object does not exist, ensuring it is not present in the proxy cache")
+			config.Namespace = req.Namespace
+			config.Name = req.Name
+			return ctrl.Result{}, r.DataplaneClient.DeleteObject(config)
+		}
+
+		// for any error other than 404, requeue
+		return ctrl.Result{}, err
+	}
+	debug(log, config, "processing gatewayconfiguration")
+
+	debug(log, config, "checking deletion timestamp")
+	if config.DeletionTimestamp != nil {
+		debug(log, config, "gatewayconfiguration is being deleted, re-configuring data-plane")
+		if err := r.DataplaneClient.DeleteObject(config); err != nil {
+			debug(log, config, "failed to delete object from data-plane, requeuing")
+			return ctrl.Result{}, err
+		}
+		debug(log, config, "ensured object was removed from the data-plane (if ever present)")
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.DataplaneClient.UpdateObject(config); err != nil {
+		debug(log, config, "failed to update object in data-plane, requeueing")
+		return ctrl.Result{}, err
+	}
+	info(log, config, "gatewayconfiguration has been configured on the data-plane")
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *GatewayConfigurationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	c, err := controller.New("gatewayconfiguration-controller", mgr, controller.Options{
+		Reconciler: r,
+		LogConstructor: func(_ *reconcile.Request) logr.Logger {
+			return r.Log
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Watch(
+		&source.Kind{Type: &configurationv1beta1.GatewayConfiguration{}},
+		&handler.EnqueueRequestForObject{},
+	)
+}
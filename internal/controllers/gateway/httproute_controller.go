@@ -6,6 +6,7 @@ import (
 	"reflect"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -76,6 +77,15 @@ func (r *HTTPRouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
+	// the labels of a namespace determine whether the listeners of Gateways which
+	// select namespaces allow the HTTPRoute objects in that namespace.
+	if err := c.Watch(
+		&source.Kind{Type: &corev1.Namespace{}},
+		handler.EnqueueRequestsFromMapFunc(r.listHTTPRoutesForNamespace),
+	); err != nil {
+		return err
+	}
+
 	// because of the additional burden of having to manage reference data-plane
 	// configurations for HTTPRoute objects in the underlying Kong Gateway, we
 	// simply reconcile ALL HTTPRoute objects. This allows us to drop the backend
@@ -218,6 +228,27 @@ func (r *HTTPRouteReconciler) listHTTPRoutesForGateway(obj client.Object) []reco
 	return queue
 }
 
+// listHTTPRoutesForNamespace is a controller-runtime event.Handler which enqueues
+// the HTTPRoute objects in a namespace whose labels may have changed.
+func (r *HTTPRouteReconciler) listHTTPRoutesForNamespace(obj client.Object) []reconcile.Request {
+	httprouteList := gatewayv1alpha2.HTTPRouteList{}
+	if err := r.Client.List(context.Background(), &httprouteList, client.InNamespace(obj.GetName())); err != nil {
+		r.Log.Error(err, "failed to list httproute objects from the cached client")
+		return nil
+	}
+
+	queue := make([]reconcile.Request, 0, len(httprouteList.Items))
+	for _, httproute := range httprouteList.Items {
+		queue = append(queue, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: httproute.Namespace,
+				Name:      httproute.Name,
+			},
+		})
+	}
+	return queue
+}
+
 // -----------------------------------------------------------------------------
 // HTTPRoute Controller - Reconciliation
 // -----------------------------------------------------------------------------
@@ -264,7 +295,7 @@ func (r *HTTPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	// we need to pull the Gateway parent objects for the HTTPRoute to verify
 	// routing behavior and ensure compatibility with Gateway configurations.
 	debug(log, httproute, "retrieving GatewayClass and Gateway for route")
-	gateways, err := getSupportedGatewayForRoute(ctx, r.Client, httproute)
+	gateways, refusedGateways, err := getSupportedGatewayForRoute(ctx, r.Client, httproute)
 	if err != nil {
 		if err.Error() == unsupportedGW {
 			debug(log, httproute, "unsupported route found, processing to verify whether it was ever supported")
@@ -293,6 +324,19 @@ func (r *HTTPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, err
 	}
 
+	// the listeners of some gateways may not allow the httproute to attach to them, the
+	// reason of each refusal is reflected in the status. If no gateway accepts the
+	// httproute it's removed from the proxy cache.
+	for _, refused := range refusedGateways {
+		if _, err := r.ensureGatewayReferenceStatusRejected(ctx, httproute, refused.reason, refused.message, refused.gateway); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	if len(gateways) == 0 {
+		debug(log, httproute, "httproute is not allowed by the listeners of its gateways, ensuring it is not present in the proxy cache")
+		return ctrl.Result{}, r.DataplaneClient.DeleteObject(httproute)
+	}
+
 	// the referenced gateway object(s) for the HTTPRoute needs to be ready
 	// before we'll attempt any configurations of it. If it's not we'll
 	// requeue the object and wait until all supported gateways are ready.
@@ -316,6 +360,21 @@ func (r *HTTPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		// we will wait until the object is reported as successfully configured before
 		// moving on to status updates.
 		if !r.DataplaneClient.KubernetesObjectIsConfigured(httproute) {
+			// if the HTTPRoute could not be translated into Kong configuration, e.g.
+			// because it uses features which Kong can't express, or the data-plane
+			// rejected the configuration generated from it, this is reflected in
+			// the status until it's fixed.
+			if msg, failed := r.DataplaneClient.KubernetesObjectTranslationFailure(httproute); failed {
+				debug(log, httproute, "httproute could not be translated into Kong configuration")
+				if _, err := r.ensureGatewayReferenceStatusRejected(ctx, httproute, routeReasonTranslationFailed, msg, gateways...); err != nil {
+					return ctrl.Result{}, err
+				}
+			} else if msg, rejected := r.DataplaneClient.KubernetesObjectConfigurationError(httproute); rejected {
+				debug(log, httproute, "httproute configuration was rejected by the data-plane")
+				if _, err := r.ensureGatewayReferenceStatusRejected(ctx, httproute, routeReasonConfigurationRejected, msg, gateways...); err != nil {
+					return ctrl.Result{}, err
+				}
+			}
 			return ctrl.Result{Requeue: true}, nil
 		}
 	}
@@ -348,10 +407,36 @@ func (r *HTTPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 // implementation supports for route object parent references.
 var httprouteParentKind = "Gateway"
 
-// ensureGatewayReferenceStatus takes any number of Gateways that should be
+// ensureGatewayReferenceStatusAdded takes any number of Gateways that should be
 // considered "attached" to a given HTTPRoute and ensures that the status
 // for the HTTPRoute is updated appropriately.
 func (r *HTTPRouteReconciler) ensureGatewayReferenceStatusAdded(ctx context.Context, httproute *gatewayv1alpha2.HTTPRoute, gateways ...*gatewayv1alpha2.Gateway) (bool, error) {
+	return r.ensureGatewayReferenceStatus(ctx, httproute, metav1.Condition{
+		Type:               string(gatewayv1alpha2.ConditionRouteAccepted),
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: httproute.Generation,
+		LastTransitionTime: metav1.Now(),
+		Reason:             string(gatewayv1alpha2.GatewayReasonReady),
+	}, gateways...)
+}
+
+// ensureGatewayReferenceStatusRejected ensures that the status of the HTTPRoute
+// indicates to each of the provided Gateways that it was not accepted for the
+// provided reason, including the problems which were reported.
+func (r *HTTPRouteReconciler) ensureGatewayReferenceStatusRejected(ctx context.Context, httproute *gatewayv1alpha2.HTTPRoute, reason, msg string, gateways ...*gatewayv1alpha2.Gateway) (bool, error) {
+	return r.ensureGatewayReferenceStatus(ctx, httproute, metav1.Condition{
+		Type:               string(gatewayv1alpha2.ConditionRouteAccepted),
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: httproute.Generation,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            msg,
+	}, gateways...)
+}
+
+// ensureGatewayReferenceStatus ensures that the status of the HTTPRoute
+// contains the provided Accepted condition for each of the provided Gateways.
+func (r *HTTPRouteReconciler) ensureGatewayReferenceStatus(ctx context.Context, httproute *gatewayv1alpha2.HTTPRoute, accepted metav1.Condition, gateways ...*gatewayv1alpha2.Gateway) (bool, error) {
 	// map the existing parentStatues to avoid duplications
 	parentStatuses := make(map[string]*gatewayv1alpha2.RouteParentStatus)
 	for _, existingParent := range httproute.Status.Parents {
@@ -375,13 +460,7 @@ func (r *HTTPRouteReconciler) ensureGatewayReferenceStatusAdded(ctx context.Cont
 				Name:      gatewayv1alpha2.ObjectName(gateway.Name),
 			},
 			ControllerName: ControllerName,
-			Conditions: []metav1.Condition{{
-				Type:               string(gatewayv1alpha2.ConditionRouteAccepted),
-				Status:             metav1.ConditionTrue,
-				ObservedGeneration: httproute.Generation,
-				LastTransitionTime: metav1.Now(),
-				Reason:             string(gatewayv1alpha2.GatewayReasonReady),
-			}},
+			Conditions:     []metav1.Condition{accepted},
 		}
 
 		// if the reference already exists and doesn't require any changes
@@ -7,9 +7,10 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
 )
 
 // -----------------------------------------------------------------------------
@@ -35,26 +36,74 @@ func parentRefsForRoute(obj client.Object) ([]gatewayv1alpha2.ParentReference, e
 	}
 }
 
+// refusedGateway is a Gateway supported by this controller which a route refers
+// to, but whose listeners don't allow the route to attach to it.
+type refusedGateway struct {
+	gateway *gatewayv1alpha2.Gateway
+	reason  string
+	message string
+}
+
+const (
+	// routeReasonNotAllowedByListeners is the reason of the Accepted condition of
+	// routes which no listener of a Gateway allows, because of their namespace or kind.
+	routeReasonNotAllowedByListeners = "NotAllowedByListeners"
+
+	// routeReasonNoMatchingListenerHostname is the reason of the Accepted condition
+	// of routes whose hostnames don't match any hostname of the listeners of a Gateway
+	// which allow them.
+	routeReasonNoMatchingListenerHostname = "NoMatchingListenerHostname"
+
+	// routeReasonConfigurationRejected is the reason of the Accepted condition
+	// of routes whose configuration was rejected by the data-plane.
+	routeReasonConfigurationRejected = "ConfigurationRejected"
+
+	// routeReasonTranslationFailed is the reason of the Accepted condition of
+	// routes which could not be translated into Kong configuration, e.g. because
+	// their backendRefs can't be expressed as a single Kong upstream.
+	routeReasonTranslationFailed = "TranslationFailed"
+)
+
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
 // getSupportedGatewayForRoute will retrieve the Gateway and GatewayClass object for any
 // Gateway APIs route object (e.g. HTTPRoute, TCPRoute, e.t.c.) from the provided cached
-// client if they match this controller. If there are no gateways present for this route
-// OR the present gateways are references to missing objects, this will return a unsupportedGW error.
-func getSupportedGatewayForRoute(ctx context.Context, mgrc client.Client, obj client.Object) ([]*gatewayv1alpha2.Gateway, error) {
+// client if they match this controller. Gateways whose listeners don't allow the route
+// to attach, because of their AllowedRoutes or hostnames, are provided separately along
+// with the reason of the refusal. If there are no gateways present for this route OR the
+// present gateways are references to missing objects, this will return a unsupportedGW error.
+func getSupportedGatewayForRoute(
+	ctx context.Context,
+	mgrc client.Client,
+	obj client.Object,
+) ([]*gatewayv1alpha2.Gateway, []refusedGateway, error) {
 	// gather the parentrefs for this route object
 	parentRefs, err := parentRefsForRoute(obj)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	// the namespace of the route is needed to verify whether namespace selectors
+	// of the listeners allow it, it's only retrieved when needed.
+	var routeNamespace *corev1.Namespace
+	getRouteNamespace := func() (*corev1.Namespace, error) {
+		if routeNamespace == nil {
+			namespace := &corev1.Namespace{}
+			if err := mgrc.Get(ctx, client.ObjectKey{Name: obj.GetNamespace()}, namespace); err != nil {
+				return nil, fmt.Errorf("failed to retrieve namespace for route: %w", err)
+			}
+			routeNamespace = namespace
+		}
+		return routeNamespace, nil
 	}
 
 	// search each parentRef to see if this controller is one of the supported ones
 	gateways := make([]*gatewayv1alpha2.Gateway, 0)
+	refused := make([]refusedGateway, 0)
 	for _, parentRef := range parentRefs {
 		// gather the namespace/name for the gateway
 		namespace := obj.GetNamespace()
 		if parentRef.Namespace != nil {
-			// TODO: need namespace restrictions implementation done before
-			// merging this, need to filter out objects with a disallowed NS.
-			// https://github.com/Kong/kubernetes-ingress-controller/issues/2080
 			namespace = string(*parentRef.Namespace)
 		}
 		name := string(parentRef.Name)
@@ -70,7 +119,7 @@ func getSupportedGatewayForRoute(ctx context.Context, mgrc client.Client, obj cl
 				// that there's another gateway, so keep searching through the list.
 				continue
 			}
-			return nil, fmt.Errorf("failed to retrieve gateway for route: %w", err)
+			return nil, nil, fmt.Errorf("failed to retrieve gateway for route: %w", err)
 		}
 
 		// pull the GatewayClass for the Gateway object from the cached client
@@ -84,80 +133,98 @@ func getSupportedGatewayForRoute(ctx context.Context, mgrc client.Client, obj cl
 				// so keep searching through the list.
 				continue
 			}
-			return nil, fmt.Errorf("failed to retrieve gatewayclass for gateway: %w", err)
+			return nil, nil, fmt.Errorf("failed to retrieve gatewayclass for gateway: %w", err)
 		}
 
-		// if the GatewayClass matches this controller we're all set and this controller
-		// should reconcile this object.
-		if gatewayClass.Spec.ControllerName == ControllerName {
-			allowedNamespaces := make(map[string]interface{})
-			// set true if we find any AllowedRoutes. there may be none, in which case any namespace is permitted
-			filtered := false
-			for _, listener := range gateway.Spec.Listeners {
-				// TODO https://github.com/Kong/kubernetes-ingress-controller/issues/2408
-				// This currently only performs a baseline filter to ensure that routes cannot match based on namespace
-				// criteria on a listener that cannot possibly handle them (e.g. an HTTPRoute should not be included
-				// based on matching a filter for a UDP listener). This needs to be expanded to an allowedRoutes.kind
-				// implementation with default allowed kinds when there's no user-specified filter.
-				switch obj.(type) {
-				case *gatewayv1alpha2.HTTPRoute:
-					if !(listener.Protocol == gatewayv1alpha2.HTTPProtocolType || listener.Protocol == gatewayv1alpha2.HTTPSProtocolType) {
-						continue
-					}
-				case *gatewayv1alpha2.TCPRoute:
-					if listener.Protocol != gatewayv1alpha2.TCPProtocolType {
-						continue
-					}
-				case *gatewayv1alpha2.UDPRoute:
-					if listener.Protocol != gatewayv1alpha2.UDPProtocolType {
-						continue
-					}
-				case *gatewayv1alpha2.TLSRoute:
-					if listener.Protocol != gatewayv1alpha2.TLSProtocolType {
-						continue
-					}
-				default:
-					continue
-				}
-				if listener.AllowedRoutes != nil {
-					filtered = true
-					if *listener.AllowedRoutes.Namespaces.From == gatewayv1alpha2.NamespacesFromAll {
-						// we allow "all" by just stuffing the namespace we want to find into the map
-						allowedNamespaces[obj.GetNamespace()] = nil
-					} else if *listener.AllowedRoutes.Namespaces.From == gatewayv1alpha2.NamespacesFromSame {
-						allowedNamespaces[gateway.ObjectMeta.Namespace] = nil
-					} else if *listener.AllowedRoutes.Namespaces.From == gatewayv1alpha2.NamespacesFromSelector {
-						namespaces := &corev1.NamespaceList{}
-						selector, err := metav1.LabelSelectorAsSelector(listener.AllowedRoutes.Namespaces.Selector)
-						if err != nil {
-							return nil, fmt.Errorf("failed to convert LabelSelector to Selector for gateway %s",
-								gateway.ObjectMeta.Name)
-						}
-						err = mgrc.List(ctx, namespaces,
-							&client.ListOptions{LabelSelector: selector})
-						if err != nil {
-							return nil, fmt.Errorf("could not fetch allowed namespaces for gateway %s",
-								gateway.ObjectMeta.Name)
-						}
-						for _, allowed := range namespaces.Items {
-							allowedNamespaces[allowed.ObjectMeta.Name] = nil
-						}
-					}
-				}
-			}
+		// if the GatewayClass doesn't match this controller the route isn't attached
+		// to the Gateway as far as this controller is concerned.
+		if gatewayClass.Spec.ControllerName != ControllerName {
+			continue
+		}
 
-			_, allowedNamespace := allowedNamespaces[obj.GetNamespace()]
-			if !filtered || allowedNamespace {
-				gateways = append(gateways, &gateway)
+		// the route is attached to the Gateway if one of the listeners it refers to
+		// allows the route, and the hostnames of the route and the listener intersect.
+		reason, message := routeReasonNotAllowedByListeners, "no listener of the gateway allows the route"
+		for _, listener := range gateway.Spec.Listeners {
+			if parentRef.SectionName != nil && *parentRef.SectionName != listener.Name {
+				continue
+			}
+			if !util.IsRouteKindAllowedByListener(obj, listener) {
+				continue
+			}
+			allowed, err := util.IsRouteNamespaceAllowedByListener(obj, &gateway, listener, getRouteNamespace)
+			if err != nil {
+				return nil, nil, err
+			}
+			if !allowed {
+				continue
+			}
+			if !isRouteHostnameAllowedByListener(obj, listener) {
+				reason, message = routeReasonNoMatchingListenerHostname, "no hostname of the route matches the listeners of the gateway"
+				continue
 			}
+			reason = ""
+			break
+		}
+
+		if reason == "" {
+			gateways = append(gateways, &gateway)
+		} else {
+			refused = append(refused, refusedGateway{gateway: &gateway, reason: reason, message: message})
 		}
 	}
 
-	if len(gateways) == 0 {
+	// a route may refer to the same gateway several times (e.g. for several listeners),
+	// in which case it's attached to the gateway if any of its references is accepted.
+	refused = filterRefusedGateways(refused, gateways)
+
+	if len(gateways) == 0 && len(refused) == 0 {
 		// TODO https://github.com/Kong/kubernetes-ingress-controller/issues/2417 separate out various rejected reasons
 		// and apply specific statuses for those failures in the Route controllers
-		return nil, fmt.Errorf(unsupportedGW)
+		return nil, nil, fmt.Errorf(unsupportedGW)
+	}
+
+	return gateways, refused, nil
+}
+
+// isRouteHostnameAllowedByListener indicates whether the hostnames of a route
+// intersect with the hostname of a listener. Routes and listeners without
+// hostnames match any hostname, as do the routes which don't have hostnames.
+func isRouteHostnameAllowedByListener(obj client.Object, listener gatewayv1alpha2.Listener) bool {
+	var hostnames []gatewayv1alpha2.Hostname
+	switch route := obj.(type) {
+	case *gatewayv1alpha2.HTTPRoute:
+		hostnames = route.Spec.Hostnames
+	case *gatewayv1alpha2.TLSRoute:
+		hostnames = route.Spec.Hostnames
+	}
+
+	if listener.Hostname == nil || len(hostnames) == 0 {
+		return true
+	}
+	for _, hostname := range hostnames {
+		if _, ok := util.IntersectHostnames(string(*listener.Hostname), string(hostname)); ok {
+			return true
+		}
 	}
+	return false
+}
 
-	return gateways, nil
+// filterRefusedGateways drops the refused gateways which are also accepted, or
+// which are refused several times.
+func filterRefusedGateways(refused []refusedGateway, accepted []*gatewayv1alpha2.Gateway) []refusedGateway {
+	seen := make(map[string]struct{}, len(accepted)+len(refused))
+	for _, gateway := range accepted {
+		seen[gateway.Namespace+"/"+gateway.Name] = struct{}{}
+	}
+	filtered := make([]refusedGateway, 0, len(refused))
+	for _, r := range refused {
+		key := r.gateway.Namespace + "/" + r.gateway.Name
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		filtered = append(filtered, r)
+	}
+	return filtered
 }
@@ -0,0 +1,146 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+func Test_getSupportedGatewayForRoute(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, gatewayv1alpha2.AddToScheme(scheme))
+
+	fromAll := gatewayv1alpha2.NamespacesFromAll
+	fromSelector := gatewayv1alpha2.NamespacesFromSelector
+	hostname := gatewayv1alpha2.Hostname("*.konghq.com")
+	gateway := func(name string, listeners ...gatewayv1alpha2.Listener) *gatewayv1alpha2.Gateway {
+		return &gatewayv1alpha2.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kong", Name: name},
+			Spec:       gatewayv1alpha2.GatewaySpec{GatewayClassName: "kong", Listeners: listeners},
+		}
+	}
+	listener := func(protocol gatewayv1alpha2.ProtocolType, allowedRoutes *gatewayv1alpha2.AllowedRoutes) gatewayv1alpha2.Listener {
+		return gatewayv1alpha2.Listener{Name: "listener", Port: 80, Protocol: protocol, AllowedRoutes: allowedRoutes}
+	}
+	httproute := func(namespace, gateway string, hostnames ...gatewayv1alpha2.Hostname) *gatewayv1alpha2.HTTPRoute {
+		gatewayNamespace := gatewayv1alpha2.Namespace("kong")
+		return &gatewayv1alpha2.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "httproute"},
+			Spec: gatewayv1alpha2.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1alpha2.CommonRouteSpec{
+					ParentRefs: []gatewayv1alpha2.ParentReference{{Name: gatewayv1alpha2.ObjectName(gateway), Namespace: &gatewayNamespace}},
+				},
+				Hostnames: hostnames,
+			},
+		}
+	}
+
+	hostnameListener := listener(gatewayv1alpha2.HTTPProtocolType, &gatewayv1alpha2.AllowedRoutes{
+		Namespaces: &gatewayv1alpha2.RouteNamespaces{From: &fromAll},
+	})
+	hostnameListener.Hostname = &hostname
+	mgrc := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&gatewayv1alpha2.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "kong"},
+			Spec:       gatewayv1alpha2.GatewayClassSpec{ControllerName: ControllerName},
+		},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kong"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant", Labels: map[string]string{"shared-gateway": "true"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+		gateway("same", listener(gatewayv1alpha2.HTTPProtocolType, nil)),
+		gateway("selector", listener(gatewayv1alpha2.HTTPProtocolType, &gatewayv1alpha2.AllowedRoutes{
+			Namespaces: &gatewayv1alpha2.RouteNamespaces{
+				From:     &fromSelector,
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"shared-gateway": "true"}},
+			},
+		})),
+		gateway("tcp-only", listener(gatewayv1alpha2.HTTPProtocolType, &gatewayv1alpha2.AllowedRoutes{
+			Namespaces: &gatewayv1alpha2.RouteNamespaces{From: &fromAll},
+			Kinds:      []gatewayv1alpha2.RouteGroupKind{{Kind: "TCPRoute"}},
+		})),
+		gateway("udp", listener(gatewayv1alpha2.UDPProtocolType, &gatewayv1alpha2.AllowedRoutes{
+			Namespaces: &gatewayv1alpha2.RouteNamespaces{From: &fromAll},
+		})),
+		gateway("hostname", hostnameListener),
+	).Build()
+
+	for _, tt := range []struct {
+		msg      string
+		route    *gatewayv1alpha2.HTTPRoute
+		accepted bool
+		reason   string
+	}{
+		{
+			msg:      "listeners allow routes from the namespace of the gateway by default",
+			route:    httproute("kong", "same"),
+			accepted: true,
+		},
+		{
+			msg:    "listeners refuse routes from other namespaces by default",
+			route:  httproute("tenant", "same"),
+			reason: routeReasonNotAllowedByListeners,
+		},
+		{
+			msg:      "listeners allow routes from namespaces matching their selector",
+			route:    httproute("tenant", "selector"),
+			accepted: true,
+		},
+		{
+			msg:    "listeners refuse routes from namespaces not matching their selector",
+			route:  httproute("other", "selector"),
+			reason: routeReasonNotAllowedByListeners,
+		},
+		{
+			msg:    "listeners refuse routes of kinds they don't allow",
+			route:  httproute("kong", "tcp-only"),
+			reason: routeReasonNotAllowedByListeners,
+		},
+		{
+			msg:    "listeners refuse routes which can't be used with their protocol",
+			route:  httproute("kong", "udp"),
+			reason: routeReasonNotAllowedByListeners,
+		},
+		{
+			msg:      "listeners allow routes with a hostname matching theirs",
+			route:    httproute("other", "hostname", "docs.konghq.com", "example.com"),
+			accepted: true,
+		},
+		{
+			msg:      "listeners allow routes without hostnames",
+			route:    httproute("other", "hostname"),
+			accepted: true,
+		},
+		{
+			msg:    "listeners refuse routes without any hostname matching theirs",
+			route:  httproute("other", "hostname", "example.com"),
+			reason: routeReasonNoMatchingListenerHostname,
+		},
+	} {
+		t.Run(tt.msg, func(t *testing.T) {
+			gateways, refused, err := getSupportedGatewayForRoute(context.Background(), mgrc, tt.route)
+			require.NoError(t, err)
+			if tt.accepted {
+				assert.Len(t, gateways, 1)
+				assert.Empty(t, refused)
+			} else {
+				assert.Empty(t, gateways)
+				require.Len(t, refused, 1)
+				assert.Equal(t, tt.reason, refused[0].reason)
+			}
+		})
+	}
+
+	t.Log("verifying that routes without any supported gateway are reported as unsupported")
+	_, _, err := getSupportedGatewayForRoute(context.Background(), mgrc, httproute("kong", "missing"))
+	require.Error(t, err)
+	assert.Equal(t, unsupportedGW, err.Error())
+}
@@ -6,6 +6,7 @@ import (
 	"reflect"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -76,6 +77,15 @@ func (r *TLSRouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
+	// the labels of a namespace determine whether the listeners of Gateways which
+	// select namespaces allow the TLSRoute objects in that namespace.
+	if err := c.Watch(
+		&source.Kind{Type: &corev1.Namespace{}},
+		handler.EnqueueRequestsFromMapFunc(r.listTLSRoutesForNamespace),
+	); err != nil {
+		return err
+	}
+
 	// because of the additional burden of having to manage reference data-plane
 	// configurations for TLSRoute objects in the underlying Kong Gateway, we
 	// simply reconcile ALL TLSRoute objects. This allows us to drop the backend
@@ -218,6 +228,27 @@ func (r *TLSRouteReconciler) listTLSRoutesForGateway(obj client.Object) []reconc
 	return queue
 }
 
+// listTLSRoutesForNamespace is a controller-runtime event.Handler which enqueues
+// the TLSRoute objects in a namespace whose labels may have changed.
+func (r *TLSRouteReconciler) listTLSRoutesForNamespace(obj client.Object) []reconcile.Request {
+	tlsrouteList := gatewayv1alpha2.TLSRouteList{}
+	if err := r.Client.List(context.Background(), &tlsrouteList, client.InNamespace(obj.GetName())); err != nil {
+		r.Log.Error(err, "failed to list tlsroute objects from the cached client")
+		return nil
+	}
+
+	queue := make([]reconcile.Request, 0, len(tlsrouteList.Items))
+	for _, tlsroute := range tlsrouteList.Items {
+		queue = append(queue, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: tlsroute.Namespace,
+				Name:      tlsroute.Name,
+			},
+		})
+	}
+	return queue
+}
+
 // -----------------------------------------------------------------------------
 // TLSRoute Controller - Reconciliation
 // -----------------------------------------------------------------------------
@@ -264,7 +295,7 @@ func (r *TLSRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	// we need to pull the Gateway parent objects for the TLSRoute to verify
 	// routing behavior and ensure compatibility with Gateway configurations.
 	debug(log, tlsroute, "retrieving GatewayClass and Gateway for route")
-	gateways, err := getSupportedGatewayForRoute(ctx, r.Client, tlsroute)
+	gateways, refusedGateways, err := getSupportedGatewayForRoute(ctx, r.Client, tlsroute)
 	if err != nil {
 		if err.Error() == unsupportedGW {
 			debug(log, tlsroute, "unsupported route found, processing to verify whether it was ever supported")
@@ -293,6 +324,19 @@ func (r *TLSRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return ctrl.Result{}, err
 	}
 
+	// the listeners of some gateways may not allow the tlsroute to attach to them, the
+	// reason of each refusal is reflected in the status. If no gateway accepts the
+	// tlsroute it's removed from the proxy cache.
+	for _, refused := range refusedGateways {
+		if _, err := r.ensureGatewayReferenceStatusRejected(ctx, tlsroute, refused.reason, refused.message, refused.gateway); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	if len(gateways) == 0 {
+		debug(log, tlsroute, "tlsroute is not allowed by the listeners of its gateways, ensuring it is not present in the proxy cache")
+		return ctrl.Result{}, r.DataplaneClient.DeleteObject(tlsroute)
+	}
+
 	// the referenced gateway object(s) for the TLSRoute needs to be ready
 	// before we'll attempt any configurations of it. If it's not we'll
 	// requeue the object and wait until all supported gateways are ready.
@@ -316,6 +360,21 @@ func (r *TLSRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		// we will wait until the object is reported as successfully configured before
 		// moving on to status updates.
 		if !r.DataplaneClient.KubernetesObjectIsConfigured(tlsroute) {
+			// if the TLSRoute could not be translated into Kong configuration, e.g.
+			// because its backendRefs can't be combined, or the data-plane rejected
+			// the configuration generated from it, this is reflected in the status
+			// until it's fixed.
+			if msg, failed := r.DataplaneClient.KubernetesObjectTranslationFailure(tlsroute); failed {
+				debug(log, tlsroute, "tlsroute could not be translated into Kong configuration")
+				if _, err := r.ensureGatewayReferenceStatusRejected(ctx, tlsroute, routeReasonTranslationFailed, msg, gateways...); err != nil {
+					return ctrl.Result{}, err
+				}
+			} else if msg, rejected := r.DataplaneClient.KubernetesObjectConfigurationError(tlsroute); rejected {
+				debug(log, tlsroute, "tlsroute configuration was rejected by the data-plane")
+				if _, err := r.ensureGatewayReferenceStatusRejected(ctx, tlsroute, routeReasonConfigurationRejected, msg, gateways...); err != nil {
+					return ctrl.Result{}, err
+				}
+			}
 			return ctrl.Result{Requeue: true}, nil
 		}
 	}
@@ -348,10 +407,36 @@ func (r *TLSRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 // implementation supports for route object parent references.
 var tlsrouteParentKind = "Gateway"
 
-// ensureGatewayReferenceStatus takes any number of Gateways that should be
+// ensureGatewayReferenceStatusAdded takes any number of Gateways that should be
 // considered "attached" to a given TLSRoute and ensures that the status
 // for the TLSRoute is updated appropriately.
 func (r *TLSRouteReconciler) ensureGatewayReferenceStatusAdded(ctx context.Context, tlsroute *gatewayv1alpha2.TLSRoute, gateways ...*gatewayv1alpha2.Gateway) (bool, error) {
+	return r.ensureGatewayReferenceStatus(ctx, tlsroute, metav1.Condition{
+		Type:               string(gatewayv1alpha2.ConditionRouteAccepted),
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: tlsroute.Generation,
+		LastTransitionTime: metav1.Now(),
+		Reason:             string(gatewayv1alpha2.GatewayReasonReady),
+	}, gateways...)
+}
+
+// ensureGatewayReferenceStatusRejected ensures that the status of the TLSRoute
+// indicates to each of the provided Gateways that it was not accepted for the
+// provided reason.
+func (r *TLSRouteReconciler) ensureGatewayReferenceStatusRejected(ctx context.Context, tlsroute *gatewayv1alpha2.TLSRoute, reason, msg string, gateways ...*gatewayv1alpha2.Gateway) (bool, error) {
+	return r.ensureGatewayReferenceStatus(ctx, tlsroute, metav1.Condition{
+		Type:               string(gatewayv1alpha2.ConditionRouteAccepted),
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: tlsroute.Generation,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            msg,
+	}, gateways...)
+}
+
+// ensureGatewayReferenceStatus ensures that the status of the TLSRoute
+// contains the provided Accepted condition for each of the provided Gateways.
+func (r *TLSRouteReconciler) ensureGatewayReferenceStatus(ctx context.Context, tlsroute *gatewayv1alpha2.TLSRoute, accepted metav1.Condition, gateways ...*gatewayv1alpha2.Gateway) (bool, error) {
 	// map the existing parentStatues to avoid duplications
 	parentStatuses := make(map[string]*gatewayv1alpha2.RouteParentStatus)
 	for _, existingParent := range tlsroute.Status.Parents {
@@ -375,13 +460,7 @@ func (r *TLSRouteReconciler) ensureGatewayReferenceStatusAdded(ctx context.Conte
 				Name:      gatewayv1alpha2.ObjectName(gateway.Name),
 			},
 			ControllerName: ControllerName,
-			Conditions: []metav1.Condition{{
-				Type:               string(gatewayv1alpha2.ConditionRouteAccepted),
-				Status:             metav1.ConditionTrue,
-				ObservedGeneration: tlsroute.Generation,
-				LastTransitionTime: metav1.Now(),
-				Reason:             string(gatewayv1alpha2.GatewayReasonReady),
-			}},
+			Conditions:     []metav1.Condition{accepted},
 		}
 
 		// if the reference already exists and doesn't require any changes
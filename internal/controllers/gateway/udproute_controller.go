@@ -6,6 +6,7 @@ import (
 	"reflect"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -76,6 +77,15 @@ func (r *UDPRouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
+	// the labels of a namespace determine whether the listeners of Gateways which
+	// select namespaces allow the UDPRoute objects in that namespace.
+	if err := c.Watch(
+		&source.Kind{Type: &corev1.Namespace{}},
+		handler.EnqueueRequestsFromMapFunc(r.listUDPRoutesForNamespace),
+	); err != nil {
+		return err
+	}
+
 	// because of the additional burden of having to manage reference data-plane
 	// configurations for UDPRoute objects in the underlying Kong Gateway, we
 	// simply reconcile ALL UDPRoute objects. This allows us to drop the backend
@@ -218,6 +228,27 @@ func (r *UDPRouteReconciler) listUDPRoutesForGateway(obj client.Object) []reconc
 	return queue
 }
 
+// listUDPRoutesForNamespace is a controller-runtime event.Handler which enqueues
+// the UDPRoute objects in a namespace whose labels may have changed.
+func (r *UDPRouteReconciler) listUDPRoutesForNamespace(obj client.Object) []reconcile.Request {
+	udprouteList := gatewayv1alpha2.UDPRouteList{}
+	if err := r.Client.List(context.Background(), &udprouteList, client.InNamespace(obj.GetName())); err != nil {
+		r.Log.Error(err, "failed to list udproute objects from the cached client")
+		return nil
+	}
+
+	queue := make([]reconcile.Request, 0, len(udprouteList.Items))
+	for _, udproute := range udprouteList.Items {
+		queue = append(queue, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: udproute.Namespace,
+				Name:      udproute.Name,
+			},
+		})
+	}
+	return queue
+}
+
 // -----------------------------------------------------------------------------
 // UDPRoute Controller - Reconciliation
 // -----------------------------------------------------------------------------
@@ -264,7 +295,7 @@ func (r *UDPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	// we need to pull the Gateway parent objects for the UDPRoute to verify
 	// routing behavior and ensure compatibility with Gateway configurations.
 	debug(log, udproute, "retrieving GatewayClass and Gateway for route")
-	gateways, err := getSupportedGatewayForRoute(ctx, r.Client, udproute)
+	gateways, refusedGateways, err := getSupportedGatewayForRoute(ctx, r.Client, udproute)
 	if err != nil {
 		if err.Error() == unsupportedGW {
 			debug(log, udproute, "unsupported route found, processing to verify whether it was ever supported")
@@ -293,6 +324,19 @@ func (r *UDPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return ctrl.Result{}, err
 	}
 
+	// the listeners of some gateways may not allow the udproute to attach to them, the
+	// reason of each refusal is reflected in the status. If no gateway accepts the
+	// udproute it's removed from the proxy cache.
+	for _, refused := range refusedGateways {
+		if _, err := r.ensureGatewayReferenceStatusRejected(ctx, udproute, refused.reason, refused.message, refused.gateway); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	if len(gateways) == 0 {
+		debug(log, udproute, "udproute is not allowed by the listeners of its gateways, ensuring it is not present in the proxy cache")
+		return ctrl.Result{}, r.DataplaneClient.DeleteObject(udproute)
+	}
+
 	// the referenced gateway object(s) for the UDPRoute needs to be ready
 	// before we'll attempt any configurations of it. If it's not we'll
 	// requeue the object and wait until all supported gateways are ready.
@@ -316,6 +360,21 @@ func (r *UDPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		// we will wait until the object is reported as successfully configured before
 		// moving on to status updates.
 		if !r.DataplaneClient.KubernetesObjectIsConfigured(udproute) {
+			// if the UDPRoute could not be translated into Kong configuration, e.g.
+			// because its backendRefs can't be combined, or the data-plane rejected
+			// the configuration generated from it, this is reflected in the status
+			// until it's fixed.
+			if msg, failed := r.DataplaneClient.KubernetesObjectTranslationFailure(udproute); failed {
+				debug(log, udproute, "udproute could not be translated into Kong configuration")
+				if _, err := r.ensureGatewayReferenceStatusRejected(ctx, udproute, routeReasonTranslationFailed, msg, gateways...); err != nil {
+					return ctrl.Result{}, err
+				}
+			} else if msg, rejected := r.DataplaneClient.KubernetesObjectConfigurationError(udproute); rejected {
+				debug(log, udproute, "udproute configuration was rejected by the data-plane")
+				if _, err := r.ensureGatewayReferenceStatusRejected(ctx, udproute, routeReasonConfigurationRejected, msg, gateways...); err != nil {
+					return ctrl.Result{}, err
+				}
+			}
 			return ctrl.Result{Requeue: true}, nil
 		}
 	}
@@ -348,10 +407,36 @@ func (r *UDPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 // implementation supports for route object parent references.
 var udprouteParentKind = "Gateway"
 
-// ensureGatewayReferenceStatus takes any number of Gateways that should be
+// ensureGatewayReferenceStatusAdded takes any number of Gateways that should be
 // considered "attached" to a given UDPRoute and ensures that the status
 // for the UDPRoute is updated appropriately.
 func (r *UDPRouteReconciler) ensureGatewayReferenceStatusAdded(ctx context.Context, udproute *gatewayv1alpha2.UDPRoute, gateways ...*gatewayv1alpha2.Gateway) (bool, error) {
+	return r.ensureGatewayReferenceStatus(ctx, udproute, metav1.Condition{
+		Type:               string(gatewayv1alpha2.ConditionRouteAccepted),
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: udproute.Generation,
+		LastTransitionTime: metav1.Now(),
+		Reason:             string(gatewayv1alpha2.GatewayReasonReady),
+	}, gateways...)
+}
+
+// ensureGatewayReferenceStatusRejected ensures that the status of the UDPRoute
+// indicates to each of the provided Gateways that it was not accepted for the
+// provided reason.
+func (r *UDPRouteReconciler) ensureGatewayReferenceStatusRejected(ctx context.Context, udproute *gatewayv1alpha2.UDPRoute, reason, msg string, gateways ...*gatewayv1alpha2.Gateway) (bool, error) {
+	return r.ensureGatewayReferenceStatus(ctx, udproute, metav1.Condition{
+		Type:               string(gatewayv1alpha2.ConditionRouteAccepted),
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: udproute.Generation,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            msg,
+	}, gateways...)
+}
+
+// ensureGatewayReferenceStatus ensures that the status of the UDPRoute
+// contains the provided Accepted condition for each of the provided Gateways.
+func (r *UDPRouteReconciler) ensureGatewayReferenceStatus(ctx context.Context, udproute *gatewayv1alpha2.UDPRoute, accepted metav1.Condition, gateways ...*gatewayv1alpha2.Gateway) (bool, error) {
 	// map the existing parentStatues to avoid duplications
 	parentStatuses := make(map[string]*gatewayv1alpha2.RouteParentStatus)
 	for _, existingParent := range udproute.Status.Parents {
@@ -375,13 +460,7 @@ func (r *UDPRouteReconciler) ensureGatewayReferenceStatusAdded(ctx context.Conte
 				Name:      gatewayv1alpha2.ObjectName(gateway.Name),
 			},
 			ControllerName: ControllerName,
-			Conditions: []metav1.Condition{{
-				Type:               string(gatewayv1alpha2.ConditionRouteAccepted),
-				Status:             metav1.ConditionTrue,
-				ObservedGeneration: udproute.Generation,
-				LastTransitionTime: metav1.Now(),
-				Reason:             string(gatewayv1alpha2.GatewayReasonReady),
-			}},
+			Conditions:     []metav1.Condition{accepted},
 		}
 
 		// if the reference already exists and doesn't require any changes
@@ -0,0 +1,155 @@
+package dataplane
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+// -----------------------------------------------------------------------------
+// Circuit Breaker Condition - Public Vars & Consts
+// -----------------------------------------------------------------------------
+
+// CircuitBreakerConditionType is the type of the condition of the controller's
+// Pod which reports the state of the circuit breaker of data-plane updates. It
+// is not meant to be used as a readiness gate: Kong keeps serving the last
+// configuration it applied while updates are backed off.
+const CircuitBreakerConditionType corev1.PodConditionType = "konghq.com/DataPlaneUpdates"
+
+// DefaultCircuitBreakerConditionInterval is how often the state of the circuit
+// breaker is checked for changes to report by default.
+const DefaultCircuitBreakerConditionInterval = 5 * time.Second
+
+// -----------------------------------------------------------------------------
+// Circuit Breaker Condition - Public Types
+// -----------------------------------------------------------------------------
+
+// CircuitBreakerConditionReporter reports the state of the circuit breaker of
+// data-plane updates as a condition of the controller's Pod.
+type CircuitBreakerConditionReporter struct {
+	logger   logrus.FieldLogger
+	client   client.Client
+	pod      k8stypes.NamespacedName
+	status   func() util.CircuitBreakerStatus
+	interval time.Duration
+
+	// reported is the state of the circuit breaker which was last reported.
+	reported util.CircuitBreakerState
+}
+
+// NewCircuitBreakerConditionReporter provides a reporter which sets the state
+// provided by status as a condition of the provided Pod every time it changes,
+// checking for changes at the provided interval.
+func NewCircuitBreakerConditionReporter(
+	logger logrus.FieldLogger,
+	c client.Client,
+	pod k8stypes.NamespacedName,
+	status func() util.CircuitBreakerStatus,
+	interval time.Duration,
+) *CircuitBreakerConditionReporter {
+	return &CircuitBreakerConditionReporter{
+		logger:   logger,
+		client:   c,
+		pod:      pod,
+		status:   status,
+		interval: interval,
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Circuit Breaker Condition - Public Methods
+// -----------------------------------------------------------------------------
+
+// Start implements the controller-runtime Runnable interface, and reports the
+// state of the circuit breaker until the provided context is done.
+func (r *CircuitBreakerConditionReporter) Start(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		if err := r.report(ctx); err != nil {
+			r.logger.WithError(err).Error("failed to report the circuit breaker state on the controller pod")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection implements the controller-runtime Runnable interface, as
+// the circuit breaker is only relevant for the instance updating the data-plane.
+func (r *CircuitBreakerConditionReporter) NeedLeaderElection() bool {
+	return true
+}
+
+// -----------------------------------------------------------------------------
+// Circuit Breaker Condition - Private Methods
+// -----------------------------------------------------------------------------
+
+// report sets the condition of the Pod if the state of the circuit breaker
+// changed since it was last reported.
+func (r *CircuitBreakerConditionReporter) report(ctx context.Context) error {
+	status := r.status()
+	if status.State == r.reported {
+		return nil
+	}
+
+	condition := circuitBreakerCondition(status)
+	// conditions are merged by type, so that the conditions the kubelet
+	// manages are left untouched.
+	patch, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"conditions": []corev1.PodCondition{condition},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: r.pod.Namespace, Name: r.pod.Name}}
+	if err := r.client.Status().Patch(ctx, pod, client.RawPatch(k8stypes.StrategicMergePatchType, patch)); err != nil {
+		return fmt.Errorf("patching pod %s: %w", r.pod, err)
+	}
+	r.reported = status.State
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// Circuit Breaker Condition - Private Functions
+// -----------------------------------------------------------------------------
+
+// circuitBreakerCondition describes the provided state of the circuit breaker
+// as a Pod condition: it's true when updates are applied normally, false while
+// they are backed off and unknown while the next update decides.
+func circuitBreakerCondition(status util.CircuitBreakerStatus) corev1.PodCondition {
+	condition := corev1.PodCondition{
+		Type:               CircuitBreakerConditionType,
+		LastTransitionTime: metav1.Now(),
+	}
+	switch status.State {
+	case util.CircuitBreakerOpen:
+		condition.Status = corev1.ConditionFalse
+		condition.Reason = "CircuitBreakerOpen"
+		condition.Message = fmt.Sprintf("updates of the data-plane are backed off after %d consecutive failures: %s",
+			status.ConsecutiveFailures, status.LastError)
+	case util.CircuitBreakerHalfOpen:
+		condition.Status = corev1.ConditionUnknown
+		condition.Reason = "CircuitBreakerHalfOpen"
+		condition.Message = fmt.Sprintf("the next update of the data-plane is retried after %d consecutive failures: %s",
+			status.ConsecutiveFailures, status.LastError)
+	default:
+		condition.Status = corev1.ConditionTrue
+		condition.Reason = "CircuitBreakerClosed"
+		condition.Message = "the data-plane is updated normally"
+	}
+	return condition
+}
@@ -0,0 +1,62 @@
+package dataplane
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+func TestCircuitBreakerConditionReporter(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kong", Name: "ingress-kong"},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pod).Build()
+
+	status := util.CircuitBreakerStatus{State: util.CircuitBreakerClosed}
+	r := NewCircuitBreakerConditionReporter(logrus.New(), c, k8stypes.NamespacedName{Namespace: "kong", Name: "ingress-kong"},
+		func() util.CircuitBreakerStatus { return status }, DefaultCircuitBreakerConditionInterval)
+	conditions := func() map[corev1.PodConditionType]corev1.PodCondition {
+		got := &corev1.Pod{}
+		require.NoError(t, c.Get(context.Background(), k8stypes.NamespacedName{Namespace: "kong", Name: "ingress-kong"}, got))
+		res := map[corev1.PodConditionType]corev1.PodCondition{}
+		for _, condition := range got.Status.Conditions {
+			res[condition.Type] = condition
+		}
+		return res
+	}
+
+	t.Log("verifying that a closed circuit breaker is reported as a true condition")
+	require.NoError(t, r.report(context.Background()))
+	got := conditions()
+	assert.Equal(t, corev1.ConditionTrue, got[CircuitBreakerConditionType].Status)
+	assert.Equal(t, corev1.ConditionTrue, got[corev1.PodReady].Status, "the readiness of the pod must be left untouched")
+
+	t.Log("verifying that an open circuit breaker is reported as a false condition without affecting readiness")
+	status = util.CircuitBreakerStatus{State: util.CircuitBreakerOpen, ConsecutiveFailures: 5, LastError: "connection refused"}
+	require.NoError(t, r.report(context.Background()))
+	got = conditions()
+	assert.Equal(t, corev1.ConditionFalse, got[CircuitBreakerConditionType].Status)
+	assert.Equal(t, "CircuitBreakerOpen", got[CircuitBreakerConditionType].Reason)
+	assert.Contains(t, got[CircuitBreakerConditionType].Message, "connection refused")
+	assert.Equal(t, corev1.ConditionTrue, got[corev1.PodReady].Status, "the readiness of the pod must be left untouched")
+
+	t.Log("verifying that an unchanged state isn't reported again")
+	require.NoError(t, c.Delete(context.Background(), pod))
+	assert.NoError(t, r.report(context.Background()))
+}
@@ -30,3 +30,12 @@ type Client interface {
 	// it to the backend API.
 	Update(ctx context.Context) error
 }
+
+// ChangeNotifier is implemented by Clients which signal when the configuration
+// they would send to the data-plane may have changed, so that Update() only
+// needs to be called after changes.
+type ChangeNotifier interface {
+	// Changes provides a channel which receives a value whenever the
+	// configuration may have changed. Bursts of changes may be coalesced.
+	Changes() <-chan struct{}
+}
@@ -0,0 +1,140 @@
+package dataplane
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/blang/semver/v4"
+	"github.com/ghodss/yaml"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/deckgen"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+// -----------------------------------------------------------------------------
+// Custom Entities - Public Vars & Consts
+// -----------------------------------------------------------------------------
+
+// CustomEntitiesSecretKey is the key in the custom entities Secret (see the
+// --kong-custom-entities-secret flag) which holds the custom entities that will
+// be merged into the DB-less configuration.
+const CustomEntitiesSecretKey = "config"
+
+// KongCustomEntitiesInvalidEventReason is the reason of the Warning Events
+// emitted for the custom entities Secret when its custom entities can't be
+// parsed.
+const KongCustomEntitiesInvalidEventReason = "KongCustomEntitiesInvalid"
+
+// -----------------------------------------------------------------------------
+// Custom Entities - Public Functions
+// -----------------------------------------------------------------------------
+
+// WithGeneratedEntities merges the consumer groups and the vaults of the
+// provided state into the provided custom entities, as the decK configuration
+// can't hold them, laid out for the provided version of Kong. The entities
+// which can't be merged are left out.
+func WithGeneratedEntities(
+	ctx context.Context,
+	logger logrus.FieldLogger,
+	state *kongstate.KongState,
+	schemas *util.PluginSchemaStore,
+	kongVersion semver.Version,
+	customEntities []byte,
+) []byte {
+	groups := deckgen.ToDeckConsumerGroups(ctx, logger, state, schemas)
+	entities, replaced, err := customEntitiesWithConsumerGroups(customEntities, groups)
+	if err != nil {
+		logger.WithError(err).Error("failed to merge consumer groups into the configuration, they will not be applied")
+		entities = customEntities
+	} else if replaced {
+		logger.Warn("the consumer groups of the custom entities are replaced by the ones of KongConsumerGroups")
+	}
+
+	vaultsKey := deckgen.VaultsKey(kongVersion)
+	withVaults, replaced, err := customEntitiesWithVaults(entities, vaultsKey, deckgen.ToDeckVaults(state))
+	if err != nil {
+		logger.WithError(err).Error("failed to merge vaults into the configuration, they will not be applied")
+		return entities
+	}
+	if replaced {
+		logger.Warn("the vaults of the custom entities are replaced by the ones of KongVaults")
+	}
+	return withVaults
+}
+
+// -----------------------------------------------------------------------------
+// Custom Entities - Private Functions
+// -----------------------------------------------------------------------------
+
+// customEntitiesFromSecret extracts the custom entities from the provided Secret.
+// The entities may be provided as either JSON or YAML, but will always be
+// returned as JSON so that they can be merged into the declarative config.
+func customEntitiesFromSecret(secret *corev1.Secret) ([]byte, error) {
+	raw, ok := secret.Data[CustomEntitiesSecretKey]
+	if !ok {
+		return nil, fmt.Errorf("no key '%s' in custom entities secret %s/%s",
+			CustomEntitiesSecretKey, secret.Namespace, secret.Name)
+	}
+
+	// JSON is valid YAML, so this covers both supported formats.
+	customEntities, err := yaml.YAMLToJSON(raw)
+	if err != nil {
+		return nil, fmt.Errorf("custom entities secret %s/%s contains neither valid JSON nor valid YAML: %w",
+			secret.Namespace, secret.Name, err)
+	}
+
+	// custom entities are merged at the top level of the declarative config,
+	// so anything other than an object can't be used.
+	var entities map[string]interface{}
+	if err := yaml.Unmarshal(customEntities, &entities); err != nil {
+		return nil, fmt.Errorf("custom entities secret %s/%s must contain a map of entity types to entities: %w",
+			secret.Namespace, secret.Name, err)
+	}
+
+	return customEntities, nil
+}
+
+// customEntitiesWithConsumerGroups merges the provided consumer groups, which
+// the decK configuration can't hold, into the provided custom entities. The
+// consumer groups generated from KongConsumerGroups replace the ones of the
+// custom entities, if any, which is indicated by the returned boolean.
+func customEntitiesWithConsumerGroups(customEntities []byte, groups *deckgen.ConsumerGroupsContent) ([]byte, bool, error) {
+	if groups == nil {
+		return customEntities, false, nil
+	}
+	return mergeCustomEntities(customEntities, "consumer_groups", groups.ConsumerGroups)
+}
+
+// customEntitiesWithVaults merges the provided vaults, which the decK
+// configuration can't hold, into the provided custom entities under the
+// provided key. The vaults generated from KongVaults replace the ones of the
+// custom entities, if any, which is indicated by the returned boolean.
+func customEntitiesWithVaults(customEntities []byte, key string, vaults []deckgen.FVault) ([]byte, bool, error) {
+	if len(vaults) == 0 {
+		return customEntities, false, nil
+	}
+	return mergeCustomEntities(customEntities, key, vaults)
+}
+
+// mergeCustomEntities sets the provided entities under the provided key of the
+// provided custom entities, and indicates whether that replaced entities.
+func mergeCustomEntities(customEntities []byte, key string, value interface{}) ([]byte, bool, error) {
+	entities := map[string]interface{}{}
+	if len(customEntities) > 0 {
+		if err := json.Unmarshal(customEntities, &entities); err != nil {
+			return nil, false, fmt.Errorf("unmarshaling custom entities: %w", err)
+		}
+	}
+	_, replaced := entities[key]
+	entities[key] = value
+
+	merged, err := json.Marshal(entities)
+	if err != nil {
+		return nil, false, fmt.Errorf("marshaling custom entities: %w", err)
+	}
+	return merged, replaced, nil
+}
@@ -0,0 +1,149 @@
+package dataplane
+
+import (
+	"testing"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/deckgen"
+)
+
+func TestCustomEntitiesFromSecret(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		data    map[string][]byte
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "JSON entities are returned as-is",
+			data: map[string][]byte{
+				CustomEntitiesSecretKey: []byte(`{"degraphql_routes":[{"uri":"/foo","query":"query{ foo }"}]}`),
+			},
+			want: `{"degraphql_routes":[{"query":"query{ foo }","uri":"/foo"}]}`,
+		},
+		{
+			name: "YAML entities are converted to JSON",
+			data: map[string][]byte{
+				CustomEntitiesSecretKey: []byte("degraphql_routes:\n- uri: /foo\n  query: query{ foo }\n"),
+			},
+			want: `{"degraphql_routes":[{"query":"query{ foo }","uri":"/foo"}]}`,
+		},
+		{
+			name:    "missing config key is an error",
+			data:    map[string][]byte{"entities": []byte(`{}`)},
+			wantErr: true,
+		},
+		{
+			name:    "invalid content is an error",
+			data:    map[string][]byte{CustomEntitiesSecretKey: []byte("{ not: valid: yaml")},
+			wantErr: true,
+		},
+		{
+			name:    "entities which are not a map are an error",
+			data:    map[string][]byte{CustomEntitiesSecretKey: []byte(`["foo", "bar"]`)},
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "kong", Name: "custom-entities"},
+				Data:       tt.data,
+			}
+			got, err := customEntitiesFromSecret(secret)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestCustomEntitiesWithConsumerGroups(t *testing.T) {
+	groups := &deckgen.ConsumerGroupsContent{
+		ConsumerGroups: []deckgen.FConsumerGroup{{
+			Name:      kong.String("gold"),
+			Consumers: []*kong.Consumer{{Username: kong.String("alice")}},
+		}},
+	}
+
+	for _, tt := range []struct {
+		name           string
+		customEntities string
+		groups         *deckgen.ConsumerGroupsContent
+		want           string
+		wantReplaced   bool
+	}{
+		{
+			name:           "custom entities are returned as-is without consumer groups",
+			customEntities: `{"degraphql_routes":[{"uri":"/foo"}]}`,
+			want:           `{"degraphql_routes":[{"uri":"/foo"}]}`,
+		},
+		{
+			name:   "consumer groups are rendered without custom entities",
+			groups: groups,
+			want:   `{"consumer_groups":[{"name":"gold","consumers":[{"username":"alice"}]}]}`,
+		},
+		{
+			name:           "consumer groups are merged into custom entities",
+			customEntities: `{"degraphql_routes":[{"uri":"/foo"}]}`,
+			groups:         groups,
+			want: `{
+				"degraphql_routes":[{"uri":"/foo"}],
+				"consumer_groups":[{"name":"gold","consumers":[{"username":"alice"}]}]
+			}`,
+		},
+		{
+			name:           "consumer groups replace the ones of custom entities",
+			customEntities: `{"consumer_groups":[{"name":"silver"}]}`,
+			groups:         groups,
+			want:           `{"consumer_groups":[{"name":"gold","consumers":[{"username":"alice"}]}]}`,
+			wantReplaced:   true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var customEntities []byte
+			if tt.customEntities != "" {
+				customEntities = []byte(tt.customEntities)
+			}
+			got, replaced, err := customEntitiesWithConsumerGroups(customEntities, tt.groups)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+			assert.Equal(t, tt.wantReplaced, replaced)
+		})
+	}
+}
+
+func TestCustomEntitiesWithVaults(t *testing.T) {
+	vaults := []deckgen.FVault{{
+		Name:   kong.String("env"),
+		Prefix: kong.String("my-env"),
+	}}
+
+	t.Log("verifying that custom entities are returned as-is without vaults")
+	got, replaced, err := customEntitiesWithVaults([]byte(`{"degraphql_routes":[{"uri":"/foo"}]}`), "vaults", nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"degraphql_routes":[{"uri":"/foo"}]}`, string(got))
+	assert.False(t, replaced)
+
+	t.Log("verifying that vaults are merged into custom entities under the provided key")
+	got, replaced, err = customEntitiesWithVaults([]byte(`{"degraphql_routes":[{"uri":"/foo"}]}`), "vaults_beta", vaults)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"degraphql_routes":[{"uri":"/foo"}],
+		"vaults_beta":[{"name":"env","prefix":"my-env"}]
+	}`, string(got))
+	assert.False(t, replaced)
+
+	t.Log("verifying that vaults replace the ones of custom entities")
+	got, replaced, err = customEntitiesWithVaults([]byte(`{"vaults":[{"name":"env","prefix":"other"}]}`), "vaults", vaults)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"vaults":[{"name":"env","prefix":"my-env"}]}`, string(got))
+	assert.True(t, replaced)
+}
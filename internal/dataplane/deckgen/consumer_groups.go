@@ -0,0 +1,85 @@
+package deckgen
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/kong/deck/file"
+	"github.com/kong/go-kong/kong"
+	"github.com/sirupsen/logrus"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+// ConsumerGroupsContent holds the consumer groups of a Kong declarative
+// configuration. The decK file format of the decK version in use has no
+// consumer groups, so they are generated separately from ToDeckContent() and
+// merged into the declarative configuration of DB-less data-planes.
+type ConsumerGroupsContent struct {
+	ConsumerGroups []FConsumerGroup `json:"consumer_groups,omitempty" yaml:"consumer_groups,omitempty"`
+}
+
+// FConsumerGroup is a consumer group along with its members and the plugins
+// scoped to it, laid out like in the decK file format.
+type FConsumerGroup struct {
+	Name      *string          `json:"name,omitempty" yaml:"name,omitempty"`
+	Consumers []*kong.Consumer `json:"consumers,omitempty" yaml:"consumers,omitempty"`
+	Plugins   []*file.FPlugin  `json:"plugins,omitempty" yaml:"plugins,omitempty"`
+}
+
+// ToDeckConsumerGroups generates the consumer groups of `k8sState`, which
+// consumers refer to by username. It returns nil when the state has no
+// consumer groups.
+func ToDeckConsumerGroups(
+	ctx context.Context,
+	log logrus.FieldLogger,
+	k8sState *kongstate.KongState,
+	schemas *util.PluginSchemaStore,
+) *ConsumerGroupsContent {
+	if len(k8sState.ConsumerGroups) == 0 {
+		return nil
+	}
+
+	members := map[string][]*kong.Consumer{}
+	for _, c := range k8sState.Consumers {
+		// consumers are only configured with a username, see ToDeckContent().
+		if c.Username == nil {
+			continue
+		}
+		for _, group := range c.ConsumerGroups {
+			members[group] = append(members[group], &kong.Consumer{Username: kong.String(*c.Username)})
+		}
+	}
+
+	var content ConsumerGroupsContent
+	for _, g := range k8sState.ConsumerGroups {
+		group := FConsumerGroup{
+			Name:      kong.String(g.Name),
+			Consumers: members[g.Name],
+		}
+		sort.SliceStable(group.Consumers, func(i, j int) bool {
+			return strings.Compare(*group.Consumers[i].Username, *group.Consumers[j].Username) > 0
+		})
+
+		for _, p := range g.Plugins {
+			plugin := file.FPlugin{
+				Plugin: *p.DeepCopy(),
+			}
+			if err := fillPlugin(ctx, &plugin, schemas); err != nil {
+				log.Errorf("failed to fill-in defaults for plugin: %s", *plugin.Name)
+			}
+			group.Plugins = append(group.Plugins, &plugin)
+		}
+		sort.SliceStable(group.Plugins, func(i, j int) bool {
+			return strings.Compare(*group.Plugins[i].Name, *group.Plugins[j].Name) > 0
+		})
+		content.ConsumerGroups = append(content.ConsumerGroups, group)
+	}
+	sort.SliceStable(content.ConsumerGroups, func(i, j int) bool {
+		return strings.Compare(*content.ConsumerGroups[i].Name, *content.ConsumerGroups[j].Name) > 0
+	})
+
+	return &content
+}
@@ -0,0 +1,62 @@
+package deckgen
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
+)
+
+func TestToDeckConsumerGroups(t *testing.T) {
+	t.Log("verifying that states without consumer groups produce no consumer groups")
+	assert.Nil(t, ToDeckConsumerGroups(context.Background(), logrus.New(), &kongstate.KongState{}, nil))
+
+	state := &kongstate.KongState{
+		Consumers: []kongstate.Consumer{
+			{Consumer: kong.Consumer{Username: kong.String("alice")}, ConsumerGroups: []string{"gold"}},
+			{Consumer: kong.Consumer{Username: kong.String("bob")}, ConsumerGroups: []string{"gold", "silver"}},
+			{Consumer: kong.Consumer{Username: kong.String("carol")}},
+		},
+		ConsumerGroups: []kongstate.ConsumerGroup{
+			{Name: "silver"},
+			{
+				Name: "gold",
+				Plugins: []kongstate.Plugin{{
+					Plugin: kong.Plugin{
+						Name:   kong.String("rate-limiting"),
+						Config: kong.Configuration{"minute": 10},
+					},
+				}},
+			},
+		},
+	}
+
+	content := ToDeckConsumerGroups(context.Background(), logrus.New(), state, nil)
+	require.NotNil(t, content)
+	got, err := json.Marshal(content)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"consumer_groups": [
+			{
+				"name": "silver",
+				"consumers": [{"username": "bob"}]
+			},
+			{
+				"name": "gold",
+				"consumers": [{"username": "bob"}, {"username": "alice"}],
+				"plugins": [{
+					"name": "rate-limiting",
+					"config": {"minute": 10},
+					"enabled": true,
+					"protocols": ["http", "https"]
+				}]
+			}
+		]
+	}`, string(got))
+}
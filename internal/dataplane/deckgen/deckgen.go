@@ -147,11 +147,18 @@ func fillRecord(schema gjson.Result, config kong.Configuration) (kong.Configurat
 		}
 		ftype := value.Get(fname + ".type")
 		if ftype.String() == "record" {
-			subConfig := config[fname]
-			if subConfig == nil {
+			var subConfig map[string]interface{}
+			switch v := config[fname].(type) {
+			case nil:
 				subConfig = make(map[string]interface{})
+			case map[string]interface{}:
+				subConfig = v
+			default:
+				// the value can't be filled in, e.g. a vault reference. It's
+				// left as-is for Kong to resolve or reject.
+				return true
 			}
-			newSubConfig, err := fillRecord(value.Get(fname), subConfig.(map[string]interface{}))
+			newSubConfig, err := fillRecord(value.Get(fname), subConfig)
 			if err != nil {
 				panic(err)
 			}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/kong/go-kong/kong"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -632,6 +633,28 @@ func TestKeyAuthSetKeys(t *testing.T) {
 	assert.Equal(true, res["key_in_body"])
 }
 
+func TestFillVaultReferences(t *testing.T) {
+	var schema map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(KeyAuthSchema), &schema))
+
+	config := kong.Configuration{
+		"anonymous": "{vault://env/anonymous}",
+		"key_names": []interface{}{"{vault://env/key-name}"},
+	}
+	res, err := FillPluginConfig(schema, config)
+	require.NoError(t, err)
+	assert.Equal(t, "{vault://env/anonymous}", res["anonymous"])
+	assert.Equal(t, []interface{}{"{vault://env/key-name}"}, res["key_names"])
+	assert.Equal(t, false, res["key_in_body"])
+
+	t.Log("verifying that values of records which aren't records are left as-is")
+	require.NoError(t, json.Unmarshal([]byte(RequestTransformerSchema), &schema))
+	config = kong.Configuration{"add": "{vault://env/headers}"}
+	res, err = FillPluginConfig(schema, config)
+	require.NoError(t, err)
+	assert.Equal(t, "{vault://env/headers}", res["add"])
+}
+
 func TestFillReqeustTransformer(t *testing.T) {
 	assert := assert.New(t)
 	var schema map[string]interface{}
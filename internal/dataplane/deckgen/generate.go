@@ -189,18 +189,22 @@ func fillPlugin(ctx context.Context, plugin *file.FPlugin, schemas *util.PluginS
 	if plugin.Name == nil || *plugin.Name == "" {
 		return fmt.Errorf("plugin doesn't have a name")
 	}
-	schema, err := schemas.Schema(ctx, *plugin.Name)
-	if err != nil {
-		return fmt.Errorf("error retrieveing schema for plugin %s: %w", *plugin.Name, err)
-	}
 	if plugin.Config == nil {
 		plugin.Config = make(kong.Configuration)
 	}
-	newConfig, err := FillPluginConfig(schema, plugin.Config)
-	if err != nil {
-		return fmt.Errorf("error filling in default for plugin %s: %w", *plugin.Name, err)
+	// without plugin schemas (e.g. when translating manifests offline) the
+	// defaults of the plugin's configuration can't be filled in.
+	if schemas != nil {
+		schema, err := schemas.Schema(ctx, *plugin.Name)
+		if err != nil {
+			return fmt.Errorf("error retrieveing schema for plugin %s: %w", *plugin.Name, err)
+		}
+		newConfig, err := FillPluginConfig(schema, plugin.Config)
+		if err != nil {
+			return fmt.Errorf("error filling in default for plugin %s: %w", *plugin.Name, err)
+		}
+		plugin.Config = newConfig
 	}
-	plugin.Config = newConfig
 	if plugin.RunOn == nil {
 		plugin.RunOn = kong.String("first")
 	}
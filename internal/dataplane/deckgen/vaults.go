@@ -0,0 +1,56 @@
+package deckgen
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/kong/go-kong/kong"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
+)
+
+// minVaultsKongVersion is the first version of Kong which names vaults
+// "vaults" rather than "vaults_beta" in its declarative configuration.
+var minVaultsKongVersion = semver.MustParse("3.0.0")
+
+// FVault is a vault, laid out like in the declarative configuration of Kong.
+// The decK file format of the decK version in use has no vaults, so they are
+// generated separately from ToDeckContent() and merged into the declarative
+// configuration of DB-less data-planes.
+type FVault struct {
+	Name        *string            `json:"name,omitempty" yaml:"name,omitempty"`
+	Prefix      *string            `json:"prefix,omitempty" yaml:"prefix,omitempty"`
+	Description *string            `json:"description,omitempty" yaml:"description,omitempty"`
+	Config      kong.Configuration `json:"config,omitempty" yaml:"config,omitempty"`
+}
+
+// ToDeckVaults generates the vaults of `k8sState`. It returns nil when the
+// state has no vaults.
+func ToDeckVaults(k8sState *kongstate.KongState) []FVault {
+	var vaults []FVault
+	for _, v := range k8sState.Vaults {
+		vault := FVault{
+			Name:   kong.String(v.Name),
+			Prefix: kong.String(v.Prefix),
+			Config: v.Config.DeepCopy(),
+		}
+		if v.Description != "" {
+			vault.Description = kong.String(v.Description)
+		}
+		vaults = append(vaults, vault)
+	}
+	sort.SliceStable(vaults, func(i, j int) bool {
+		return strings.Compare(*vaults[i].Prefix, *vaults[j].Prefix) > 0
+	})
+	return vaults
+}
+
+// VaultsKey provides the name of the vaults in the declarative configuration
+// of the provided version of Kong.
+func VaultsKey(kongVersion semver.Version) string {
+	if kongVersion.GTE(minVaultsKongVersion) {
+		return "vaults"
+	}
+	return "vaults_beta"
+}
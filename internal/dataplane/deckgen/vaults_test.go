@@ -0,0 +1,36 @@
+package deckgen
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
+)
+
+func TestToDeckVaults(t *testing.T) {
+	t.Log("verifying that states without vaults produce no vaults")
+	assert.Nil(t, ToDeckVaults(&kongstate.KongState{}))
+
+	state := &kongstate.KongState{
+		Vaults: []kongstate.Vault{
+			{Name: "env", Prefix: "env-a", Config: kong.Configuration{"prefix": "SECRET_"}},
+			{Name: "env", Prefix: "env-b", Description: "secrets of team b"},
+		},
+	}
+	got, err := json.Marshal(ToDeckVaults(state))
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"name": "env", "prefix": "env-b", "description": "secrets of team b"},
+		{"name": "env", "prefix": "env-a", "config": {"prefix": "SECRET_"}}
+	]`, string(got))
+}
+
+func TestVaultsKey(t *testing.T) {
+	assert.Equal(t, "vaults_beta", VaultsKey(semver.MustParse("2.8.1")))
+	assert.Equal(t, "vaults", VaultsKey(semver.MustParse("3.0.0")))
+}
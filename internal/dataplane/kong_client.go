@@ -2,6 +2,7 @@ package dataplane
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -10,9 +11,13 @@ import (
 	"github.com/kong/go-kong/kong"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/deckgen"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/parser"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/sendconfig"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/metrics"
@@ -20,6 +25,7 @@ import (
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
 	k8sobj "github.com/kong/kubernetes-ingress-controller/v2/internal/util/kubernetes/object"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util/kubernetes/object/status"
+	configurationv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
 )
 
 // -----------------------------------------------------------------------------
@@ -51,6 +57,21 @@ type KongClient struct {
 	// environments. See https://github.com/Kong/deck/pull/617
 	skipCACertificates bool
 
+	// customEntitiesSecret is the Secret which contains custom entities that
+	// should be merged into the configuration sent to DB-less data-planes.
+	// This is nil unless custom entities have been enabled.
+	customEntitiesSecret *k8stypes.NamespacedName
+
+	// lastCustomEntities are the custom entities which were last successfully
+	// retrieved from the custom entities Secret. They are used while the Secret
+	// is invalid, as sending a configuration without them to DB-less
+	// data-planes would delete them.
+	lastCustomEntities []byte
+
+	// lastCustomEntitiesError is the problem which was last reported for the
+	// custom entities Secret, so that each problem is only reported once.
+	lastCustomEntitiesError string
+
 	// requestTimeout is the maximum amount of time that should be waited for
 	// requests to the data-plane to receive a response.
 	requestTimeout time.Duration
@@ -59,9 +80,38 @@ type KongClient struct {
 	// objects for parsing into Kong objects.
 	cache *store.CacheStores
 
-	// kongConfig is the client configuration for the Kong Admin API
+	// changes is signalled whenever the objects in the cache change, see
+	// Changes().
+	changes chan struct{}
+
+	// kongConfig is the client configuration for the Kong Admin API. When
+	// multiple proxies are managed this is the template for the configuration
+	// of each proxy, and its Admin API client is the one used for retrieving
+	// plugin schemas, listeners, e.t.c.
 	kongConfig sendconfig.Kong
 
+	// proxies are the proxy instances (Admin APIs) that configuration is sent
+	// to, indexed by Admin API URL.
+	proxies map[string]*proxy
+
+	// gatewayDataPlanes are the proxy instances provisioned for managed
+	// Gateways, indexed by Gateway.
+	gatewayDataPlanes map[k8stypes.NamespacedName]*gatewayDataPlane
+
+	// adminAPIClientFactory builds Admin API clients for newly discovered
+	// proxies. This is nil unless proxy discovery has been enabled.
+	adminAPIClientFactory AdminAPIClientFactory
+
+	// validationProxy is a DB-less proxy instance which doesn't serve traffic,
+	// used to find the objects to quarantine when the data-plane rejects a
+	// configuration without reporting which entities are invalid. This is nil
+	// unless a validation proxy has been enabled.
+	validationProxy *proxy
+
+	// lastGoodConfig is the last configuration which was successfully applied
+	// to the data-plane.
+	lastGoodConfig *lastGoodConfig
+
 	// dbmode indicates the current database mode of the backend Kong Admin API
 	dbmode string
 
@@ -104,6 +154,62 @@ type KongClient struct {
 	// whether a Kubernetes object has corresponding data-plane configuration that
 	// is actively configured (e.g. to know how to set the object status).
 	kubernetesObjectReportsFilter k8sobj.Set
+
+	// kubernetesObjectConfigErrors are the problems the data-plane reported
+	// with the configuration generated from Kubernetes objects when the most
+	// recent Update() was rejected, indexed by object.
+	kubernetesObjectConfigErrors map[string]string
+
+	// kubernetesObjectTranslationFailures are the reasons why Kubernetes
+	// objects could not be (fully) translated into Kong configuration by the
+	// most recent Update(), indexed by object.
+	kubernetesObjectTranslationFailures map[string]string
+
+	// kubernetesObjectPluginAttachments are the objects which the plugins
+	// generated from KongPlugins and KongClusterPlugins were attached to by the
+	// most recent successful Update(), indexed by plugin object.
+	kubernetesObjectPluginAttachments map[string]kongstate.PluginAttachment
+
+	// kubernetesObjectConsumerCredentials are the results of resolving the
+	// credential Secrets of KongConsumers by the most recent Update(), indexed
+	// by KongConsumer.
+	kubernetesObjectConsumerCredentials map[string][]configurationv1.ConsumerCredentialStatus
+
+	// kubernetesObjectConsumerIDs are the IDs which the data-plane assigned to
+	// the consumers of KongConsumers, indexed by KongConsumer.
+	kubernetesObjectConsumerIDs map[string]string
+
+	// lastFailedConfigSHA is a checksum of the last configuration which was
+	// rejected by the data-plane.
+	lastFailedConfigSHA []byte
+
+	// eventRecorder is used to emit Kubernetes Events for objects whose
+	// configuration was rejected by the data-plane.
+	eventRecorder record.EventRecorder
+
+	// reportedTranslationFailures are the translation failures which Events
+	// were emitted for by the most recent Update().
+	reportedTranslationFailures map[string]struct{}
+
+	// quarantine is the set of Kubernetes objects which were excluded from the
+	// most recent Update() because the data-plane rejected the configuration
+	// generated from them.
+	quarantine quarantine
+
+	// dryRun indicates that configuration is only built and reported, and
+	// never sent to the data-plane.
+	dryRun bool
+
+	// lastDryRunConfigSHA is a checksum of the last configuration which was
+	// reported in dry-run mode.
+	lastDryRunConfigSHA []byte
+
+	// lastDryRunReportTime is when the last configuration was reported in
+	// dry-run mode.
+	lastDryRunReportTime time.Time
+
+	// backoff tracks the consecutive failures to update the data-plane.
+	backoff updateBackoff
 }
 
 // NewKongClient provides a new KongClient object after connecting to the
@@ -116,6 +222,7 @@ func NewKongClient(
 	skipCACertificates bool,
 	diagnostic util.ConfigDumpDiagnostic,
 	kongConfig sendconfig.Kong,
+	eventRecorder record.EventRecorder,
 ) (*KongClient, error) {
 	// build the client object
 	cache := store.NewCacheStores()
@@ -128,7 +235,9 @@ func NewKongClient(
 		diagnostic:         diagnostic,
 		prometheusMetrics:  metrics.NewCtrlFuncMetrics(),
 		cache:              &cache,
+		changes:            make(chan struct{}, 1),
 		kongConfig:         kongConfig,
+		eventRecorder:      eventRecorder,
 	}
 
 	// download the kong root configuration (and validate connectivity to the proxy API)
@@ -169,6 +278,12 @@ func NewKongClient(
 	c.kongConfig.Version = proxySemver
 	c.dbmode = dbmode
 
+	// the proxy the client was configured with is the initial (and unless proxy
+	// discovery is enabled, the only) proxy that configuration is sent to.
+	c.proxies = map[string]*proxy{
+		c.kongConfig.URL: c.newProxy(c.kongConfig.URL, c.kongConfig.Client),
+	}
+
 	return c, nil
 }
 
@@ -180,9 +295,22 @@ func NewKongClient(
 // It will be asynchronously converted into the upstream Kong DSL and applied to the Kong Admin API.
 // A status will later be added to the object whether the configuration update succeeds or fails.
 func (c *KongClient) UpdateObject(obj client.Object) error {
+	// objects are reconciled again after e.g. their status was updated, which
+	// doesn't change the configuration generated from them.
+	if cached, exists, err := c.cache.Get(obj); err == nil && exists {
+		if cachedObj, ok := cached.(client.Object); ok &&
+			obj.GetResourceVersion() != "" && cachedObj.GetResourceVersion() == obj.GetResourceVersion() {
+			return nil
+		}
+	}
+
 	// we do a deep copy of the object here so that the caller can continue to use
 	// the original object in a threadsafe manner.
-	return c.cache.Add(obj.DeepCopyObject())
+	if err := c.cache.Add(obj.DeepCopyObject()); err != nil {
+		return err
+	}
+	c.notifyChange()
+	return nil
 }
 
 // DeleteObject accepts a Kubernetes controller-runtime client.Object and removes it from the configuration cache.
@@ -192,7 +320,14 @@ func (c *KongClient) UpdateObject(obj client.Object) error {
 // under the hood the cache implementation will ignore deletions on objects
 // that are not present in the cache, so in those cases this is a no-op.
 func (c *KongClient) DeleteObject(obj client.Object) error {
-	return c.cache.Delete(obj)
+	if _, exists, err := c.cache.Get(obj); err != nil || !exists {
+		return err
+	}
+	if err := c.cache.Delete(obj); err != nil {
+		return err
+	}
+	c.notifyChange()
+	return nil
 }
 
 // ObjectExists indicates whether or not any version of the provided object is already present in the proxy.
@@ -201,11 +336,17 @@ func (c *KongClient) ObjectExists(obj client.Object) (bool, error) {
 	return exists, err
 }
 
+// Changes implements ChangeNotifier: the provided channel is signalled whenever
+// UpdateObject() or DeleteObject() change the configuration cache.
+func (c *KongClient) Changes() <-chan struct{} {
+	return c.changes
+}
+
 // Listeners retrieves the currently configured listeners from the
 // underlying proxy so that callers can gather this metadata to
 // know which ports and protocols are in use by the proxy.
 func (c *KongClient) Listeners(ctx context.Context) ([]kong.ProxyListener, []kong.StreamListener, error) {
-	return c.kongConfig.Client.Listeners(ctx)
+	return c.adminAPIClient().Listeners(ctx)
 }
 
 // RootWithTimeout provides the root configuration from Kong, but uses a configurable timeout to avoid long waits if the Admin API
@@ -213,7 +354,7 @@ func (c *KongClient) Listeners(ctx context.Context) ([]kong.ProxyListener, []kon
 func (c *KongClient) RootWithTimeout() (map[string]interface{}, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), c.requestTimeout)
 	defer cancel()
-	return c.kongConfig.Client.Root(ctx)
+	return c.adminAPIClient().Root(ctx)
 }
 
 // -----------------------------------------------------------------------------
@@ -272,6 +413,43 @@ func (c *KongClient) AreCombinedServiceRoutesEnabled() bool {
 	return c.enableCombinedServiceRoutes
 }
 
+// EnableCustomEntities configures the client to merge the custom entities
+// found in the provided Secret into every configuration update that is sent to
+// a DB-less data-plane. The Secret is read from the client's object cache, so
+// changes to it are picked up on the next Update().
+func (c *KongClient) EnableCustomEntities(secret k8stypes.NamespacedName) {
+	c.additionalFeaturesLock.Lock()
+	defer c.additionalFeaturesLock.Unlock()
+	c.customEntitiesSecret = &secret
+}
+
+// CustomEntitiesSecret provides the Secret custom entities are read from, if
+// custom entities have been enabled.
+func (c *KongClient) CustomEntitiesSecret() (k8stypes.NamespacedName, bool) {
+	c.additionalFeaturesLock.RLock()
+	defer c.additionalFeaturesLock.RUnlock()
+	if c.customEntitiesSecret == nil {
+		return k8stypes.NamespacedName{}, false
+	}
+	return *c.customEntitiesSecret, true
+}
+
+// EnableDryRun configures the client to build configuration and report it, and
+// the changes it would make to the data-plane, without ever sending it to the
+// data-plane. See Update().
+func (c *KongClient) EnableDryRun() {
+	c.additionalFeaturesLock.Lock()
+	defer c.additionalFeaturesLock.Unlock()
+	c.dryRun = true
+}
+
+// IsDryRunEnabled indicates whether the client runs in dry-run mode.
+func (c *KongClient) IsDryRunEnabled() bool {
+	c.additionalFeaturesLock.RLock()
+	defer c.additionalFeaturesLock.RUnlock()
+	return c.dryRun
+}
+
 // -----------------------------------------------------------------------------
 // Dataplane Client - Kong - Interface Implementation
 // -----------------------------------------------------------------------------
@@ -285,26 +463,22 @@ func (c *KongClient) DBMode() string {
 
 // Update parses the Cache present in the client and converts current
 // Kubernetes state into Kong objects and state, and then ships the
-// resulting configuration to the data-plane (Kong Admin API).
+// resulting configuration to the data-plane (Kong Admin API). In dry-run mode
+// the configuration is only reported instead.
 func (c *KongClient) Update(ctx context.Context) error {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
-	// build the kongstate object from the Kubernetes objects in the storer
-	storer := store.New(*c.cache, c.ingressClass, false, false, false, c.logger)
-
-	// initialize a parser
-	c.logger.Debug("parsing kubernetes objects into data-plane configuration")
-	p := parser.NewParser(c.logger, storer)
-	if c.AreKubernetesObjectReportsEnabled() {
-		p.EnableKubernetesObjectReports()
-	}
-	if c.AreCombinedServiceRoutesEnabled() {
-		p.EnableCombinedServiceRoutes()
+	// build the kongstate object from the Kubernetes objects in the storer. The
+	// routes of managed Gateways are only configured on their own proxies.
+	cacheStorer := store.New(*c.cache, c.ingressClass, false, false, false, c.logger)
+	var storer store.Storer = cacheStorer
+	if len(c.gatewayDataPlanes) > 0 {
+		storer = store.NewExcludingGatewaysStorer(cacheStorer, c.managedGateways())
 	}
 
 	// parse the Kubernetes objects from the storer into Kong configuration
-	kongstate, err := p.Build()
+	p, state, targetConfig, err := c.buildConfig(ctx, storer)
 	if err != nil {
 		c.prometheusMetrics.TranslationCount.With(prometheus.Labels{
 			metrics.SuccessKey: metrics.SuccessFalse,
@@ -314,85 +488,120 @@ func (c *KongClient) Update(ctx context.Context) error {
 	c.prometheusMetrics.TranslationCount.With(prometheus.Labels{
 		metrics.SuccessKey: metrics.SuccessTrue,
 	}).Inc()
-	c.logger.Debug("successfully built data-plane configuration")
 
-	// generate the deck configuration to be applied to the admin API
-	c.logger.Debug("converting configuration to deck config")
-	targetConfig := deckgen.ToDeckContent(ctx,
-		c.logger, kongstate,
-		c.kongConfig.PluginSchemaStore,
-		c.kongConfig.FilterTags,
-	)
+	gatewayConfigs, gatewayTranslationFailures := c.buildGatewayConfigs(ctx, cacheStorer)
 
-	// generate diagnostic configuration if enabled
-	// "diagnostic" will be empty if --dump-config is not set
-	var diagnosticConfig *file.Content
-	if c.diagnostic != (util.ConfigDumpDiagnostic{}) {
-		if !c.diagnostic.DumpsIncludeSensitive {
-			redactedConfig := deckgen.ToDeckContent(ctx,
-				c.logger,
-				kongstate.SanitizedCopy(),
-				c.kongConfig.PluginSchemaStore,
-				c.kongConfig.FilterTags,
-			)
-			diagnosticConfig = redactedConfig
-		} else {
-			diagnosticConfig = targetConfig
-		}
+	// let the owners of objects which couldn't be translated know about it.
+	translationFailures := append(p.PopTranslationFailures(), gatewayTranslationFailures...)
+	c.reportTranslationFailures(translationFailures)
+	if c.AreKubernetesObjectReportsEnabled() {
+		c.updateKubernetesObjectConsumerCredentials(state.Consumers)
 	}
 
-	// apply the configuration update in Kong
-	c.logger.Debug("sending configuration to Kong Admin API")
-	timedCtx, cancel := context.WithTimeout(ctx, c.requestTimeout)
-	defer cancel()
-	newConfigSHA, err := sendconfig.PerformUpdate(timedCtx,
-		c.logger,
-		&c.kongConfig,
-		c.kongConfig.InMemory,
-		c.enableReverseSync,
-		c.skipCACertificates,
-		targetConfig,
-		c.kongConfig.FilterTags,
-		nil,
-		c.lastConfigSHA,
-		c.prometheusMetrics,
-	)
+	if c.IsDryRunEnabled() {
+		return c.updateDryRun(ctx, state, targetConfig, translationFailures)
+	}
+
+	// the proxies of managed Gateways are updated independently of the others.
+	gatewayReport, gatewayConfigsChanged := c.sendGatewayConfigs(ctx, gatewayConfigs)
+
+	// gather the custom entities to merge into the configuration. These are only
+	// supported by DB-less data-planes.
+	var customEntities []byte
+	if c.kongConfig.InMemory {
+		customEntities = c.loadCustomEntities(storer)
+	}
+	entities := c.withGeneratedEntities(ctx, state, customEntities)
+
+	// generate the checksum of the configuration, which is used to determine
+	// whether the configuration has changed since the last update.
+	newConfigSHA, err := deckgen.GenerateSHA(targetConfig, entities)
 	if err != nil {
-		// ship diagnostics if enabled
-		if c.diagnostic != (util.ConfigDumpDiagnostic{}) {
-			select {
-			case c.diagnostic.Configs <- util.ConfigDump{Failed: true, Config: *diagnosticConfig}:
-				c.logger.Debug("shipping config to diagnostic server")
-			default:
-				c.logger.Error("config diagnostic buffer full, dropping diagnostic config")
-			}
-		}
 		return err
 	}
 
-	// ship diagnostics if enabled
-	if c.diagnostic != (util.ConfigDumpDiagnostic{}) {
-		select {
-		case c.diagnostic.Configs <- util.ConfigDump{Failed: false, Config: *diagnosticConfig}:
-			c.logger.Debug("shipping config to diagnostic server")
-		default:
-			c.logger.Error("config diagnostic buffer full, dropping diagnostic config")
+	// a configuration which the data-plane failed to apply recently is only
+	// sent again after a backoff delay, unless it changes.
+	if err := c.checkUpdateBackoff(newConfigSHA); err != nil {
+		return err
+	}
+
+	// apply the configuration update in Kong. If the configuration hasn't
+	// changed since it was rejected and objects were quarantined because of
+	// that, sending it again is pointless and the quarantine is reused.
+	proxies := c.sortedProxies()
+	reuseQuarantine := c.kongConfig.InMemory && len(c.quarantine) > 0 &&
+		string(newConfigSHA) == string(c.lastFailedConfigSHA)
+	if !reuseQuarantine {
+		c.logger.Debug("sending configuration to Kong Admin API")
+		err = c.sendToProxies(ctx, proxies, targetConfig, entities)
+		if err != nil {
+			// attribute the problems the data-plane found to the Kubernetes objects
+			// they originate from so that they can be surfaced on those objects.
+			c.reportConfigErrors(state, proxies, newConfigSHA)
+			c.shipDiagnostic(ctx, true, state, targetConfig)
+		}
+	}
+
+	// when a DB-less data-plane rejects the configuration, the objects which
+	// are responsible for that are excluded so that the rest of the objects
+	// still get configured.
+	var q quarantine
+	if reuseQuarantine || (err != nil && c.kongConfig.InMemory && configRejected(proxies)) {
+		var previous quarantine
+		if reuseQuarantine {
+			previous = c.quarantine
 		}
+		c.logger.Debug("excluding the Kubernetes objects the data-plane rejected the configuration of")
+		var degradedParser *parser.Parser
+		var degradedState *kongstate.KongState
+		var degradedConfig *file.Content
+		q, degradedParser, degradedState, degradedConfig, err = c.updateDegraded(ctx, storer, state, proxies, customEntities, previous)
+		if err != nil {
+			c.logger.WithError(err).Error("failed to exclude the Kubernetes objects the data-plane rejected the configuration of")
+			err = fmt.Errorf("configuration was rejected and no objects could be excluded to fix it: %w", err)
+		} else {
+			p, state, targetConfig = degradedParser, degradedState, degradedConfig
+			entities = c.withGeneratedEntities(ctx, state, customEntities)
+			newConfigSHA, err = deckgen.GenerateSHA(targetConfig, entities)
+		}
+	}
+	c.recordUpdateResult(newConfigSHA, err)
+	if err != nil {
+		return err
 	}
+	c.shipDiagnostic(ctx, false, state, targetConfig)
+
+	// the configuration was accepted, so there are no more problems to report
+	// for any of the Kubernetes objects other than the quarantined ones.
+	if len(q) == 0 {
+		c.updateKubernetesObjectConfigErrors(nil)
+		c.lastFailedConfigSHA = nil
+	}
+	c.setQuarantine(q)
 
 	// report on configured Kubernetes objects if enabled
 	if c.AreKubernetesObjectReportsEnabled() {
-		if string(c.lastConfigSHA) != string(newConfigSHA) {
-			report := p.GenerateKubernetesObjectReport()
+		detachedPlugins := c.updateKubernetesObjectPluginParents(state.PluginAttachments())
+		if string(c.lastConfigSHA) != string(newConfigSHA) || gatewayConfigsChanged {
+			c.updateKubernetesObjectConsumerIDs(ctx, state.Consumers)
+			report := append(p.GenerateKubernetesObjectReport(), gatewayReport...)
 			c.logger.Debugf("triggering report for %d configured Kubernetes objects", len(report))
 			c.triggerKubernetesObjectReport(report...)
+			// plugins which are no longer attached aren't part of the report,
+			// but need to report that they aren't configured anymore.
+			for _, plugin := range detachedPlugins {
+				c.kubernetesObjectStatusQueue.Publish(plugin)
+			}
 		} else {
 			c.logger.Debug("no configuration change, skipping kubernetes object report")
 		}
 	}
 
-	// update the lastConfigSHA with the new updated checksum
+	// update the lastConfigSHA with the new updated checksum and retain the
+	// configuration for any proxies which are discovered later
 	c.lastConfigSHA = newConfigSHA
+	c.lastGoodConfig = &lastGoodConfig{content: targetConfig, customEntities: entities}
 	return nil
 }
 
@@ -400,6 +609,167 @@ func (c *KongClient) Update(ctx context.Context) error {
 // Dataplane Client - Kong - Private
 // -----------------------------------------------------------------------------
 
+// adminAPIClient provides the Admin API client used for requests which only
+// need to be made to a single proxy (e.g. retrieving listeners).
+func (c *KongClient) adminAPIClient() *kong.Client {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.kongConfig.Client
+}
+
+// isPluginAvailable tells whether the data-plane provides the plugin by the
+// provided name, which is assumed not to be the case when it can't be checked.
+func (c *KongClient) isPluginAvailable(ctx context.Context, name string) bool {
+	if c.kongConfig.PluginSchemaStore == nil {
+		return false
+	}
+	available, err := c.kongConfig.PluginSchemaStore.IsAvailable(ctx, name)
+	if err != nil {
+		c.logger.WithError(err).Errorf("failed to check whether the %s plugin is available", name)
+		return false
+	}
+	return available
+}
+
+// notifyChange signals a change of the configuration cache through Changes(),
+// unless a change is already pending.
+func (c *KongClient) notifyChange() {
+	select {
+	case c.changes <- struct{}{}:
+	default:
+	}
+}
+
+// buildConfig parses the Kubernetes objects from the provided storer into Kong
+// configuration, and converts it to the deck configuration which is applied to
+// the Admin API. The parser is returned so that the objects it configured can
+// be reported.
+func (c *KongClient) buildConfig(ctx context.Context, storer store.Storer) (*parser.Parser, *kongstate.KongState, *file.Content, error) {
+	// initialize a parser
+	c.logger.Debug("parsing kubernetes objects into data-plane configuration")
+	p := parser.NewParser(c.logger, storer)
+	if c.AreKubernetesObjectReportsEnabled() {
+		p.EnableKubernetesObjectReports()
+	}
+	if c.AreCombinedServiceRoutesEnabled() {
+		p.EnableCombinedServiceRoutes()
+	}
+	if c.kongConfig.InMemory {
+		p.EnableConsumerGroups()
+		if c.kongConfig.Version.GTE(parser.MinVaultKongVersion) {
+			p.EnableVaults()
+		}
+	}
+	if c.isPluginAvailable(ctx, parser.RequestMirrorPluginName) {
+		p.EnableRequestMirrorPlugin()
+	}
+
+	// parse the Kubernetes objects from the storer into Kong configuration
+	state, err := p.Build()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	c.logger.Debug("successfully built data-plane configuration")
+
+	// generate the deck configuration to be applied to the admin API
+	c.logger.Debug("converting configuration to deck config")
+	targetConfig := deckgen.ToDeckContent(ctx,
+		c.logger, state,
+		c.kongConfig.PluginSchemaStore,
+		c.kongConfig.FilterTags,
+	)
+	return p, state, targetConfig, nil
+}
+
+// shipDiagnostic sends the provided configuration to the diagnostic server if
+// it's enabled.
+func (c *KongClient) shipDiagnostic(ctx context.Context, failed bool, state *kongstate.KongState, targetConfig *file.Content) {
+	// the channel will be nil if --dump-config is not set
+	if c.diagnostic.Configs == nil {
+		return
+	}
+
+	select {
+	case c.diagnostic.Configs <- util.ConfigDump{Failed: failed, Config: *c.diagnosticConfig(ctx, state, targetConfig)}:
+		c.logger.Debug("shipping config to diagnostic server")
+	default:
+		c.logger.Error("config diagnostic buffer full, dropping diagnostic config")
+	}
+}
+
+// diagnosticConfig provides the configuration to report to the diagnostic
+// server for the provided configuration. Unless sensitive information was
+// requested, it's regenerated from a sanitized copy of the provided state.
+func (c *KongClient) diagnosticConfig(ctx context.Context, state *kongstate.KongState, targetConfig *file.Content) *file.Content {
+	if c.diagnostic.DumpsIncludeSensitive {
+		return targetConfig
+	}
+	return deckgen.ToDeckContent(ctx,
+		c.logger,
+		state.SanitizedCopy(),
+		c.kongConfig.PluginSchemaStore,
+		c.kongConfig.FilterTags,
+	)
+}
+
+// loadCustomEntities retrieves the custom entities to merge into the
+// configuration from the configured custom entities Secret as JSON. There are
+// none when custom entities are not enabled or the Secret doesn't exist. When
+// the Secret can't be parsed, the custom entities which were last retrieved
+// successfully are used instead so that the data-planes keep them, and a
+// Warning Event is emitted for the Secret.
+func (c *KongClient) loadCustomEntities(storer store.Storer) []byte {
+	nsn, ok := c.CustomEntitiesSecret()
+	if !ok {
+		return nil
+	}
+
+	secret, err := storer.GetSecret(nsn.Namespace, nsn.Name)
+	if err != nil {
+		if !errors.As(err, &store.ErrNotFound{}) {
+			c.logger.WithError(err).Errorf("failed to fetch custom entities secret %s, the last custom entities loaded are applied instead", nsn)
+			return c.lastCustomEntities
+		}
+		if c.lastCustomEntities != nil {
+			c.logger.Infof("custom entities secret %s was not found, no custom entities are applied", nsn)
+		}
+		c.lastCustomEntities = nil
+		c.lastCustomEntitiesError = ""
+		return nil
+	}
+
+	customEntities, err := customEntitiesFromSecret(secret)
+	if err != nil {
+		c.reportInvalidCustomEntities(secret, err)
+		return c.lastCustomEntities
+	}
+	c.lastCustomEntities = customEntities
+	c.lastCustomEntitiesError = ""
+	return customEntities
+}
+
+// reportInvalidCustomEntities logs the provided error and emits a Warning
+// Event for the provided custom entities Secret, unless the same error was
+// already reported.
+func (c *KongClient) reportInvalidCustomEntities(secret *corev1.Secret, err error) {
+	if c.lastCustomEntitiesError == err.Error() {
+		return
+	}
+	c.lastCustomEntitiesError = err.Error()
+	c.logger.WithError(err).Error("invalid custom entities, the last custom entities loaded are applied instead")
+	if c.eventRecorder == nil {
+		return
+	}
+	c.eventRecorder.Event(secret, corev1.EventTypeWarning, KongCustomEntitiesInvalidEventReason,
+		fmt.Sprintf("the custom entities can't be applied, the last ones loaded are kept instead: %s", err))
+}
+
+// withGeneratedEntities merges the entities which the decK configuration can't
+// hold into the provided custom entities, see WithGeneratedEntities().
+func (c *KongClient) withGeneratedEntities(ctx context.Context, state *kongstate.KongState, customEntities []byte) []byte {
+	return WithGeneratedEntities(ctx, c.logger, state, c.kongConfig.PluginSchemaStore, c.kongConfig.Version, customEntities)
+}
+
 // triggerKubernetesObjectReport will update the KongClient with a set which
 // enables filtering for which objects are currently applied to the data-plane,
 // as well as updating the c.kubernetesObjectStatusQueue to queue those objects
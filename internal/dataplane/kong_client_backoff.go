@@ -0,0 +1,203 @@
+package dataplane
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/metrics"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+// -----------------------------------------------------------------------------
+// Dataplane Client - Kong - Backoff - Public Types
+// -----------------------------------------------------------------------------
+
+// UpdateBackoffError is returned by Update() instead of sending a configuration
+// which the data-plane recently failed to apply to it again. Update() sends it
+// again after RetryAfter, or as soon as the configuration changes.
+type UpdateBackoffError struct {
+	// RetryAfter is when the configuration is sent again.
+	RetryAfter time.Time
+
+	// ConsecutiveFailures is how many times in a row the configuration failed
+	// to be applied.
+	ConsecutiveFailures int
+
+	// Err is the error of the most recent attempt to apply the configuration.
+	Err error
+}
+
+func (e *UpdateBackoffError) Error() string {
+	return fmt.Sprintf("backing off after %d consecutive failures to update the data-plane until %s: %s",
+		e.ConsecutiveFailures, e.RetryAfter.Format(time.RFC3339), e.Err)
+}
+
+func (e *UpdateBackoffError) Unwrap() error {
+	return e.Err
+}
+
+// -----------------------------------------------------------------------------
+// Dataplane Client - Kong - Backoff - Private Vars & Consts
+// -----------------------------------------------------------------------------
+
+const (
+	// updateBackoffInitialDelay is the backoff delay after the first failure
+	// to apply a configuration. It doubles with every further failure.
+	updateBackoffInitialDelay = time.Second
+
+	// updateBackoffMaxDelay limits the backoff delay.
+	updateBackoffMaxDelay = 5 * time.Minute
+
+	// updateBackoffJitter is the fraction by which backoff delays are randomly
+	// lengthened or shortened, so that the instances of a deployment which
+	// fail together don't retry together.
+	updateBackoffJitter = 0.2
+
+	// circuitBreakerThreshold is the number of consecutive failures after
+	// which the circuit breaker opens.
+	circuitBreakerThreshold = 5
+)
+
+// -----------------------------------------------------------------------------
+// Dataplane Client - Kong - Backoff - Private Types
+// -----------------------------------------------------------------------------
+
+// updateBackoff tracks the consecutive failures to apply a configuration to
+// the data-plane, to back off from sending it again.
+type updateBackoff struct {
+	// configSHA is the checksum of the configuration which failed.
+	configSHA []byte
+
+	failures   int
+	retryAfter time.Time
+	lastError  error
+
+	lock sync.RWMutex
+}
+
+// status describes the circuit breaker at the provided time.
+func (b *updateBackoff) status(now time.Time) util.CircuitBreakerStatus {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	status := util.CircuitBreakerStatus{
+		State:               util.CircuitBreakerClosed,
+		ConsecutiveFailures: b.failures,
+	}
+	if b.failures == 0 {
+		return status
+	}
+	retryAfter := b.retryAfter
+	status.RetryAfter = &retryAfter
+	status.LastError = b.lastError.Error()
+	if b.failures >= circuitBreakerThreshold {
+		if now.Before(b.retryAfter) {
+			status.State = util.CircuitBreakerOpen
+		} else {
+			status.State = util.CircuitBreakerHalfOpen
+		}
+	}
+	return status
+}
+
+// -----------------------------------------------------------------------------
+// Dataplane Client - Kong - Backoff - Public Methods
+// -----------------------------------------------------------------------------
+
+// CircuitBreakerStatus describes whether the client is backing off from
+// updating the data-plane after consecutive failures.
+func (c *KongClient) CircuitBreakerStatus() util.CircuitBreakerStatus {
+	return c.backoff.status(time.Now())
+}
+
+// -----------------------------------------------------------------------------
+// Dataplane Client - Kong - Backoff - Private Methods
+// -----------------------------------------------------------------------------
+
+// checkUpdateBackoff provides an UpdateBackoffError if the configuration with
+// the provided checksum failed to be applied recently and shouldn't be sent to
+// the data-plane yet. A different configuration resets the backoff.
+func (c *KongClient) checkUpdateBackoff(configSHA []byte) error {
+	c.backoff.lock.Lock()
+	if c.backoff.failures > 0 && string(configSHA) != string(c.backoff.configSHA) {
+		c.logger.Info("configuration changed, resetting the backoff of data-plane updates")
+		c.backoff.configSHA, c.backoff.failures, c.backoff.lastError = nil, 0, nil
+	}
+	var err error
+	if c.backoff.failures > 0 && time.Now().Before(c.backoff.retryAfter) {
+		err = &UpdateBackoffError{
+			RetryAfter:          c.backoff.retryAfter,
+			ConsecutiveFailures: c.backoff.failures,
+			Err:                 c.backoff.lastError,
+		}
+	}
+	c.backoff.lock.Unlock()
+
+	c.reportCircuitBreaker()
+	return err
+}
+
+// recordUpdateResult records whether applying the configuration with the
+// provided checksum to the data-plane failed, to back off from sending it again
+// with a delay growing exponentially with the number of consecutive failures.
+func (c *KongClient) recordUpdateResult(configSHA []byte, updateErr error) {
+	c.backoff.lock.Lock()
+	if updateErr == nil {
+		c.backoff.configSHA, c.backoff.failures, c.backoff.lastError = nil, 0, nil
+	} else {
+		c.backoff.configSHA = configSHA
+		c.backoff.failures++
+		c.backoff.lastError = updateErr
+		c.backoff.retryAfter = time.Now().Add(updateBackoffDelay(c.backoff.failures))
+		if c.backoff.failures == circuitBreakerThreshold {
+			c.logger.Errorf("failed to update the data-plane %d times in a row, retrying with backoff until the configuration changes or is applied",
+				circuitBreakerThreshold)
+		}
+	}
+	c.backoff.lock.Unlock()
+
+	c.reportCircuitBreaker()
+}
+
+// reportCircuitBreaker reports the current status of the circuit breaker to
+// metrics and the diagnostic server.
+func (c *KongClient) reportCircuitBreaker() {
+	status := c.CircuitBreakerStatus()
+	for _, state := range []util.CircuitBreakerState{util.CircuitBreakerClosed, util.CircuitBreakerOpen, util.CircuitBreakerHalfOpen} {
+		value := 0.0
+		if state == status.State {
+			value = 1
+		}
+		c.prometheusMetrics.CircuitBreakerState.With(prometheus.Labels{metrics.StateKey: string(state)}).Set(value)
+	}
+
+	if c.diagnostic.CircuitBreaker != nil {
+		select {
+		case c.diagnostic.CircuitBreaker <- status:
+		default:
+			c.logger.Debug("circuit breaker diagnostic buffer full, dropping status")
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Dataplane Client - Kong - Backoff - Private Functions
+// -----------------------------------------------------------------------------
+
+// updateBackoffDelay provides the randomized delay to wait before sending a
+// configuration again after the provided number of consecutive failures.
+func updateBackoffDelay(failures int) time.Duration {
+	delay := updateBackoffInitialDelay
+	for i := 1; i < failures && delay < updateBackoffMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > updateBackoffMaxDelay {
+		delay = updateBackoffMaxDelay
+	}
+	jitter := 1 - updateBackoffJitter + 2*updateBackoffJitter*rand.Float64() //nolint:gosec // jitter doesn't need a secure source
+	return time.Duration(float64(delay) * jitter)
+}
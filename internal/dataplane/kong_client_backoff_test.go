@@ -0,0 +1,90 @@
+package dataplane
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/metrics"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+func TestUpdateBackoffDelay(t *testing.T) {
+	for failures, expected := range map[int]time.Duration{
+		1:   updateBackoffInitialDelay,
+		2:   updateBackoffInitialDelay * 2,
+		5:   updateBackoffInitialDelay * 16,
+		100: updateBackoffMaxDelay,
+	} {
+		delay := updateBackoffDelay(failures)
+		assert.GreaterOrEqual(t, float64(delay), float64(expected)*(1-updateBackoffJitter), "failures: %d", failures)
+		assert.LessOrEqual(t, float64(delay), float64(expected)*(1+updateBackoffJitter), "failures: %d", failures)
+	}
+}
+
+func TestKongClient_updateBackoff(t *testing.T) {
+	stateMetric := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "test"}, []string{metrics.StateKey})
+	statuses := make(chan util.CircuitBreakerStatus, 100)
+	c := &KongClient{
+		logger:            logrus.New(),
+		prometheusMetrics: &metrics.CtrlFuncMetrics{CircuitBreakerState: stateMetric},
+		diagnostic:        util.ConfigDumpDiagnostic{CircuitBreaker: statuses},
+	}
+	sha, otherSHA := []byte("sha"), []byte("other")
+	updateErr := errors.New("connection refused")
+
+	t.Log("verifying that configurations are sent while nothing failed")
+	require.NoError(t, c.checkUpdateBackoff(sha))
+	assert.Equal(t, util.CircuitBreakerClosed, c.CircuitBreakerStatus().State)
+	assert.Equal(t, float64(1), testutil.ToFloat64(stateMetric.WithLabelValues(string(util.CircuitBreakerClosed))))
+
+	t.Log("verifying that a failed configuration is backed off")
+	c.recordUpdateResult(sha, updateErr)
+	err := c.checkUpdateBackoff(sha)
+	var backoffErr *UpdateBackoffError
+	require.True(t, errors.As(err, &backoffErr))
+	assert.Equal(t, 1, backoffErr.ConsecutiveFailures)
+	assert.True(t, errors.Is(err, updateErr))
+	assert.Equal(t, util.CircuitBreakerClosed, c.CircuitBreakerStatus().State)
+
+	t.Log("verifying that the circuit breaker opens after consecutive failures")
+	for i := 1; i < circuitBreakerThreshold; i++ {
+		c.recordUpdateResult(sha, updateErr)
+	}
+	status := c.CircuitBreakerStatus()
+	assert.Equal(t, util.CircuitBreakerOpen, status.State)
+	assert.Equal(t, circuitBreakerThreshold, status.ConsecutiveFailures)
+	assert.Equal(t, updateErr.Error(), status.LastError)
+	assert.Equal(t, float64(1), testutil.ToFloat64(stateMetric.WithLabelValues(string(util.CircuitBreakerOpen))))
+	assert.Equal(t, float64(0), testutil.ToFloat64(stateMetric.WithLabelValues(string(util.CircuitBreakerClosed))))
+	require.NotEmpty(t, statuses)
+	var last util.CircuitBreakerStatus
+	for len(statuses) > 0 {
+		last = <-statuses
+	}
+	assert.Equal(t, util.CircuitBreakerOpen, last.State)
+
+	t.Log("verifying that the circuit breaker is half-open once the backoff delay elapsed")
+	c.backoff.retryAfter = time.Now().Add(-time.Second)
+	require.NoError(t, c.checkUpdateBackoff(sha))
+	assert.Equal(t, util.CircuitBreakerHalfOpen, c.CircuitBreakerStatus().State)
+	c.recordUpdateResult(sha, fmt.Errorf("still failing"))
+	assert.Equal(t, util.CircuitBreakerOpen, c.CircuitBreakerStatus().State)
+
+	t.Log("verifying that a different configuration resets the backoff immediately")
+	require.NoError(t, c.checkUpdateBackoff(otherSHA))
+	assert.Equal(t, util.CircuitBreakerStatus{State: util.CircuitBreakerClosed}, c.CircuitBreakerStatus())
+
+	t.Log("verifying that a successful update resets the backoff")
+	c.recordUpdateResult(otherSHA, updateErr)
+	c.recordUpdateResult(otherSHA, nil)
+	require.NoError(t, c.checkUpdateBackoff(otherSHA))
+	assert.Equal(t, 0, c.CircuitBreakerStatus().ConsecutiveFailures)
+}
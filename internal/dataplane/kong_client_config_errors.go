@@ -0,0 +1,135 @@
+package dataplane
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/sendconfig"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+// -----------------------------------------------------------------------------
+// Dataplane Client - Kong - Config Errors - Public Vars & Consts
+// -----------------------------------------------------------------------------
+
+// KongConfigurationApplyFailedEventReason is the reason of the Warning Events
+// emitted for Kubernetes objects whose generated configuration was rejected by
+// the data-plane.
+const KongConfigurationApplyFailedEventReason = "KongConfigurationApplyFailed"
+
+// -----------------------------------------------------------------------------
+// Dataplane Client - Kong - Config Errors - Public Methods
+// -----------------------------------------------------------------------------
+
+// KubernetesObjectConfigurationError provides the problems which the data-plane
+// reported with the configuration generated from the provided object when the
+// most recent Update() was rejected. If the most recent Update() succeeded or
+// the object was not blamed for its failure this returns false.
+func (c *KongClient) KubernetesObjectConfigurationError(obj client.Object) (string, bool) {
+	c.kubernetesObjectReportLock.RLock()
+	defer c.kubernetesObjectReportLock.RUnlock()
+	msg, ok := c.kubernetesObjectConfigErrors[objectKey(
+		obj.GetObjectKind().GroupVersionKind().String(), obj.GetNamespace(), obj.GetName(),
+	)]
+	return msg, ok
+}
+
+// -----------------------------------------------------------------------------
+// Dataplane Client - Kong - Config Errors - Private Methods
+// -----------------------------------------------------------------------------
+
+// reportConfigErrors attributes the entity errors which the provided proxies
+// reported for a rejected configuration to the Kubernetes objects the entities
+// were generated from. Those objects can then be looked up with
+// KubernetesObjectConfigurationError(), and Warning Events are emitted for them
+// unless the same configuration was already rejected by the previous Update().
+// The caller must hold the client lock.
+func (c *KongClient) reportConfigErrors(state *kongstate.KongState, proxies []*proxy, configSHA []byte) {
+	objects, configErrors := c.attributeConfigErrors(state, proxies)
+	c.updateKubernetesObjectConfigErrors(configErrors)
+
+	// the same configuration is retried on every sync, so the events are only
+	// emitted the first time that a configuration is rejected.
+	if string(configSHA) == string(c.lastFailedConfigSHA) {
+		return
+	}
+	c.lastFailedConfigSHA = configSHA
+
+	if c.eventRecorder == nil {
+		return
+	}
+	for key, source := range objects {
+		if source.GroupVersionKind.Empty() {
+			continue
+		}
+		c.eventRecorder.Event(source.ToPartialObjectMetadata(), corev1.EventTypeWarning,
+			KongConfigurationApplyFailedEventReason,
+			fmt.Sprintf("the data-plane rejected the configuration generated from this object: %s", configErrors[key]),
+		)
+	}
+}
+
+// attributeConfigErrors provides the Kubernetes objects which the entity
+// errors reported by the provided proxies for a rejected configuration are
+// attributed to, along with a description of the problems found with each of
+// them. Both are indexed by object.
+func (c *KongClient) attributeConfigErrors(state *kongstate.KongState, proxies []*proxy) (map[string]util.K8sObjectInfo, map[string]string) {
+	objects := map[string]util.K8sObjectInfo{}
+	problems := map[string]map[string]struct{}{}
+	for _, p := range proxies {
+		var configErr *sendconfig.ConfigError
+		if !errors.As(p.lastError, &configErr) {
+			continue
+		}
+		for _, entityErr := range configErr.EntityErrors {
+			sources := state.SourcesForEntity(entityErr.Type, entityErr.Name, entityErr.Entity)
+			if len(sources) == 0 {
+				c.logger.WithField("kong_url", p.kongConfig.URL).
+					Errorf("invalid %s could not be attributed to a Kubernetes object: %s", entityErr.Type, entityErr)
+				continue
+			}
+			for _, source := range sources {
+				key := objectKey(source.GroupVersionKind.String(), source.Namespace, source.Name)
+				objects[key] = source
+				if problems[key] == nil {
+					problems[key] = map[string]struct{}{}
+				}
+				problems[key][entityErr.String()] = struct{}{}
+			}
+		}
+	}
+
+	configErrors := make(map[string]string, len(objects))
+	for key := range objects {
+		msgs := make([]string, 0, len(problems[key]))
+		for msg := range problems[key] {
+			msgs = append(msgs, msg)
+		}
+		sort.Strings(msgs)
+		configErrors[key] = strings.Join(msgs, "; ")
+	}
+	return objects, configErrors
+}
+
+// updateKubernetesObjectConfigErrors overrides the problems reported for
+// Kubernetes objects with the provided ones.
+func (c *KongClient) updateKubernetesObjectConfigErrors(configErrors map[string]string) {
+	c.kubernetesObjectReportLock.Lock()
+	defer c.kubernetesObjectReportLock.Unlock()
+	c.kubernetesObjectConfigErrors = configErrors
+}
+
+// -----------------------------------------------------------------------------
+// Dataplane Client - Kong - Config Errors - Private Functions
+// -----------------------------------------------------------------------------
+
+// objectKey produces a key which identifies a Kubernetes object.
+func objectKey(gvk, namespace, name string) string {
+	return gvk + "/" + namespace + "/" + name
+}
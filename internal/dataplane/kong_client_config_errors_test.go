@@ -0,0 +1,76 @@
+package dataplane
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/sendconfig"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+func TestKongClient_reportConfigErrors(t *testing.T) {
+	httproute := &gatewayv1alpha2.HTTPRoute{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: gatewayv1alpha2.GroupVersion.String(),
+			Kind:       "HTTPRoute",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "httproute",
+		},
+	}
+	state := &kongstate.KongState{
+		Services: []kongstate.Service{{
+			Service: kong.Service{Name: kong.String("default.svc.80")},
+			Routes: []kongstate.Route{{
+				Route:   kong.Route{Name: kong.String("httproute.default.httproute.0.0")},
+				Ingress: util.FromK8sObject(httproute),
+			}},
+		}},
+	}
+	configErr := &sendconfig.ConfigError{
+		Code:    400,
+		Message: "declarative config is invalid: {}",
+		EntityErrors: []sendconfig.EntityError{{
+			Type:   "route",
+			Name:   "httproute.default.httproute.0.0",
+			Errors: []sendconfig.EntityFieldError{{Field: "paths.1", Message: "invalid regex"}},
+		}},
+	}
+	proxies := []*proxy{
+		{lastError: fmt.Errorf("posting new config to /config: %w", configErr)},
+		{lastError: fmt.Errorf("posting new config to /config: %w", configErr)},
+	}
+
+	recorder := record.NewFakeRecorder(10)
+	c := &KongClient{logger: logrus.New(), eventRecorder: recorder}
+
+	t.Log("reporting the errors of a rejected configuration")
+	c.reportConfigErrors(state, proxies, []byte("sha"))
+	msg, rejected := c.KubernetesObjectConfigurationError(httproute)
+	require.True(t, rejected)
+	assert.Equal(t, `route "httproute.default.httproute.0.0": paths.1: invalid regex`, msg)
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, KongConfigurationApplyFailedEventReason)
+
+	t.Log("verifying that the same configuration being rejected again doesn't emit more events")
+	c.reportConfigErrors(state, proxies, []byte("sha"))
+	assert.Len(t, recorder.Events, 0)
+	_, rejected = c.KubernetesObjectConfigurationError(httproute)
+	assert.True(t, rejected)
+
+	t.Log("verifying that objects which were not blamed are not reported")
+	other := httproute.DeepCopy()
+	other.Name = "other"
+	_, rejected = c.KubernetesObjectConfigurationError(other)
+	assert.False(t, rejected)
+}
@@ -0,0 +1,118 @@
+package dataplane
+
+import (
+	"context"
+
+	"github.com/kong/go-kong/kong"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
+	configurationv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+)
+
+// -----------------------------------------------------------------------------
+// Dataplane Client - Kong - Consumers - Public Methods
+// -----------------------------------------------------------------------------
+
+// KubernetesObjectConsumerCredentials provides the results of resolving the
+// credential Secrets of the provided KongConsumer by the most recent Update().
+func (c *KongClient) KubernetesObjectConsumerCredentials(obj client.Object) []configurationv1.ConsumerCredentialStatus {
+	c.kubernetesObjectReportLock.RLock()
+	defer c.kubernetesObjectReportLock.RUnlock()
+	return c.kubernetesObjectConsumerCredentials[objectKey(
+		obj.GetObjectKind().GroupVersionKind().String(), obj.GetNamespace(), obj.GetName(),
+	)]
+}
+
+// KubernetesObjectConsumerID provides the ID which the data-plane assigned to
+// the consumer of the provided KongConsumer, if known. IDs are retrieved from
+// the Admin API whenever an Update() changes the configuration.
+func (c *KongClient) KubernetesObjectConsumerID(obj client.Object) string {
+	c.kubernetesObjectReportLock.RLock()
+	defer c.kubernetesObjectReportLock.RUnlock()
+	return c.kubernetesObjectConsumerIDs[objectKey(
+		obj.GetObjectKind().GroupVersionKind().String(), obj.GetNamespace(), obj.GetName(),
+	)]
+}
+
+// -----------------------------------------------------------------------------
+// Dataplane Client - Kong - Consumers - Private Methods
+// -----------------------------------------------------------------------------
+
+// updateKubernetesObjectConsumerCredentials overrides the credential results
+// of the KongConsumers with the ones of the provided consumers.
+func (c *KongClient) updateKubernetesObjectConsumerCredentials(consumers []kongstate.Consumer) {
+	results := make(map[string][]configurationv1.ConsumerCredentialStatus, len(consumers))
+	for i := range consumers {
+		info := consumers[i].K8sObjectInfo()
+		results[objectKey(info.GroupVersionKind.String(), info.Namespace, info.Name)] = consumers[i].K8sCredentials
+	}
+
+	c.kubernetesObjectReportLock.Lock()
+	defer c.kubernetesObjectReportLock.Unlock()
+	c.kubernetesObjectConsumerCredentials = results
+}
+
+// updateKubernetesObjectConsumerIDs retrieves the consumers from the Admin API
+// and records the IDs of the ones generated from the provided consumers. The
+// previous IDs are kept if the consumers can't be retrieved.
+func (c *KongClient) updateKubernetesObjectConsumerIDs(ctx context.Context, consumers []kongstate.Consumer) {
+	if len(consumers) == 0 {
+		c.setKubernetesObjectConsumerIDs(nil)
+		return
+	}
+	if c.kongConfig.Client == nil {
+		return
+	}
+
+	kongConsumers, err := c.kongConfig.Client.Consumers.ListAll(ctx)
+	if err != nil {
+		c.logger.WithError(err).Error("failed to retrieve the consumers from the Kong Admin API")
+		return
+	}
+	c.setKubernetesObjectConsumerIDs(consumerIDs(consumers, kongConsumers))
+}
+
+// setKubernetesObjectConsumerIDs overrides the IDs of the consumers of the
+// KongConsumers with the provided ones.
+func (c *KongClient) setKubernetesObjectConsumerIDs(ids map[string]string) {
+	c.kubernetesObjectReportLock.Lock()
+	defer c.kubernetesObjectReportLock.Unlock()
+	c.kubernetesObjectConsumerIDs = ids
+}
+
+// consumerIDs maps the provided consumers to the IDs of the matching Kong
+// consumers, indexed by KongConsumer. Consumers are matched by username, or by
+// custom ID for consumers without a username.
+func consumerIDs(consumers []kongstate.Consumer, kongConsumers []*kong.Consumer) map[string]string {
+	byUsername := make(map[string]string, len(kongConsumers))
+	byCustomID := make(map[string]string, len(kongConsumers))
+	for _, kongConsumer := range kongConsumers {
+		if kongConsumer.ID == nil {
+			continue
+		}
+		if kongConsumer.Username != nil {
+			byUsername[*kongConsumer.Username] = *kongConsumer.ID
+		}
+		if kongConsumer.CustomID != nil {
+			byCustomID[*kongConsumer.CustomID] = *kongConsumer.ID
+		}
+	}
+
+	ids := make(map[string]string, len(consumers))
+	for i := range consumers {
+		var id string
+		var ok bool
+		if consumers[i].Username != nil {
+			id, ok = byUsername[*consumers[i].Username]
+		} else if consumers[i].CustomID != nil {
+			id, ok = byCustomID[*consumers[i].CustomID]
+		}
+		if !ok {
+			continue
+		}
+		info := consumers[i].K8sObjectInfo()
+		ids[objectKey(info.GroupVersionKind.String(), info.Namespace, info.Name)] = id
+	}
+	return ids
+}
@@ -0,0 +1,40 @@
+package dataplane
+
+import (
+	"testing"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
+	configurationv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+)
+
+func TestConsumerIDs(t *testing.T) {
+	consumer := func(name string, username, customID *string) kongstate.Consumer {
+		return kongstate.Consumer{
+			Consumer: kong.Consumer{Username: username, CustomID: customID},
+			K8sKongConsumer: configurationv1.KongConsumer{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			},
+		}
+	}
+	kongConsumers := []*kong.Consumer{
+		{ID: kong.String("1"), Username: kong.String("alice")},
+		{ID: kong.String("2"), CustomID: kong.String("bob-1234")},
+		{ID: kong.String("3"), Username: kong.String("carol"), CustomID: kong.String("carol-1234")},
+	}
+
+	gvk := configurationv1.SchemeGroupVersion.WithKind("KongConsumer").String()
+	assert.Equal(t, map[string]string{
+		objectKey(gvk, "default", "alice"): "1",
+		objectKey(gvk, "default", "bob"):   "2",
+		objectKey(gvk, "default", "carol"): "3",
+	}, consumerIDs([]kongstate.Consumer{
+		consumer("alice", kong.String("alice"), nil),
+		consumer("bob", nil, kong.String("bob-1234")),
+		consumer("carol", kong.String("carol"), kong.String("carol-1234")),
+		consumer("dave", kong.String("dave"), nil),
+	}, kongConsumers))
+}
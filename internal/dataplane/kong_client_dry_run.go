@@ -0,0 +1,127 @@
+package dataplane
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kong/deck/file"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/deckgen"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/parser"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/sendconfig"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/metrics"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+// -----------------------------------------------------------------------------
+// Dataplane Client - Kong - Dry Run - Private Vars & Consts
+// -----------------------------------------------------------------------------
+
+// dryRunReportInterval is how often an unchanged configuration is compared with
+// the configuration of the data-plane again in dry-run mode, as the
+// data-plane may be changed by others (e.g. the controller it shadows).
+const dryRunReportInterval = time.Minute
+
+// sensitiveConfigChangeKinds are the kinds of entities the changes of which
+// are reported without their diffs unless the diagnostic dumps include
+// sensitive information, as their diffs would include secrets.
+var sensitiveConfigChangeKinds = map[string]struct{}{
+	"certificate": {},
+	"basic-auth":  {},
+	"hmac-auth":   {},
+	"jwt-auth":    {},
+	"key-auth":    {},
+	"oauth2-cred": {},
+	"mtls-auth":   {},
+}
+
+// -----------------------------------------------------------------------------
+// Dataplane Client - Kong - Dry Run - Private Methods
+// -----------------------------------------------------------------------------
+
+// updateDryRun reports the provided configuration, the changes it would make
+// to the data-plane and the provided translation failures to the diagnostic
+// server and metrics, instead of sending the configuration to the data-plane.
+// Unchanged configurations are only reported again every dryRunReportInterval.
+// The caller must hold the client lock.
+func (c *KongClient) updateDryRun(
+	ctx context.Context,
+	state *kongstate.KongState,
+	targetConfig *file.Content,
+	translationFailures []parser.TranslationFailure,
+) error {
+	configSHA, err := deckgen.GenerateSHA(targetConfig, nil)
+	if err != nil {
+		return err
+	}
+	if string(configSHA) == string(c.lastDryRunConfigSHA) && time.Since(c.lastDryRunReportTime) < dryRunReportInterval {
+		c.logger.Debug("no configuration change, skipping dry-run report")
+		return nil
+	}
+
+	report := util.DryRunReport{
+		Changes:             []util.ConfigChange{},
+		TranslationFailures: translationFailureReports(translationFailures),
+	}
+
+	c.logger.Debug("comparing configuration with the Kong Admin API (dry-run)")
+	changes, diffErr := sendconfig.Diff(ctx, &c.kongConfig, targetConfig, c.kongConfig.FilterTags, c.skipCACertificates)
+	if diffErr != nil {
+		report.DiffError = diffErr.Error()
+	} else {
+		counts := map[string]int{metrics.ActionCreate: 0, metrics.ActionUpdate: 0, metrics.ActionDelete: 0}
+		for _, change := range changes {
+			counts[change.Action]++
+			if _, ok := sensitiveConfigChangeKinds[change.Kind]; ok && !c.diagnostic.DumpsIncludeSensitive {
+				change.Diff = ""
+			}
+			report.Changes = append(report.Changes, change)
+		}
+		for action, count := range counts {
+			c.prometheusMetrics.DryRunConfigChanges.With(prometheus.Labels{metrics.ActionKey: action}).Set(float64(count))
+		}
+		c.logger.Infof("dry-run: applying the configuration would create %d, update %d and delete %d Kong entities",
+			counts[metrics.ActionCreate], counts[metrics.ActionUpdate], counts[metrics.ActionDelete])
+	}
+
+	if c.diagnostic.DryRunReports != nil {
+		report.Config = *c.diagnosticConfig(ctx, state, targetConfig)
+		select {
+		case c.diagnostic.DryRunReports <- report:
+			c.logger.Debug("shipping dry-run report to diagnostic server")
+		default:
+			c.logger.Error("dry-run report diagnostic buffer full, dropping dry-run report")
+		}
+	}
+
+	if diffErr != nil {
+		return fmt.Errorf("comparing configuration with the Kong Admin API: %w", diffErr)
+	}
+	c.lastDryRunConfigSHA = configSHA
+	c.lastDryRunReportTime = time.Now()
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// Dataplane Client - Kong - Dry Run - Private Functions
+// -----------------------------------------------------------------------------
+
+// translationFailureReports describes the objects of the provided translation
+// failures for diagnostics.
+func translationFailureReports(failures []parser.TranslationFailure) []util.TranslationFailureReport {
+	reports := []util.TranslationFailureReport{}
+	for _, failure := range failures {
+		for _, obj := range failure.CausingObjects {
+			reports = append(reports, util.TranslationFailureReport{
+				Kind:      obj.GetObjectKind().GroupVersionKind().Kind,
+				Namespace: obj.GetNamespace(),
+				Name:      obj.GetName(),
+				Reason:    failure.Reason,
+			})
+		}
+	}
+	return reports
+}
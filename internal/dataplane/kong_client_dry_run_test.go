@@ -0,0 +1,113 @@
+package dataplane
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/kong/deck/file"
+	"github.com/kong/go-kong/kong"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/parser"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/sendconfig"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/metrics"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+	configurationv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
+)
+
+func TestKongClient_updateDryRun(t *testing.T) {
+	var writes int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			atomic.AddInt32(&writes, 1)
+		}
+		switch {
+		case r.URL.Path == "/config":
+			_, _ = w.Write([]byte(`{"config": "_format_version: \"2.1\"\nservices:\n- name: stale\n  host: stale.example\n  id: 0a8c8bba-4bd6-4bd4-8c36-2a4e8b1e7a9f\n"}`))
+		case strings.HasPrefix(r.URL.Path, "/schemas/"):
+			_, _ = w.Write([]byte(`{"fields": []}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	kongClient, err := kong.NewClient(kong.String(srv.URL), srv.Client())
+	require.NoError(t, err)
+
+	changesMetric := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "test"}, []string{metrics.ActionKey})
+	reports := make(chan util.DryRunReport, 1)
+	c := &KongClient{
+		logger: logrus.New(),
+		kongConfig: sendconfig.Kong{
+			URL:      srv.URL,
+			Client:   kongClient,
+			InMemory: true,
+		},
+		diagnostic: util.ConfigDumpDiagnostic{
+			DumpsIncludeSensitive: true,
+			DryRunReports:         reports,
+		},
+		prometheusMetrics: &metrics.CtrlFuncMetrics{DryRunConfigChanges: changesMetric},
+	}
+
+	targetConfig := &file.Content{
+		FormatVersion: "2.1",
+		Services: []file.FService{{
+			Service: kong.Service{Name: kong.String("echo"), Host: kong.String("echo.default.svc")},
+			Routes: []*file.FRoute{{
+				Route: kong.Route{Name: kong.String("echo"), Paths: kong.StringSlice("/echo")},
+			}},
+		}},
+	}
+	failure := parser.TranslationFailure{
+		CausingObjects: []client.Object{&configurationv1beta1.TCPIngress{
+			TypeMeta:   metav1.TypeMeta{Kind: "TCPIngress"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tcpingress"},
+		}},
+		Reason: "invalid port",
+	}
+
+	t.Log("reporting a configuration in dry-run mode")
+	require.NoError(t, c.updateDryRun(context.Background(), nil, targetConfig, []parser.TranslationFailure{failure}))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&writes), "no changes should be made to kong")
+	require.Len(t, reports, 1)
+	report := <-reports
+	assert.Empty(t, report.DiffError)
+	assert.Equal(t, "echo", *report.Config.Services[0].Name)
+	assert.Equal(t, []util.TranslationFailureReport{{
+		Kind: "TCPIngress", Namespace: "default", Name: "tcpingress", Reason: "invalid port",
+	}}, report.TranslationFailures)
+
+	actions := map[string][]string{}
+	for _, change := range report.Changes {
+		actions[change.Action] = append(actions[change.Action], change.Kind)
+	}
+	assert.ElementsMatch(t, []string{"service", "route"}, actions[metrics.ActionCreate])
+	assert.Equal(t, []string{"service"}, actions[metrics.ActionDelete])
+	assert.Equal(t, float64(2), testutil.ToFloat64(changesMetric.WithLabelValues(metrics.ActionCreate)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(changesMetric.WithLabelValues(metrics.ActionDelete)))
+	assert.Equal(t, float64(0), testutil.ToFloat64(changesMetric.WithLabelValues(metrics.ActionUpdate)))
+
+	t.Log("verifying that an unchanged configuration is not reported again right away")
+	require.NoError(t, c.updateDryRun(context.Background(), nil, targetConfig, nil))
+	assert.Len(t, reports, 0)
+
+	t.Log("verifying that a failure to compare the configuration is reported")
+	srv.Close()
+	targetConfig.Services[0].Host = kong.String("echo.other.svc")
+	require.Error(t, c.updateDryRun(context.Background(), nil, targetConfig, nil))
+	require.Len(t, reports, 1)
+	report = <-reports
+	assert.NotEmpty(t, report.DiffError)
+	assert.Empty(t, report.Changes)
+}
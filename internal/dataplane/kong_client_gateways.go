@@ -0,0 +1,190 @@
+package dataplane
+
+import (
+	"context"
+	"sort"
+
+	"github.com/kong/deck/file"
+	"github.com/kong/go-kong/kong"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/deckgen"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/parser"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+)
+
+// -----------------------------------------------------------------------------
+// Dataplane Client - Kong - Gateways - Private Types
+// -----------------------------------------------------------------------------
+
+// gatewayDataPlane tracks the proxy instances provisioned for a managed
+// Gateway. They are only configured with the Gateway API routes attached to
+// that Gateway, which are in turn not configured on any other proxies.
+type gatewayDataPlane struct {
+	// proxies are the proxy instances of the Gateway, indexed by Admin API URL.
+	proxies map[string]*proxy
+
+	// lastConfigSHA is a checksum of the last configuration which was
+	// successfully applied to the proxies of the Gateway.
+	lastConfigSHA []byte
+}
+
+// gatewayConfig is the configuration built for a managed Gateway.
+type gatewayConfig struct {
+	gateway   k8stypes.NamespacedName
+	parser    *parser.Parser
+	content   *file.Content
+	configSHA []byte
+}
+
+// -----------------------------------------------------------------------------
+// Dataplane Client - Kong - Gateways - Public Methods
+// -----------------------------------------------------------------------------
+
+// UpdateGatewayProxies replaces the set of proxy instances of the provided
+// managed Gateway with the provided proxies, indexed by Admin API URL. The
+// configuration of those proxies is only made of the Gateway API routes
+// attached to the Gateway, and is sent to them by the next Update().
+func (c *KongClient) UpdateGatewayProxies(gateway k8stypes.NamespacedName, clients map[string]*kong.Client) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.gatewayDataPlanes == nil {
+		c.gatewayDataPlanes = make(map[k8stypes.NamespacedName]*gatewayDataPlane)
+	}
+	dataPlane, ok := c.gatewayDataPlanes[gateway]
+	if !ok {
+		dataPlane = &gatewayDataPlane{proxies: make(map[string]*proxy)}
+		c.gatewayDataPlanes[gateway] = dataPlane
+	}
+
+	changed := !ok
+	for url := range dataPlane.proxies {
+		if _, ok := clients[url]; !ok {
+			c.logger.WithField("gateway", gateway.String()).WithField("kong_url", url).Info("gateway proxy instance removed, it will no longer be configured")
+			delete(dataPlane.proxies, url)
+			changed = true
+		}
+	}
+	for url, kongClient := range clients {
+		if _, ok := dataPlane.proxies[url]; ok {
+			continue
+		}
+		p := c.newProxy(url, kongClient)
+		// the proxies of managed Gateways are always provisioned DB-less.
+		p.kongConfig.InMemory = true
+		dataPlane.proxies[url] = p
+		changed = true
+		c.logger.WithField("gateway", gateway.String()).WithField("kong_url", url).Info("gateway proxy instance discovered")
+	}
+
+	if changed {
+		// the routes of the Gateway move between data-planes, so everything
+		// needs to be configured again.
+		dataPlane.lastConfigSHA = nil
+		c.notifyChange()
+	}
+}
+
+// DeleteGatewayProxies stops configuring the proxy instances of the provided
+// managed Gateway. The Gateway API routes attached to it are configured on the
+// other proxies again by the next Update().
+func (c *KongClient) DeleteGatewayProxies(gateway k8stypes.NamespacedName) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if _, ok := c.gatewayDataPlanes[gateway]; ok {
+		delete(c.gatewayDataPlanes, gateway)
+		c.notifyChange()
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Dataplane Client - Kong - Gateways - Private Methods
+// -----------------------------------------------------------------------------
+
+// managedGateways lists the managed Gateways which have their own proxy
+// instances, sorted by namespace and name. The caller must hold the client lock.
+func (c *KongClient) managedGateways() []k8stypes.NamespacedName {
+	gateways := make([]k8stypes.NamespacedName, 0, len(c.gatewayDataPlanes))
+	for gateway := range c.gatewayDataPlanes {
+		gateways = append(gateways, gateway)
+	}
+	sort.Slice(gateways, func(i, j int) bool {
+		return gateways[i].String() < gateways[j].String()
+	})
+	return gateways
+}
+
+// buildGatewayConfigs builds the configuration of each of the managed Gateways
+// from the Kubernetes objects in the provided storer. The translation failures
+// of all the Gateways are returned along with the configurations. The caller
+// must hold the client lock.
+func (c *KongClient) buildGatewayConfigs(ctx context.Context, storer store.Storer) ([]gatewayConfig, []parser.TranslationFailure) {
+	var (
+		configs  []gatewayConfig
+		failures []parser.TranslationFailure
+	)
+	for _, gateway := range c.managedGateways() {
+		log := c.logger.WithField("gateway", gateway.String())
+		p, _, content, err := c.buildConfig(ctx, store.NewGatewayScopedStorer(storer, gateway))
+		if err != nil {
+			log.WithError(err).Error("failed to build the configuration of the gateway")
+			continue
+		}
+		failures = append(failures, p.PopTranslationFailures()...)
+
+		configSHA, err := deckgen.GenerateSHA(content, nil)
+		if err != nil {
+			log.WithError(err).Error("failed to generate the checksum of the configuration of the gateway")
+			continue
+		}
+		configs = append(configs, gatewayConfig{gateway: gateway, parser: p, content: content, configSHA: configSHA})
+	}
+	return configs, failures
+}
+
+// sendGatewayConfigs sends the provided configurations to the proxies of their
+// managed Gateways. The failures of individual Gateways are logged rather than
+// returned, so that they don't hold back the other data-planes. The Kubernetes
+// objects configured on the Gateways whose configuration was applied are
+// returned, along with whether any of the configurations changed since they
+// were last applied. The caller must hold the client lock.
+func (c *KongClient) sendGatewayConfigs(ctx context.Context, configs []gatewayConfig) ([]client.Object, bool) {
+	var (
+		configured []client.Object
+		changed    bool
+	)
+	for _, config := range configs {
+		dataPlane, ok := c.gatewayDataPlanes[config.gateway]
+		if !ok {
+			continue
+		}
+		log := c.logger.WithField("gateway", config.gateway.String())
+
+		proxies := make([]*proxy, 0, len(dataPlane.proxies))
+		for _, p := range dataPlane.proxies {
+			proxies = append(proxies, p)
+		}
+		if len(proxies) == 0 {
+			log.Debug("no proxy instances are available for the gateway yet")
+			continue
+		}
+
+		// the proxies of managed Gateways are DB-less, which expects plugin
+		// configs without nulls.
+		deckgen.CleanUpNullsInPluginConfigs(config.content)
+		if err := c.sendToProxies(ctx, proxies, config.content, nil); err != nil {
+			log.WithError(err).Error("failed to update the proxy instances of the gateway")
+			continue
+		}
+
+		if string(dataPlane.lastConfigSHA) != string(config.configSHA) {
+			changed = true
+		}
+		dataPlane.lastConfigSHA = config.configSHA
+		configured = append(configured, config.parser.GenerateKubernetesObjectReport()...)
+	}
+	return configured, changed
+}
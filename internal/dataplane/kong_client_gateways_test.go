@@ -0,0 +1,47 @@
+package dataplane
+
+import (
+	"testing"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+func TestKongClient_UpdateGatewayProxies(t *testing.T) {
+	c := &KongClient{logger: logrus.New(), changes: make(chan struct{}, 1)}
+	gateway := k8stypes.NamespacedName{Namespace: "team-a", Name: "kong"}
+	kongClient, err := kong.NewClient(kong.String("https://10.0.0.1:8444"), nil)
+	require.NoError(t, err)
+
+	t.Log("verifying that discovering gateway proxies signals a change")
+	c.UpdateGatewayProxies(gateway, map[string]*kong.Client{"https://10.0.0.1:8444": kongClient})
+	require.Len(t, c.Changes(), 1)
+	<-c.Changes()
+	assert.Equal(t, []k8stypes.NamespacedName{gateway}, c.managedGateways())
+	require.Contains(t, c.gatewayDataPlanes[gateway].proxies, "https://10.0.0.1:8444")
+	assert.True(t, c.gatewayDataPlanes[gateway].proxies["https://10.0.0.1:8444"].kongConfig.InMemory)
+
+	t.Log("verifying that the same gateway proxies don't signal a change")
+	c.gatewayDataPlanes[gateway].lastConfigSHA = []byte("sha")
+	c.UpdateGatewayProxies(gateway, map[string]*kong.Client{"https://10.0.0.1:8444": kongClient})
+	assert.Len(t, c.Changes(), 0)
+	assert.Equal(t, []byte("sha"), c.gatewayDataPlanes[gateway].lastConfigSHA)
+
+	t.Log("verifying that removed gateway proxies signal a change and reset the configuration checksum")
+	c.UpdateGatewayProxies(gateway, map[string]*kong.Client{})
+	require.Len(t, c.Changes(), 1)
+	<-c.Changes()
+	assert.Empty(t, c.gatewayDataPlanes[gateway].proxies)
+	assert.Nil(t, c.gatewayDataPlanes[gateway].lastConfigSHA)
+
+	t.Log("verifying that deleting the gateway proxies signals a change, unless they're not known")
+	c.DeleteGatewayProxies(gateway)
+	require.Len(t, c.Changes(), 1)
+	<-c.Changes()
+	assert.Empty(t, c.managedGateways())
+	c.DeleteGatewayProxies(gateway)
+	assert.Len(t, c.Changes(), 0)
+}
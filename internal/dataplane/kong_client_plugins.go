@@ -0,0 +1,53 @@
+package dataplane
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+// -----------------------------------------------------------------------------
+// Dataplane Client - Kong - Plugins - Public Methods
+// -----------------------------------------------------------------------------
+
+// KubernetesObjectPluginParents provides the objects which the plugins
+// generated from the provided KongPlugin or KongClusterPlugin were attached to
+// by the most recent successful Update(), e.g. the Ingresses, routes, Services
+// and KongConsumers referring to it. Plugins which apply globally, or which
+// weren't configured, have no parents.
+func (c *KongClient) KubernetesObjectPluginParents(obj client.Object) []util.K8sObjectInfo {
+	c.kubernetesObjectReportLock.RLock()
+	defer c.kubernetesObjectReportLock.RUnlock()
+	return c.kubernetesObjectPluginAttachments[objectKey(
+		obj.GetObjectKind().GroupVersionKind().String(), obj.GetNamespace(), obj.GetName(),
+	)].Parents
+}
+
+// -----------------------------------------------------------------------------
+// Dataplane Client - Kong - Plugins - Private Methods
+// -----------------------------------------------------------------------------
+
+// updateKubernetesObjectPluginParents overrides the attachments of the
+// plugins with the provided ones. It returns the plugins which were attached
+// before and no longer are, as nothing else triggers an update of their
+// status.
+func (c *KongClient) updateKubernetesObjectPluginParents(attachments []kongstate.PluginAttachment) []client.Object {
+	updated := make(map[string]kongstate.PluginAttachment, len(attachments))
+	for _, attachment := range attachments {
+		updated[objectKey(
+			attachment.Plugin.GroupVersionKind.String(), attachment.Plugin.Namespace, attachment.Plugin.Name,
+		)] = attachment
+	}
+
+	c.kubernetesObjectReportLock.Lock()
+	defer c.kubernetesObjectReportLock.Unlock()
+	var detached []client.Object
+	for key, attachment := range c.kubernetesObjectPluginAttachments {
+		if _, ok := updated[key]; !ok {
+			detached = append(detached, attachment.Plugin.ToPartialObjectMetadata())
+		}
+	}
+	c.kubernetesObjectPluginAttachments = updated
+	return detached
+}
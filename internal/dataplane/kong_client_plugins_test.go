@@ -0,0 +1,44 @@
+package dataplane
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	netv1 "k8s.io/api/networking/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+	configurationv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+)
+
+func TestUpdateKubernetesObjectPluginParents(t *testing.T) {
+	plugin := func(name string) util.K8sObjectInfo {
+		return util.K8sObjectInfo{
+			Namespace:        "default",
+			Name:             name,
+			GroupVersionKind: configurationv1.SchemeGroupVersion.WithKind("KongPlugin"),
+		}
+	}
+	ingress := util.K8sObjectInfo{
+		Namespace:        "default",
+		Name:             "ingress",
+		GroupVersionKind: netv1.SchemeGroupVersion.WithKind("Ingress"),
+	}
+	c := &KongClient{}
+
+	t.Log("verifying that the parents of attached plugins are provided")
+	detached := c.updateKubernetesObjectPluginParents([]kongstate.PluginAttachment{
+		{Plugin: plugin("rate-limiting"), Parents: []util.K8sObjectInfo{ingress}},
+		{Plugin: plugin("key-auth"), Parents: []util.K8sObjectInfo{ingress}},
+	})
+	assert.Empty(t, detached)
+	assert.Equal(t, []util.K8sObjectInfo{ingress}, c.KubernetesObjectPluginParents(plugin("key-auth").ToPartialObjectMetadata()))
+
+	t.Log("verifying that plugins which are no longer attached are provided")
+	detached = c.updateKubernetesObjectPluginParents([]kongstate.PluginAttachment{
+		{Plugin: plugin("rate-limiting"), Parents: []util.K8sObjectInfo{ingress}},
+	})
+	assert.Equal(t, []client.Object{plugin("key-auth").ToPartialObjectMetadata()}, detached)
+	assert.Empty(t, c.KubernetesObjectPluginParents(plugin("key-auth").ToPartialObjectMetadata()))
+}
@@ -0,0 +1,273 @@
+package dataplane
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/kong/deck/file"
+	"github.com/kong/go-kong/kong"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/deckgen"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/sendconfig"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+// -----------------------------------------------------------------------------
+// Dataplane Client - Kong - Proxies - Public Types
+// -----------------------------------------------------------------------------
+
+// AdminAPIClientFactory produces a Kong Admin API client for the proxy instance
+// whose Admin API is served at the provided URL.
+type AdminAPIClientFactory func(ctx context.Context, url string) (*kong.Client, error)
+
+// ProxyStatus describes the configuration state of a single Kong proxy instance
+// which is managed by the KongClient.
+type ProxyStatus struct {
+	// URL is the Admin API URL of the proxy instance.
+	URL string `json:"url"`
+
+	// ConfigSHA is the checksum of the last configuration which was successfully
+	// applied to the proxy instance.
+	ConfigSHA string `json:"configSHA"`
+
+	// Ready indicates that configuration has successfully been applied to the
+	// proxy instance at least once.
+	Ready bool `json:"ready"`
+
+	// LastError is the error which occurred during the most recent update of
+	// the proxy instance, if any.
+	LastError string `json:"lastError,omitempty"`
+}
+
+// -----------------------------------------------------------------------------
+// Dataplane Client - Kong - Proxies - Private Types
+// -----------------------------------------------------------------------------
+
+// proxy tracks an individual Kong proxy instance (and its Admin API) which the
+// KongClient is responsible for sending configuration to.
+type proxy struct {
+	// kongConfig is the client configuration for this proxy's Admin API.
+	kongConfig sendconfig.Kong
+
+	// lastConfigSHA is a checksum of the last successful update to this proxy.
+	lastConfigSHA []byte
+
+	// configApplied indicates whether any configuration has been successfully
+	// applied to this proxy.
+	configApplied bool
+
+	// lastError is the error produced by the most recent update of this proxy.
+	lastError error
+}
+
+// lastGoodConfig is the most recent configuration which was accepted by the
+// data-plane, retained so that it can be sent to newly discovered proxies.
+type lastGoodConfig struct {
+	content        *file.Content
+	customEntities []byte
+}
+
+// -----------------------------------------------------------------------------
+// Dataplane Client - Kong - Proxies - Public Methods
+// -----------------------------------------------------------------------------
+
+// EnableProxyDiscovery configures the client to manage multiple DB-less proxy
+// instances whose Admin APIs are provided by UpdateProxies() calls (e.g. as a
+// result of watching the Endpoints of the Admin API Service). The provided
+// factory is used to build the Admin API clients for newly discovered proxies.
+func (c *KongClient) EnableProxyDiscovery(factory AdminAPIClientFactory) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.adminAPIClientFactory = factory
+}
+
+// EnableValidationProxy configures the client to probe configurations on the
+// DB-less proxy instance whose Admin API is served at the provided URL when the
+// data-plane rejects a configuration without reporting which entities are
+// invalid. As probed configurations are applied, the instance must not serve
+// traffic.
+func (c *KongClient) EnableValidationProxy(url string, client *kong.Client) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.validationProxy = c.newProxy(url, client)
+}
+
+// UpdateProxies replaces the set of proxy instances the client sends
+// configuration to with the proxies whose Admin APIs are served at the provided
+// URLs. Proxies which were not previously known immediately receive the last
+// configuration which was successfully applied to the data-plane (if any) so
+// that they don't have to wait for the next update to start serving traffic.
+func (c *KongClient) UpdateProxies(ctx context.Context, urls []string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.adminAPIClientFactory == nil {
+		return fmt.Errorf("proxy discovery is not enabled for this client")
+	}
+
+	wanted := make(map[string]struct{}, len(urls))
+	for _, url := range urls {
+		wanted[url] = struct{}{}
+	}
+
+	// drop any proxies which are no longer present.
+	for url := range c.proxies {
+		if _, ok := wanted[url]; !ok {
+			c.logger.WithField("kong_url", url).Info("proxy instance removed, it will no longer be configured")
+			delete(c.proxies, url)
+		}
+	}
+
+	// add any newly discovered proxies.
+	var added []*proxy
+	for _, url := range urls {
+		if _, ok := c.proxies[url]; ok {
+			continue
+		}
+		client, err := c.adminAPIClientFactory(ctx, url)
+		if err != nil {
+			c.logger.WithField("kong_url", url).WithError(err).Error("failed to build admin api client for proxy instance")
+			continue
+		}
+		p := c.newProxy(url, client)
+		c.proxies[url] = p
+		added = append(added, p)
+		c.logger.WithField("kong_url", url).Info("proxy instance discovered")
+	}
+
+	// if the proxy used for plugin schemas, listeners and so on went away then
+	// one of the remaining proxies has to take over that role.
+	if _, ok := c.proxies[c.kongConfig.URL]; !ok && len(c.proxies) > 0 {
+		p := c.sortedProxies()[0]
+		c.kongConfig.URL = p.kongConfig.URL
+		c.kongConfig.Client = p.kongConfig.Client
+		c.kongConfig.PluginSchemaStore = util.NewPluginSchemaStore(p.kongConfig.Client)
+	}
+
+	// newly discovered proxies get the last good configuration right away.
+	if c.lastGoodConfig != nil && len(added) > 0 {
+		if err := c.sendToProxies(ctx, added, c.lastGoodConfig.content, c.lastGoodConfig.customEntities); err != nil {
+			return fmt.Errorf("sending last good configuration to new proxy instances: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ProxyStatuses provides the configuration state of each of the proxy
+// instances which the client is currently sending configuration to.
+func (c *KongClient) ProxyStatuses() []ProxyStatus {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	statuses := make([]ProxyStatus, 0, len(c.proxies))
+	for _, p := range c.sortedProxies() {
+		status := ProxyStatus{
+			URL:       p.kongConfig.URL,
+			ConfigSHA: hex.EncodeToString(p.lastConfigSHA),
+			Ready:     p.configApplied,
+		}
+		if p.lastError != nil {
+			status.LastError = p.lastError.Error()
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// -----------------------------------------------------------------------------
+// Dataplane Client - Kong - Proxies - Private Methods
+// -----------------------------------------------------------------------------
+
+// newProxy builds the tracking object for a proxy instance. The configuration
+// of the client is used as the template for the proxy's configuration.
+func (c *KongClient) newProxy(url string, client *kong.Client) *proxy {
+	kongConfig := c.kongConfig
+	kongConfig.URL = url
+	kongConfig.Client = client
+	return &proxy{kongConfig: kongConfig}
+}
+
+// sortedProxies lists the proxies managed by the client ordered by their
+// Admin API URLs. The caller must hold the client lock.
+func (c *KongClient) sortedProxies() []*proxy {
+	proxies := make([]*proxy, 0, len(c.proxies))
+	for _, p := range c.proxies {
+		proxies = append(proxies, p)
+	}
+	sort.Slice(proxies, func(i, j int) bool {
+		return proxies[i].kongConfig.URL < proxies[j].kongConfig.URL
+	})
+	return proxies
+}
+
+// sendToProxies concurrently sends the provided configuration to each of the
+// provided proxies, tracking the results for each of them individually. An
+// error is returned if the configuration could not be applied to any of the
+// proxies. The caller must hold the client lock.
+func (c *KongClient) sendToProxies(ctx context.Context, proxies []*proxy, content *file.Content, customEntities []byte) error {
+	if len(proxies) == 0 {
+		return fmt.Errorf("no proxy instances are available to send configuration to")
+	}
+
+	// the configuration is shared between all the concurrent updates below, and
+	// DB-less updates expect plugin configs without nulls, so the configuration
+	// is cleaned up front to make sure the updates only ever read from it.
+	if c.kongConfig.InMemory {
+		deckgen.CleanUpNullsInPluginConfigs(content)
+	}
+
+	var wg sync.WaitGroup
+	for _, p := range proxies {
+		wg.Add(1)
+		go func(p *proxy) {
+			defer wg.Done()
+			timedCtx, cancel := context.WithTimeout(ctx, c.requestTimeout)
+			defer cancel()
+			newConfigSHA, err := sendconfig.PerformUpdate(timedCtx,
+				c.logger.WithField("kong_url", p.kongConfig.URL),
+				&p.kongConfig,
+				p.kongConfig.InMemory,
+				c.enableReverseSync,
+				c.skipCACertificates,
+				content,
+				p.kongConfig.FilterTags,
+				customEntities,
+				p.lastConfigSHA,
+				c.prometheusMetrics,
+			)
+			p.lastError = err
+			if err != nil {
+				return
+			}
+			p.lastConfigSHA = newConfigSHA
+			p.configApplied = true
+		}(p)
+	}
+	wg.Wait()
+
+	// with a single proxy its error is returned as-is, otherwise the failures
+	// are summarized with the first error wrapped for context.
+	if len(proxies) == 1 {
+		return proxies[0].lastError
+	}
+	var failed []string
+	var firstErr error
+	for _, p := range proxies {
+		if p.lastError != nil {
+			c.logger.WithField("kong_url", p.kongConfig.URL).WithError(p.lastError).Error("failed to update proxy instance")
+			failed = append(failed, p.kongConfig.URL)
+			if firstErr == nil {
+				firstErr = p.lastError
+			}
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("configuration could not be applied to %d of %d proxy instances %v: %w",
+			len(failed), len(proxies), failed, firstErr)
+	}
+	return nil
+}
@@ -0,0 +1,368 @@
+package dataplane
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/kong/deck/file"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/parser"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/sendconfig"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/metrics"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+// -----------------------------------------------------------------------------
+// Dataplane Client - Kong - Quarantine - Public Vars & Consts
+// -----------------------------------------------------------------------------
+
+// KongConfigurationQuarantinedEventReason is the reason of the Warning Events
+// emitted for Kubernetes objects which are excluded from the configuration sent
+// to the data-plane because the data-plane rejected the configuration generated
+// from them.
+const KongConfigurationQuarantinedEventReason = "KongConfigurationQuarantined"
+
+// -----------------------------------------------------------------------------
+// Dataplane Client - Kong - Quarantine - Private Vars & Consts
+// -----------------------------------------------------------------------------
+
+const (
+	// maxQuarantineRounds limits how many times the objects blamed by the
+	// data-plane are excluded, as every reduced configuration may be rejected
+	// for new reasons.
+	maxQuarantineRounds = 5
+
+	// maxBisectionProbes limits how many configurations are sent to the
+	// validation proxy while searching for the objects to quarantine when the
+	// data-plane doesn't report which entities are invalid.
+	maxBisectionProbes = 20
+
+	// bisectedReason is the problem reported for objects which were found by
+	// bisection, as nothing more specific is known about them.
+	bisectedReason = "the data-plane rejected the configuration generated from this object"
+)
+
+// -----------------------------------------------------------------------------
+// Dataplane Client - Kong - Quarantine - Private Types
+// -----------------------------------------------------------------------------
+
+// quarantinedObject is a Kubernetes object which is excluded from the
+// configuration sent to the data-plane.
+type quarantinedObject struct {
+	info util.K8sObjectInfo
+
+	// reason describes the problems the data-plane found with the
+	// configuration generated from the object.
+	reason string
+}
+
+// quarantine is a set of Kubernetes objects excluded from the configuration
+// sent to the data-plane, indexed by group kind, namespace and name.
+type quarantine map[string]quarantinedObject
+
+func quarantineKey(gk schema.GroupKind, namespace, name string) string {
+	return objectKey(gk.String(), namespace, name)
+}
+
+// add quarantines the provided object, unless it's already quarantined.
+func (q quarantine) add(info util.K8sObjectInfo, reason string) bool {
+	key := quarantineKey(info.GroupVersionKind.GroupKind(), info.Namespace, info.Name)
+	if _, ok := q[key]; ok {
+		return false
+	}
+	q[key] = quarantinedObject{info: info, reason: reason}
+	return true
+}
+
+// has indicates whether the provided object is quarantined.
+func (q quarantine) has(info util.K8sObjectInfo) bool {
+	return q.excludes(info.GroupVersionKind.GroupKind(), info.Namespace, info.Name)
+}
+
+// excludes implements store.ExcludeFunc.
+func (q quarantine) excludes(gk schema.GroupKind, namespace, name string) bool {
+	_, ok := q[quarantineKey(gk, namespace, name)]
+	return ok
+}
+
+func (q quarantine) copy() quarantine {
+	c := make(quarantine, len(q))
+	for key, obj := range q {
+		c[key] = obj
+	}
+	return c
+}
+
+// -----------------------------------------------------------------------------
+// Dataplane Client - Kong - Quarantine - Private Methods
+// -----------------------------------------------------------------------------
+
+// updateDegraded is used when the data-plane rejected the configuration
+// generated from all the Kubernetes objects in the provided storer, so that
+// a single broken object doesn't block the configuration of all the others.
+//
+// The objects the data-plane blamed for the rejection are excluded from the
+// configuration until it's accepted. When the data-plane doesn't report which
+// entities are invalid, the objects the configuration was generated from are
+// bisected against the validation proxy, which doesn't serve traffic, and only
+// the resulting configuration is sent to the provided proxies. Without a
+// validation proxy no reduced configuration is sent and the proxies keep
+// serving the previous configuration, as a rejected configuration isn't
+// applied. The objects in the provided previous quarantine are excluded
+// upfront.
+//
+// The quarantined objects are returned along with the parser, state and content
+// of the configuration which was applied. The caller must hold the client lock.
+func (c *KongClient) updateDegraded(
+	ctx context.Context,
+	storer store.Storer,
+	state *kongstate.KongState,
+	proxies []*proxy,
+	customEntities []byte,
+	previous quarantine,
+) (quarantine, *parser.Parser, *kongstate.KongState, *file.Content, error) {
+	q := previous.copy()
+	q.addBlamed(c.attributeConfigErrors(state, proxies))
+	if len(q) == 0 {
+		if err := c.bisect(ctx, storer, state, q, customEntities); err != nil {
+			return nil, nil, nil, nil, err
+		}
+	}
+
+	for round := 0; round < maxQuarantineRounds; round++ {
+		p, reducedState, content, err := c.applyExcluding(ctx, storer, q, proxies, customEntities)
+		if err == nil {
+			return q, p, reducedState, content, nil
+		}
+		if !configRejected(proxies) {
+			return nil, nil, nil, nil, fmt.Errorf("configuration could not be applied to the data-plane: %w", err)
+		}
+		if !q.addBlamed(c.attributeConfigErrors(reducedState, proxies)) {
+			if err := c.bisect(ctx, storer, reducedState, q, customEntities); err != nil {
+				return nil, nil, nil, nil, err
+			}
+		}
+	}
+	return nil, nil, nil, nil, fmt.Errorf("configuration is still rejected after excluding objects %d times, the previous configuration is kept", maxQuarantineRounds)
+}
+
+// bisect quarantines the objects the provided state was generated from which
+// need to be excluded for the validation proxy to accept the configuration,
+// as found by bisectSources(). The caller must hold the client lock.
+func (c *KongClient) bisect(
+	ctx context.Context,
+	storer store.Storer,
+	state *kongstate.KongState,
+	q quarantine,
+	customEntities []byte,
+) error {
+	if c.validationProxy == nil {
+		return fmt.Errorf("the data-plane didn't report which entities are invalid and no validation proxy is configured " +
+			"to find them, the previous configuration is kept")
+	}
+
+	var candidates []util.K8sObjectInfo
+	for _, info := range state.Sources() {
+		if !info.GroupVersionKind.Empty() && !q.has(info) {
+			candidates = append(candidates, info)
+		}
+	}
+	c.logger.Infof("bisecting %d Kubernetes objects against the validation proxy to find the ones the data-plane rejects the configuration of",
+		len(candidates))
+	probeProxies := []*proxy{c.validationProxy}
+	culprits, err := bisectSources(candidates, func(excluded []util.K8sObjectInfo) (bool, error) {
+		probe := q.copy()
+		for _, info := range excluded {
+			probe.add(info, bisectedReason)
+		}
+		// errors building the configuration leave the outcome of the previous
+		// probe in place, so it's reset upfront.
+		c.validationProxy.lastError = nil
+		if _, _, _, err := c.applyExcluding(ctx, storer, probe, probeProxies, customEntities); err != nil {
+			if !configRejected(probeProxies) {
+				return false, err
+			}
+			return true, nil
+		}
+		return false, nil
+	}, maxBisectionProbes)
+	if err != nil {
+		return fmt.Errorf("bisecting Kubernetes objects against the validation proxy, the previous configuration is kept: %w", err)
+	}
+	for _, info := range culprits {
+		q.add(info, bisectedReason)
+	}
+	return nil
+}
+
+// applyExcluding builds the configuration for the Kubernetes objects in the
+// provided storer, excluding the quarantined ones, and sends it to the provided
+// proxies. The resulting state is returned even if it's rejected, so that the
+// problems with it can be attributed. The caller must hold the client lock.
+func (c *KongClient) applyExcluding(
+	ctx context.Context,
+	storer store.Storer,
+	q quarantine,
+	proxies []*proxy,
+	customEntities []byte,
+) (*parser.Parser, *kongstate.KongState, *file.Content, error) {
+	p, state, content, err := c.buildConfig(ctx, store.NewExcludingStorer(storer, q.excludes))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return p, state, content, c.sendToProxies(ctx, proxies, content, c.withGeneratedEntities(ctx, state, customEntities))
+}
+
+// setQuarantine records the objects which are currently excluded from the
+// configuration, reports them through their configuration errors, Events and
+// metrics. The caller must hold the client lock.
+func (c *KongClient) setQuarantine(q quarantine) {
+	configErrors := make(map[string]string, len(q))
+	counts := map[string]int{}
+	for key, obj := range q {
+		configErrors[objectKey(obj.info.GroupVersionKind.String(), obj.info.Namespace, obj.info.Name)] = obj.reason
+		counts[obj.info.GroupVersionKind.Kind]++
+
+		if _, ok := c.quarantine[key]; ok {
+			continue
+		}
+		c.logger.WithField("kind", obj.info.GroupVersionKind.Kind).
+			Errorf("excluding %s/%s from the configuration: %s", obj.info.Namespace, obj.info.Name, obj.reason)
+		if c.eventRecorder != nil {
+			c.eventRecorder.Event(obj.info.ToPartialObjectMetadata(), corev1.EventTypeWarning,
+				KongConfigurationQuarantinedEventReason,
+				fmt.Sprintf("this object is excluded from the configuration of the data-plane until it's fixed: %s", obj.reason),
+			)
+		}
+	}
+	if len(q) > 0 {
+		c.updateKubernetesObjectConfigErrors(configErrors)
+	}
+	c.quarantine = q
+
+	c.prometheusMetrics.QuarantinedObjects.Reset()
+	for kind, count := range counts {
+		c.prometheusMetrics.QuarantinedObjects.With(prometheus.Labels{metrics.KindKey: kind}).Set(float64(count))
+	}
+}
+
+// addBlamed quarantines the objects which the data-plane blamed for rejecting
+// the configuration, as provided by attributeConfigErrors(). It reports whether
+// any of them weren't quarantined already.
+func (q quarantine) addBlamed(objects map[string]util.K8sObjectInfo, configErrors map[string]string) bool {
+	keys := make([]string, 0, len(objects))
+	for key := range objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	added := false
+	for _, key := range keys {
+		info := objects[key]
+		if info.GroupVersionKind.Empty() {
+			continue
+		}
+		if q.add(info, configErrors[key]) {
+			added = true
+		}
+	}
+	return added
+}
+
+// -----------------------------------------------------------------------------
+// Dataplane Client - Kong - Quarantine - Private Functions
+// -----------------------------------------------------------------------------
+
+// configRejected indicates whether the data-plane rejected the configuration
+// most recently sent to any of the provided proxies, as opposed to the
+// configuration not reaching it.
+func configRejected(proxies []*proxy) bool {
+	for _, p := range proxies {
+		var configErr *sendconfig.ConfigError
+		if errors.As(p.lastError, &configErr) {
+			return true
+		}
+	}
+	return false
+}
+
+// bisectSources searches the provided candidates for objects which need to be
+// excluded for the configuration to be accepted. rejected reports whether the
+// configuration excluding the provided objects is rejected.
+//
+// The candidates are split in a prefix which is included and a suffix which is
+// excluded, and the shortest rejected prefix is searched for: its last object
+// is a culprit. Culprits are excluded and searched for until the configuration
+// is accepted. At most maxProbes configurations are probed.
+func bisectSources(
+	candidates []util.K8sObjectInfo,
+	rejected func(excluded []util.K8sObjectInfo) (bool, error),
+	maxProbes int,
+) ([]util.K8sObjectInfo, error) {
+	var culprits []util.K8sObjectInfo
+	remaining := append([]util.K8sObjectInfo{}, candidates...)
+
+	probes := 0
+	probe := func(included int) (bool, error) {
+		if probes >= maxProbes {
+			return false, fmt.Errorf("no accepted configuration found after %d attempts", probes)
+		}
+		probes++
+		excluded := append(append([]util.K8sObjectInfo{}, culprits...), remaining[included:]...)
+		return rejected(excluded)
+	}
+
+	// the configuration excluding all the candidates has to be accepted,
+	// otherwise the problem lies elsewhere, and the configuration including
+	// all of them has to be rejected, otherwise there is nothing to find.
+	if isRejected, err := probe(0); err != nil {
+		return nil, err
+	} else if isRejected {
+		return nil, fmt.Errorf("configuration is rejected even when all %d candidates are excluded", len(candidates))
+	}
+	if isRejected, err := probe(len(remaining)); err != nil {
+		return nil, err
+	} else if !isRejected {
+		return nil, fmt.Errorf("configuration including all %d candidates is accepted", len(candidates))
+	}
+
+	// remaining[:accepted] is known to be accepted, and all of remaining is
+	// known to be rejected.
+	accepted := 0
+	for {
+		lo, hi := accepted, len(remaining)
+		if hi <= lo {
+			return nil, fmt.Errorf("configuration is rejected inconsistently")
+		}
+		for hi-lo > 1 {
+			mid := (lo + hi) / 2
+			isRejected, err := probe(mid)
+			if err != nil {
+				return nil, err
+			}
+			if isRejected {
+				hi = mid
+			} else {
+				lo = mid
+			}
+		}
+		culprits = append(culprits, remaining[lo])
+		remaining = append(remaining[:lo], remaining[lo+1:]...)
+		accepted = lo
+
+		isRejected, err := probe(len(remaining))
+		if err != nil {
+			return nil, err
+		}
+		if !isRejected {
+			return culprits, nil
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package dataplane
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/sendconfig"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/metrics"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+	configurationv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+)
+
+func TestBisectSources(t *testing.T) {
+	candidates := make([]util.K8sObjectInfo, 10)
+	for i := range candidates {
+		candidates[i] = util.K8sObjectInfo{
+			Namespace:        "default",
+			Name:             fmt.Sprintf("plugin-%d", i),
+			GroupVersionKind: configurationv1.SchemeGroupVersion.WithKind("KongPlugin"),
+		}
+	}
+
+	// rejectedIncluding builds a probe which rejects configurations which
+	// include any of the provided candidates.
+	rejectedIncluding := func(broken ...int) func([]util.K8sObjectInfo) (bool, error) {
+		return func(excluded []util.K8sObjectInfo) (bool, error) {
+			for _, i := range broken {
+				isExcluded := false
+				for _, info := range excluded {
+					if info.Name == candidates[i].Name {
+						isExcluded = true
+					}
+				}
+				if !isExcluded {
+					return true, nil
+				}
+			}
+			return false, nil
+		}
+	}
+
+	t.Run("a single broken object is found", func(t *testing.T) {
+		culprits, err := bisectSources(candidates, rejectedIncluding(7), maxBisectionProbes)
+		require.NoError(t, err)
+		assert.Equal(t, []util.K8sObjectInfo{candidates[7]}, culprits)
+	})
+
+	t.Run("multiple broken objects are found", func(t *testing.T) {
+		culprits, err := bisectSources(candidates, rejectedIncluding(2, 9), maxBisectionProbes)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []util.K8sObjectInfo{candidates[2], candidates[9]}, culprits)
+	})
+
+	t.Run("nothing is found when excluding all the candidates doesn't help", func(t *testing.T) {
+		_, err := bisectSources(candidates, func([]util.K8sObjectInfo) (bool, error) { return true, nil }, maxBisectionProbes)
+		assert.Error(t, err)
+	})
+
+	t.Run("nothing is found when the configuration including all the candidates is accepted", func(t *testing.T) {
+		_, err := bisectSources(candidates, rejectedIncluding(), maxBisectionProbes)
+		assert.Error(t, err)
+	})
+
+	t.Run("the number of probes is limited", func(t *testing.T) {
+		probes := 0
+		probe := rejectedIncluding(0, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+		_, err := bisectSources(candidates, func(excluded []util.K8sObjectInfo) (bool, error) {
+			probes++
+			return probe(excluded)
+		}, 5)
+		assert.Error(t, err)
+		assert.Equal(t, 5, probes)
+	})
+
+	t.Run("probe errors are returned", func(t *testing.T) {
+		_, err := bisectSources(candidates, func([]util.K8sObjectInfo) (bool, error) {
+			return false, fmt.Errorf("connection refused")
+		}, maxBisectionProbes)
+		assert.Error(t, err)
+	})
+}
+
+func TestKongClient_setQuarantine(t *testing.T) {
+	plugin := util.K8sObjectInfo{
+		Namespace:        "default",
+		Name:             "rate-limiting",
+		GroupVersionKind: configurationv1.SchemeGroupVersion.WithKind("KongPlugin"),
+	}
+	pluginObj := &configurationv1.KongPlugin{}
+	pluginObj.SetGroupVersionKind(plugin.GroupVersionKind)
+	pluginObj.Namespace, pluginObj.Name = plugin.Namespace, plugin.Name
+
+	recorder := record.NewFakeRecorder(10)
+	c := &KongClient{
+		logger:        logrus.New(),
+		eventRecorder: recorder,
+		prometheusMetrics: &metrics.CtrlFuncMetrics{
+			QuarantinedObjects: prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "test"}, []string{metrics.KindKey}),
+		},
+	}
+
+	t.Log("quarantining an object")
+	q := quarantine{}
+	q.add(plugin, "config.minute: invalid value")
+	c.setQuarantine(q)
+	msg, rejected := c.KubernetesObjectConfigurationError(pluginObj)
+	require.True(t, rejected)
+	assert.Equal(t, "config.minute: invalid value", msg)
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, KongConfigurationQuarantinedEventReason)
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.prometheusMetrics.QuarantinedObjects.WithLabelValues("KongPlugin")))
+
+	t.Log("verifying that objects which stay quarantined don't emit more events")
+	c.setQuarantine(q.copy())
+	assert.Len(t, recorder.Events, 0)
+
+	t.Log("lifting the quarantine")
+	c.setQuarantine(nil)
+	assert.Equal(t, 0, testutil.CollectAndCount(c.prometheusMetrics.QuarantinedObjects))
+}
+
+func TestKongClient_updateDegraded(t *testing.T) {
+	consumer := func(name string) *configurationv1.KongConsumer {
+		return &configurationv1.KongConsumer{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        name,
+				Annotations: map[string]string{annotations.IngressClassKey: annotations.DefaultIngressClass},
+			},
+			Username: name,
+		}
+	}
+	s, err := store.NewFakeStore(store.FakeObjects{
+		KongConsumers: []*configurationv1.KongConsumer{consumer("alice"), consumer("bob")},
+	})
+	require.NoError(t, err)
+
+	// the proxy records the configurations it receives and accepts them.
+	var lock sync.Mutex
+	var pushes []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == "/config" {
+			body, _ := io.ReadAll(r.Body)
+			lock.Lock()
+			pushes = append(pushes, string(body))
+			lock.Unlock()
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+	kongClient, err := kong.NewClient(kong.String(srv.URL), srv.Client())
+	require.NoError(t, err)
+
+	newClient := func(configErr *sendconfig.ConfigError) (*KongClient, []*proxy) {
+		c := &KongClient{
+			logger:         logrus.New(),
+			requestTimeout: time.Second,
+			kongConfig:     sendconfig.Kong{URL: srv.URL, Client: kongClient, InMemory: true},
+			prometheusMetrics: &metrics.CtrlFuncMetrics{
+				ConfigPushCount:    prometheus.NewCounterVec(prometheus.CounterOpts{Name: "count"}, []string{metrics.SuccessKey, metrics.ProtocolKey}),
+				ConfigPushDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: "duration"}, []string{metrics.SuccessKey, metrics.ProtocolKey}),
+			},
+		}
+		p := c.newProxy(srv.URL, kongClient)
+		p.lastError = fmt.Errorf("posting new config to /config: %w", configErr)
+		return c, []*proxy{p}
+	}
+
+	t.Run("a rejected configuration which names no entities is kept from the proxies without a validation proxy", func(t *testing.T) {
+		pushes = nil
+		c, proxies := newClient(&sendconfig.ConfigError{Code: 400, Message: "declarative config is invalid: {}"})
+		_, state, _, err := c.buildConfig(context.Background(), s)
+		require.NoError(t, err)
+
+		q, _, _, _, err := c.updateDegraded(context.Background(), s, state, proxies, nil, nil)
+		require.Error(t, err)
+		assert.Empty(t, q)
+		assert.Empty(t, pushes, "no reduced configuration should be sent to the proxies")
+	})
+
+	t.Run("only the objects the data-plane blames are excluded", func(t *testing.T) {
+		pushes = nil
+		c, proxies := newClient(&sendconfig.ConfigError{
+			Code:    400,
+			Message: "declarative config is invalid: {}",
+			EntityErrors: []sendconfig.EntityError{{
+				Type:   "consumer",
+				Name:   "alice",
+				Errors: []sendconfig.EntityFieldError{{Field: "username", Message: "invalid value"}},
+			}},
+		})
+		_, state, _, err := c.buildConfig(context.Background(), s)
+		require.NoError(t, err)
+
+		q, _, reducedState, _, err := c.updateDegraded(context.Background(), s, state, proxies, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, q, 1)
+		for _, obj := range q {
+			assert.Equal(t, "alice", obj.info.Name)
+		}
+		require.Len(t, reducedState.Consumers, 1)
+		assert.Equal(t, "bob", *reducedState.Consumers[0].Username)
+		require.Len(t, pushes, 1)
+		assert.NotContains(t, pushes[0], "alice")
+		assert.Contains(t, pushes[0], "bob")
+	})
+
+	t.Run("the objects a rejected configuration which names no entities was generated from are bisected on the validation proxy", func(t *testing.T) {
+		pushes = nil
+		// the validation proxy rejects the configurations which include alice
+		// without naming the invalid entities.
+		var probes int
+		validation := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost && r.URL.Path == "/config" {
+				body, _ := io.ReadAll(r.Body)
+				lock.Lock()
+				probes++
+				lock.Unlock()
+				if strings.Contains(string(body), "alice") {
+					w.WriteHeader(http.StatusBadRequest)
+					_, _ = w.Write([]byte(`{"message": "declarative config is invalid: {}"}`))
+					return
+				}
+				w.WriteHeader(http.StatusCreated)
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer validation.Close()
+		validationClient, err := kong.NewClient(kong.String(validation.URL), validation.Client())
+		require.NoError(t, err)
+
+		c, proxies := newClient(&sendconfig.ConfigError{Code: 400, Message: "declarative config is invalid: {}"})
+		c.EnableValidationProxy(validation.URL, validationClient)
+		_, state, _, err := c.buildConfig(context.Background(), s)
+		require.NoError(t, err)
+
+		q, _, reducedState, _, err := c.updateDegraded(context.Background(), s, state, proxies, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, q, 1)
+		for _, obj := range q {
+			assert.Equal(t, "alice", obj.info.Name)
+			assert.Equal(t, bisectedReason, obj.reason)
+		}
+		require.Len(t, reducedState.Consumers, 1)
+		assert.Equal(t, "bob", *reducedState.Consumers[0].Username)
+		assert.Greater(t, probes, 1)
+		require.Len(t, pushes, 1, "only the resulting configuration should be sent to the proxies")
+		assert.NotContains(t, pushes[0], "alice")
+		assert.Contains(t, pushes[0], "bob")
+	})
+}
@@ -0,0 +1,81 @@
+package dataplane
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+)
+
+func TestKongClient_Changes(t *testing.T) {
+	cache := store.NewCacheStores()
+	c := &KongClient{cache: &cache, changes: make(chan struct{}, 1)}
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "svc", ResourceVersion: "1"}}
+
+	t.Log("verifying that adding an object signals a change")
+	require.NoError(t, c.UpdateObject(svc))
+	require.Len(t, c.Changes(), 1)
+	<-c.Changes()
+
+	t.Log("verifying that updating an object with the same resource version doesn't signal a change")
+	require.NoError(t, c.UpdateObject(svc))
+	assert.Len(t, c.Changes(), 0)
+
+	t.Log("verifying that updating an object with a new resource version signals a change")
+	svc.ResourceVersion = "2"
+	require.NoError(t, c.UpdateObject(svc))
+	require.Len(t, c.Changes(), 1)
+	<-c.Changes()
+
+	t.Log("verifying that deleting an object signals a change, unless it's not cached")
+	require.NoError(t, c.DeleteObject(svc))
+	require.Len(t, c.Changes(), 1)
+	<-c.Changes()
+	require.NoError(t, c.DeleteObject(svc))
+	assert.Len(t, c.Changes(), 0)
+}
+
+func TestKongClient_loadCustomEntities(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "custom-entities"},
+		Data:       map[string][]byte{CustomEntitiesSecretKey: []byte(`{"foos": [{"name": "foo"}]}`)},
+	}
+	invalid := secret.DeepCopy()
+	invalid.Data[CustomEntitiesSecretKey] = []byte(`- not a map`)
+	recorder := record.NewFakeRecorder(10)
+	c := &KongClient{logger: logrus.New(), eventRecorder: recorder}
+	c.EnableCustomEntities(k8stypes.NamespacedName{Namespace: "default", Name: "custom-entities"})
+	emptyStore, err := store.NewFakeStore(store.FakeObjects{})
+	require.NoError(t, err)
+	validStore, err := store.NewFakeStore(store.FakeObjects{Secrets: []*corev1.Secret{secret}})
+	require.NoError(t, err)
+	invalidStore, err := store.NewFakeStore(store.FakeObjects{Secrets: []*corev1.Secret{invalid}})
+	require.NoError(t, err)
+
+	t.Log("verifying that there are no custom entities while the Secret doesn't exist")
+	assert.Nil(t, c.loadCustomEntities(emptyStore))
+
+	t.Log("verifying that there are no custom entities while the Secret is invalid and none were loaded")
+	assert.Nil(t, c.loadCustomEntities(invalidStore))
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, KongCustomEntitiesInvalidEventReason)
+
+	t.Log("verifying that custom entities are loaded from the Secret")
+	assert.JSONEq(t, `{"foos": [{"name": "foo"}]}`, string(c.loadCustomEntities(validStore)))
+
+	t.Log("verifying that the last custom entities loaded are kept while the Secret is invalid, which is reported once")
+	assert.JSONEq(t, `{"foos": [{"name": "foo"}]}`, string(c.loadCustomEntities(invalidStore)))
+	assert.JSONEq(t, `{"foos": [{"name": "foo"}]}`, string(c.loadCustomEntities(invalidStore)))
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, KongCustomEntitiesInvalidEventReason)
+
+	t.Log("verifying that the custom entities are removed when the Secret is deleted")
+	assert.Nil(t, c.loadCustomEntities(emptyStore))
+}
@@ -0,0 +1,102 @@
+package dataplane
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/parser"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/metrics"
+)
+
+// -----------------------------------------------------------------------------
+// Dataplane Client - Kong - Translation Failures - Public Vars & Consts
+// -----------------------------------------------------------------------------
+
+// KongConfigurationTranslationFailedEventReason is the reason of the Warning
+// Events emitted for Kubernetes objects which (in part) couldn't be translated
+// into Kong configuration.
+const KongConfigurationTranslationFailedEventReason = "KongConfigurationTranslationFailed"
+
+// -----------------------------------------------------------------------------
+// Dataplane Client - Kong - Translation Failures - Public Methods
+// -----------------------------------------------------------------------------
+
+// KubernetesObjectTranslationFailure provides the reasons why the provided
+// object could not be (fully) translated into Kong configuration by the most
+// recent Update(). If the object was translated this returns false.
+func (c *KongClient) KubernetesObjectTranslationFailure(obj client.Object) (string, bool) {
+	c.kubernetesObjectReportLock.RLock()
+	defer c.kubernetesObjectReportLock.RUnlock()
+	reason, ok := c.kubernetesObjectTranslationFailures[objectKey(
+		obj.GetObjectKind().GroupVersionKind().String(), obj.GetNamespace(), obj.GetName(),
+	)]
+	return reason, ok
+}
+
+// -----------------------------------------------------------------------------
+// Dataplane Client - Kong - Translation Failures - Private Methods
+// -----------------------------------------------------------------------------
+
+// reportTranslationFailures emits Warning Events for the objects of the
+// provided translation failures and counts them in the metrics. As the same
+// objects are translated on every sync, failures which were already reported
+// by the previous Update() are not reported again. The caller must hold the
+// client lock.
+func (c *KongClient) reportTranslationFailures(failures []parser.TranslationFailure) {
+	reported := make(map[string]struct{}, len(failures))
+	failedObjects := map[string][]string{}
+	counts := map[string]int{}
+	for _, failure := range failures {
+		for _, obj := range failure.CausingObjects {
+			key := translationFailureKey(obj, failure.Reason)
+			if _, ok := reported[key]; ok {
+				continue
+			}
+			reported[key] = struct{}{}
+
+			gvk := obj.GetObjectKind().GroupVersionKind()
+			objKey := objectKey(gvk.String(), obj.GetNamespace(), obj.GetName())
+			if _, ok := failedObjects[objKey]; !ok {
+				counts[gvk.Kind]++
+			}
+			failedObjects[objKey] = append(failedObjects[objKey], failure.Reason)
+
+			if _, ok := c.reportedTranslationFailures[key]; ok || c.eventRecorder == nil {
+				continue
+			}
+			c.eventRecorder.Event(obj, corev1.EventTypeWarning, KongConfigurationTranslationFailedEventReason,
+				fmt.Sprintf("failed to translate this object into Kong configuration: %s", failure.Reason),
+			)
+		}
+	}
+	c.reportedTranslationFailures = reported
+
+	reasons := make(map[string]string, len(failedObjects))
+	for objKey, objReasons := range failedObjects {
+		sort.Strings(objReasons)
+		reasons[objKey] = strings.Join(objReasons, "; ")
+	}
+	c.kubernetesObjectReportLock.Lock()
+	c.kubernetesObjectTranslationFailures = reasons
+	c.kubernetesObjectReportLock.Unlock()
+
+	c.prometheusMetrics.TranslationFailures.Reset()
+	for kind, count := range counts {
+		c.prometheusMetrics.TranslationFailures.With(prometheus.Labels{metrics.KindKey: kind}).Set(float64(count))
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Dataplane Client - Kong - Translation Failures - Private Functions
+// -----------------------------------------------------------------------------
+
+// translationFailureKey produces a key which identifies a translation failure
+// of a single Kubernetes object.
+func translationFailureKey(obj client.Object, reason string) string {
+	return objectKey(obj.GetObjectKind().GroupVersionKind().String(), obj.GetNamespace(), obj.GetName()) + ":" + reason
+}
@@ -0,0 +1,59 @@
+package dataplane
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/parser"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/metrics"
+	configurationv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
+)
+
+func TestKongClient_reportTranslationFailures(t *testing.T) {
+	tcpIngress := &configurationv1beta1.TCPIngress{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: configurationv1beta1.SchemeGroupVersion.String(),
+			Kind:       "TCPIngress",
+		},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tcpingress"},
+	}
+	failure := parser.TranslationFailure{
+		CausingObjects: []client.Object{tcpIngress},
+		Reason:         "rule 0 skipped: invalid port: 0",
+	}
+
+	recorder := record.NewFakeRecorder(10)
+	failuresMetric := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "test"}, []string{metrics.KindKey})
+	c := &KongClient{
+		eventRecorder:     recorder,
+		prometheusMetrics: &metrics.CtrlFuncMetrics{TranslationFailures: failuresMetric},
+	}
+
+	t.Log("reporting a translation failure")
+	c.reportTranslationFailures([]parser.TranslationFailure{failure, failure})
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, KongConfigurationTranslationFailedEventReason)
+	assert.Equal(t, float64(1), testutil.ToFloat64(failuresMetric.WithLabelValues("TCPIngress")))
+	reason, failed := c.KubernetesObjectTranslationFailure(tcpIngress)
+	assert.True(t, failed)
+	assert.Equal(t, failure.Reason, reason)
+
+	t.Log("verifying that the same failure is not reported again on the next sync")
+	c.reportTranslationFailures([]parser.TranslationFailure{failure})
+	assert.Len(t, recorder.Events, 0)
+
+	t.Log("verifying that a failure which went away is reported again when it comes back")
+	c.reportTranslationFailures(nil)
+	assert.Equal(t, 0, testutil.CollectAndCount(failuresMetric))
+	_, failed = c.KubernetesObjectTranslationFailure(tcpIngress)
+	assert.False(t, failed)
+	c.reportTranslationFailures([]parser.TranslationFailure{failure})
+	assert.Len(t, recorder.Events, 1)
+}
@@ -2,11 +2,16 @@ package kongstate
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/blang/semver/v4"
 	"github.com/kong/go-kong/kong"
+	"github.com/sirupsen/logrus"
 
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/validation/consumers/credentials"
 	configurationv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
 )
 
@@ -27,7 +32,20 @@ type Consumer struct {
 	Oauth2Creds []*Oauth2Credential
 	MTLSAuths   []*MTLSAuth
 
+	// ConsumerGroups are the names of the Kong consumer groups the consumer is
+	// a member of.
+	ConsumerGroups []string
+
 	K8sKongConsumer configurationv1.KongConsumer
+
+	// K8sCredentials are the results of resolving the credential Secrets of
+	// the KongConsumer, in the order of its credentials.
+	K8sCredentials []configurationv1.ConsumerCredentialStatus
+}
+
+// K8sObjectInfo describes the KongConsumer the consumer was generated from.
+func (c *Consumer) K8sObjectInfo() util.K8sObjectInfo {
+	return consumerObjectInfo(&c.K8sKongConsumer)
 }
 
 // SanitizedCopy returns a shallow copy with sensitive values redacted best-effort.
@@ -67,7 +85,9 @@ func (c *Consumer) SanitizedCopy() *Consumer {
 		}(),
 		ACLGroups:       c.ACLGroups,
 		MTLSAuths:       c.MTLSAuths,
+		ConsumerGroups:  c.ConsumerGroups,
 		K8sKongConsumer: c.K8sKongConsumer,
+		K8sCredentials:  c.K8sCredentials,
 	}
 }
 
@@ -123,3 +143,66 @@ func (c *Consumer) SetCredential(credType string, credConfig interface{}) error
 	}
 	return nil
 }
+
+// setCredentialFromSecret sets the credential of the provided Secret of the
+// KongConsumer on the consumer, and returns the result of resolving it.
+// Secrets which don't pass credentials.ValidateCredentials() are skipped.
+func (c *Consumer) setCredentialFromSecret(
+	log logrus.FieldLogger,
+	s store.Storer,
+	secretName string,
+) configurationv1.ConsumerCredentialStatus {
+	status := configurationv1.ConsumerCredentialStatus{SecretName: secretName}
+	secret, err := s.GetSecret(c.K8sKongConsumer.Namespace, secretName)
+	if err != nil {
+		log.WithError(err).Error("failed to fetch secret")
+		status.Reason = configurationv1.CredentialReasonSecretNotFound
+		status.Message = err.Error()
+		return status
+	}
+
+	status.Type = string(secret.Data[credentials.TypeKey])
+	if err := credentials.ValidateCredentials(secret); err != nil {
+		log.WithError(err).Error("failed to provision credential")
+		if credentials.SupportedTypes.Has(status.Type) {
+			status.Reason = configurationv1.CredentialReasonMissingFields
+		} else {
+			status.Reason = configurationv1.CredentialReasonInvalidType
+		}
+		status.Message = err.Error()
+		return status
+	}
+
+	credConfig := map[string]interface{}{}
+	for k, v := range secret.Data {
+		// TODO populate these based on schema from Kong
+		// and remove this workaround
+		if k == "redirect_uris" {
+			credConfig[k] = strings.Split(string(v), ",")
+			continue
+		}
+		// TODO this is a kongCredType-agnostic mutation that should only apply to Oauth2 credentials.
+		// However, the credential-specific code after deals only in interface{}s, and we can't fix individual
+		// keys. To handle this properly we'd need to refactor the types used in all following code.
+		if k == "hash_secret" {
+			boolVal, err := strconv.ParseBool(string(v))
+			if err != nil {
+				log.WithError(err).Errorf("failed to parse hash_secret to bool. defaulting to false")
+				credConfig[k] = false
+			} else {
+				credConfig[k] = boolVal
+			}
+			continue
+		}
+		credConfig[k] = string(v)
+	}
+	if err := c.SetCredential(status.Type, credConfig); err != nil {
+		log.WithError(err).Errorf("failed to provision credential")
+		status.Reason = configurationv1.CredentialReasonInvalid
+		status.Message = err.Error()
+		return status
+	}
+
+	status.Reason = configurationv1.CredentialReasonFound
+	return status
+}
@@ -0,0 +1,121 @@
+package kongstate
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+	configurationv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
+)
+
+// ConsumerGroup holds a Kong consumer group and the plugins scoped to it. The
+// members of the group are the consumers which list its name in their
+// ConsumerGroups.
+type ConsumerGroup struct {
+	Name string
+
+	// Plugins are the plugins set by the konghq.com/plugins annotation of the
+	// KongConsumerGroup. They only apply to the members of the group, and
+	// therefore don't refer to any route, service or consumer.
+	Plugins []Plugin
+
+	K8sKongConsumerGroup configurationv1beta1.KongConsumerGroup
+}
+
+// K8sObjectInfo describes the KongConsumerGroup the consumer group was
+// generated from.
+func (g *ConsumerGroup) K8sObjectInfo() util.K8sObjectInfo {
+	return consumerGroupObjectInfo(&g.K8sKongConsumerGroup)
+}
+
+// FillConsumerGroups generates the Kong consumer groups of the
+// KongConsumerGroups along with the plugins scoped to them, and records the
+// groups each consumer of the state is a member of. It must be called after
+// the consumers are filled. The KongConsumerGroups which no consumer group
+// could be generated from, and the plugins which couldn't be generated, are
+// returned.
+func (ks *KongState) FillConsumerGroups(log logrus.FieldLogger, s store.Storer) []ObjectFailure {
+	k8sGroups := s.ListKongConsumerGroups()
+	// the names of consumer groups are global in Kong, so when several
+	// KongConsumerGroups claim the same name the oldest one keeps it.
+	sort.SliceStable(k8sGroups, func(i, j int) bool {
+		if !k8sGroups[i].CreationTimestamp.Equal(&k8sGroups[j].CreationTimestamp) {
+			return k8sGroups[i].CreationTimestamp.Before(&k8sGroups[j].CreationTimestamp)
+		}
+		if k8sGroups[i].Namespace != k8sGroups[j].Namespace {
+			return k8sGroups[i].Namespace < k8sGroups[j].Namespace
+		}
+		return k8sGroups[i].Name < k8sGroups[j].Name
+	})
+
+	var failures []ObjectFailure
+	owners := make(map[string]*configurationv1beta1.KongConsumerGroup, len(k8sGroups))
+	kongNames := make(map[string]string, len(k8sGroups))
+	for _, k8sGroup := range k8sGroups {
+		name := k8sGroup.KongName()
+		if owner, ok := owners[name]; ok {
+			failures = append(failures, ObjectFailure{
+				Object: consumerGroupObjectInfo(k8sGroup),
+				Reason: fmt.Sprintf("consumer group name %q is already used by KongConsumerGroup %s/%s",
+					name, owner.Namespace, owner.Name),
+			})
+			continue
+		}
+		owners[name] = k8sGroup
+		kongNames[k8sGroup.Namespace+"/"+k8sGroup.Name] = name
+
+		group := ConsumerGroup{Name: name, K8sKongConsumerGroup: *k8sGroup}
+		for _, pluginName := range annotations.ExtractKongPluginsFromAnnotations(k8sGroup.Annotations) {
+			plugin, source, err := getPlugin(s, k8sGroup.Namespace, pluginName)
+			if err != nil {
+				log.WithFields(logrus.Fields{
+					"kongplugin_name":             pluginName,
+					"kongplugin_namespace":        k8sGroup.Namespace,
+					"kongconsumergroup_name":      k8sGroup.Name,
+					"kongconsumergroup_namespace": k8sGroup.Namespace,
+				}).WithError(err).Errorf("failed to fetch KongPlugin")
+				// the plugin is only known when it exists but is invalid.
+				if !source.GroupVersionKind.Empty() {
+					failures = append(failures, ObjectFailure{Object: source, Reason: err.Error()})
+				}
+				continue
+			}
+			group.Plugins = append(group.Plugins, Plugin{Plugin: plugin, K8sParent: source})
+		}
+		ks.ConsumerGroups = append(ks.ConsumerGroups, group)
+	}
+
+	for i := range ks.Consumers {
+		consumer := &ks.Consumers[i].K8sKongConsumer
+		seen := make(map[string]struct{}, len(consumer.ConsumerGroups))
+		for _, groupName := range consumer.ConsumerGroups {
+			name, ok := kongNames[consumer.Namespace+"/"+groupName]
+			if !ok {
+				log.WithFields(logrus.Fields{
+					"kongconsumer_name":      consumer.Name,
+					"kongconsumer_namespace": consumer.Namespace,
+				}).Errorf("KongConsumerGroup %s not found, the consumer is not added to it", groupName)
+				continue
+			}
+			if _, ok := seen[name]; ok {
+				continue
+			}
+			seen[name] = struct{}{}
+			ks.Consumers[i].ConsumerGroups = append(ks.Consumers[i].ConsumerGroups, name)
+		}
+	}
+	return failures
+}
+
+// consumerGroupObjectInfo describes the provided KongConsumerGroup. The kind
+// is always set as objects retrieved from the store are not guaranteed to
+// include it.
+func consumerGroupObjectInfo(group *configurationv1beta1.KongConsumerGroup) util.K8sObjectInfo {
+	info := util.FromK8sObject(group)
+	info.GroupVersionKind = configurationv1beta1.SchemeGroupVersion.WithKind("KongConsumerGroup")
+	return info
+}
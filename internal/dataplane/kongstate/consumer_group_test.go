@@ -0,0 +1,96 @@
+package kongstate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+	configurationv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+	configurationv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
+)
+
+func TestKongState_FillConsumerGroups(t *testing.T) {
+	objectMeta := func(namespace, name string, created time.Time, plugins string) metav1.ObjectMeta {
+		meta := metav1.ObjectMeta{
+			Namespace:         namespace,
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(created),
+			Annotations: map[string]string{
+				annotations.IngressClassKey: annotations.DefaultIngressClass,
+			},
+		}
+		if plugins != "" {
+			meta.Annotations[annotations.AnnotationPrefix+annotations.PluginsKey] = plugins
+		}
+		return meta
+	}
+	now := time.Now()
+
+	s, err := store.NewFakeStore(store.FakeObjects{
+		KongConsumerGroups: []*configurationv1beta1.KongConsumerGroup{
+			{
+				ObjectMeta: objectMeta("default", "gold", now, "rate-limiting,missing,broken"),
+			},
+			{
+				// claims the name of the older "gold" group.
+				ObjectMeta: objectMeta("other", "premium", now.Add(time.Minute), ""),
+				Spec:       configurationv1beta1.KongConsumerGroupSpec{Name: "gold"},
+			},
+			{
+				ObjectMeta: objectMeta("default", "silver", now, ""),
+				Spec:       configurationv1beta1.KongConsumerGroupSpec{Name: "tier-silver"},
+			},
+		},
+		KongPlugins: []*configurationv1.KongPlugin{
+			{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "rate-limiting"},
+				PluginName: "rate-limiting",
+				Config:     apiextensionsv1.JSON{Raw: []byte(`{"minute": 10}`)},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "broken"},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	state := KongState{
+		Consumers: []Consumer{{
+			Consumer: kong.Consumer{Username: kong.String("alice")},
+			K8sKongConsumer: configurationv1.KongConsumer{
+				ObjectMeta:     metav1.ObjectMeta{Namespace: "default", Name: "alice"},
+				ConsumerGroups: []string{"gold", "silver", "gold", "bronze"},
+			},
+		}},
+	}
+	failures := state.FillConsumerGroups(logrus.New(), s)
+
+	t.Log("verifying that the groups which claim a name already in use and invalid plugins are reported")
+	require.Len(t, failures, 2)
+	assert.Equal(t, "broken", failures[0].Object.Name)
+	assert.Equal(t, "KongPlugin", failures[0].Object.GroupVersionKind.Kind)
+	assert.Equal(t, "premium", failures[1].Object.Name)
+	assert.Equal(t, "KongConsumerGroup", failures[1].Object.GroupVersionKind.Kind)
+	assert.Equal(t, `consumer group name "gold" is already used by KongConsumerGroup default/gold`, failures[1].Reason)
+
+	t.Log("verifying that the consumer groups are generated with their plugins")
+	require.Len(t, state.ConsumerGroups, 2)
+	gold := state.ConsumerGroups[0]
+	assert.Equal(t, "gold", gold.Name)
+	require.Len(t, gold.Plugins, 1)
+	assert.Equal(t, "rate-limiting", *gold.Plugins[0].Name)
+	assert.Equal(t, "rate-limiting", gold.Plugins[0].K8sParent.Name)
+	assert.Nil(t, gold.Plugins[0].Consumer)
+	assert.Equal(t, "tier-silver", state.ConsumerGroups[1].Name)
+
+	t.Log("verifying that consumers are members of the groups they refer to which exist")
+	assert.Equal(t, []string{"gold", "tier-silver"}, state.Consumers[0].ConsumerGroups)
+}
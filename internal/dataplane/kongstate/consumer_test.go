@@ -41,7 +41,11 @@ func TestConsumer_SanitizedCopy(t *testing.T) {
 					{kong.Oauth2Credential{ID: kong.String("1"), ClientSecret: kong.String("secret")}},
 				},
 				MTLSAuths:       []*MTLSAuth{{kong.MTLSAuth{ID: kong.String("1"), SubjectName: kong.String("foo@example.com")}}},
+				ConsumerGroups:  []string{"gold"},
 				K8sKongConsumer: configurationv1.KongConsumer{Username: "foo"},
+				K8sCredentials: []configurationv1.ConsumerCredentialStatus{
+					{SecretName: "foo-key", Type: "key-auth", Reason: configurationv1.CredentialReasonFound},
+				},
 			},
 			want: Consumer{
 				Consumer: kong.Consumer{
@@ -61,7 +65,11 @@ func TestConsumer_SanitizedCopy(t *testing.T) {
 					{kong.Oauth2Credential{ID: kong.String("1"), ClientSecret: redactedString}},
 				},
 				MTLSAuths:       []*MTLSAuth{{kong.MTLSAuth{ID: kong.String("1"), SubjectName: kong.String("foo@example.com")}}},
+				ConsumerGroups:  []string{"gold"},
 				K8sKongConsumer: configurationv1.KongConsumer{Username: "foo"},
+				K8sCredentials: []configurationv1.ConsumerCredentialStatus{
+					{SecretName: "foo-key", Type: "key-auth", Reason: configurationv1.CredentialReasonFound},
+				},
 			},
 		},
 	} {
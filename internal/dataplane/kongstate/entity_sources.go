@@ -0,0 +1,230 @@
+package kongstate
+
+import (
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+	configurationv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+)
+
+// SourcesForEntity provides the Kubernetes objects which the Kong entity of the
+// provided type (e.g. "route" or "routes") and name was generated from, which
+// makes it possible to attribute problems the data-plane reports for entities
+// to the objects users actually manage.
+//
+// Plugins are not uniquely named, so for plugins the route, service and
+// consumer references of the provided entity (if any) are used to narrow down
+// the candidates. If that's not possible all the plugins of that name are
+// considered to be the source.
+func (ks *KongState) SourcesForEntity(entityType, entityName string, entity map[string]interface{}) []util.K8sObjectInfo {
+	var sources []util.K8sObjectInfo
+	switch strings.TrimSuffix(entityType, "s") {
+	case "service":
+		for _, s := range ks.Services {
+			if s.Name != nil && *s.Name == entityName {
+				sources = append(sources, servicesObjectInfo(s.K8sServices)...)
+				if s.ServicePolicy != nil {
+					sources = append(sources, servicePolicyObjectInfo(s.ServicePolicy))
+				}
+			}
+		}
+	case "route":
+		for _, s := range ks.Services {
+			for _, r := range s.Routes {
+				if r.Name != nil && *r.Name == entityName {
+					sources = append(sources, r.Ingress)
+				}
+			}
+		}
+	case "upstream":
+		for _, u := range ks.Upstreams {
+			if u.Name != nil && *u.Name == entityName {
+				sources = append(sources, servicesObjectInfo(u.Service.K8sServices)...)
+				if u.UpstreamPolicy != nil {
+					sources = append(sources, upstreamPolicyObjectInfo(u.UpstreamPolicy))
+				}
+			}
+		}
+	case "consumer":
+		for _, c := range ks.Consumers {
+			if c.Username != nil && *c.Username == entityName {
+				sources = append(sources, consumerObjectInfo(&c.K8sKongConsumer))
+			}
+		}
+	case "consumer_group":
+		for i := range ks.ConsumerGroups {
+			if ks.ConsumerGroups[i].Name == entityName {
+				sources = append(sources, ks.ConsumerGroups[i].K8sObjectInfo())
+			}
+		}
+	case "vault", "vaults_beta":
+		// vaults are identified by their prefix.
+		for i := range ks.Vaults {
+			if ks.Vaults[i].Prefix == entityName {
+				sources = append(sources, ks.Vaults[i].K8sObjectInfo())
+			}
+		}
+	case "plugin":
+		for _, p := range ks.Plugins {
+			if p.Name == nil || *p.Name != entityName {
+				continue
+			}
+			if p.Route != nil && !entityReferences(entity, "route", p.Route.ID) {
+				continue
+			}
+			if p.Service != nil && !entityReferences(entity, "service", p.Service.ID) {
+				continue
+			}
+			if p.Consumer != nil && !entityReferences(entity, "consumer", p.Consumer.ID) {
+				continue
+			}
+			sources = append(sources, p.K8sParent)
+		}
+		// plugins generated for routes, e.g. from HTTPRoute filters, originate
+		// from the objects of those routes.
+		for _, s := range ks.Services {
+			for _, r := range s.Routes {
+				for _, p := range r.Plugins {
+					if p.Name != nil && *p.Name == entityName && entityReferences(entity, "route", r.Name) {
+						sources = append(sources, r.Ingress)
+					}
+				}
+			}
+		}
+		for i := range ks.ConsumerGroups {
+			group := &ks.ConsumerGroups[i]
+			for _, p := range group.Plugins {
+				if p.Name != nil && *p.Name == entityName && entityReferences(entity, "consumer_group", &group.Name) {
+					sources = append(sources, p.K8sParent)
+				}
+			}
+		}
+	}
+	return sources
+}
+
+// Sources provides all the Kubernetes objects which the entities of the state
+// were generated from, without duplicates and ordered by kind, namespace and
+// name.
+func (ks *KongState) Sources() []util.K8sObjectInfo {
+	seen := map[string]util.K8sObjectInfo{}
+	add := func(infos ...util.K8sObjectInfo) {
+		for _, info := range infos {
+			if info.Name == "" {
+				continue
+			}
+			seen[objectInfoKey(info)] = info
+		}
+	}
+	for _, s := range ks.Services {
+		add(servicesObjectInfo(s.K8sServices)...)
+		for _, r := range s.Routes {
+			add(r.Ingress)
+		}
+	}
+	add(ks.PolicySources()...)
+	for _, c := range ks.Consumers {
+		add(consumerObjectInfo(&c.K8sKongConsumer))
+	}
+	for _, p := range ks.Plugins {
+		add(p.K8sParent)
+	}
+	for i := range ks.ConsumerGroups {
+		add(ks.ConsumerGroups[i].K8sObjectInfo())
+		for _, p := range ks.ConsumerGroups[i].Plugins {
+			add(p.K8sParent)
+		}
+	}
+	for i := range ks.Vaults {
+		add(ks.Vaults[i].K8sObjectInfo())
+	}
+
+	keys := make([]string, 0, len(seen))
+	for key := range seen {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	sources := make([]util.K8sObjectInfo, 0, len(keys))
+	for _, key := range keys {
+		sources = append(sources, seen[key])
+	}
+	return sources
+}
+
+// PolicySources provides the KongServicePolicies and KongUpstreamPolicies
+// applied to the services and upstreams of the state, without duplicates.
+func (ks *KongState) PolicySources() []util.K8sObjectInfo {
+	var sources []util.K8sObjectInfo
+	seen := map[string]struct{}{}
+	add := func(info util.K8sObjectInfo) {
+		if _, ok := seen[objectInfoKey(info)]; !ok {
+			seen[objectInfoKey(info)] = struct{}{}
+			sources = append(sources, info)
+		}
+	}
+	for _, s := range ks.Services {
+		if s.ServicePolicy != nil {
+			add(servicePolicyObjectInfo(s.ServicePolicy))
+		}
+	}
+	for _, u := range ks.Upstreams {
+		if u.UpstreamPolicy != nil {
+			add(upstreamPolicyObjectInfo(u.UpstreamPolicy))
+		}
+	}
+	return sources
+}
+
+// entityReferences indicates whether the foreign reference of the provided
+// entity for the given field matches the provided identifier. Entities
+// lacking a usable reference are considered to match, as nothing can be
+// ruled out for them.
+func entityReferences(entity map[string]interface{}, field string, id *string) bool {
+	if id == nil {
+		return true
+	}
+
+	var ref string
+	switch v := entity[field].(type) {
+	case string:
+		ref = v
+	case map[string]interface{}:
+		if name, ok := v["name"].(string); ok {
+			ref = name
+		} else if refID, ok := v["id"].(string); ok {
+			ref = refID
+		}
+	}
+
+	return ref == "" || ref == *id
+}
+
+// servicesObjectInfo describes the provided Kubernetes Services. The kind is
+// always set as objects retrieved from the store are not guaranteed to
+// include it.
+func servicesObjectInfo(services map[string]*corev1.Service) []util.K8sObjectInfo {
+	infos := make([]util.K8sObjectInfo, 0, len(services))
+	for _, svc := range services {
+		info := util.FromK8sObject(svc)
+		info.GroupVersionKind = corev1.SchemeGroupVersion.WithKind("Service")
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		if infos[i].Namespace != infos[j].Namespace {
+			return infos[i].Namespace < infos[j].Namespace
+		}
+		return infos[i].Name < infos[j].Name
+	})
+	return infos
+}
+
+// consumerObjectInfo describes the provided KongConsumer. The kind is always
+// set as objects retrieved from the store are not guaranteed to include it.
+func consumerObjectInfo(consumer *configurationv1.KongConsumer) util.K8sObjectInfo {
+	info := util.FromK8sObject(consumer)
+	info.GroupVersionKind = configurationv1.SchemeGroupVersion.WithKind("KongConsumer")
+	return info
+}
@@ -0,0 +1,165 @@
+package kongstate
+
+import (
+	"testing"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+	configurationv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+)
+
+func TestKongState_SourcesForEntity(t *testing.T) {
+	ingress := util.K8sObjectInfo{Namespace: "default", Name: "ingress"}
+	rateLimitingFoo := util.K8sObjectInfo{Namespace: "default", Name: "rate-limiting-foo"}
+	rateLimitingBar := util.K8sObjectInfo{Namespace: "default", Name: "rate-limiting-bar"}
+
+	state := KongState{
+		Services: []Service{{
+			Service: kong.Service{Name: kong.String("default.svc.80")},
+			Routes: []Route{{
+				Route:   kong.Route{Name: kong.String("default.ingress.00")},
+				Ingress: ingress,
+				Plugins: []kong.Plugin{{Name: kong.String("request-transformer")}},
+			}},
+			K8sServices: map[string]*corev1.Service{
+				"default/svc": {ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "svc"}},
+			},
+		}},
+		Consumers: []Consumer{{
+			Consumer: kong.Consumer{Username: kong.String("alice")},
+			K8sKongConsumer: configurationv1.KongConsumer{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "alice"},
+			},
+		}},
+		Plugins: []Plugin{
+			{
+				Plugin: kong.Plugin{
+					Name:  kong.String("rate-limiting"),
+					Route: &kong.Route{ID: kong.String("default.foo.00")},
+				},
+				K8sParent: rateLimitingFoo,
+			},
+			{
+				Plugin: kong.Plugin{
+					Name:  kong.String("rate-limiting"),
+					Route: &kong.Route{ID: kong.String("default.bar.00")},
+				},
+				K8sParent: rateLimitingBar,
+			},
+		},
+	}
+
+	for _, tt := range []struct {
+		name       string
+		entityType string
+		entityName string
+		entity     map[string]interface{}
+		want       []util.K8sObjectInfo
+	}{
+		{
+			name:       "routes are attributed to the object they were translated from",
+			entityType: "route",
+			entityName: "default.ingress.00",
+			want:       []util.K8sObjectInfo{ingress},
+		},
+		{
+			name:       "plural entity types are supported",
+			entityType: "routes",
+			entityName: "default.ingress.00",
+			want:       []util.K8sObjectInfo{ingress},
+		},
+		{
+			name:       "services are attributed to the kubernetes services",
+			entityType: "service",
+			entityName: "default.svc.80",
+			want: []util.K8sObjectInfo{{
+				Namespace:        "default",
+				Name:             "svc",
+				Annotations:      map[string]string{},
+				GroupVersionKind: corev1.SchemeGroupVersion.WithKind("Service"),
+			}},
+		},
+		{
+			name:       "consumers are attributed to the kong consumer",
+			entityType: "consumer",
+			entityName: "alice",
+			want: []util.K8sObjectInfo{{
+				Namespace:        "default",
+				Name:             "alice",
+				Annotations:      map[string]string{},
+				GroupVersionKind: configurationv1.SchemeGroupVersion.WithKind("KongConsumer"),
+			}},
+		},
+		{
+			name:       "plugins are narrowed down by their references",
+			entityType: "plugin",
+			entityName: "rate-limiting",
+			entity:     map[string]interface{}{"route": "default.bar.00"},
+			want:       []util.K8sObjectInfo{rateLimitingBar},
+		},
+		{
+			name:       "plugins without usable references are attributed to all candidates",
+			entityType: "plugin",
+			entityName: "rate-limiting",
+			want:       []util.K8sObjectInfo{rateLimitingFoo, rateLimitingBar},
+		},
+		{
+			name:       "plugins of routes are attributed to the objects of the routes",
+			entityType: "plugin",
+			entityName: "request-transformer",
+			entity:     map[string]interface{}{"route": map[string]interface{}{"name": "default.ingress.00"}},
+			want:       []util.K8sObjectInfo{ingress},
+		},
+		{
+			name:       "unknown entities have no sources",
+			entityType: "route",
+			entityName: "default.missing.00",
+			want:       nil,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, state.SourcesForEntity(tt.entityType, tt.entityName, tt.entity))
+		})
+	}
+}
+
+func TestKongState_Sources(t *testing.T) {
+	ingressGVK := netv1.SchemeGroupVersion.WithKind("Ingress")
+	pluginGVK := configurationv1.SchemeGroupVersion.WithKind("KongPlugin")
+	ingress := util.K8sObjectInfo{Namespace: "default", Name: "ingress", GroupVersionKind: ingressGVK}
+	plugin := util.K8sObjectInfo{Namespace: "default", Name: "rate-limiting", GroupVersionKind: pluginGVK}
+
+	state := KongState{
+		Services: []Service{{
+			Service: kong.Service{Name: kong.String("default.svc.80")},
+			Routes: []Route{
+				{Route: kong.Route{Name: kong.String("default.ingress.00")}, Ingress: ingress},
+				{Route: kong.Route{Name: kong.String("default.ingress.01")}, Ingress: ingress},
+			},
+			K8sServices: map[string]*corev1.Service{
+				"default/svc": {ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "svc"}},
+			},
+		}},
+		Plugins: []Plugin{
+			{Plugin: kong.Plugin{Name: kong.String("rate-limiting")}, K8sParent: plugin},
+			{Plugin: kong.Plugin{Name: kong.String("rate-limiting")}, K8sParent: plugin},
+			{Plugin: kong.Plugin{Name: kong.String("cors")}},
+		},
+	}
+
+	assert.Equal(t, []util.K8sObjectInfo{
+		ingress,
+		plugin,
+		{
+			Namespace:        "default",
+			Name:             "svc",
+			Annotations:      map[string]string{},
+			GroupVersionKind: corev1.SchemeGroupVersion.WithKind("Service"),
+		},
+	}, state.Sources())
+}
@@ -2,7 +2,6 @@ package kongstate
 
 import (
 	"fmt"
-	"strconv"
 	"strings"
 
 	"github.com/blang/semver/v4"
@@ -12,7 +11,8 @@ import (
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
-	"github.com/kong/kubernetes-ingress-controller/v2/internal/validation/consumers/credentials"
+	configurationv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+	configurationv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
 )
 
 // KongState holds the configuration that should be applied to Kong.
@@ -23,6 +23,8 @@ type KongState struct {
 	CACertificates []kong.CACertificate
 	Plugins        []Plugin
 	Consumers      []Consumer
+	ConsumerGroups []ConsumerGroup
+	Vaults         []Vault
 	Version        semver.Version
 }
 
@@ -45,16 +47,32 @@ func (ks *KongState) SanitizedCopy() *KongState {
 			}
 			return
 		}(),
+		ConsumerGroups: ks.ConsumerGroups,
+		Vaults: func() (res []Vault) {
+			for _, v := range ks.Vaults {
+				res = append(res, *v.SanitizedCopy())
+			}
+			return
+		}(),
 	}
 }
 
-func (ks *KongState) FillConsumersAndCredentials(log logrus.FieldLogger, s store.Storer) {
+// FillConsumersAndCredentials generates the Kong consumers of the
+// KongConsumers and the credentials of their Secrets. The results of resolving
+// the credentials are recorded on the consumers, and the KongConsumers which no
+// consumer could be generated from are returned.
+func (ks *KongState) FillConsumersAndCredentials(log logrus.FieldLogger, s store.Storer) []ObjectFailure {
 	consumerIndex := make(map[string]Consumer)
+	var failures []ObjectFailure
 
 	// build consumer index
 	for _, consumer := range s.ListKongConsumers() {
 		var c Consumer
 		if consumer.Username == "" && consumer.CustomID == "" {
+			failures = append(failures, ObjectFailure{
+				Object: consumerObjectInfo(consumer),
+				Reason: "KongConsumer must have a username or a custom_id",
+			})
 			continue
 		}
 		if consumer.Username != "" {
@@ -65,62 +83,27 @@ func (ks *KongState) FillConsumersAndCredentials(log logrus.FieldLogger, s store
 		}
 		c.K8sKongConsumer = *consumer
 
-		log = log.WithFields(logrus.Fields{
+		consumerLog := log.WithFields(logrus.Fields{
 			"kongconsumer_name":      consumer.Name,
 			"kongconsumer_namespace": consumer.Namespace,
 		})
+		seen := make(map[string]struct{}, len(consumer.Credentials))
 		for _, cred := range consumer.Credentials {
-			log = log.WithFields(logrus.Fields{
+			credLog := consumerLog.WithFields(logrus.Fields{
 				"secret_name":      cred,
 				"secret_namespace": consumer.Namespace,
 			})
-			secret, err := s.GetSecret(consumer.Namespace, cred)
-			if err != nil {
-				log.WithError(err).Error("failed to fetch secret")
-				continue
-			}
-			credConfig := map[string]interface{}{}
-			for k, v := range secret.Data {
-				// TODO populate these based on schema from Kong
-				// and remove this workaround
-				if k == "redirect_uris" {
-					credConfig[k] = strings.Split(string(v), ",")
-					continue
-				}
-				// TODO this is a kongCredType-agnostic mutation that should only apply to Oauth2 credentials.
-				// However, the credential-specific code after deals only in interface{}s, and we can't fix individual
-				// keys. To handle this properly we'd need to refactor the types used in all following code.
-				if k == "hash_secret" {
-					boolVal, err := strconv.ParseBool(string(v))
-					if err != nil {
-						log.WithError(err).Errorf("failed to parse hash_secret to bool. defaulting to false")
-						credConfig[k] = false
-					} else {
-						credConfig[k] = boolVal
-					}
-					continue
-				}
-				credConfig[k] = string(v)
-			}
-			credType, ok := credConfig["kongCredType"].(string)
-			if !ok {
-				err := fmt.Errorf("invalid credType: %v", credType)
-				log.WithError(err).Errorf("failed to provision credential")
-			}
-			if !credentials.SupportedTypes.Has(credType) {
-				err := fmt.Errorf("invalid credType: %v", credType)
-				log.WithError(err).Error("failed to provision credential")
-				continue
-			}
-			if len(credConfig) <= 1 { // 1 key of credType itself
-				log.Error("failed to provision credential: empty secret")
-				continue
-			}
-			err = c.SetCredential(credType, credConfig)
-			if err != nil {
-				log.WithError(err).Errorf("failed to provision credential")
+			if _, ok := seen[cred]; ok {
+				credLog.Error("failed to provision credential: secret is referenced more than once")
+				c.K8sCredentials = append(c.K8sCredentials, configurationv1.ConsumerCredentialStatus{
+					SecretName: cred,
+					Reason:     configurationv1.CredentialReasonDuplicate,
+					Message:    "the Secret is referenced more than once by the consumer",
+				})
 				continue
 			}
+			seen[cred] = struct{}{}
+			c.K8sCredentials = append(c.K8sCredentials, c.setCredentialFromSecret(credLog, s, cred))
 		}
 
 		consumerIndex[consumer.Namespace+"/"+consumer.Name] = c
@@ -130,19 +113,34 @@ func (ks *KongState) FillConsumersAndCredentials(log logrus.FieldLogger, s store
 	for _, c := range consumerIndex {
 		ks.Consumers = append(ks.Consumers, c)
 	}
+	return failures
 }
 
-func (ks *KongState) FillOverrides(log logrus.FieldLogger, s store.Storer) {
+// FillOverrides applies the KongIngresses, KongServicePolicies,
+// KongUpstreamPolicies and annotations attached to the Kubernetes objects of
+// the routes, services and upstreams of the state. The policies which couldn't
+// be applied, and the Services whose annotations name such policies, are
+// returned.
+func (ks *KongState) FillOverrides(log logrus.FieldLogger, s store.Storer) []ObjectFailure {
+	servicePolicies, servicePolicyIndex := newServicePolicyResolver(s)
+	upstreamPolicies, upstreamPolicyIndex := newUpstreamPolicyResolver(s)
+
 	for i := 0; i < len(ks.Services); i++ {
-		// Services
+		// Services. The policies and annotations apply even when the KongIngress
+		// can't be fetched.
 		kongIngress, err := getKongIngressForServices(s, ks.Services[i].K8sServices)
 		if err != nil {
 			log.WithError(err).Errorf("failed to fetch KongIngress resource for Services %s", PrettyPrintServiceList(ks.Services[i].K8sServices))
-			continue
 		}
 
+		var policy *configurationv1beta1.KongServicePolicy
+		if ref := servicePolicies.forServices(ks.Services[i].K8sServices); ref != nil {
+			policy = servicePolicyIndex[ref.key()]
+		}
+		ks.Services[i].ServicePolicy = policy
+
 		for _, svc := range ks.Services[i].K8sServices {
-			ks.Services[i].override(kongIngress, svc.Annotations)
+			ks.Services[i].override(kongIngress, policy, svc.Annotations)
 		}
 
 		// Routes
@@ -164,13 +162,20 @@ func (ks *KongState) FillOverrides(log logrus.FieldLogger, s store.Storer) {
 		kongIngress, err := getKongIngressForServices(s, ks.Upstreams[i].Service.K8sServices)
 		if err != nil {
 			log.WithError(err).Errorf("failed to fetch KongIngress resource for Services %s", PrettyPrintServiceList(ks.Upstreams[i].Service.K8sServices))
-			continue
 		}
 
+		var policy *configurationv1beta1.KongUpstreamPolicy
+		if ref := upstreamPolicies.forServices(ks.Upstreams[i].Service.K8sServices); ref != nil {
+			policy = upstreamPolicyIndex[ref.key()]
+		}
+		ks.Upstreams[i].UpstreamPolicy = policy
+
 		for _, svc := range ks.Upstreams[i].Service.K8sServices {
-			ks.Upstreams[i].override(kongIngress, svc.Annotations)
+			ks.Upstreams[i].override(kongIngress, policy, svc.Annotations)
 		}
 	}
+
+	return append(servicePolicies.failures, upstreamPolicies.failures...)
 }
 
 func (ks *KongState) getPluginRelations() map[string]util.ForeignRelations {
@@ -231,18 +236,25 @@ func (ks *KongState) getPluginRelations() map[string]util.ForeignRelations {
 	return pluginRels
 }
 
-func buildPlugins(log logrus.FieldLogger, s store.Storer, pluginRels map[string]util.ForeignRelations) []Plugin {
-	var plugins []Plugin
+func buildPlugins(log logrus.FieldLogger, s store.Storer, pluginRels map[string]util.ForeignRelations) ([]Plugin, []ObjectFailure) {
+	var (
+		plugins  []Plugin
+		failures []ObjectFailure
+	)
 
 	for pluginIdentifier, relations := range pluginRels {
 		identifier := strings.Split(pluginIdentifier, ":")
 		namespace, kongPluginName := identifier[0], identifier[1]
-		plugin, err := getPlugin(s, namespace, kongPluginName)
+		plugin, source, err := getPlugin(s, namespace, kongPluginName)
 		if err != nil {
 			log.WithFields(logrus.Fields{
 				"kongplugin_name":      kongPluginName,
 				"kongplugin_namespace": namespace,
 			}).WithError(err).Errorf("failed to fetch KongPlugin")
+			// the plugin is only known when it exists but is invalid.
+			if !source.GroupVersionKind.Empty() {
+				failures = append(failures, ObjectFailure{Object: source, Reason: err.Error()})
+			}
 			continue
 		}
 
@@ -259,25 +271,26 @@ func buildPlugins(log logrus.FieldLogger, s store.Storer, pluginRels map[string]
 			if rel.Consumer != "" {
 				plugin.Consumer = &kong.Consumer{ID: kong.String(rel.Consumer)}
 			}
-			plugins = append(plugins, Plugin{plugin})
+			plugins = append(plugins, Plugin{Plugin: plugin, K8sParent: source})
 		}
 	}
 
-	globalPlugins, err := globalPlugins(log, s)
+	globalPlugins, globalFailures, err := globalPlugins(log, s)
 	if err != nil {
 		log.WithError(err).Error("failed to fetch global plugins")
 	}
 	plugins = append(plugins, globalPlugins...)
+	failures = append(failures, globalFailures...)
 
-	return plugins
+	return plugins, failures
 }
 
-func globalPlugins(log logrus.FieldLogger, s store.Storer) ([]Plugin, error) {
+func globalPlugins(log logrus.FieldLogger, s store.Storer) ([]Plugin, []ObjectFailure, error) {
 	// removed as of 0.10.0
 	// only retrieved now to warn users
 	globalPlugins, err := s.ListGlobalKongPlugins()
 	if err != nil {
-		return nil, fmt.Errorf("error listing global KongPlugins: %w", err)
+		return nil, nil, fmt.Errorf("error listing global KongPlugins: %w", err)
 	}
 	if len(globalPlugins) > 0 {
 		log.Warning("global KongPlugins found. These are no longer applied and",
@@ -294,8 +307,9 @@ func globalPlugins(log logrus.FieldLogger, s store.Storer) ([]Plugin, error) {
 
 	globalClusterPlugins, err := s.ListGlobalKongClusterPlugins()
 	if err != nil {
-		return nil, fmt.Errorf("error listing global KongClusterPlugins: %w", err)
+		return nil, nil, fmt.Errorf("error listing global KongClusterPlugins: %w", err)
 	}
+	var failures []ObjectFailure
 	for i := 0; i < len(globalClusterPlugins); i++ {
 		k8sPlugin := *globalClusterPlugins[i]
 		pluginName := k8sPlugin.PluginName
@@ -315,12 +329,14 @@ func globalPlugins(log logrus.FieldLogger, s store.Storer) ([]Plugin, error) {
 		}
 		if plugin, err := kongPluginFromK8SClusterPlugin(s, k8sPlugin); err == nil {
 			res[pluginName] = Plugin{
-				Plugin: plugin,
+				Plugin:    plugin,
+				K8sParent: clusterPluginObjectInfo(&k8sPlugin),
 			}
 		} else {
 			log.WithFields(logrus.Fields{
 				"kongclusterplugin_name": k8sPlugin.Name,
 			}).WithError(err).Error("failed to generate configuration from KongClusterPlugin")
+			failures = append(failures, ObjectFailure{Object: clusterPluginObjectInfo(&k8sPlugin), Reason: err.Error()})
 		}
 	}
 	for _, plugin := range duplicates {
@@ -330,9 +346,15 @@ func globalPlugins(log logrus.FieldLogger, s store.Storer) ([]Plugin, error) {
 	for _, p := range res {
 		plugins = append(plugins, p)
 	}
-	return plugins, nil
+	return plugins, failures, nil
 }
 
-func (ks *KongState) FillPlugins(log logrus.FieldLogger, s store.Storer) {
-	ks.Plugins = buildPlugins(log, s, ks.getPluginRelations())
+// FillPlugins generates the Kong plugins of the KongPlugins and
+// KongClusterPlugins the entities of the state refer to, as well as the global
+// KongClusterPlugins. The plugins which configuration couldn't be generated
+// from are returned.
+func (ks *KongState) FillPlugins(log logrus.FieldLogger, s store.Storer) []ObjectFailure {
+	var failures []ObjectFailure
+	ks.Plugins, failures = buildPlugins(log, s, ks.getPluginRelations())
+	return failures
 }
@@ -24,7 +24,7 @@ func TestKongState_SanitizedCopy(t *testing.T) {
 		want KongState
 	}{
 		{
-			name: "sanitizes all consumers and certificates and copies all other fields",
+			name: "sanitizes all consumers, certificates and vaults and copies all other fields",
 			in: KongState{
 				Services:       []Service{{Service: kong.Service{ID: kong.String("1")}}},
 				Upstreams:      []Upstream{{Upstream: kong.Upstream{ID: kong.String("1")}}},
@@ -34,6 +34,8 @@ func TestKongState_SanitizedCopy(t *testing.T) {
 				Consumers: []Consumer{{
 					KeyAuths: []*KeyAuth{{kong.KeyAuth{ID: kong.String("1"), Key: kong.String("secret")}}},
 				}},
+				ConsumerGroups: []ConsumerGroup{{Name: "gold"}},
+				Vaults:         []Vault{{Name: "aws", Prefix: "aws", Config: kong.Configuration{"secret_key": "secret"}}},
 			},
 			want: KongState{
 				Services:       []Service{{Service: kong.Service{ID: kong.String("1")}}},
@@ -44,6 +46,8 @@ func TestKongState_SanitizedCopy(t *testing.T) {
 				Consumers: []Consumer{{
 					KeyAuths: []*KeyAuth{{kong.KeyAuth{ID: kong.String("1"), Key: redactedString}}},
 				}},
+				ConsumerGroups: []ConsumerGroup{{Name: "gold"}},
+				Vaults:         []Vault{{Name: "aws", Prefix: "aws", Config: kong.Configuration{"secret_key": "REDACTED"}}},
 			},
 		},
 	} {
@@ -392,3 +396,74 @@ func Test_FillConsumersAndCredentials(t *testing.T) {
 		assert.Equal(t, want.Consumers[0].Oauth2Creds[0].RedirectURIs, state.Consumers[0].Oauth2Creds[0].RedirectURIs)
 	})
 }
+
+func Test_FillConsumersAndCredentialsReportsCredentials(t *testing.T) {
+	secret := func(name string, data map[string]string) *corev1.Secret {
+		s := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Data:       map[string][]byte{},
+		}
+		for k, v := range data {
+			s.Data[k] = []byte(v)
+		}
+		return s
+	}
+	store, err := store.NewFakeStore(store.FakeObjects{
+		Secrets: []*corev1.Secret{
+			secret("key", map[string]string{"kongCredType": "key-auth", "key": "whatever"}),
+			secret("unknown-type", map[string]string{"kongCredType": "magic-auth", "key": "whatever"}),
+			secret("no-type", map[string]string{"key": "whatever"}),
+			secret("no-password", map[string]string{"kongCredType": "basic-auth", "username": "foo"}),
+		},
+		KongConsumers: []*configurationv1.KongConsumer{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "foo",
+					Namespace:   "default",
+					Annotations: map[string]string{"kubernetes.io/ingress.class": annotations.DefaultIngressClass},
+				},
+				Username:    "foo",
+				Credentials: []string{"key", "missing", "unknown-type", "no-type", "no-password", "key"},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "anonymous",
+					Namespace:   "default",
+					Annotations: map[string]string{"kubernetes.io/ingress.class": annotations.DefaultIngressClass},
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	state := KongState{}
+	failures := state.FillConsumersAndCredentials(logrus.New(), store)
+
+	t.Log("verifying that consumers without a username or custom_id are reported")
+	assert.Len(t, failures, 1)
+	assert.Equal(t, "anonymous", failures[0].Object.Name)
+	assert.Equal(t, "KongConsumer", failures[0].Object.GroupVersionKind.Kind)
+
+	t.Log("verifying that only the valid credential is configured")
+	assert.Len(t, state.Consumers, 1)
+	consumer := state.Consumers[0]
+	assert.Len(t, consumer.KeyAuths, 1)
+	assert.Empty(t, consumer.BasicAuths)
+
+	t.Log("verifying that the result of resolving each credential is recorded")
+	reasons := make([]string, 0, len(consumer.K8sCredentials))
+	for _, cred := range consumer.K8sCredentials {
+		reasons = append(reasons, cred.SecretName+": "+cred.Reason)
+	}
+	assert.Equal(t, []string{
+		"key: " + configurationv1.CredentialReasonFound,
+		"missing: " + configurationv1.CredentialReasonSecretNotFound,
+		"unknown-type: " + configurationv1.CredentialReasonInvalidType,
+		"no-type: " + configurationv1.CredentialReasonInvalidType,
+		"no-password: " + configurationv1.CredentialReasonMissingFields,
+		"key: " + configurationv1.CredentialReasonDuplicate,
+	}, reasons)
+	assert.Equal(t, "key-auth", consumer.K8sCredentials[0].Type)
+	assert.Empty(t, consumer.K8sCredentials[0].Message)
+	assert.Equal(t, "missing required field(s): password", consumer.K8sCredentials[4].Message)
+}
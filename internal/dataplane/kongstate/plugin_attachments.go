@@ -0,0 +1,115 @@
+package kongstate
+
+import (
+	"sort"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+// PluginAttachment describes the Kubernetes objects which the Kong plugins
+// generated from a KongPlugin or KongClusterPlugin are attached to.
+type PluginAttachment struct {
+	// Plugin is the KongPlugin or KongClusterPlugin.
+	Plugin util.K8sObjectInfo
+
+	// Parents are the objects which the routes, services, consumers and
+	// consumer groups the plugin is configured on were generated from, ordered
+	// by kind, namespace and name. Plugins which apply globally have no
+	// parents.
+	Parents []util.K8sObjectInfo
+}
+
+// PluginAttachments provides the objects which each KongPlugin and
+// KongClusterPlugin of the state is attached to, ordered by plugin kind,
+// namespace and name. The Kong plugins of the state are generated from the
+// relations computed by getPluginRelations(), whose route, service and consumer
+// references are mapped back to the objects they were generated from. The
+// plugins of consumer groups are attached to their KongConsumerGroups.
+func (ks *KongState) PluginAttachments() []PluginAttachment {
+	routes := map[string]util.K8sObjectInfo{}
+	services := map[string][]util.K8sObjectInfo{}
+	for _, s := range ks.Services {
+		if s.Name != nil {
+			services[*s.Name] = servicesObjectInfo(s.K8sServices)
+		}
+		for _, r := range s.Routes {
+			if r.Name != nil {
+				routes[*r.Name] = r.Ingress
+			}
+		}
+	}
+	consumers := map[string]util.K8sObjectInfo{}
+	for _, c := range ks.Consumers {
+		if c.Username != nil {
+			consumers[*c.Username] = consumerObjectInfo(&c.K8sKongConsumer)
+		}
+	}
+
+	attachments := map[string]*PluginAttachment{}
+	parents := map[string]map[string]struct{}{}
+	attach := func(plugin util.K8sObjectInfo, candidates ...util.K8sObjectInfo) {
+		key := objectInfoKey(plugin)
+		attachment, ok := attachments[key]
+		if !ok {
+			attachment = &PluginAttachment{Plugin: plugin}
+			attachments[key] = attachment
+			parents[key] = map[string]struct{}{}
+		}
+		for _, parent := range candidates {
+			// objects whose kind is unknown can't be referred to.
+			if parent.Name == "" || parent.GroupVersionKind.Empty() {
+				continue
+			}
+			parentKey := objectInfoKey(parent)
+			if _, ok := parents[key][parentKey]; ok {
+				continue
+			}
+			parents[key][parentKey] = struct{}{}
+			attachment.Parents = append(attachment.Parents, parent)
+		}
+	}
+	for _, p := range ks.Plugins {
+		if p.K8sParent.GroupVersionKind.Empty() {
+			continue
+		}
+		var candidates []util.K8sObjectInfo
+		if p.Route != nil && p.Route.ID != nil {
+			candidates = append(candidates, routes[*p.Route.ID])
+		}
+		if p.Service != nil && p.Service.ID != nil {
+			candidates = append(candidates, services[*p.Service.ID]...)
+		}
+		if p.Consumer != nil && p.Consumer.ID != nil {
+			candidates = append(candidates, consumers[*p.Consumer.ID])
+		}
+		attach(p.K8sParent, candidates...)
+	}
+	for i := range ks.ConsumerGroups {
+		for _, p := range ks.ConsumerGroups[i].Plugins {
+			if !p.K8sParent.GroupVersionKind.Empty() {
+				attach(p.K8sParent, ks.ConsumerGroups[i].K8sObjectInfo())
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(attachments))
+	for key := range attachments {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	result := make([]PluginAttachment, 0, len(keys))
+	for _, key := range keys {
+		attachment := attachments[key]
+		sort.Slice(attachment.Parents, func(i, j int) bool {
+			return objectInfoKey(attachment.Parents[i]) < objectInfoKey(attachment.Parents[j])
+		})
+		result = append(result, *attachment)
+	}
+	return result
+}
+
+// objectInfoKey produces a key which identifies the described object, and
+// orders objects by kind, namespace and name.
+func objectInfoKey(info util.K8sObjectInfo) string {
+	return info.GroupVersionKind.GroupKind().String() + "/" + info.Namespace + "/" + info.Name
+}
@@ -0,0 +1,125 @@
+package kongstate
+
+import (
+	"testing"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+	configurationv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+	configurationv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
+)
+
+func TestKongState_PluginAttachments(t *testing.T) {
+	ingress := util.K8sObjectInfo{
+		Namespace:        "default",
+		Name:             "ingress",
+		GroupVersionKind: netv1.SchemeGroupVersion.WithKind("Ingress"),
+	}
+	rateLimiting := util.K8sObjectInfo{
+		Namespace:        "default",
+		Name:             "rate-limiting",
+		GroupVersionKind: configurationv1.SchemeGroupVersion.WithKind("KongPlugin"),
+	}
+	cors := util.K8sObjectInfo{
+		Name:             "cors",
+		GroupVersionKind: configurationv1.SchemeGroupVersion.WithKind("KongClusterPlugin"),
+	}
+	prometheus := util.K8sObjectInfo{
+		Name:             "prometheus",
+		GroupVersionKind: configurationv1.SchemeGroupVersion.WithKind("KongClusterPlugin"),
+	}
+
+	state := KongState{
+		Services: []Service{{
+			Service: kong.Service{Name: kong.String("default.svc.80")},
+			Routes: []Route{
+				{Route: kong.Route{Name: kong.String("default.ingress.00")}, Ingress: ingress},
+				{Route: kong.Route{Name: kong.String("default.ingress.01")}, Ingress: ingress},
+			},
+			K8sServices: map[string]*corev1.Service{
+				"default/svc": {ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "svc"}},
+			},
+		}},
+		Consumers: []Consumer{{
+			Consumer: kong.Consumer{Username: kong.String("alice")},
+			K8sKongConsumer: configurationv1.KongConsumer{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "alice"},
+			},
+		}},
+		ConsumerGroups: []ConsumerGroup{{
+			Name: "gold",
+			Plugins: []Plugin{{
+				Plugin:    kong.Plugin{Name: kong.String("rate-limiting")},
+				K8sParent: rateLimiting,
+			}},
+			K8sKongConsumerGroup: configurationv1beta1.KongConsumerGroup{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gold"},
+			},
+		}},
+		Plugins: []Plugin{
+			{
+				Plugin:    kong.Plugin{Name: kong.String("rate-limiting"), Route: &kong.Route{ID: kong.String("default.ingress.00")}},
+				K8sParent: rateLimiting,
+			},
+			{
+				Plugin:    kong.Plugin{Name: kong.String("rate-limiting"), Route: &kong.Route{ID: kong.String("default.ingress.01")}},
+				K8sParent: rateLimiting,
+			},
+			{
+				Plugin: kong.Plugin{
+					Name:     kong.String("rate-limiting"),
+					Service:  &kong.Service{ID: kong.String("default.svc.80")},
+					Consumer: &kong.Consumer{ID: kong.String("alice")},
+				},
+				K8sParent: rateLimiting,
+			},
+			{
+				Plugin:    kong.Plugin{Name: kong.String("cors"), Route: &kong.Route{ID: kong.String("default.ingress.00")}},
+				K8sParent: cors,
+			},
+			{
+				Plugin:    kong.Plugin{Name: kong.String("prometheus")},
+				K8sParent: prometheus,
+			},
+			{
+				// plugins generated from unknown objects are not reported.
+				Plugin: kong.Plugin{Name: kong.String("key-auth")},
+			},
+		},
+	}
+
+	attachments := state.PluginAttachments()
+	assert.Equal(t, []PluginAttachment{
+		{Plugin: cors, Parents: []util.K8sObjectInfo{ingress}},
+		{Plugin: prometheus},
+		{
+			Plugin: rateLimiting,
+			Parents: []util.K8sObjectInfo{
+				ingress,
+				{
+					Namespace:        "default",
+					Name:             "alice",
+					Annotations:      map[string]string{},
+					GroupVersionKind: configurationv1.SchemeGroupVersion.WithKind("KongConsumer"),
+				},
+				{
+					Namespace:        "default",
+					Name:             "gold",
+					Annotations:      map[string]string{},
+					GroupVersionKind: configurationv1beta1.SchemeGroupVersion.WithKind("KongConsumerGroup"),
+				},
+				{
+					Namespace:        "default",
+					Name:             "svc",
+					Annotations:      map[string]string{},
+					GroupVersionKind: corev1.SchemeGroupVersion.WithKind("Service"),
+				},
+			},
+		},
+	}, attachments)
+}
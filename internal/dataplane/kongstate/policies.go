@@ -0,0 +1,228 @@
+package kongstate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+	policiesvalidation "github.com/kong/kubernetes-ingress-controller/v2/internal/validation/policies"
+	configurationv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
+)
+
+// policyRef is a KongUpstreamPolicy or a KongServicePolicy, along with its
+// targetRef.
+type policyRef struct {
+	meta      *metav1.ObjectMeta
+	info      util.K8sObjectInfo
+	targetRef *configurationv1beta1.PolicyTargetReference
+}
+
+func (p *policyRef) key() string {
+	return p.meta.Namespace + "/" + p.meta.Name
+}
+
+// policyResolver determines which policy of a kind applies to Services. A
+// Service is attached to a policy either by an annotation naming the policy
+// or by the targetRef of the policy. When several policies are attached to a
+// Service, the one named by the annotation applies, otherwise the oldest one.
+// The policies which don't apply, and the Services whose annotation names a
+// policy which can't apply, are reported as failures.
+type policyResolver struct {
+	kind     string
+	extract  func(anns map[string]string) string
+	byKey    map[string]*policyRef
+	byTarget map[string][]*policyRef
+	invalid  map[string]struct{}
+
+	resolved map[string]*policyRef
+	failures []ObjectFailure
+	failed   map[string]struct{}
+}
+
+// newPolicyResolver indexes the provided policies of the provided kind. The
+// policies which are invalid according to the provided function are reported
+// as failures and never apply.
+func newPolicyResolver(
+	kind string,
+	extract func(anns map[string]string) string,
+	policies []*policyRef,
+	validate func(p *policyRef) (bool, string),
+) *policyResolver {
+	r := &policyResolver{
+		kind:     kind,
+		extract:  extract,
+		byKey:    make(map[string]*policyRef, len(policies)),
+		byTarget: make(map[string][]*policyRef),
+		invalid:  make(map[string]struct{}),
+		resolved: make(map[string]*policyRef),
+		failed:   make(map[string]struct{}),
+	}
+
+	sort.SliceStable(policies, func(i, j int) bool {
+		a, b := policies[i].meta, policies[j].meta
+		if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+			return a.CreationTimestamp.Before(&b.CreationTimestamp)
+		}
+		return policies[i].key() < policies[j].key()
+	})
+	for _, p := range policies {
+		if ok, msg := validate(p); !ok {
+			r.fail(p, msg)
+			r.invalid[p.key()] = struct{}{}
+			continue
+		}
+		r.byKey[p.key()] = p
+		if p.targetRef != nil && p.targetRef.Kind == "Service" {
+			target := p.meta.Namespace + "/" + p.targetRef.Name
+			r.byTarget[target] = append(r.byTarget[target], p)
+		}
+	}
+	return r
+}
+
+// forServices provides the policy which applies to the Kong entity generated
+// for the provided Services, if any. All the Services attached to a policy
+// must be attached to the same one, otherwise no policy applies.
+func (r *policyResolver) forServices(services map[string]*corev1.Service) *policyRef {
+	keys := make([]string, 0, len(services))
+	for key := range services {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var policies []*policyRef
+	seen := map[*policyRef]struct{}{}
+	for _, key := range keys {
+		p := r.forService(services[key])
+		if p == nil {
+			continue
+		}
+		if _, ok := seen[p]; !ok {
+			seen[p] = struct{}{}
+			policies = append(policies, p)
+		}
+	}
+	switch len(policies) {
+	case 0:
+		return nil
+	case 1:
+		return policies[0]
+	}
+
+	var names []string
+	for _, info := range servicesObjectInfo(services) {
+		names = append(names, info.Namespace+"/"+info.Name)
+	}
+	for _, p := range policies {
+		r.fail(p, fmt.Sprintf("the Services %s are backing the same Kong entity but are attached to different %s objects",
+			strings.Join(names, ", "), r.kind))
+	}
+	return nil
+}
+
+// forService provides the policy which applies to the provided Service, if any.
+func (r *policyResolver) forService(svc *corev1.Service) *policyRef {
+	key := svc.Namespace + "/" + svc.Name
+	if p, ok := r.resolved[key]; ok {
+		return p
+	}
+
+	var candidates []*policyRef
+	if name := r.extract(svc.Annotations); name != "" {
+		policyKey := svc.Namespace + "/" + name
+		if p, ok := r.byKey[policyKey]; ok {
+			candidates = append(candidates, p)
+		} else if _, ok := r.invalid[policyKey]; ok {
+			r.failService(svc, fmt.Sprintf("%s %s is invalid, it is not applied", r.kind, policyKey))
+		} else {
+			r.failService(svc, fmt.Sprintf("%s %s does not exist, it is not applied", r.kind, policyKey))
+		}
+	}
+	candidates = append(candidates, r.byTarget[key]...)
+
+	var policy *policyRef
+	for _, p := range candidates {
+		if policy == nil {
+			policy = p
+			continue
+		}
+		if p != policy {
+			r.fail(p, fmt.Sprintf("Service %s is already attached to %s %s", key, r.kind, policy.key()))
+		}
+	}
+	r.resolved[key] = policy
+	return policy
+}
+
+// fail reports the provided policy as a failure, once per reason.
+func (r *policyResolver) fail(p *policyRef, reason string) {
+	key := p.key() + "/" + reason
+	if _, ok := r.failed[key]; ok {
+		return
+	}
+	r.failed[key] = struct{}{}
+	r.failures = append(r.failures, ObjectFailure{Object: p.info, Reason: reason})
+}
+
+// failService reports the provided Service as a failure.
+func (r *policyResolver) failService(svc *corev1.Service, reason string) {
+	info := util.FromK8sObject(svc)
+	info.GroupVersionKind = corev1.SchemeGroupVersion.WithKind("Service")
+	r.failures = append(r.failures, ObjectFailure{Object: info, Reason: reason})
+}
+
+// newUpstreamPolicyResolver provides a policyResolver for the
+// KongUpstreamPolicies of the store, along with an index of them.
+func newUpstreamPolicyResolver(s store.Storer) (*policyResolver, map[string]*configurationv1beta1.KongUpstreamPolicy) {
+	index := map[string]*configurationv1beta1.KongUpstreamPolicy{}
+	var refs []*policyRef
+	for _, p := range s.ListKongUpstreamPolicies() {
+		ref := &policyRef{meta: &p.ObjectMeta, info: upstreamPolicyObjectInfo(p), targetRef: p.Spec.TargetRef}
+		index[ref.key()] = p
+		refs = append(refs, ref)
+	}
+	validate := func(p *policyRef) (bool, string) {
+		return policiesvalidation.ValidateKongUpstreamPolicy(index[p.key()])
+	}
+	return newPolicyResolver("KongUpstreamPolicy", annotations.ExtractUpstreamPolicy, refs, validate), index
+}
+
+// newServicePolicyResolver provides a policyResolver for the
+// KongServicePolicies of the store, along with an index of them.
+func newServicePolicyResolver(s store.Storer) (*policyResolver, map[string]*configurationv1beta1.KongServicePolicy) {
+	index := map[string]*configurationv1beta1.KongServicePolicy{}
+	var refs []*policyRef
+	for _, p := range s.ListKongServicePolicies() {
+		ref := &policyRef{meta: &p.ObjectMeta, info: servicePolicyObjectInfo(p), targetRef: p.Spec.TargetRef}
+		index[ref.key()] = p
+		refs = append(refs, ref)
+	}
+	validate := func(p *policyRef) (bool, string) {
+		return policiesvalidation.ValidateKongServicePolicy(index[p.key()])
+	}
+	return newPolicyResolver("KongServicePolicy", annotations.ExtractServicePolicy, refs, validate), index
+}
+
+// upstreamPolicyObjectInfo describes the provided KongUpstreamPolicy. The kind
+// is always set as objects retrieved from the store are not guaranteed to
+// include it.
+func upstreamPolicyObjectInfo(policy *configurationv1beta1.KongUpstreamPolicy) util.K8sObjectInfo {
+	info := util.FromK8sObject(policy)
+	info.GroupVersionKind = configurationv1beta1.SchemeGroupVersion.WithKind("KongUpstreamPolicy")
+	return info
+}
+
+// servicePolicyObjectInfo describes the provided KongServicePolicy. The kind
+// is always set as objects retrieved from the store are not guaranteed to
+// include it.
+func servicePolicyObjectInfo(policy *configurationv1beta1.KongServicePolicy) util.K8sObjectInfo {
+	info := util.FromK8sObject(policy)
+	info.GroupVersionKind = configurationv1beta1.SchemeGroupVersion.WithKind("KongServicePolicy")
+	return info
+}
@@ -0,0 +1,217 @@
+package kongstate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+	configurationv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+	configurationv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
+)
+
+func TestOverrideUpstreamByPolicy(t *testing.T) {
+	upstream := Upstream{Upstream: kong.Upstream{Name: kong.String("foo.com")}}
+	kongIngress := &configurationv1.KongIngress{
+		Upstream: &configurationv1.KongIngressUpstream{
+			Algorithm: kong.String("round-robin"),
+			Slots:     kong.Int(42),
+		},
+	}
+	policy := &configurationv1beta1.KongUpstreamPolicy{
+		Spec: configurationv1beta1.KongUpstreamPolicySpec{
+			Algorithm:      kong.String("consistent-hashing"),
+			HashOn:         &configurationv1beta1.KongUpstreamHash{Header: kong.String("x-user")},
+			HashOnFallback: &configurationv1beta1.KongUpstreamHash{Input: kong.String("ip")},
+			Healthchecks: &kong.Healthcheck{
+				Threshold: kong.Float64(50),
+			},
+		},
+	}
+
+	t.Log("verifying that policies take precedence over KongIngresses, and annotations over policies")
+	upstream.override(kongIngress, policy, map[string]string{"konghq.com/host-header": "example.net"})
+	assert.Equal(t, kong.Upstream{
+		Name:               kong.String("foo.com"),
+		Algorithm:          kong.String("consistent-hashing"),
+		Slots:              kong.Int(42),
+		HashOn:             kong.String("header"),
+		HashOnHeader:       kong.String("x-user"),
+		HashFallback:       kong.String("ip"),
+		HostHeader:         kong.String("example.net"),
+		Healthchecks:       &kong.Healthcheck{Threshold: kong.Float64(50)},
+		HashFallbackHeader: nil,
+	}, upstream.Upstream)
+
+	t.Log("verifying that cookies are used as hashing input")
+	upstream = Upstream{Upstream: kong.Upstream{Name: kong.String("foo.com")}}
+	upstream.override(nil, &configurationv1beta1.KongUpstreamPolicy{
+		Spec: configurationv1beta1.KongUpstreamPolicySpec{
+			Algorithm: kong.String("consistent-hashing"),
+			HashOn:    &configurationv1beta1.KongUpstreamHash{Cookie: kong.String("session"), CookiePath: kong.String("/app")},
+		},
+	}, nil)
+	assert.Equal(t, "cookie", *upstream.HashOn)
+	assert.Equal(t, "session", *upstream.HashOnCookie)
+	assert.Equal(t, "/app", *upstream.HashOnCookiePath)
+	assert.Nil(t, upstream.HashOnHeader)
+}
+
+func TestOverrideServiceByPolicy(t *testing.T) {
+	service := Service{Service: kong.Service{
+		Name:     kong.String("foo"),
+		Protocol: kong.String("http"),
+		Path:     kong.String("/"),
+	}}
+	kongIngress := &configurationv1.KongIngress{
+		Proxy: &configurationv1.KongIngressService{
+			Retries:     kong.Int(5),
+			ReadTimeout: kong.Int(100),
+		},
+	}
+	policy := &configurationv1beta1.KongServicePolicy{
+		Spec: configurationv1beta1.KongServicePolicySpec{
+			Protocol:       kong.String("https"),
+			Path:           kong.String("/api"),
+			ReadTimeout:    kong.Int(2000),
+			ConnectTimeout: kong.Int(1000),
+		},
+	}
+
+	t.Log("verifying that policies take precedence over KongIngresses, and annotations over policies")
+	service.override(kongIngress, policy, map[string]string{"konghq.com/path": "/v2"})
+	assert.Equal(t, kong.Service{
+		Name:           kong.String("foo"),
+		Protocol:       kong.String("https"),
+		Path:           kong.String("/v2"),
+		Retries:        kong.Int(5),
+		ReadTimeout:    kong.Int(2000),
+		ConnectTimeout: kong.Int(1000),
+	}, service.Service)
+
+	t.Log("verifying that the path of grpc services is dropped")
+	policy.Spec.Protocol = kong.String("grpc")
+	service.override(nil, policy, nil)
+	assert.Equal(t, "grpc", *service.Protocol)
+	assert.Nil(t, service.Path)
+}
+
+func TestKongState_FillOverridesPolicies(t *testing.T) {
+	now := time.Now()
+	k8sService := func(name string, anns map[string]string) *corev1.Service {
+		return &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Annotations: anns}}
+	}
+	upstreamPolicy := func(name string, created time.Time, target string, algorithm string) *configurationv1beta1.KongUpstreamPolicy {
+		p := &configurationv1beta1.KongUpstreamPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, CreationTimestamp: metav1.NewTime(created)},
+			Spec:       configurationv1beta1.KongUpstreamPolicySpec{Algorithm: kong.String(algorithm)},
+		}
+		if target != "" {
+			p.Spec.TargetRef = &configurationv1beta1.PolicyTargetReference{Kind: "Service", Name: target}
+		}
+		return p
+	}
+
+	annotated := k8sService("annotated", map[string]string{"konghq.com/upstream-policy": "by-annotation"})
+	targeted := k8sService("targeted", nil)
+	other := k8sService("other", nil)
+	// the KongIngress of the Service doesn't exist.
+	missingKongIngress := k8sService("missing-kongingress", map[string]string{"konghq.com/override": "missing"})
+	missingPolicy := k8sService("missing-policy", map[string]string{"konghq.com/upstream-policy": "missing"})
+	invalidPolicy := k8sService("invalid-policy", map[string]string{"konghq.com/upstream-policy": "invalid"})
+	s, err := store.NewFakeStore(store.FakeObjects{
+		KongUpstreamPolicies: []*configurationv1beta1.KongUpstreamPolicy{
+			upstreamPolicy("by-annotation", now, "", "least-connections"),
+			// targets a Service which is attached to another policy by annotation.
+			upstreamPolicy("also-annotated", now, "annotated", "round-robin"),
+			upstreamPolicy("by-target", now, "targeted", "round-robin"),
+			upstreamPolicy("other", now.Add(time.Minute), "other", "least-connections"),
+			upstreamPolicy("despite-kongingress", now, "missing-kongingress", "least-connections"),
+			{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "invalid"},
+				Spec: configurationv1beta1.KongUpstreamPolicySpec{
+					TargetRef: &configurationv1beta1.PolicyTargetReference{Kind: "Service", Name: "targeted"},
+					HashOn:    &configurationv1beta1.KongUpstreamHash{Input: kong.String("ip")},
+				},
+			},
+		},
+		KongServicePolicies: []*configurationv1beta1.KongServicePolicy{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "timeouts"},
+			Spec: configurationv1beta1.KongServicePolicySpec{
+				TargetRef:   &configurationv1beta1.PolicyTargetReference{Kind: "Service", Name: "targeted"},
+				ReadTimeout: kong.Int(5000),
+			},
+		}},
+	})
+	require.NoError(t, err)
+
+	newService := func(name string, services ...*corev1.Service) Service {
+		svc := Service{
+			Service:     kong.Service{Name: kong.String(name), Protocol: kong.String("http")},
+			K8sServices: map[string]*corev1.Service{},
+		}
+		for _, k8sSvc := range services {
+			svc.K8sServices[k8sSvc.Name] = k8sSvc
+		}
+		return svc
+	}
+	state := KongState{
+		Services: []Service{newService("targeted", targeted)},
+		Upstreams: []Upstream{
+			{Upstream: kong.Upstream{Name: kong.String("annotated")}, Service: newService("annotated", annotated)},
+			{Upstream: kong.Upstream{Name: kong.String("targeted")}, Service: newService("targeted", targeted)},
+			{Upstream: kong.Upstream{Name: kong.String("mixed")}, Service: newService("mixed", targeted, other)},
+			{Upstream: kong.Upstream{Name: kong.String("missing-kongingress")}, Service: newService("missing-kongingress", missingKongIngress)},
+			{Upstream: kong.Upstream{Name: kong.String("missing-policy")}, Service: newService("missing-policy", missingPolicy)},
+			{Upstream: kong.Upstream{Name: kong.String("invalid-policy")}, Service: newService("invalid-policy", invalidPolicy)},
+		},
+	}
+	failures := state.FillOverrides(logrus.New(), s)
+
+	t.Log("verifying that policies are applied to the Services attached to them")
+	assert.Equal(t, "timeouts", state.Services[0].ServicePolicy.Name)
+	assert.Equal(t, 5000, *state.Services[0].ReadTimeout)
+	assert.Equal(t, "by-annotation", state.Upstreams[0].UpstreamPolicy.Name)
+	assert.Equal(t, "least-connections", *state.Upstreams[0].Algorithm)
+	assert.Equal(t, "by-target", state.Upstreams[1].UpstreamPolicy.Name)
+	assert.Equal(t, "round-robin", *state.Upstreams[1].Algorithm)
+
+	t.Log("verifying that no policy is applied to Services backing the same entity with different policies")
+	assert.Nil(t, state.Upstreams[2].UpstreamPolicy)
+	assert.Nil(t, state.Upstreams[2].Algorithm)
+
+	t.Log("verifying that policies are applied even when the KongIngress of the Service can't be fetched")
+	assert.Equal(t, "despite-kongingress", state.Upstreams[3].UpstreamPolicy.Name)
+	assert.Equal(t, "least-connections", *state.Upstreams[3].Algorithm)
+
+	t.Log("verifying that no policy is applied to Services annotated with a policy which can't apply")
+	assert.Nil(t, state.Upstreams[4].UpstreamPolicy)
+	assert.Nil(t, state.Upstreams[5].UpstreamPolicy)
+
+	t.Log("verifying that the policies which couldn't be applied and the Services naming them are reported")
+	reasons := map[string]string{}
+	for _, failure := range failures {
+		reasons[failure.Object.GroupVersionKind.Kind+"/"+failure.Object.Name] = failure.Reason
+	}
+	assert.Equal(t, map[string]string{
+		"KongUpstreamPolicy/invalid":        "hashOn and hashOnFallback require the consistent-hashing algorithm",
+		"KongUpstreamPolicy/also-annotated": "Service default/annotated is already attached to KongUpstreamPolicy default/by-annotation",
+		"KongUpstreamPolicy/by-target":      "the Services default/other, default/targeted are backing the same Kong entity but are attached to different KongUpstreamPolicy objects",
+		"KongUpstreamPolicy/other":          "the Services default/other, default/targeted are backing the same Kong entity but are attached to different KongUpstreamPolicy objects",
+		"Service/missing-policy":            "KongUpstreamPolicy default/missing does not exist, it is not applied",
+		"Service/invalid-policy":            "KongUpstreamPolicy default/invalid is invalid, it is not applied",
+	}, reasons)
+
+	t.Log("verifying that the applied policies are sources of the state")
+	var names []string
+	for _, info := range state.PolicySources() {
+		names = append(names, info.GroupVersionKind.Kind+"/"+info.Name)
+	}
+	assert.Equal(t, []string{"KongServicePolicy/timeouts", "KongUpstreamPolicy/by-annotation", "KongUpstreamPolicy/by-target", "KongUpstreamPolicy/despite-kongingress"}, names)
+}
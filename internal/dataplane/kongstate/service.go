@@ -9,6 +9,7 @@ import (
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
 	configurationv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+	configurationv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
 )
 
 // Services is a list of kongstate.Service objects with sorting enabled based
@@ -48,6 +49,9 @@ type Service struct {
 
 	Backends    []ServiceBackend
 	K8sServices map[string]*corev1.Service
+
+	// ServicePolicy is the KongServicePolicy applied to the service, if any.
+	ServicePolicy *configurationv1beta1.KongServicePolicy
 }
 
 // overrideByKongIngress sets Service fields by KongIngress
@@ -76,6 +80,32 @@ func (s *Service) overrideByKongIngress(kongIngress *configurationv1.KongIngress
 	}
 }
 
+// overrideByPolicy sets Service fields by KongServicePolicy
+func (s *Service) overrideByPolicy(policy *configurationv1beta1.KongServicePolicy) {
+	if policy == nil {
+		return
+	}
+	p := policy.Spec
+	if p.Protocol != nil {
+		s.Protocol = kong.String(*p.Protocol)
+	}
+	if p.Path != nil {
+		s.Path = kong.String(*p.Path)
+	}
+	if p.Retries != nil {
+		s.Retries = kong.Int(*p.Retries)
+	}
+	if p.ConnectTimeout != nil {
+		s.ConnectTimeout = kong.Int(*p.ConnectTimeout)
+	}
+	if p.ReadTimeout != nil {
+		s.ReadTimeout = kong.Int(*p.ReadTimeout)
+	}
+	if p.WriteTimeout != nil {
+		s.WriteTimeout = kong.Int(*p.WriteTimeout)
+	}
+}
+
 func (s *Service) overridePath(anns map[string]string) {
 	if s == nil {
 		return
@@ -112,14 +142,17 @@ func (s *Service) overrideByAnnotation(anns map[string]string) {
 	s.overridePath(anns)
 }
 
-// override sets Service fields by KongIngress first, then by annotation
+// override sets Service fields by KongIngress first, then by KongServicePolicy
+// and finally by annotation
 func (s *Service) override(kongIngress *configurationv1.KongIngress,
+	policy *configurationv1beta1.KongServicePolicy,
 	anns map[string]string) {
 	if s == nil {
 		return
 	}
 
 	s.overrideByKongIngress(kongIngress)
+	s.overrideByPolicy(policy)
 	s.overrideByAnnotation(anns)
 
 	if *s.Protocol == "grpc" || *s.Protocol == "grpcs" {
@@ -361,13 +361,13 @@ func TestOverrideService(t *testing.T) {
 	}
 
 	for _, testcase := range testTable {
-		testcase.inService.override(&testcase.inKongIngresss, testcase.inAnnotation)
+		testcase.inService.override(&testcase.inKongIngresss, nil, testcase.inAnnotation)
 		assert.Equal(testcase.inService, testcase.outService)
 	}
 
 	assert.NotPanics(func() {
 		var nilService *Service
-		nilService.override(nil, nil)
+		nilService.override(nil, nil, nil)
 	})
 }
 
@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"github.com/kong/go-kong/kong"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
 )
 
 type PortMode int
@@ -78,4 +80,18 @@ func (c *Certificate) SanitizedCopy() *Certificate {
 // Plugin represetns a plugin Object in Kong.
 type Plugin struct {
 	kong.Plugin
+
+	// K8sParent is the KongPlugin or KongClusterPlugin the plugin was
+	// generated from.
+	K8sParent util.K8sObjectInfo
+}
+
+// ObjectFailure describes a Kubernetes object which Kong configuration couldn't
+// be generated from.
+type ObjectFailure struct {
+	// Object is the object.
+	Object util.K8sObjectInfo
+
+	// Reason describes the problem.
+	Reason string
 }
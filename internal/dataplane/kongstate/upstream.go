@@ -5,6 +5,7 @@ import (
 
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
 	configurationv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+	configurationv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
 )
 
 // Upstream is a wrapper around Upstream object in Kong.
@@ -13,6 +14,9 @@ type Upstream struct {
 	Targets []Target
 	// Service this upstream is asosciated with.
 	Service Service
+
+	// UpstreamPolicy is the KongUpstreamPolicy applied to the upstream, if any.
+	UpstreamPolicy *configurationv1beta1.KongUpstreamPolicy
 }
 
 func (u *Upstream) overrideHostHeader(anns map[string]string) {
@@ -79,12 +83,65 @@ func (u *Upstream) overrideByKongIngress(kongIngress *configurationv1.KongIngres
 	// TODO https://github.com/Kong/kubernetes-ingress-controller/issues/2075
 }
 
-// override sets Upstream fields by KongIngress first, then by annotation
-func (u *Upstream) override(kongIngress *configurationv1.KongIngress, anns map[string]string) {
+// overrideByPolicy modifies the Kong upstream based on the KongUpstreamPolicy
+// attached to the Kubernetes service.
+func (u *Upstream) overrideByPolicy(policy *configurationv1beta1.KongUpstreamPolicy) {
+	if u == nil || policy == nil {
+		return
+	}
+
+	p := policy.Spec
+	if p.Algorithm != nil {
+		u.Algorithm = kong.String(*p.Algorithm)
+	}
+	if p.Slots != nil {
+		u.Slots = kong.Int(*p.Slots)
+	}
+	if p.Healthchecks != nil {
+		u.Healthchecks = p.Healthchecks.DeepCopy()
+	}
+	if p.HashOn != nil {
+		hashOn, header := upstreamHashInput(p.HashOn)
+		u.HashOn = kong.String(hashOn)
+		u.HashOnHeader = header
+		if p.HashOn.Cookie != nil {
+			u.HashOnCookie = kong.String(*p.HashOn.Cookie)
+		}
+		if p.HashOn.CookiePath != nil {
+			u.HashOnCookiePath = kong.String(*p.HashOn.CookiePath)
+		}
+	}
+	if p.HashOnFallback != nil {
+		hashFallback, header := upstreamHashInput(p.HashOnFallback)
+		u.HashFallback = kong.String(hashFallback)
+		u.HashFallbackHeader = header
+	}
+}
+
+// upstreamHashInput provides the Kong hashing input of the provided hash of a
+// KongUpstreamPolicy, along with the name of the header for header inputs.
+func upstreamHashInput(hash *configurationv1beta1.KongUpstreamHash) (string, *string) {
+	switch {
+	case hash.Header != nil:
+		return "header", kong.String(*hash.Header)
+	case hash.Cookie != nil:
+		return "cookie", nil
+	case hash.Input != nil:
+		return *hash.Input, nil
+	}
+	return "none", nil
+}
+
+// override sets Upstream fields by KongIngress first, then by
+// KongUpstreamPolicy and finally by annotation
+func (u *Upstream) override(kongIngress *configurationv1.KongIngress,
+	policy *configurationv1beta1.KongUpstreamPolicy,
+	anns map[string]string) {
 	if u == nil {
 		return
 	}
 
 	u.overrideByKongIngress(kongIngress)
+	u.overrideByPolicy(policy)
 	u.overrideByAnnotation(anns)
 }
@@ -72,7 +72,7 @@ func getKongIngressFromIngressAnnotations(s store.Storer, namespace, name string
 }
 
 // getPlugin constructs a plugins from a KongPlugin resource.
-func getPlugin(s store.Storer, namespace, name string) (kong.Plugin, error) {
+func getPlugin(s store.Storer, namespace, name string) (kong.Plugin, util.K8sObjectInfo, error) {
 	var plugin kong.Plugin
 	k8sPlugin, err := s.GetKongPlugin(namespace, name)
 	if err != nil {
@@ -82,26 +82,42 @@ func getPlugin(s store.Storer, namespace, name string) (kong.Plugin, error) {
 			clusterPlugin, err := s.GetKongClusterPlugin(name)
 			// not found
 			if errors.As(err, &store.ErrNotFound{}) {
-				return plugin, errors.New(
+				return plugin, util.K8sObjectInfo{}, errors.New(
 					"no KongPlugin or KongClusterPlugin was found")
 			}
 			if err != nil {
-				return plugin, err
+				return plugin, util.K8sObjectInfo{}, err
 			}
 			if clusterPlugin.PluginName == "" {
-				return plugin, fmt.Errorf("invalid empty 'plugin' property")
+				return plugin, clusterPluginObjectInfo(clusterPlugin), fmt.Errorf("invalid empty 'plugin' property")
 			}
 			plugin, err = kongPluginFromK8SClusterPlugin(s, *clusterPlugin)
-			return plugin, err
+			return plugin, clusterPluginObjectInfo(clusterPlugin), err
 		}
 	}
 	// ignore plugins with no name
 	if k8sPlugin.PluginName == "" {
-		return plugin, fmt.Errorf("invalid empty 'plugin' property")
+		return plugin, pluginObjectInfo(k8sPlugin), fmt.Errorf("invalid empty 'plugin' property")
 	}
 
 	plugin, err = kongPluginFromK8SPlugin(s, *k8sPlugin)
-	return plugin, err
+	return plugin, pluginObjectInfo(k8sPlugin), err
+}
+
+// pluginObjectInfo describes the provided KongPlugin. The kind is always set
+// as objects retrieved from the store are not guaranteed to include it.
+func pluginObjectInfo(plugin *configurationv1.KongPlugin) util.K8sObjectInfo {
+	info := util.FromK8sObject(plugin)
+	info.GroupVersionKind = configurationv1.SchemeGroupVersion.WithKind("KongPlugin")
+	return info
+}
+
+// clusterPluginObjectInfo describes the provided KongClusterPlugin. The kind is
+// always set as objects retrieved from the store are not guaranteed to include it.
+func clusterPluginObjectInfo(plugin *configurationv1.KongClusterPlugin) util.K8sObjectInfo {
+	info := util.FromK8sObject(plugin)
+	info.GroupVersionKind = configurationv1.SchemeGroupVersion.WithKind("KongClusterPlugin")
+	return info
 }
 
 func kongPluginFromK8SClusterPlugin(
@@ -240,10 +256,37 @@ func SecretToConfiguration(
 					"valid JSON nor valid YAML)",
 					reference.Key, namespace, reference.Secret)
 		}
+		for k, v := range config {
+			config[k] = restoreVaultReferences(v)
+		}
 	}
 	return config, nil
 }
 
+// restoreVaultReferences restores the unquoted vault references of a YAML
+// configuration, e.g. {vault://env/my-secret}, which YAML parses as mappings
+// with a single key and no value.
+func restoreVaultReferences(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) == 1 {
+			for k, nested := range v {
+				if ref := "{" + k + "}"; nested == nil && util.IsVaultReference(ref) {
+					return ref
+				}
+			}
+		}
+		for k, nested := range v {
+			v[k] = restoreVaultReferences(nested)
+		}
+	case []interface{}:
+		for i, nested := range v {
+			v[i] = restoreVaultReferences(nested)
+		}
+	}
+	return value
+}
+
 // PrettyPrintServiceList makes a clean printable list of a map of Kubernetes
 // services for the purpose of logging (errors, info, e.t.c.).
 func PrettyPrintServiceList(services map[string]*corev1.Service) string {
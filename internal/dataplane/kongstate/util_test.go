@@ -161,6 +161,8 @@ func TestKongPluginFromK8SPlugin(t *testing.T) {
 				},
 				Data: map[string][]byte{
 					"correlation-id-config": []byte(`{"header_name": "foo"}`),
+					"key-auth-config": []byte("key_names:\n- {vault://env/key-name}\n" +
+						"anonymous: {vault://env/anonymous}\n"),
 				},
 			},
 		},
@@ -221,6 +223,50 @@ func TestKongPluginFromK8SPlugin(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "vault references",
+			args: args{
+				plugin: configurationv1.KongPlugin{
+					PluginName: "correlation-id",
+					Config: apiextensionsv1.JSON{
+						Raw: []byte(`{"header_name": "{vault://env/header-name}"}`),
+					},
+				},
+			},
+			want: kong.Plugin{
+				Name: kong.String("correlation-id"),
+				Config: kong.Configuration{
+					"header_name": "{vault://env/header-name}",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unquoted vault references in YAML secret configuration",
+			args: args{
+				plugin: configurationv1.KongPlugin{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "foo",
+						Namespace: "default",
+					},
+					PluginName: "key-auth",
+					ConfigFrom: &configurationv1.ConfigSource{
+						SecretValue: configurationv1.SecretValueFromSource{
+							Key:    "key-auth-config",
+							Secret: "conf-secret",
+						},
+					},
+				},
+			},
+			want: kong.Plugin{
+				Name: kong.String("key-auth"),
+				Config: kong.Configuration{
+					"key_names": []interface{}{"{vault://env/key-name}"},
+					"anonymous": "{vault://env/anonymous}",
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "missing secret configuration",
 			args: args{
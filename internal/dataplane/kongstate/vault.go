@@ -0,0 +1,129 @@
+package kongstate
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/kong/go-kong/kong"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+	configurationv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
+)
+
+// Vault holds a Kong vault, which plugin configurations refer to with vault
+// references using its prefix.
+type Vault struct {
+	// Name is the name of the vault backend, e.g. "env".
+	Name        string
+	Prefix      string
+	Description string
+	Config      kong.Configuration
+
+	K8sKongVault configurationv1beta1.KongVault
+}
+
+// K8sObjectInfo describes the KongVault the vault was generated from.
+func (v *Vault) K8sObjectInfo() util.K8sObjectInfo {
+	return vaultObjectInfo(&v.K8sKongVault)
+}
+
+// SanitizedCopy returns a shallow copy with sensitive values redacted best-effort.
+// The configuration of vault backends typically holds the credentials used to
+// access the vault, so all its values but the vault references are redacted.
+func (v *Vault) SanitizedCopy() *Vault {
+	return &Vault{
+		Name:         v.Name,
+		Prefix:       v.Prefix,
+		Description:  v.Description,
+		Config:       redactConfiguration(v.Config),
+		K8sKongVault: v.K8sKongVault,
+	}
+}
+
+// FillVaults generates the Kong vaults of the KongVaults. The KongVaults which
+// no vault could be generated from are returned.
+func (ks *KongState) FillVaults(s store.Storer) []ObjectFailure {
+	k8sVaults := s.ListKongVaults()
+	// prefixes are unique in Kong, so when several KongVaults claim the same
+	// prefix the oldest one keeps it.
+	sort.SliceStable(k8sVaults, func(i, j int) bool {
+		if !k8sVaults[i].CreationTimestamp.Equal(&k8sVaults[j].CreationTimestamp) {
+			return k8sVaults[i].CreationTimestamp.Before(&k8sVaults[j].CreationTimestamp)
+		}
+		return k8sVaults[i].Name < k8sVaults[j].Name
+	})
+
+	var failures []ObjectFailure
+	owners := make(map[string]*configurationv1beta1.KongVault, len(k8sVaults))
+	for _, k8sVault := range k8sVaults {
+		prefix := k8sVault.Spec.Prefix
+		if owner, ok := owners[prefix]; ok {
+			failures = append(failures, ObjectFailure{
+				Object: vaultObjectInfo(k8sVault),
+				Reason: fmt.Sprintf("vault prefix %q is already used by KongVault %s", prefix, owner.Name),
+			})
+			continue
+		}
+
+		config, err := RawConfigToConfiguration(k8sVault.Spec.Config)
+		if err != nil {
+			failures = append(failures, ObjectFailure{
+				Object: vaultObjectInfo(k8sVault),
+				Reason: fmt.Sprintf("could not parse the vault config: %v", err),
+			})
+			continue
+		}
+		owners[prefix] = k8sVault
+
+		ks.Vaults = append(ks.Vaults, Vault{
+			Name:         k8sVault.Spec.Backend,
+			Prefix:       prefix,
+			Description:  k8sVault.Spec.Description,
+			Config:       config,
+			K8sKongVault: *k8sVault,
+		})
+	}
+	return failures
+}
+
+// redactConfiguration returns a copy of the provided configuration with all
+// its values redacted, except for vault references which hold no secret.
+func redactConfiguration(config kong.Configuration) kong.Configuration {
+	if config == nil {
+		return nil
+	}
+	res := make(kong.Configuration, len(config))
+	for k, v := range config {
+		res[k] = redactValue(v)
+	}
+	return res
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case map[string]interface{}:
+		return map[string]interface{}(redactConfiguration(v))
+	case []interface{}:
+		res := make([]interface{}, 0, len(v))
+		for _, nested := range v {
+			res = append(res, redactValue(nested))
+		}
+		return res
+	case string:
+		if util.IsVaultReference(v) {
+			return v
+		}
+	}
+	return *redactedString
+}
+
+// vaultObjectInfo describes the provided KongVault. The kind is always set as
+// objects retrieved from the store are not guaranteed to include it.
+func vaultObjectInfo(vault *configurationv1beta1.KongVault) util.K8sObjectInfo {
+	info := util.FromK8sObject(vault)
+	info.GroupVersionKind = configurationv1beta1.SchemeGroupVersion.WithKind("KongVault")
+	return info
+}
@@ -0,0 +1,107 @@
+package kongstate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+	configurationv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
+)
+
+func TestKongState_FillVaults(t *testing.T) {
+	objectMeta := func(name string, created time.Time) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(created),
+			Annotations: map[string]string{
+				annotations.IngressClassKey: annotations.DefaultIngressClass,
+			},
+		}
+	}
+	now := time.Now()
+
+	s, err := store.NewFakeStore(store.FakeObjects{
+		KongVaults: []*configurationv1beta1.KongVault{
+			{
+				// claims the prefix of the older "env" vault.
+				ObjectMeta: objectMeta("env-copy", now.Add(time.Minute)),
+				Spec:       configurationv1beta1.KongVaultSpec{Backend: "env", Prefix: "my-env"},
+			},
+			{
+				ObjectMeta: objectMeta("env", now),
+				Spec: configurationv1beta1.KongVaultSpec{
+					Backend:     "env",
+					Prefix:      "my-env",
+					Description: "environment variables",
+					Config:      apiextensionsv1.JSON{Raw: []byte(`{"prefix": "SECRET_"}`)},
+				},
+			},
+			{
+				ObjectMeta: objectMeta("broken", now),
+				Spec: configurationv1beta1.KongVaultSpec{
+					Backend: "aws",
+					Prefix:  "my-aws",
+					Config:  apiextensionsv1.JSON{Raw: []byte(`["region"]`)},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	state := KongState{}
+	failures := state.FillVaults(s)
+
+	t.Log("verifying that the vaults which claim a prefix already in use or have an invalid config are reported")
+	require.Len(t, failures, 2)
+	assert.Equal(t, "broken", failures[0].Object.Name)
+	assert.Equal(t, "KongVault", failures[0].Object.GroupVersionKind.Kind)
+	assert.Contains(t, failures[0].Reason, "could not parse the vault config")
+	assert.Equal(t, "env-copy", failures[1].Object.Name)
+	assert.Equal(t, `vault prefix "my-env" is already used by KongVault env`, failures[1].Reason)
+
+	t.Log("verifying that the vault is generated from the oldest KongVault")
+	require.Len(t, state.Vaults, 1)
+	vault := state.Vaults[0]
+	assert.Equal(t, "env", vault.Name)
+	assert.Equal(t, "my-env", vault.Prefix)
+	assert.Equal(t, "environment variables", vault.Description)
+	assert.Equal(t, kong.Configuration{"prefix": "SECRET_"}, vault.Config)
+	assert.Equal(t, "env", vault.K8sObjectInfo().Name)
+}
+
+func TestVault_SanitizedCopy(t *testing.T) {
+	vault := Vault{
+		Name:   "hcv",
+		Prefix: "my-hcv",
+		Config: kong.Configuration{
+			"host":  "vault.example.com",
+			"port":  float64(8200),
+			"token": "{vault://env/hcv-token}",
+			"auth": map[string]interface{}{
+				"role":    "kong",
+				"secrets": []interface{}{"secret", "{vault://env/other}"},
+			},
+			"namespace": nil,
+		},
+	}
+
+	got := vault.SanitizedCopy()
+	assert.Equal(t, kong.Configuration{
+		"host":  "REDACTED",
+		"port":  "REDACTED",
+		"token": "{vault://env/hcv-token}",
+		"auth": map[string]interface{}{
+			"role":    "REDACTED",
+			"secrets": []interface{}{"REDACTED", "{vault://env/other}"},
+		},
+		"namespace": nil,
+	}, got.Config)
+	assert.Equal(t, "vault.example.com", vault.Config["host"], "the original configuration must not be modified")
+}
@@ -145,6 +145,19 @@ func (m SecretNameToSNIs) filterHosts(hosts []string) []string {
 	return hostsToAdd
 }
 
+// secretForHost provides the key of the Secret whose certificate is served for
+// the provided host, if any.
+func (m SecretNameToSNIs) secretForHost(host string) (string, bool) {
+	for secretKey, hosts := range m {
+		for _, h := range hosts {
+			if h == host {
+				return secretKey, true
+			}
+		}
+	}
+	return "", false
+}
+
 func getK8sServicesForBackends(
 	log logrus.FieldLogger,
 	storer store.Storer,
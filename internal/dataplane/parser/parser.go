@@ -37,9 +37,13 @@ type Parser struct {
 	logger                      logrus.FieldLogger
 	storer                      store.Storer
 	configuredKubernetesObjects []client.Object
+	translationFailures         []TranslationFailure
 
 	featureEnabledReportConfiguredKubernetesObjects bool
 	featureEnabledCombinedServiceRoutes             bool
+	featureEnabledConsumerGroups                    bool
+	featureEnabledVaults                            bool
+	featureEnabledRequestMirrorPlugin               bool
 }
 
 // NewParser produces a new Parser object provided a logging mechanism
@@ -62,13 +66,19 @@ func NewParser(
 // defined in Kuberentes.
 // It throws an error if there is an error returned from client-go.
 func (p *Parser) Build() (*kongstate.KongState, error) {
-	// parse and merge all rules together from all Kubernetes API sources
-	ingressRules := mergeIngressRules(
+	// parse and merge all rules together from all Kubernetes API sources. The
+	// certificates of Gateway listeners are only served for the hostnames
+	// which Ingresses don't already serve a certificate for.
+	ingressRulesFromIngresses := mergeIngressRules(
 		p.ingressRulesFromIngressV1beta1(),
 		p.ingressRulesFromIngressV1(),
 		p.ingressRulesFromTCPIngressV1beta1(),
 		p.ingressRulesFromUDPIngressV1beta1(),
 		p.ingressRulesFromKnativeIngress(),
+	)
+	ingressRules := mergeIngressRules(
+		ingressRulesFromIngresses,
+		p.ingressRulesFromGateways(ingressRulesFromIngresses.SecretNameToSNIs),
 		p.ingressRulesFromHTTPRoutes(),
 		p.ingressRulesFromUDPRoutes(),
 		p.ingressRulesFromTCPRoutes(),
@@ -87,19 +97,62 @@ func (p *Parser) Build() (*kongstate.KongState, error) {
 	}
 
 	// generate Upstreams and Targets from service defs
-	result.Upstreams = getUpstreams(p.logger, p.storer, ingressRules.ServiceNameToServices)
+	result.Upstreams = p.getUpstreams(ingressRules.ServiceNameToServices)
 
-	// merge KongIngress with Routes, Services and Upstream
-	result.FillOverrides(p.logger, p.storer)
+	// merge KongIngress and policies with Routes, Services and Upstream
+	for _, failure := range result.FillOverrides(p.logger, p.storer) {
+		p.registerTranslationFailure(failure.Reason, failure.Object.ToPartialObjectMetadata())
+	}
+	for _, policy := range result.PolicySources() {
+		p.ReportKubernetesObjectUpdate(policy.ToPartialObjectMetadata())
+	}
 
 	// generate consumers and credentials
-	result.FillConsumersAndCredentials(p.logger, p.storer)
+	for _, failure := range result.FillConsumersAndCredentials(p.logger, p.storer) {
+		p.registerTranslationFailure(failure.Reason, failure.Object.ToPartialObjectMetadata())
+	}
+	for i := range result.Consumers {
+		p.ReportKubernetesObjectUpdate(result.Consumers[i].K8sObjectInfo().ToPartialObjectMetadata())
+	}
+
+	// generate consumer groups and the plugins scoped to them
+	if p.featureEnabledConsumerGroups {
+		for _, failure := range result.FillConsumerGroups(p.logger, p.storer) {
+			p.registerTranslationFailure(failure.Reason, failure.Object.ToPartialObjectMetadata())
+		}
+	} else {
+		for _, group := range p.storer.ListKongConsumerGroups() {
+			info := util.FromK8sObject(group)
+			info.GroupVersionKind = configurationv1beta1.SchemeGroupVersion.WithKind("KongConsumerGroup")
+			p.registerTranslationFailure("consumer groups are only supported by DB-less data-planes",
+				info.ToPartialObjectMetadata())
+		}
+	}
+
+	// generate vaults
+	if p.featureEnabledVaults {
+		for _, failure := range result.FillVaults(p.storer) {
+			p.registerTranslationFailure(failure.Reason, failure.Object.ToPartialObjectMetadata())
+		}
+	} else {
+		for _, vault := range p.storer.ListKongVaults() {
+			info := util.FromK8sObject(vault)
+			info.GroupVersionKind = configurationv1beta1.SchemeGroupVersion.WithKind("KongVault")
+			p.registerTranslationFailure(fmt.Sprintf("vaults are only supported by DB-less data-planes running Kong %s or later",
+				MinVaultKongVersion), info.ToPartialObjectMetadata())
+		}
+	}
 
 	// process annotation plugins
-	result.FillPlugins(p.logger, p.storer)
+	for _, failure := range result.FillPlugins(p.logger, p.storer) {
+		p.registerTranslationFailure(failure.Reason, failure.Object.ToPartialObjectMetadata())
+	}
+	for _, attachment := range result.PluginAttachments() {
+		p.ReportKubernetesObjectUpdate(attachment.Plugin.ToPartialObjectMetadata())
+	}
 
 	// generate Certificates and SNIs
-	result.Certificates = getCerts(p.logger, p.storer, ingressRules.SecretNameToSNIs)
+	result.Certificates = p.getCerts(ingressRules.SecretNameToSNIs)
 
 	// populate CA certificates in Kong
 	var err error
@@ -155,6 +208,30 @@ func (p *Parser) EnableCombinedServiceRoutes() {
 	p.featureEnabledCombinedServiceRoutes = true
 }
 
+// EnableConsumerGroups turns on the translation of KongConsumerGroups. The
+// consumer groups can't be represented in the decK configuration, and are
+// only applied to DB-less data-planes through their declarative configuration.
+// While disabled, KongConsumerGroups are reported as translation failures.
+func (p *Parser) EnableConsumerGroups() {
+	p.featureEnabledConsumerGroups = true
+}
+
+// EnableVaults turns on the translation of KongVaults. Like consumer groups,
+// vaults can't be represented in the decK configuration, and are only applied
+// to DB-less data-planes which support them (see MinVaultKongVersion). While
+// disabled, KongVaults are reported as translation failures.
+func (p *Parser) EnableVaults() {
+	p.featureEnabledVaults = true
+}
+
+// EnableRequestMirrorPlugin indicates that the request-mirror plugin, which
+// Kong doesn't bundle, is available in the data-plane, so that the
+// RequestMirror filters of HTTPRoutes can be translated. While disabled,
+// HTTPRoutes with RequestMirror filters are reported as translation failures.
+func (p *Parser) EnableRequestMirrorPlugin() {
+	p.featureEnabledRequestMirrorPlugin = true
+}
+
 // -----------------------------------------------------------------------------
 // Parser - Private Methods
 // -----------------------------------------------------------------------------
@@ -275,11 +352,8 @@ func findPort(svc *corev1.Service, wantPort kongstate.PortDef) (*corev1.ServiceP
 	return nil, fmt.Errorf("no suitable port found")
 }
 
-func getUpstreams(
-	log logrus.FieldLogger,
-	s store.Storer,
-	serviceMap map[string]kongstate.Service,
-) []kongstate.Upstream {
+func (p *Parser) getUpstreams(serviceMap map[string]kongstate.Service) []kongstate.Upstream {
+	log, s := p.logger, p.storer
 	upstreamDedup := make(map[string]struct{}, len(serviceMap))
 	var empty struct{}
 	upstreams := make([]kongstate.Upstream, 0, len(serviceMap))
@@ -297,6 +371,10 @@ func getUpstreams(
 				k8sService, ok := service.K8sServices[backend.Name]
 				if !ok {
 					log.WithField("service_name", *service.Name).Errorf("can't add target for backend %s: no kubernetes service found", backend.Name)
+					p.registerTranslationFailure(
+						fmt.Sprintf("can't add target for backend %s: no kubernetes service found", backend.Name),
+						routeSourceObjects(service)...,
+					)
 					continue
 				}
 
@@ -304,6 +382,10 @@ func getUpstreams(
 				port, err := findPort(k8sService, backend.PortDef)
 				if err != nil {
 					log.WithField("service_name", *service.Name).Errorf("can't find port for backend kubernetes service %s/%s: %v", k8sService.Namespace, k8sService.Name, err)
+					p.registerTranslationFailure(
+						fmt.Sprintf("can't find port for backend kubernetes service %s/%s: %v", k8sService.Namespace, k8sService.Name, err),
+						routeSourceObjects(service)...,
+					)
 					continue
 				}
 
@@ -386,7 +468,8 @@ func getCertFromSecret(secret *corev1.Secret) (string, string, error) {
 	return cert, key, nil
 }
 
-func getCerts(log logrus.FieldLogger, s store.Storer, secretsToSNIs map[string][]string) []kongstate.Certificate {
+func (p *Parser) getCerts(secretsToSNIs map[string][]string) []kongstate.Certificate {
+	log, s := p.logger, p.storer
 	snisAdded := make(map[string]bool)
 	// map of cert public key + private key to certificate
 	type certWrapper struct {
@@ -411,6 +494,7 @@ func getCerts(log logrus.FieldLogger, s store.Storer, secretsToSNIs map[string][
 				"secret_name":      namespaceName[1],
 				"secret_namespace": namespaceName[0],
 			}).WithError(err).Error("failed to construct certificate from secret")
+			p.registerTranslationFailure(fmt.Sprintf("failed to construct certificate from secret: %v", err), secret)
 			continue
 		}
 		kongCert, ok := certs[cert+key]
@@ -452,6 +536,23 @@ func getCerts(log logrus.FieldLogger, s store.Storer, secretsToSNIs map[string][
 	return res
 }
 
+// routeSourceObjects provides the Kubernetes objects which the routes of the
+// provided service were translated from.
+func routeSourceObjects(service kongstate.Service) []client.Object {
+	seen := map[string]struct{}{}
+	var objs []client.Object
+	for _, route := range service.Routes {
+		info := route.Ingress
+		key := info.GroupVersionKind.String() + "/" + info.Namespace + "/" + info.Name
+		if _, ok := seen[key]; ok || info.Name == "" {
+			continue
+		}
+		seen[key] = struct{}{}
+		objs = append(objs, info.ToPartialObjectMetadata())
+	}
+	return objs
+}
+
 func getServiceEndpoints(
 	log logrus.FieldLogger,
 	s store.Storer,
@@ -25,6 +25,7 @@ import (
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
 	configurationv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+	configurationv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
 )
 
 type TLSPair struct {
@@ -4779,3 +4780,93 @@ func TestCertificate(t *testing.T) {
 		assert.Equal(state.Certificates[0], fooCertificate)
 	})
 }
+
+func TestConsumerGroups(t *testing.T) {
+	objectMeta := func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			Annotations: map[string]string{
+				annotations.IngressClassKey: annotations.DefaultIngressClass,
+			},
+		}
+	}
+	s, err := store.NewFakeStore(store.FakeObjects{
+		KongConsumers: []*configurationv1.KongConsumer{{
+			ObjectMeta:     objectMeta("alice"),
+			Username:       "alice",
+			ConsumerGroups: []string{"gold"},
+		}},
+		KongConsumerGroups: []*configurationv1beta1.KongConsumerGroup{{
+			ObjectMeta: objectMeta("gold"),
+		}},
+	})
+	assert.NoError(t, err)
+
+	t.Run("consumer groups are reported as translation failures unless enabled", func(t *testing.T) {
+		p := NewParser(logrus.New(), s)
+		state, err := p.Build()
+		assert.NoError(t, err)
+		assert.Empty(t, state.ConsumerGroups)
+		assert.Empty(t, state.Consumers[0].ConsumerGroups)
+
+		failures := p.PopTranslationFailures()
+		assert.Len(t, failures, 1)
+		assert.Equal(t, "gold", failures[0].CausingObjects[0].GetName())
+		assert.Equal(t, "KongConsumerGroup", failures[0].CausingObjects[0].GetObjectKind().GroupVersionKind().Kind)
+	})
+
+	t.Run("consumer groups are translated when enabled", func(t *testing.T) {
+		p := NewParser(logrus.New(), s)
+		p.EnableConsumerGroups()
+		state, err := p.Build()
+		assert.NoError(t, err)
+		assert.Len(t, state.ConsumerGroups, 1)
+		assert.Equal(t, "gold", state.ConsumerGroups[0].Name)
+		assert.Equal(t, []string{"gold"}, state.Consumers[0].ConsumerGroups)
+		assert.Empty(t, p.PopTranslationFailures())
+	})
+}
+
+func TestVaults(t *testing.T) {
+	s, err := store.NewFakeStore(store.FakeObjects{
+		KongVaults: []*configurationv1beta1.KongVault{{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "env",
+				Annotations: map[string]string{
+					annotations.IngressClassKey: annotations.DefaultIngressClass,
+				},
+			},
+			Spec: configurationv1beta1.KongVaultSpec{
+				Backend: "env",
+				Prefix:  "my-env",
+				Config:  apiextensionsv1.JSON{Raw: []byte(`{"prefix": "SECRET_"}`)},
+			},
+		}},
+	})
+	assert.NoError(t, err)
+
+	t.Run("vaults are reported as translation failures unless enabled", func(t *testing.T) {
+		p := NewParser(logrus.New(), s)
+		state, err := p.Build()
+		assert.NoError(t, err)
+		assert.Empty(t, state.Vaults)
+
+		failures := p.PopTranslationFailures()
+		assert.Len(t, failures, 1)
+		assert.Equal(t, "env", failures[0].CausingObjects[0].GetName())
+		assert.Equal(t, "KongVault", failures[0].CausingObjects[0].GetObjectKind().GroupVersionKind().Kind)
+	})
+
+	t.Run("vaults are translated when enabled", func(t *testing.T) {
+		p := NewParser(logrus.New(), s)
+		p.EnableVaults()
+		state, err := p.Build()
+		assert.NoError(t, err)
+		assert.Len(t, state.Vaults, 1)
+		assert.Equal(t, "env", state.Vaults[0].Name)
+		assert.Equal(t, "my-env", state.Vaults[0].Prefix)
+		assert.Equal(t, kong.Configuration{"prefix": "SECRET_"}, state.Vaults[0].Config)
+		assert.Empty(t, p.PopTranslationFailures())
+	})
+}
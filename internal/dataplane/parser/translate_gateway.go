@@ -0,0 +1,285 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+// -----------------------------------------------------------------------------
+// Translate Gateway - IngressRules Translation
+// -----------------------------------------------------------------------------
+
+// ingressRulesFromGateways processes a list of Gateway objects and translates
+// the certificateRefs of their listeners into the certificates Kong serves for
+// the listener hostnames, except for the hostnames which are already claimed
+// by the provided SNIs.
+func (p *Parser) ingressRulesFromGateways(claimed SecretNameToSNIs) ingressRules {
+	result := newIngressRules()
+
+	gatewayList, err := p.storer.ListGateways()
+	if err != nil {
+		p.logger.WithError(err).Error("failed to list Gateways")
+		return result
+	}
+	if len(gatewayList) == 0 {
+		return result
+	}
+
+	policies, err := p.storer.ListReferencePolicies()
+	if err != nil {
+		p.logger.WithError(err).Error("failed to list ReferencePolicies")
+		return result
+	}
+
+	for _, gateway := range gatewayList {
+		if err := ingressRulesFromGateway(&result, claimed, gateway, policies); err != nil {
+			err = fmt.Errorf("Gateway %s/%s TLS configuration can't be translated: %w", gateway.Namespace, gateway.Name, err)
+			p.logger.Errorf(err.Error())
+			p.registerTranslationFailure(err.Error(), gateway)
+		} else {
+			// at this point the object has been configured and can be
+			// reported as successfully parsed.
+			p.ReportKubernetesObjectUpdate(gateway)
+		}
+	}
+
+	return result
+}
+
+// ingressRulesFromGateway adds the Secrets referenced by the listeners of the
+// provided Gateway to the certificates of the listener hostnames. Listeners
+// which reference certificates that can't be used, which have no hostname Kong
+// could serve their certificates for, or whose hostname is already served
+// another certificate by the provided claimed SNIs or another listener, don't
+// prevent the others from being translated, but are reported in the returned
+// error.
+func ingressRulesFromGateway(
+	result *ingressRules,
+	claimed SecretNameToSNIs,
+	gateway *gatewayv1alpha2.Gateway,
+	policies []*gatewayv1alpha2.ReferencePolicy,
+) error {
+	allowed := getPermittedForReferencePolicyFrom(gatewayv1alpha2.ReferencePolicyFrom{
+		Group:     gatewayv1alpha2.Group(gatewayv1alpha2.GroupName),
+		Kind:      gatewayv1alpha2.Kind("Gateway"),
+		Namespace: gatewayv1alpha2.Namespace(gateway.Namespace),
+	}, policies)
+
+	var problems []string
+	for _, listener := range gateway.Spec.Listeners {
+		if !isListenerTerminatingTLS(listener) {
+			continue
+		}
+		var secretKeys []string
+		for _, ref := range listener.TLS.CertificateRefs {
+			secretKey, err := getListenerCertificateSecretKey(gateway.Namespace, ref, allowed)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("listener %s: %s", listener.Name, err))
+				continue
+			}
+			secretKeys = append(secretKeys, secretKey)
+		}
+		if len(secretKeys) == 0 {
+			continue
+		}
+		// listeners without a hostname match any SNI, which Kong
+		// certificates can't express.
+		if listener.Hostname == nil || *listener.Hostname == "" {
+			problems = append(problems, fmt.Sprintf("listener %s: certificates can only be served for listeners with a hostname", listener.Name))
+			continue
+		}
+		hostname := string(*listener.Hostname)
+		for _, secretKey := range secretKeys {
+			owner, ok := claimed.secretForHost(hostname)
+			if !ok {
+				owner, ok = result.SecretNameToSNIs.secretForHost(hostname)
+			}
+			if !ok {
+				result.SecretNameToSNIs[secretKey] = append(result.SecretNameToSNIs[secretKey], hostname)
+			} else if owner != secretKey {
+				problems = append(problems, fmt.Sprintf("listener %s: hostname %s is already served the certificate of Secret %s",
+					listener.Name, hostname, owner))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// Translate Gateway - Route Hostnames
+// -----------------------------------------------------------------------------
+
+// getRouteHostnamesForListeners provides the hostnames Kong routes are generated
+// with for a route attached to the provided parentRefs: the intersections of the
+// hostnames of the route with the hostnames of the listeners which allow the
+// route. Routes without hostnames get the hostnames of the listeners, and
+// listeners without hostnames don't restrict the hostnames of the route.
+func (p *Parser) getRouteHostnamesForListeners(
+	route client.Object,
+	parentRefs []gatewayv1alpha2.ParentReference,
+	hostnames []gatewayv1alpha2.Hostname,
+) ([]gatewayv1alpha2.Hostname, error) {
+	// the namespace of the route is only needed for the namespace selectors of
+	// listeners. Namespaces which aren't known have no labels.
+	getRouteNamespace := func() (*corev1.Namespace, error) {
+		namespace, err := p.storer.GetNamespace(route.GetNamespace())
+		if err != nil {
+			if errors.As(err, &store.ErrNotFound{}) {
+				return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: route.GetNamespace()}}, nil
+			}
+			return nil, err
+		}
+		return namespace, nil
+	}
+
+	var listenerHostnames []gatewayv1alpha2.Hostname
+	for _, parentRef := range parentRefs {
+		if parentRef.Group != nil && string(*parentRef.Group) != gatewayv1alpha2.GroupName {
+			continue
+		}
+		if parentRef.Kind != nil && string(*parentRef.Kind) != "Gateway" {
+			continue
+		}
+		gatewayNamespace := route.GetNamespace()
+		if parentRef.Namespace != nil {
+			gatewayNamespace = string(*parentRef.Namespace)
+		}
+		gateway, err := p.storer.GetGateway(gatewayNamespace, string(parentRef.Name))
+		if err != nil {
+			if errors.As(err, &store.ErrNotFound{}) {
+				continue
+			}
+			return nil, err
+		}
+
+		for _, listener := range gateway.Spec.Listeners {
+			if parentRef.SectionName != nil && *parentRef.SectionName != listener.Name {
+				continue
+			}
+			// the hostnames of the listeners which don't allow the route don't
+			// apply to it, as the route isn't attached to them.
+			if !util.IsRouteKindAllowedByListener(route, listener) {
+				continue
+			}
+			allowed, err := util.IsRouteNamespaceAllowedByListener(route, gateway, listener, getRouteNamespace)
+			if err != nil {
+				return nil, err
+			}
+			if !allowed {
+				continue
+			}
+			if listener.Hostname == nil || *listener.Hostname == "" {
+				return hostnames, nil
+			}
+			listenerHostnames = append(listenerHostnames, *listener.Hostname)
+		}
+	}
+
+	// routes which aren't attached to any known listener are left as they are,
+	// the controllers don't let them be configured.
+	if len(listenerHostnames) == 0 {
+		return hostnames, nil
+	}
+	if len(hostnames) == 0 {
+		return uniqueHostnames(listenerHostnames), nil
+	}
+
+	var intersection []gatewayv1alpha2.Hostname
+	for _, hostname := range hostnames {
+		for _, listenerHostname := range listenerHostnames {
+			if h, ok := util.IntersectHostnames(string(listenerHostname), string(hostname)); ok {
+				intersection = append(intersection, gatewayv1alpha2.Hostname(h))
+			}
+		}
+	}
+	if len(intersection) == 0 {
+		return nil, fmt.Errorf("no hostname matches the hostnames of the gateway listeners")
+	}
+	return uniqueHostnames(intersection), nil
+}
+
+// -----------------------------------------------------------------------------
+// Translate Gateway - Utils
+// -----------------------------------------------------------------------------
+
+// isListenerTerminatingTLS indicates whether the provided listener terminates
+// TLS using its certificateRefs, as opposed to passing TLS through or not
+// using TLS at all.
+func isListenerTerminatingTLS(listener gatewayv1alpha2.Listener) bool {
+	if listener.TLS == nil {
+		return false
+	}
+	if listener.Protocol != gatewayv1alpha2.HTTPSProtocolType && listener.Protocol != gatewayv1alpha2.TLSProtocolType {
+		return false
+	}
+	return listener.TLS.Mode == nil || *listener.TLS.Mode == gatewayv1alpha2.TLSModeTerminate
+}
+
+// uniqueHostnames drops the duplicates of a list of hostnames, keeping the
+// first occurrence of each.
+func uniqueHostnames(hostnames []gatewayv1alpha2.Hostname) []gatewayv1alpha2.Hostname {
+	seen := make(map[gatewayv1alpha2.Hostname]struct{}, len(hostnames))
+	unique := make([]gatewayv1alpha2.Hostname, 0, len(hostnames))
+	for _, hostname := range hostnames {
+		if _, ok := seen[hostname]; ok {
+			continue
+		}
+		seen[hostname] = struct{}{}
+		unique = append(unique, hostname)
+	}
+	return unique
+}
+
+// getListenerCertificateSecretKey provides the namespace/name key of the Secret
+// a listener certificateRef of a Gateway in the provided namespace refers to.
+// References to other kinds of objects, and references to Secrets in other
+// namespaces which no ReferencePolicy in the provided set permits, are refused.
+func getListenerCertificateSecretKey(
+	gatewayNamespace string,
+	ref *gatewayv1alpha2.SecretObjectReference,
+	allowed map[gatewayv1alpha2.Namespace][]gatewayv1alpha2.ReferencePolicyTo,
+) (string, error) {
+	if ref == nil {
+		return "", fmt.Errorf("empty certificateRef")
+	}
+	if (ref.Group != nil && *ref.Group != "") || (ref.Kind != nil && *ref.Kind != "Secret") {
+		return "", fmt.Errorf("certificateRef %s refers to an unsupported kind, only Secrets are supported", ref.Name)
+	}
+
+	namespace := gatewayNamespace
+	if ref.Namespace != nil && string(*ref.Namespace) != gatewayNamespace {
+		namespace = string(*ref.Namespace)
+		if !isSecretRefAllowedByPolicy(*ref, allowed) {
+			return "", fmt.Errorf("certificateRef to Secret %s/%s is not permitted by any ReferencePolicy", namespace, ref.Name)
+		}
+	}
+	return namespace + "/" + string(ref.Name), nil
+}
+
+// isSecretRefAllowedByPolicy checks if a cross-namespace reference to a Secret
+// is permitted by the provided namespace-indexed ReferencePolicyTo set, as
+// returned by getPermittedForReferencePolicyFrom.
+func isSecretRefAllowedByPolicy(
+	ref gatewayv1alpha2.SecretObjectReference,
+	allowed map[gatewayv1alpha2.Namespace][]gatewayv1alpha2.ReferencePolicyTo,
+) bool {
+	for _, to := range allowed[*ref.Namespace] {
+		if to.Group == "" && to.Kind == "Secret" && (to.Name == nil || *to.Name == ref.Name) {
+			return true
+		}
+	}
+	return false
+}
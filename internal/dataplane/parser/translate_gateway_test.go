@@ -0,0 +1,297 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+)
+
+func Test_ingressRulesFromGateways(t *testing.T) {
+	hostname := gatewayv1alpha2.Hostname("konghq.com")
+	otherHostname := gatewayv1alpha2.Hostname("docs.konghq.com")
+	passthrough := gatewayv1alpha2.TLSModePassthrough
+	certsNamespace := gatewayv1alpha2.Namespace("certs")
+	configMap := gatewayv1alpha2.Kind("ConfigMap")
+
+	gateway := &gatewayv1alpha2.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "kong", Namespace: corev1.NamespaceDefault},
+		Spec: gatewayv1alpha2.GatewaySpec{
+			GatewayClassName: "kong",
+			Listeners: []gatewayv1alpha2.Listener{
+				{
+					Name:     "https",
+					Hostname: &hostname,
+					Port:     443,
+					Protocol: gatewayv1alpha2.HTTPSProtocolType,
+					TLS: &gatewayv1alpha2.GatewayTLSConfig{
+						CertificateRefs: []*gatewayv1alpha2.SecretObjectReference{{Name: "local-cert"}},
+					},
+				},
+				{
+					Name:     "https-remote",
+					Hostname: &otherHostname,
+					Port:     443,
+					Protocol: gatewayv1alpha2.HTTPSProtocolType,
+					TLS: &gatewayv1alpha2.GatewayTLSConfig{
+						CertificateRefs: []*gatewayv1alpha2.SecretObjectReference{{Name: "remote-cert", Namespace: &certsNamespace}},
+					},
+				},
+				{
+					Name:     "tls-passthrough",
+					Hostname: &hostname,
+					Port:     8443,
+					Protocol: gatewayv1alpha2.TLSProtocolType,
+					TLS: &gatewayv1alpha2.GatewayTLSConfig{
+						Mode:            &passthrough,
+						CertificateRefs: []*gatewayv1alpha2.SecretObjectReference{{Name: "passthrough-cert"}},
+					},
+				},
+				{
+					Name:     "http",
+					Port:     80,
+					Protocol: gatewayv1alpha2.HTTPProtocolType,
+				},
+			},
+		},
+	}
+	policy := &gatewayv1alpha2.ReferencePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "allow-gateways", Namespace: string(certsNamespace)},
+		Spec: gatewayv1alpha2.ReferencePolicySpec{
+			From: []gatewayv1alpha2.ReferencePolicyFrom{{
+				Group:     gatewayv1alpha2.Group(gatewayv1alpha2.GroupName),
+				Kind:      gatewayv1alpha2.Kind("Gateway"),
+				Namespace: gatewayv1alpha2.Namespace(corev1.NamespaceDefault),
+			}},
+			To: []gatewayv1alpha2.ReferencePolicyTo{{Group: "", Kind: "Secret"}},
+		},
+	}
+
+	t.Log("verifying that certificateRefs are translated into SNIs for the listener hostnames")
+	fakestore, err := store.NewFakeStore(store.FakeObjects{
+		Gateways:          []*gatewayv1alpha2.Gateway{gateway},
+		ReferencePolicies: []*gatewayv1alpha2.ReferencePolicy{policy},
+	})
+	require.NoError(t, err)
+	p := NewParser(logrus.New(), fakestore)
+	result := p.ingressRulesFromGateways(nil)
+	assert.Equal(t, SecretNameToSNIs{
+		"default/local-cert": {"konghq.com"},
+		"certs/remote-cert":  {"docs.konghq.com"},
+	}, result.SecretNameToSNIs)
+	assert.Empty(t, p.PopTranslationFailures())
+
+	t.Log("verifying that certificateRefs to other namespaces need a ReferencePolicy")
+	fakestore, err = store.NewFakeStore(store.FakeObjects{Gateways: []*gatewayv1alpha2.Gateway{gateway}})
+	require.NoError(t, err)
+	p = NewParser(logrus.New(), fakestore)
+	result = p.ingressRulesFromGateways(nil)
+	assert.Equal(t, SecretNameToSNIs{"default/local-cert": {"konghq.com"}}, result.SecretNameToSNIs)
+	failures := p.PopTranslationFailures()
+	require.Len(t, failures, 1)
+	assert.Contains(t, failures[0].Reason, "listener https-remote: certificateRef to Secret certs/remote-cert is not permitted by any ReferencePolicy")
+
+	t.Log("verifying that certificateRefs to objects other than Secrets are refused")
+	result = newIngressRules()
+	err = ingressRulesFromGateway(&result, nil, &gatewayv1alpha2.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "kong", Namespace: corev1.NamespaceDefault},
+		Spec: gatewayv1alpha2.GatewaySpec{
+			Listeners: []gatewayv1alpha2.Listener{{
+				Name:     "https",
+				Hostname: &hostname,
+				Port:     443,
+				Protocol: gatewayv1alpha2.HTTPSProtocolType,
+				TLS: &gatewayv1alpha2.GatewayTLSConfig{
+					CertificateRefs: []*gatewayv1alpha2.SecretObjectReference{{Name: "cert", Kind: &configMap}},
+				},
+			}},
+		},
+	}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "only Secrets are supported")
+	assert.Empty(t, result.SecretNameToSNIs)
+
+	t.Log("verifying that listeners without a hostname are reported, as Kong can't serve their certificates")
+	result = newIngressRules()
+	err = ingressRulesFromGateway(&result, nil, &gatewayv1alpha2.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "kong", Namespace: corev1.NamespaceDefault},
+		Spec: gatewayv1alpha2.GatewaySpec{
+			Listeners: []gatewayv1alpha2.Listener{{
+				Name:     "https",
+				Port:     443,
+				Protocol: gatewayv1alpha2.HTTPSProtocolType,
+				TLS: &gatewayv1alpha2.GatewayTLSConfig{
+					CertificateRefs: []*gatewayv1alpha2.SecretObjectReference{{Name: "cert"}},
+				},
+			}},
+		},
+	}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "listener https: certificates can only be served for listeners with a hostname")
+	assert.Empty(t, result.SecretNameToSNIs)
+
+	t.Log("verifying that hostnames which an Ingress already serves a certificate for are reported")
+	fakestore, err = store.NewFakeStore(store.FakeObjects{
+		Gateways:          []*gatewayv1alpha2.Gateway{gateway},
+		ReferencePolicies: []*gatewayv1alpha2.ReferencePolicy{policy},
+	})
+	require.NoError(t, err)
+	p = NewParser(logrus.New(), fakestore)
+	result = p.ingressRulesFromGateways(SecretNameToSNIs{"default/ingress-cert": {"konghq.com"}})
+	assert.Equal(t, SecretNameToSNIs{"certs/remote-cert": {"docs.konghq.com"}}, result.SecretNameToSNIs)
+	failures = p.PopTranslationFailures()
+	require.Len(t, failures, 1)
+	assert.Contains(t, failures[0].Reason, "listener https: hostname konghq.com is already served the certificate of Secret default/ingress-cert")
+}
+
+func Test_getRouteHostnamesForListeners(t *testing.T) {
+	wildcard := gatewayv1alpha2.Hostname("*.konghq.com")
+	docs := gatewayv1alpha2.Hostname("docs.konghq.com")
+	kinds := gatewayv1alpha2.Hostname("kinds.konghq.com")
+	team := gatewayv1alpha2.Hostname("team.konghq.com")
+	other := gatewayv1alpha2.Hostname("other.konghq.com")
+	httpsSection := gatewayv1alpha2.SectionName("https")
+	fromAll := gatewayv1alpha2.NamespacesFromAll
+	fromSelector := gatewayv1alpha2.NamespacesFromSelector
+	fakestore, err := store.NewFakeStore(store.FakeObjects{
+		Gateways: []*gatewayv1alpha2.Gateway{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "hostnames", Namespace: corev1.NamespaceDefault},
+				Spec: gatewayv1alpha2.GatewaySpec{
+					Listeners: []gatewayv1alpha2.Listener{
+						{Name: "http", Protocol: gatewayv1alpha2.HTTPProtocolType, Hostname: &wildcard},
+						{Name: "https", Protocol: gatewayv1alpha2.HTTPSProtocolType, Hostname: &docs},
+						{Name: "tcp", Protocol: gatewayv1alpha2.TCPProtocolType},
+					},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "any", Namespace: corev1.NamespaceDefault},
+				Spec: gatewayv1alpha2.GatewaySpec{
+					Listeners: []gatewayv1alpha2.Listener{{Name: "http", Protocol: gatewayv1alpha2.HTTPProtocolType}},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "restricted", Namespace: corev1.NamespaceDefault},
+				Spec: gatewayv1alpha2.GatewaySpec{
+					Listeners: []gatewayv1alpha2.Listener{
+						{
+							Name:     "other-kinds",
+							Protocol: gatewayv1alpha2.HTTPProtocolType,
+							Hostname: &kinds,
+							AllowedRoutes: &gatewayv1alpha2.AllowedRoutes{
+								Namespaces: &gatewayv1alpha2.RouteNamespaces{From: &fromAll},
+								Kinds:      []gatewayv1alpha2.RouteGroupKind{{Kind: "TCPRoute"}},
+							},
+						},
+						{
+							Name:     "other-team",
+							Protocol: gatewayv1alpha2.HTTPProtocolType,
+							AllowedRoutes: &gatewayv1alpha2.AllowedRoutes{
+								Namespaces: &gatewayv1alpha2.RouteNamespaces{
+									From:     &fromSelector,
+									Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "other"}},
+								},
+							},
+						},
+						{
+							Name:     "team",
+							Protocol: gatewayv1alpha2.HTTPProtocolType,
+							Hostname: &team,
+							AllowedRoutes: &gatewayv1alpha2.AllowedRoutes{
+								Namespaces: &gatewayv1alpha2.RouteNamespaces{
+									From:     &fromSelector,
+									Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "kong"}},
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "elsewhere", Namespace: "other"},
+				Spec: gatewayv1alpha2.GatewaySpec{
+					Listeners: []gatewayv1alpha2.Listener{{Name: "http", Protocol: gatewayv1alpha2.HTTPProtocolType, Hostname: &other}},
+				},
+			},
+		},
+		Namespaces: []*corev1.Namespace{{
+			ObjectMeta: metav1.ObjectMeta{Name: corev1.NamespaceDefault, Labels: map[string]string{"team": "kong"}},
+		}},
+	})
+	require.NoError(t, err)
+	p := NewParser(logrus.New(), fakestore)
+	otherNamespace := gatewayv1alpha2.Namespace("other")
+
+	for _, tt := range []struct {
+		msg        string
+		parentRefs []gatewayv1alpha2.ParentReference
+		hostnames  []gatewayv1alpha2.Hostname
+		expected   []gatewayv1alpha2.Hostname
+		err        bool
+	}{
+		{
+			msg:        "only the hostnames matching the listeners are kept",
+			parentRefs: []gatewayv1alpha2.ParentReference{{Name: "hostnames"}},
+			hostnames:  []gatewayv1alpha2.Hostname{"*.konghq.com", "example.com"},
+			expected:   []gatewayv1alpha2.Hostname{"*.konghq.com", "docs.konghq.com"},
+		},
+		{
+			msg:        "the listener referenced by sectionName restricts the hostnames",
+			parentRefs: []gatewayv1alpha2.ParentReference{{Name: "hostnames", SectionName: &httpsSection}},
+			hostnames:  []gatewayv1alpha2.Hostname{"*.konghq.com"},
+			expected:   []gatewayv1alpha2.Hostname{"docs.konghq.com"},
+		},
+		{
+			msg:        "routes without hostnames get the hostnames of the listeners",
+			parentRefs: []gatewayv1alpha2.ParentReference{{Name: "hostnames"}},
+			expected:   []gatewayv1alpha2.Hostname{"*.konghq.com", "docs.konghq.com"},
+		},
+		{
+			msg:        "listeners without hostnames don't restrict the hostnames",
+			parentRefs: []gatewayv1alpha2.ParentReference{{Name: "hostnames"}, {Name: "any"}},
+			hostnames:  []gatewayv1alpha2.Hostname{"example.com"},
+			expected:   []gatewayv1alpha2.Hostname{"example.com"},
+		},
+		{
+			msg:        "routes without any hostname matching the listeners are refused",
+			parentRefs: []gatewayv1alpha2.ParentReference{{Name: "hostnames"}},
+			hostnames:  []gatewayv1alpha2.Hostname{"example.com"},
+			err:        true,
+		},
+		{
+			msg:        "the listeners which don't allow the route don't apply to it",
+			parentRefs: []gatewayv1alpha2.ParentReference{{Name: "restricted"}, {Name: "elsewhere", Namespace: &otherNamespace}},
+			expected:   []gatewayv1alpha2.Hostname{"team.konghq.com"},
+		},
+		{
+			msg:        "routes only matching the listeners which don't allow them are refused",
+			parentRefs: []gatewayv1alpha2.ParentReference{{Name: "restricted"}, {Name: "elsewhere", Namespace: &otherNamespace}},
+			hostnames:  []gatewayv1alpha2.Hostname{"kinds.konghq.com", "other.konghq.com"},
+			err:        true,
+		},
+		{
+			msg:        "routes attached to unknown gateways are left as they are",
+			parentRefs: []gatewayv1alpha2.ParentReference{{Name: "missing"}},
+			hostnames:  []gatewayv1alpha2.Hostname{"example.com"},
+			expected:   []gatewayv1alpha2.Hostname{"example.com"},
+		},
+	} {
+		t.Run(tt.msg, func(t *testing.T) {
+			route := &gatewayv1alpha2.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: corev1.NamespaceDefault}}
+			hostnames, err := p.getRouteHostnamesForListeners(route, tt.parentRefs, tt.hostnames)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, hostnames)
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/kong/go-kong/kong"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+	configurationv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
+)
+
+// -----------------------------------------------------------------------------
+// Translate GatewayConfiguration - Lookup
+// -----------------------------------------------------------------------------
+
+// getGatewayConfigurationForRoute returns the GatewayConfiguration referenced
+// by the GatewayClass of the Gateways the route is attached to, or nil if
+// those Gateways have no configuration. As all the Kong routes generated for a
+// route share the same settings, a route attached to Gateways with different
+// configurations can't be translated.
+func (p *Parser) getGatewayConfigurationForRoute(
+	namespace string,
+	parentRefs []gatewayv1alpha2.ParentReference,
+) (*configurationv1beta1.GatewayConfiguration, error) {
+	var (
+		config      *configurationv1beta1.GatewayConfiguration
+		configFound bool
+	)
+	for _, parentRef := range parentRefs {
+		if parentRef.Group != nil && string(*parentRef.Group) != gatewayv1alpha2.GroupName {
+			continue
+		}
+		if parentRef.Kind != nil && string(*parentRef.Kind) != "Gateway" {
+			continue
+		}
+		gatewayNamespace := namespace
+		if parentRef.Namespace != nil {
+			gatewayNamespace = string(*parentRef.Namespace)
+		}
+
+		gatewayConfig, err := p.getGatewayConfigurationForGateway(gatewayNamespace, string(parentRef.Name))
+		if err != nil {
+			return nil, err
+		}
+
+		if !configFound {
+			config, configFound = gatewayConfig, true
+			continue
+		}
+		if !isSameGatewayConfiguration(config, gatewayConfig) {
+			return nil, fmt.Errorf("attached to gateways with different GatewayConfigurations")
+		}
+	}
+	return config, nil
+}
+
+// getGatewayConfigurationForGateway returns the GatewayConfiguration referenced
+// by the class of a Gateway. Gateways and classes missing from the cache are
+// not handled by the controller and thus have no configuration, whereas a
+// missing GatewayConfiguration is reported as an error.
+func (p *Parser) getGatewayConfigurationForGateway(namespace, name string) (*configurationv1beta1.GatewayConfiguration, error) {
+	gateway, err := p.storer.GetGateway(namespace, name)
+	if err != nil {
+		if errors.As(err, &store.ErrNotFound{}) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	gatewayClass, err := p.storer.GetGatewayClass(string(gateway.Spec.GatewayClassName))
+	if err != nil {
+		if errors.As(err, &store.ErrNotFound{}) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	ref := gatewayClass.Spec.ParametersRef
+	if ref == nil ||
+		string(ref.Group) != configurationv1beta1.GroupVersion.Group ||
+		string(ref.Kind) != configurationv1beta1.GatewayConfigurationKind ||
+		ref.Namespace == nil {
+		return nil, nil
+	}
+	config, err := p.storer.GetGatewayConfiguration(string(*ref.Namespace), ref.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GatewayConfiguration of GatewayClass %s: %w", gatewayClass.Name, err)
+	}
+	return config, nil
+}
+
+// isSameGatewayConfiguration indicates whether two (possibly nil) configurations
+// are the same GatewayConfiguration.
+func isSameGatewayConfiguration(a, b *configurationv1beta1.GatewayConfiguration) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Namespace == b.Namespace && a.Name == b.Name
+}
+
+// -----------------------------------------------------------------------------
+// Translate GatewayConfiguration - Route Settings
+// -----------------------------------------------------------------------------
+
+// applyGatewayConfigurationPlugins adds the plugins of the configuration to the
+// konghq.com/plugins annotation of the routes, so that they're configured just
+// like the plugins of the route itself. The annotations of the routes are
+// copied before being updated, as they're shared with the cached object.
+func applyGatewayConfigurationPlugins(routes []kongstate.Route, config *configurationv1beta1.GatewayConfiguration) {
+	if config == nil || len(config.Spec.Plugins) == 0 {
+		return
+	}
+	for i := range routes {
+		anns := make(map[string]string, len(routes[i].Ingress.Annotations)+1)
+		for k, v := range routes[i].Ingress.Annotations {
+			anns[k] = v
+		}
+
+		plugins := annotations.ExtractKongPluginsFromAnnotations(anns)
+		for _, plugin := range config.Spec.Plugins {
+			if !containsString(plugins, plugin) {
+				plugins = append(plugins, plugin)
+			}
+		}
+		anns[annotations.AnnotationPrefix+annotations.PluginsKey] = strings.Join(plugins, ",")
+		routes[i].Ingress.Annotations = anns
+	}
+}
+
+// applyGatewayConfigurationRouteProtocols replaces the default protocols of
+// the routes generated for an HTTPRoute with the ones of the configuration.
+func applyGatewayConfigurationRouteProtocols(routes []kongstate.Route, config *configurationv1beta1.GatewayConfiguration) {
+	if config == nil || len(config.Spec.RouteProtocols) == 0 {
+		return
+	}
+	for i := range routes {
+		routes[i].Protocols = kong.StringSlice(config.Spec.RouteProtocols...)
+	}
+}
+
+// isCombinedRoutesEnabled indicates whether the matches of HTTPRoute rules are
+// combined into a single Kong route, which only the configuration can enable so
+// that the names of the existing Kong routes don't change.
+func isCombinedRoutesEnabled(config *configurationv1beta1.GatewayConfiguration) bool {
+	return config != nil && config.Spec.CombinedRoutes != nil && *config.Spec.CombinedRoutes
+}
+
+// combineKongRoutesByPath merges the routes which only differ by their paths,
+// keeping the name of the first route of each merged group.
+func combineKongRoutesByPath(routes []kongstate.Route) []kongstate.Route {
+	combined := make([]kongstate.Route, 0, len(routes))
+	for _, route := range routes {
+		merged := false
+		for i := range combined {
+			if canCombineKongRoutesByPath(combined[i], route) {
+				combined[i].Paths = append(combined[i].Paths, route.Paths...)
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			combined = append(combined, route)
+		}
+	}
+	return combined
+}
+
+// canCombineKongRoutesByPath indicates whether two routes are identical except
+// for their names and paths. Routes without paths match any path, and thus
+// can't be combined with others.
+func canCombineKongRoutesByPath(a, b kongstate.Route) bool {
+	if len(a.Paths) == 0 || len(b.Paths) == 0 {
+		return false
+	}
+	a.Name, b.Name = nil, nil
+	a.Paths, b.Paths = nil, nil
+	return reflect.DeepEqual(a, b)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
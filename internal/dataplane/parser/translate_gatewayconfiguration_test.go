@@ -0,0 +1,140 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+	configurationv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
+)
+
+func Test_ingressRulesFromHTTPRouteWithGatewayConfiguration(t *testing.T) {
+	configNamespace := gatewayv1alpha2.Namespace("kong")
+	port := gatewayv1alpha2.PortNumber(80)
+	pathPrefix := gatewayv1alpha2.PathMatchPathPrefix
+	gatewayClass := func(name string, config string) *gatewayv1alpha2.GatewayClass {
+		gwc := &gatewayv1alpha2.GatewayClass{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if config != "" {
+			gwc.Spec.ParametersRef = &gatewayv1alpha2.ParametersReference{
+				Group:     gatewayv1alpha2.Group(configurationv1beta1.GroupVersion.Group),
+				Kind:      configurationv1beta1.GatewayConfigurationKind,
+				Name:      config,
+				Namespace: &configNamespace,
+			}
+		}
+		return gwc
+	}
+	gateway := func(name, class string) *gatewayv1alpha2.Gateway {
+		return &gatewayv1alpha2.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: corev1.NamespaceDefault},
+			Spec:       gatewayv1alpha2.GatewaySpec{GatewayClassName: gatewayv1alpha2.ObjectName(class)},
+		}
+	}
+	httproute := func(name string, gateways ...gatewayv1alpha2.ObjectName) *gatewayv1alpha2.HTTPRoute {
+		httproute := &gatewayv1alpha2.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   corev1.NamespaceDefault,
+				Annotations: map[string]string{annotations.AnnotationPrefix + annotations.PluginsKey: "auth"},
+			},
+			Spec: gatewayv1alpha2.HTTPRouteSpec{
+				Rules: []gatewayv1alpha2.HTTPRouteRule{{
+					Matches: []gatewayv1alpha2.HTTPRouteMatch{
+						{Path: &gatewayv1alpha2.HTTPPathMatch{Type: &pathPrefix, Value: kong.String("/v1")}},
+						{Path: &gatewayv1alpha2.HTTPPathMatch{Type: &pathPrefix, Value: kong.String("/v2")}},
+					},
+					BackendRefs: []gatewayv1alpha2.HTTPBackendRef{{
+						BackendRef: gatewayv1alpha2.BackendRef{
+							BackendObjectReference: gatewayv1alpha2.BackendObjectReference{Name: "fake-service", Port: &port},
+						},
+					}},
+				}},
+			},
+		}
+		for _, gateway := range gateways {
+			httproute.Spec.ParentRefs = append(httproute.Spec.ParentRefs, gatewayv1alpha2.ParentReference{Name: gateway})
+		}
+		httproute.SetGroupVersionKind(httprouteGVK)
+		return httproute
+	}
+
+	combined := true
+	fakestore, err := store.NewFakeStore(store.FakeObjects{
+		GatewayClasses: []*gatewayv1alpha2.GatewayClass{
+			gatewayClass("configured", "https-only"),
+			gatewayClass("default", ""),
+			gatewayClass("missing-config", "missing"),
+		},
+		GatewayConfigs: []*configurationv1beta1.GatewayConfiguration{{
+			ObjectMeta: metav1.ObjectMeta{Name: "https-only", Namespace: string(configNamespace)},
+			Spec: configurationv1beta1.GatewayConfigurationSpec{
+				Plugins:        []string{"rate-limit", "auth"},
+				RouteProtocols: []string{"https"},
+				CombinedRoutes: &combined,
+			},
+		}},
+		Gateways: []*gatewayv1alpha2.Gateway{
+			gateway("configured", "configured"),
+			gateway("default", "default"),
+			gateway("missing-config", "missing-config"),
+		},
+	})
+	require.NoError(t, err)
+	p := NewParser(logrus.New(), fakestore)
+
+	t.Log("verifying that the routes of an httproute attached to a configured gateway use the GatewayConfiguration")
+	route := httproute("configured", "configured")
+	result := newIngressRules()
+	require.NoError(t, p.ingressRulesFromHTTPRoute(&result, route))
+	service := result.ServiceNameToServices["httproute.default.configured.0"]
+	require.Len(t, service.Routes, 1)
+	assert.Equal(t, kong.StringSlice("/v1", "/v2"), service.Routes[0].Paths)
+	assert.Equal(t, kong.StringSlice("https"), service.Routes[0].Protocols)
+	assert.Equal(t, "auth,rate-limit", service.Routes[0].Ingress.Annotations[annotations.AnnotationPrefix+annotations.PluginsKey])
+	assert.Equal(t, "auth", route.Annotations[annotations.AnnotationPrefix+annotations.PluginsKey], "the cached object must not be modified")
+
+	t.Log("verifying that the routes of an httproute attached to a gateway without configuration use the defaults")
+	result = newIngressRules()
+	require.NoError(t, p.ingressRulesFromHTTPRoute(&result, httproute("default", "default")))
+	service = result.ServiceNameToServices["httproute.default.default.0"]
+	require.Len(t, service.Routes, 2)
+	assert.Equal(t, kong.StringSlice("http", "https"), service.Routes[0].Protocols)
+	assert.Equal(t, "auth", service.Routes[0].Ingress.Annotations[annotations.AnnotationPrefix+annotations.PluginsKey])
+
+	t.Log("verifying that the CombinedRoutes feature gate doesn't combine the routes of an httproute without configuration")
+	p.EnableCombinedServiceRoutes()
+	result = newIngressRules()
+	require.NoError(t, p.ingressRulesFromHTTPRoute(&result, httproute("default", "default")))
+	require.Len(t, result.ServiceNameToServices["httproute.default.default.0"].Routes, 2)
+
+	t.Log("verifying that an httproute attached to gateways with different configurations can't be translated")
+	result = newIngressRules()
+	assert.Error(t, p.ingressRulesFromHTTPRoute(&result, httproute("mixed", "configured", "default")))
+
+	t.Log("verifying that an httproute attached to a gateway with a missing configuration can't be translated")
+	result = newIngressRules()
+	assert.Error(t, p.ingressRulesFromHTTPRoute(&result, httproute("missing", "missing-config")))
+}
+
+func Test_combineKongRoutesByPath(t *testing.T) {
+	routes := combineKongRoutesByPath([]kongstate.Route{
+		{Route: kong.Route{Name: kong.String("a"), Paths: kong.StringSlice("/a"), Methods: kong.StringSlice("GET")}},
+		{Route: kong.Route{Name: kong.String("b"), Paths: kong.StringSlice("/b"), Methods: kong.StringSlice("GET")}},
+		{Route: kong.Route{Name: kong.String("c"), Paths: kong.StringSlice("/c"), Methods: kong.StringSlice("POST")}},
+		{Route: kong.Route{Name: kong.String("d"), Methods: kong.StringSlice("GET")}},
+	})
+	require.Len(t, routes, 3)
+	assert.Equal(t, "a", *routes[0].Name)
+	assert.Equal(t, kong.StringSlice("/a", "/b"), routes[0].Paths)
+	assert.Equal(t, "c", *routes[1].Name)
+	assert.Equal(t, "d", *routes[2].Name)
+}
@@ -2,14 +2,30 @@ package parser
 
 import (
 	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/kong/go-kong/kong"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
 )
 
+// -----------------------------------------------------------------------------
+// Translate HTTPRoute - Vars & Consts
+// -----------------------------------------------------------------------------
+
+// RequestMirrorPluginName is the name of the plugin which the RequestMirror
+// filters of HTTPRoutes are translated into. Kong doesn't bundle it, see
+// EnableRequestMirrorPlugin().
+const RequestMirrorPluginName = "request-mirror"
+
 // -----------------------------------------------------------------------------
 // Translate HTTPRoute - IngressRules Translation
 // -----------------------------------------------------------------------------
@@ -25,11 +41,19 @@ func (p *Parser) ingressRulesFromHTTPRoutes() ingressRules {
 		return result
 	}
 
-	var errs []error
+	// query param matches which Kong can't tell apart from other matches are
+	// rejected, as their routes would shadow each other.
+	queryParamMatchConflicts := findQueryParamMatchConflicts(httpRouteList)
+
 	for _, httproute := range httpRouteList {
-		if err := p.ingressRulesFromHTTPRoute(&result, httproute); err != nil {
+		err := queryParamMatchConflicts[client.ObjectKeyFromObject(httproute)]
+		if err == nil {
+			err = p.ingressRulesFromHTTPRoute(&result, httproute)
+		}
+		if err != nil {
 			err = fmt.Errorf("HTTPRoute %s/%s can't be routed: %w", httproute.Namespace, httproute.Name, err)
-			errs = append(errs, err)
+			p.logger.Errorf(err.Error())
+			p.registerTranslationFailure(err.Error(), httproute)
 		} else {
 			// at this point the object has been configured and can be
 			// reported as successfully parsed.
@@ -37,12 +61,6 @@ func (p *Parser) ingressRulesFromHTTPRoutes() ingressRules {
 		}
 	}
 
-	if len(errs) > 0 {
-		for _, err := range errs {
-			p.logger.Errorf(err.Error())
-		}
-	}
-
 	return result
 }
 
@@ -58,13 +76,38 @@ func (p *Parser) ingressRulesFromHTTPRoute(result *ingressRules, httproute *gate
 		return fmt.Errorf("no rules provided")
 	}
 
+	// only the hostnames of the httproute which the listeners of its gateways
+	// accept are programmed into the generated routes.
+	hostnames, err := p.getRouteHostnamesForListeners(httproute, spec.ParentRefs, spec.Hostnames)
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(hostnames, spec.Hostnames) {
+		httproute = httproute.DeepCopy()
+		httproute.Spec.Hostnames = hostnames
+	}
+
+	// the GatewayConfiguration of the gateways the route is attached to
+	// overrides the defaults of the generated routes.
+	config, err := p.getGatewayConfigurationForRoute(httproute.Namespace, spec.ParentRefs)
+	if err != nil {
+		return err
+	}
+
 	// each rule may represent a different set of backend services that will be accepting
 	// traffic, so we make separate routes and Kong services for every present rule.
 	for ruleNumber, rule := range spec.Rules {
+		// the filters of the rule are applied by the configuration of its routes
+		// and service, as all of its routes route to the same Kong service.
+		filterConfig, err := generateKongConfigFromHTTPRouteRuleFilters(httproute, rule)
+		if err != nil {
+			return err
+		}
+
 		// TODO: add this to a generic HTTPRoute validation, and then we should probably
 		//       simply be calling validation on each httproute object at the begininning
 		//       of the topmost list.
-		if len(rule.BackendRefs) == 0 {
+		if len(rule.BackendRefs) == 0 && !filterConfig.redirects {
 			return fmt.Errorf("missing backendRef in rule")
 		}
 
@@ -73,17 +116,44 @@ func (p *Parser) ingressRulesFromHTTPRoute(result *ingressRules, httproute *gate
 		if err != nil {
 			return err
 		}
+		if isCombinedRoutesEnabled(config) {
+			routes = combineKongRoutesByPath(routes)
+		}
+		applyGatewayConfigurationRouteProtocols(routes, config)
+		applyGatewayConfigurationPlugins(routes, config)
 
 		// create a service and attach the routes to it
-		var backendRefs []gatewayv1alpha2.BackendRef
-		// HTTPRoute uses a wrapper HTTPBackendRef to add optional filters to its BackendRefs
-		for _, hRef := range rule.BackendRefs {
-			backendRefs = append(backendRefs, hRef.BackendRef)
+		var service kongstate.Service
+		if len(rule.BackendRefs) == 0 {
+			// rules which only respond with redirects never proxy requests, so
+			// their service has no backends.
+			service = generateKongServiceWithoutBackends(httproute, ruleNumber)
+		} else {
+			var backendRefs []gatewayv1alpha2.BackendRef
+			// HTTPRoute uses a wrapper HTTPBackendRef to add optional filters to its BackendRefs
+			for _, hRef := range rule.BackendRefs {
+				backendRefs = append(backendRefs, hRef.BackendRef)
+			}
+			service, err = p.generateKongServiceFromBackendRef(result, httproute, ruleNumber, "http", backendRefs...)
+			if err != nil {
+				return err
+			}
 		}
-		service, err := p.generateKongServiceFromBackendRef(result, httproute, ruleNumber, "http", backendRefs...)
-		if err != nil {
-			return err
+		// requests matched by the rule are mirrored to a separate Kong service,
+		// which has no routes of its own.
+		if filterConfig.mirrorBackendRef != nil {
+			if !p.featureEnabledRequestMirrorPlugin {
+				return fmt.Errorf("%s filter requires the %s plugin, which is not available in Kong",
+					gatewayv1alpha2.HTTPRouteFilterRequestMirror, RequestMirrorPluginName)
+			}
+			mirror, err := p.generateKongMirrorServiceFromHTTPRouteRule(result, httproute, ruleNumber, *filterConfig.mirrorBackendRef)
+			if err != nil {
+				return err
+			}
+			result.ServiceNameToServices[*mirror.Service.Name] = mirror
+			filterConfig.plugins = append(filterConfig.plugins, generateRequestMirrorPlugin(mirror))
 		}
+		filterConfig.apply(routes, &service)
 		service.Routes = append(service.Routes, routes...)
 
 		// cache the service to avoid duplicates in further loop iterations
@@ -93,6 +163,177 @@ func (p *Parser) ingressRulesFromHTTPRoute(result *ingressRules, httproute *gate
 	return nil
 }
 
+// -----------------------------------------------------------------------------
+// Translate HTTPRoute - Query Param Matches
+// -----------------------------------------------------------------------------
+
+// httpRouteMatchKey identifies the requests which an HTTPRoute match routes
+// for one of the hostnames of the HTTPRoute and one of its gateways, except
+// for their query params, which Kong routes can't match.
+type httpRouteMatchKey struct {
+	gateway  k8stypes.NamespacedName
+	hostname string
+	path     string
+	method   string
+	headers  string
+}
+
+// httpRouteMatchRef refers to a match of an HTTPRoute rule, along with its
+// query param matches.
+type httpRouteMatchRef struct {
+	httproute   k8stypes.NamespacedName
+	rule        int
+	match       int
+	queryParams string
+}
+
+// findQueryParamMatchConflicts finds the HTTPRoutes with query param matches
+// which route the same requests as another match of the same or another
+// HTTPRoute with other query param matches, or none. Kong chooses one of their
+// routes regardless of the query params, and the pre-function plugin of the
+// chosen route then responds with a 404 to requests which only the other match
+// should route, so such HTTPRoutes are reported with an error instead.
+func findQueryParamMatchConflicts(httproutes []*gatewayv1alpha2.HTTPRoute) map[k8stypes.NamespacedName]error {
+	index := make(map[httpRouteMatchKey][]httpRouteMatchRef)
+	for _, httproute := range httproutes {
+		forEachHTTPRouteMatchKey(httproute, func(key httpRouteMatchKey, ref httpRouteMatchRef) {
+			index[key] = append(index[key], ref)
+		})
+	}
+
+	conflicts := make(map[k8stypes.NamespacedName]error)
+	for _, httproute := range httproutes {
+		forEachHTTPRouteMatchKey(httproute, func(key httpRouteMatchKey, ref httpRouteMatchRef) {
+			if ref.queryParams == "" {
+				return
+			}
+			if _, ok := conflicts[ref.httproute]; ok {
+				return
+			}
+			for _, other := range index[key] {
+				if other.queryParams == ref.queryParams {
+					continue
+				}
+				otherMatch := fmt.Sprintf("match %d of rule %d", other.match, other.rule)
+				if other.httproute != ref.httproute {
+					otherMatch += fmt.Sprintf(" of HTTPRoute %s", other.httproute)
+				}
+				conflicts[ref.httproute] = fmt.Errorf("query param matches of match %d of rule %d can't be told apart by Kong from %s, "+
+					"which matches the same hostname, path, method and headers with other query params", ref.match, ref.rule, otherMatch)
+				return
+			}
+		})
+	}
+	return conflicts
+}
+
+// forEachHTTPRouteMatchKey calls the provided function for each match of the
+// provided HTTPRoute, for each of its hostnames and gateways. Rules without
+// matches match all paths.
+func forEachHTTPRouteMatchKey(httproute *gatewayv1alpha2.HTTPRoute, f func(httpRouteMatchKey, httpRouteMatchRef)) {
+	gateways := []k8stypes.NamespacedName{{}}
+	if len(httproute.Spec.ParentRefs) > 0 {
+		gateways = gateways[:0]
+		for _, parentRef := range httproute.Spec.ParentRefs {
+			namespace := httproute.Namespace
+			if parentRef.Namespace != nil {
+				namespace = string(*parentRef.Namespace)
+			}
+			gateways = append(gateways, k8stypes.NamespacedName{Namespace: namespace, Name: string(parentRef.Name)})
+		}
+	}
+	hostnames := []string{""}
+	if len(httproute.Spec.Hostnames) > 0 {
+		hostnames = hostnames[:0]
+		for _, hostname := range httproute.Spec.Hostnames {
+			hostnames = append(hostnames, string(hostname))
+		}
+	}
+
+	for ruleNumber, rule := range httproute.Spec.Rules {
+		matches := rule.Matches
+		if len(matches) == 0 {
+			matches = []gatewayv1alpha2.HTTPRouteMatch{{}}
+		}
+		for matchNumber, match := range matches {
+			ref := httpRouteMatchRef{
+				httproute:   client.ObjectKeyFromObject(httproute),
+				rule:        ruleNumber,
+				match:       matchNumber,
+				queryParams: queryParamMatchesKey(match.QueryParams),
+			}
+			for _, gateway := range gateways {
+				for _, hostname := range hostnames {
+					f(httpRouteMatchKey{
+						gateway:  gateway,
+						hostname: hostname,
+						path:     pathMatchKey(match.Path),
+						method:   methodMatchKey(match.Method),
+						headers:  headerMatchesKey(match.Headers),
+					}, ref)
+				}
+			}
+		}
+	}
+}
+
+// pathMatchKey describes a path match, which defaults to the "/" prefix.
+func pathMatchKey(path *gatewayv1alpha2.HTTPPathMatch) string {
+	matchType, value := gatewayv1alpha2.PathMatchPathPrefix, "/"
+	if path != nil {
+		if path.Type != nil {
+			matchType = *path.Type
+		}
+		if path.Value != nil {
+			value = *path.Value
+		}
+	}
+	return string(matchType) + " " + value
+}
+
+// methodMatchKey describes a method match, which defaults to any method.
+func methodMatchKey(method *gatewayv1alpha2.HTTPMethod) string {
+	if method == nil {
+		return ""
+	}
+	return string(*method)
+}
+
+// headerMatchesKey describes a set of header matches regardless of their order.
+func headerMatchesKey(headers []gatewayv1alpha2.HTTPHeaderMatch) string {
+	keys := make([]string, 0, len(headers))
+	for _, header := range headers {
+		matchType := gatewayv1alpha2.HeaderMatchExact
+		if header.Type != nil {
+			matchType = *header.Type
+		}
+		keys = append(keys, fmt.Sprintf("%s %s %q", strings.ToLower(string(header.Name)), matchType, header.Value))
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
+
+// queryParamMatchesKey describes a set of query param matches regardless of
+// their order. As for the generated plugin, only the first match of each query
+// param is considered.
+func queryParamMatchesKey(params []gatewayv1alpha2.HTTPQueryParamMatch) string {
+	seen := make(map[string]struct{}, len(params))
+	keys := make([]string, 0, len(params))
+	for _, param := range params {
+		if _, ok := seen[param.Name]; ok {
+			continue
+		}
+		seen[param.Name] = struct{}{}
+		matchType := gatewayv1alpha2.QueryParamMatchExact
+		if param.Type != nil {
+			matchType = *param.Type
+		}
+		keys = append(keys, fmt.Sprintf("%q %s %q", param.Name, matchType, param.Value))
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
+
 // -----------------------------------------------------------------------------
 // Translate HTTPRoute - Utils
 // -----------------------------------------------------------------------------
@@ -137,11 +378,6 @@ func generateKongRoutesFromHTTPRouteRule(httproute *gatewayv1alpha2.HTTPRoute, r
 				matchNumber,
 			))
 
-			// TODO: implement query param matches
-			if len(match.QueryParams) > 0 {
-				return nil, fmt.Errorf("query param matches are not yet supported")
-			}
-
 			// build the route object using the method and pathing information
 			r := kongstate.Route{
 				Ingress: objectInfo,
@@ -158,16 +394,12 @@ func generateKongRoutesFromHTTPRouteRule(httproute *gatewayv1alpha2.HTTPRoute, r
 			}
 
 			// configure path matching information about the route if paths matching was defined
-			// Kong automatically infers whether or not a path is a regular expression and uses a prefix match by
-			// default it it is not. For those types, we use the path value as-is and let Kong determine the type.
-			// For exact matches, we transform the path into a regular expression that terminates after the value
 			if match.Path != nil {
-				if *match.Path.Type == gatewayv1alpha2.PathMatchExact {
-					terminated := *match.Path.Value + "$"
-					r.Route.Paths = []*string{&terminated}
-				} else if *match.Path.Type == gatewayv1alpha2.PathMatchRegularExpression || *match.Path.Type == gatewayv1alpha2.PathMatchPathPrefix {
-					r.Route.Paths = []*string{match.Path.Value}
+				path, err := convertGatewayPathMatchToKongRoutePath(*match.Path, util.GetKongVersion())
+				if err != nil {
+					return nil, err
 				}
+				r.Route.Paths = []*string{path}
 			}
 
 			// configure method matching information about the route if method
@@ -185,6 +417,16 @@ func generateKongRoutesFromHTTPRouteRule(httproute *gatewayv1alpha2.HTTPRoute, r
 				r.Route.Headers = headers
 			}
 
+			// Kong routes can't match query params, so requests whose query params
+			// don't match are rejected by a plugin of the route instead.
+			if len(match.QueryParams) > 0 {
+				plugin, err := generateQueryParamMatchPlugin(match.QueryParams, util.GetKongVersion())
+				if err != nil {
+					return nil, err
+				}
+				r.Plugins = append(r.Plugins, plugin)
+			}
+
 			// add the route to the list of routes for the service(s)
 			routes = append(routes, r)
 		}
@@ -215,3 +457,342 @@ func generateKongRoutesFromHTTPRouteRule(httproute *gatewayv1alpha2.HTTPRoute, r
 
 	return routes, nil
 }
+
+// httpRouteRuleFilterConfig is the Kong configuration which applies the
+// filters of an HTTPRoute rule to the traffic of the routes generated for it.
+type httpRouteRuleFilterConfig struct {
+	// plugins are attached to each of the routes of the rule.
+	plugins []kong.Plugin
+
+	// servicePath, when set, is the path of the Kong service of the rule. The
+	// routes of the rule then strip the path prefix they matched, so that it
+	// gets replaced with the service path.
+	servicePath *string
+
+	// httpsRedirectStatusCode, when set, restricts the routes of the rule to
+	// HTTPS and Kong redirects HTTP requests to them with this status code.
+	httpsRedirectStatusCode *int
+
+	// redirects indicates that the routes of the rule respond to all requests
+	// with a redirect, so the rule doesn't need any backendRefs.
+	redirects bool
+
+	// mirrorBackendRef, when set, is the backend which the requests routed by
+	// the rule are mirrored to.
+	mirrorBackendRef *gatewayv1alpha2.BackendObjectReference
+}
+
+// apply configures the provided routes and service of a rule with the filters
+// of the rule.
+func (c httpRouteRuleFilterConfig) apply(routes []kongstate.Route, service *kongstate.Service) {
+	for i := range routes {
+		routes[i].Plugins = append(routes[i].Plugins, c.plugins...)
+		if c.servicePath != nil {
+			routes[i].StripPath = kong.Bool(true)
+		}
+		if c.httpsRedirectStatusCode != nil {
+			routes[i].Protocols = kong.StringSlice("https")
+			routes[i].HTTPSRedirectStatusCode = kong.Int(*c.httpsRedirectStatusCode)
+		}
+	}
+	if c.servicePath != nil {
+		service.Path = kong.String(*c.servicePath)
+	}
+}
+
+// generateKongConfigFromHTTPRouteRuleFilters converts the filters of an
+// HTTPRoute rule, and the filters of its backendRefs, into the Kong
+// configuration which applies them to the traffic of the routes generated for
+// the rule. As all the backendRefs of a rule are load-balanced by a single Kong
+// service, backendRef filters can only be applied if all the backendRefs of
+// the rule have the same filters.
+func generateKongConfigFromHTTPRouteRuleFilters(httproute *gatewayv1alpha2.HTTPRoute, rule gatewayv1alpha2.HTTPRouteRule) (httpRouteRuleFilterConfig, error) {
+	filters := rule.Filters
+	if len(rule.BackendRefs) > 0 {
+		for _, ref := range rule.BackendRefs[1:] {
+			if !reflect.DeepEqual(ref.Filters, rule.BackendRefs[0].Filters) {
+				return httpRouteRuleFilterConfig{}, fmt.Errorf("backendRefs of the same rule with different filters are not supported")
+			}
+		}
+		filters = append(append([]gatewayv1alpha2.HTTPRouteFilter{}, filters...), rule.BackendRefs[0].Filters...)
+	}
+
+	var (
+		requestHeaderModifier *gatewayv1alpha2.HTTPRequestHeaderFilter
+		requestRedirect       *gatewayv1alpha2.HTTPRequestRedirectFilter
+		urlRewrite            *gatewayv1alpha2.HTTPURLRewriteFilter
+		requestMirror         *gatewayv1alpha2.HTTPRequestMirrorFilter
+	)
+	seen := map[gatewayv1alpha2.HTTPRouteFilterType]struct{}{}
+	for _, filter := range filters {
+		if _, ok := seen[filter.Type]; ok {
+			return httpRouteRuleFilterConfig{}, fmt.Errorf("only one %s filter is supported per rule, including the filters of its backendRefs", filter.Type)
+		}
+		seen[filter.Type] = struct{}{}
+
+		var configured bool
+		switch filter.Type { //nolint:exhaustive
+		case gatewayv1alpha2.HTTPRouteFilterRequestHeaderModifier:
+			requestHeaderModifier = filter.RequestHeaderModifier
+			configured = requestHeaderModifier != nil
+		case gatewayv1alpha2.HTTPRouteFilterRequestRedirect:
+			requestRedirect = filter.RequestRedirect
+			configured = requestRedirect != nil
+		case gatewayv1alpha2.HTTPRouteFilterURLRewrite:
+			urlRewrite = filter.URLRewrite
+			configured = urlRewrite != nil
+		case gatewayv1alpha2.HTTPRouteFilterRequestMirror:
+			requestMirror = filter.RequestMirror
+			configured = requestMirror != nil
+		default:
+			return httpRouteRuleFilterConfig{}, fmt.Errorf("%s filters are not yet supported", filter.Type)
+		}
+		if !configured {
+			return httpRouteRuleFilterConfig{}, fmt.Errorf("%s filter has no configuration", filter.Type)
+		}
+	}
+	if requestRedirect != nil && urlRewrite != nil {
+		return httpRouteRuleFilterConfig{}, fmt.Errorf("%s and %s filters can't be combined",
+			gatewayv1alpha2.HTTPRouteFilterRequestRedirect, gatewayv1alpha2.HTTPRouteFilterURLRewrite)
+	}
+
+	var config httpRouteRuleFilterConfig
+	if requestHeaderModifier != nil || (urlRewrite != nil && (urlRewrite.Hostname != nil || isAbsolutePathModifier(urlRewrite.Path))) {
+		plugin, err := generateRequestTransformerPlugin(requestHeaderModifier, urlRewrite)
+		if err != nil {
+			return httpRouteRuleFilterConfig{}, err
+		}
+		config.plugins = append(config.plugins, plugin)
+	}
+	if urlRewrite != nil && urlRewrite.Path != nil && urlRewrite.Path.Type == gatewayv1alpha2.PrefixMatchHTTPPathModifier {
+		if !hasOnlyPathPrefixMatches(rule) {
+			return httpRouteRuleFilterConfig{}, fmt.Errorf("%s filters replacing the path prefix are only supported for rules with PathPrefix matches",
+				gatewayv1alpha2.HTTPRouteFilterURLRewrite)
+		}
+		servicePath := urlRewrite.Path.Substitution
+		if servicePath == "" {
+			servicePath = "/"
+		}
+		config.servicePath = &servicePath
+	}
+	if requestRedirect != nil {
+		if err := config.addRedirect(httproute, rule, requestRedirect); err != nil {
+			return httpRouteRuleFilterConfig{}, err
+		}
+	}
+	if requestMirror != nil {
+		if requestMirror.BackendRef.Port == nil {
+			return httpRouteRuleFilterConfig{}, fmt.Errorf("backendRef of %s filter has no port", gatewayv1alpha2.HTTPRouteFilterRequestMirror)
+		}
+		config.mirrorBackendRef = &requestMirror.BackendRef
+	}
+	return config, nil
+}
+
+// generateKongMirrorServiceFromHTTPRouteRule creates the Kong service which the
+// requests routed by an HTTPRoute rule are mirrored to. Like the backendRefs of
+// the rule, the backendRef of the mirror can only refer to another namespace if
+// a ReferencePolicy permits it.
+func (p *Parser) generateKongMirrorServiceFromHTTPRouteRule(
+	result *ingressRules,
+	httproute *gatewayv1alpha2.HTTPRoute,
+	ruleNumber int,
+	backendRef gatewayv1alpha2.BackendObjectReference,
+) (kongstate.Service, error) {
+	serviceName := fmt.Sprintf("%s.%d.mirror", getUniqueKongServiceNameForObject(httproute), ruleNumber)
+	service, err := p.generateNamedKongServiceFromBackendRef(result, httproute, serviceName, "http",
+		gatewayv1alpha2.BackendRef{BackendObjectReference: backendRef})
+	if err != nil {
+		return kongstate.Service{}, fmt.Errorf("%s filter: %w", gatewayv1alpha2.HTTPRouteFilterRequestMirror, err)
+	}
+	return service, nil
+}
+
+// generateRequestMirrorPlugin generates the plugin which mirrors the requests
+// of a route to the provided Kong service. Kong doesn't bundle a plugin which
+// mirrors requests, so a plugin named request-mirror which sends copies of the
+// requests to the Kong service referenced by its configuration, and discards
+// the responses, needs to be installed in Kong.
+func generateRequestMirrorPlugin(mirror kongstate.Service) kong.Plugin {
+	return kong.Plugin{
+		Name:   kong.String(RequestMirrorPluginName),
+		Config: kong.Configuration{"service": *mirror.Service.Name},
+	}
+}
+
+// addRedirect configures the routes of a rule to respond to requests with the
+// redirect described by a RequestRedirect filter. Redirects to HTTPS which
+// keep the rest of the URL are made by Kong itself, and HTTPS requests are
+// then routed to the backendRefs of the rule. Any other redirect is made by a
+// request-termination plugin, whose response gets the location of the redirect
+// from a response-transformer plugin. The location can't depend on the request,
+// so it has to be fully determined by the filter and the rule.
+func (c *httpRouteRuleFilterConfig) addRedirect(
+	httproute *gatewayv1alpha2.HTTPRoute,
+	rule gatewayv1alpha2.HTTPRouteRule,
+	redirect *gatewayv1alpha2.HTTPRequestRedirectFilter,
+) error {
+	statusCode := http.StatusFound
+	if redirect.StatusCode != nil {
+		statusCode = *redirect.StatusCode
+	}
+
+	if redirect.Scheme != nil && *redirect.Scheme == "https" && redirect.Hostname == nil && redirect.Path == nil &&
+		(redirect.Port == nil || *redirect.Port == 443) {
+		c.httpsRedirectStatusCode = &statusCode
+		return nil
+	}
+
+	location, err := getHTTPRouteRedirectLocation(httproute, rule, redirect)
+	if err != nil {
+		return err
+	}
+	c.plugins = append(c.plugins,
+		kong.Plugin{
+			Name:   kong.String("request-termination"),
+			Config: kong.Configuration{"status_code": statusCode},
+		},
+		kong.Plugin{
+			Name:   kong.String("response-transformer"),
+			Config: kong.Configuration{"add": map[string]interface{}{"headers": []string{"Location:" + location}}},
+		},
+	)
+	c.redirects = true
+	return nil
+}
+
+// getHTTPRouteRedirectLocation determines the location of the redirect
+// described by a RequestRedirect filter of an HTTPRoute rule. The hostname
+// defaults to the hostname of the HTTPRoute, the path to the path of the
+// rule's match, and the location is relative to the scheme of the request when
+// the filter doesn't set one. The port is only included if the filter sets it.
+func getHTTPRouteRedirectLocation(
+	httproute *gatewayv1alpha2.HTTPRoute,
+	rule gatewayv1alpha2.HTTPRouteRule,
+	redirect *gatewayv1alpha2.HTTPRequestRedirectFilter,
+) (string, error) {
+	var hostname string
+	switch {
+	case redirect.Hostname != nil:
+		hostname = string(*redirect.Hostname)
+	case len(httproute.Spec.Hostnames) == 1 && !strings.HasPrefix(string(httproute.Spec.Hostnames[0]), "*"):
+		hostname = string(httproute.Spec.Hostnames[0])
+	default:
+		return "", fmt.Errorf("%s filters without a hostname are only supported for HTTPRoutes with a single hostname which is not a wildcard",
+			gatewayv1alpha2.HTTPRouteFilterRequestRedirect)
+	}
+
+	var path string
+	switch {
+	case isAbsolutePathModifier(redirect.Path):
+		path = redirect.Path.Substitution
+	case redirect.Path != nil:
+		return "", fmt.Errorf("%s filters replacing the path prefix are not supported", gatewayv1alpha2.HTTPRouteFilterRequestRedirect)
+	case len(rule.Matches) == 1 && rule.Matches[0].Path != nil && rule.Matches[0].Path.Type != nil &&
+		*rule.Matches[0].Path.Type == gatewayv1alpha2.PathMatchExact && rule.Matches[0].Path.Value != nil:
+		path = *rule.Matches[0].Path.Value
+	default:
+		return "", fmt.Errorf("%s filters without a path are only supported for rules with a single Exact path match",
+			gatewayv1alpha2.HTTPRouteFilterRequestRedirect)
+	}
+
+	location := "//" + hostname
+	if redirect.Scheme != nil {
+		location = *redirect.Scheme + ":" + location
+	}
+	if redirect.Port != nil {
+		location += ":" + strconv.Itoa(int(*redirect.Port))
+	}
+	return location + path, nil
+}
+
+// isAbsolutePathModifier indicates whether the provided path modifier replaces
+// the full path.
+func isAbsolutePathModifier(modifier *gatewayv1alpha2.HTTPPathModifier) bool {
+	return modifier != nil && modifier.Type == gatewayv1alpha2.AbsoluteHTTPPathModifier
+}
+
+// hasOnlyPathPrefixMatches indicates whether all the matches of the provided
+// rule match a path prefix, which is the default.
+func hasOnlyPathPrefixMatches(rule gatewayv1alpha2.HTTPRouteRule) bool {
+	for _, match := range rule.Matches {
+		if match.Path != nil && match.Path.Type != nil && *match.Path.Type != gatewayv1alpha2.PathMatchPathPrefix {
+			return false
+		}
+	}
+	return true
+}
+
+// generateRequestTransformerPlugin converts a RequestHeaderModifier filter and
+// the hostname and full path replacement of a URLRewrite filter into a
+// request-transformer plugin. Either filter may be nil. The plugin removes
+// headers first, and only adds headers which are not present, so headers
+// which are set are both replaced and added, and headers which are added are
+// appended. The hostname is rewritten by setting the Host header.
+func generateRequestTransformerPlugin(modifier *gatewayv1alpha2.HTTPRequestHeaderFilter, rewrite *gatewayv1alpha2.HTTPURLRewriteFilter) (kong.Plugin, error) {
+	if modifier == nil {
+		modifier = &gatewayv1alpha2.HTTPRequestHeaderFilter{}
+	}
+	removed := make(map[string]struct{}, len(modifier.Remove))
+	for _, name := range modifier.Remove {
+		removed[strings.ToLower(name)] = struct{}{}
+	}
+	headers := func(list []gatewayv1alpha2.HTTPHeader) ([]string, error) {
+		res := make([]string, 0, len(list))
+		for _, header := range list {
+			if _, ok := removed[strings.ToLower(string(header.Name))]; ok {
+				return nil, fmt.Errorf("header %s can't be both removed and set or added", header.Name)
+			}
+			if rewrite != nil && rewrite.Hostname != nil && strings.EqualFold(string(header.Name), "host") {
+				return nil, fmt.Errorf("header %s can't be modified when the hostname is rewritten", header.Name)
+			}
+			// request-transformer evaluates $(...) as a template.
+			if strings.Contains(header.Value, "$(") {
+				return nil, fmt.Errorf("value of header %s can't contain \"$(\"", header.Name)
+			}
+			res = append(res, string(header.Name)+":"+header.Value)
+		}
+		return res, nil
+	}
+
+	config := kong.Configuration{}
+	if len(modifier.Remove) > 0 {
+		config["remove"] = map[string]interface{}{"headers": modifier.Remove}
+	}
+	set, err := headers(modifier.Set)
+	if err != nil {
+		return kong.Plugin{}, err
+	}
+	replace := map[string]interface{}{}
+	if rewrite != nil && rewrite.Hostname != nil {
+		if _, ok := removed["host"]; ok {
+			return kong.Plugin{}, fmt.Errorf("header Host can't be removed when the hostname is rewritten")
+		}
+		set = append(set, "Host:"+string(*rewrite.Hostname))
+	}
+	if len(set) > 0 {
+		replace["headers"] = set
+		config["add"] = map[string]interface{}{"headers": set}
+	}
+	if rewrite != nil && isAbsolutePathModifier(rewrite.Path) {
+		if strings.Contains(rewrite.Path.Substitution, "$(") {
+			return kong.Plugin{}, fmt.Errorf("rewritten path can't contain \"$(\"")
+		}
+		replace["uri"] = rewrite.Path.Substitution
+	}
+	if len(replace) > 0 {
+		config["replace"] = replace
+	}
+	add, err := headers(modifier.Add)
+	if err != nil {
+		return kong.Plugin{}, err
+	}
+	if len(add) > 0 {
+		config["append"] = map[string]interface{}{"headers": add}
+	}
+
+	return kong.Plugin{
+		Name:   kong.String("request-transformer"),
+		Config: config,
+	}, nil
+}
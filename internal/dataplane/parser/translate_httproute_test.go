@@ -2,11 +2,14 @@ package parser
 
 import (
 	"fmt"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/kong/go-kong/kong"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -34,7 +37,7 @@ func Test_ingressRulesFromHTTPRoutes(t *testing.T) {
 	pathMatchPrefix := gatewayv1alpha2.PathMatchPathPrefix
 	pathMatchRegex := gatewayv1alpha2.PathMatchRegularExpression
 	pathMatchExact := gatewayv1alpha2.PathMatchExact
-	queryMatchExact := gatewayv1alpha2.QueryParamMatchExact
+	queryMatchRegex := gatewayv1alpha2.QueryParamMatchRegularExpression
 
 	for _, tt := range []struct {
 		msg      string
@@ -113,9 +116,10 @@ func Test_ingressRulesFromHTTPRoutes(t *testing.T) {
 								},
 							},
 							Ingress: util.K8sObjectInfo{
-								Name:        "basic-httproute",
-								Namespace:   corev1.NamespaceDefault,
-								Annotations: make(map[string]string),
+								Name:             "basic-httproute",
+								Namespace:        corev1.NamespaceDefault,
+								Annotations:      make(map[string]string),
+								GroupVersionKind: httprouteGVK,
 							},
 						}},
 					},
@@ -222,9 +226,93 @@ func Test_ingressRulesFromHTTPRoutes(t *testing.T) {
 								},
 							},
 							Ingress: util.K8sObjectInfo{
-								Name:        "basic-httproute",
-								Namespace:   corev1.NamespaceDefault,
-								Annotations: make(map[string]string),
+								Name:             "basic-httproute",
+								Namespace:        corev1.NamespaceDefault,
+								Annotations:      make(map[string]string),
+								GroupVersionKind: httprouteGVK,
+							},
+						}},
+					},
+				},
+			},
+		},
+		{
+			msg: "an HTTPRoute rule which only redirects results in a service without backends",
+			routes: []*gatewayv1alpha2.HTTPRoute{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "redirect-httproute",
+					Namespace: corev1.NamespaceDefault,
+				},
+				Spec: gatewayv1alpha2.HTTPRouteSpec{
+					CommonRouteSpec: gatewayv1alpha2.CommonRouteSpec{
+						ParentRefs: []gatewayv1alpha2.ParentReference{{
+							Name: gatewayv1alpha2.ObjectName("fake-gateway"),
+						}},
+					},
+					Hostnames: []gatewayv1alpha2.Hostname{"konghq.com"},
+					Rules: []gatewayv1alpha2.HTTPRouteRule{{
+						Matches: []gatewayv1alpha2.HTTPRouteMatch{{
+							Path: &gatewayv1alpha2.HTTPPathMatch{
+								Type:  &pathMatchExact,
+								Value: kong.String("/old"),
+							},
+						}},
+						Filters: []gatewayv1alpha2.HTTPRouteFilter{{
+							Type: gatewayv1alpha2.HTTPRouteFilterRequestRedirect,
+							RequestRedirect: &gatewayv1alpha2.HTTPRequestRedirectFilter{
+								Path: &gatewayv1alpha2.HTTPPathModifier{
+									Type:         gatewayv1alpha2.AbsoluteHTTPPathModifier,
+									Substitution: "/new",
+								},
+							},
+						}},
+					}},
+				},
+			}},
+			expected: ingressRules{
+				SecretNameToSNIs: SecretNameToSNIs{},
+				ServiceNameToServices: map[string]kongstate.Service{
+					"httproute.default.redirect-httproute.0": {
+						Service: kong.Service{
+							ConnectTimeout: kong.Int(60000),
+							Host:           kong.String("httproute.default.redirect-httproute.0"),
+							Name:           kong.String("httproute.default.redirect-httproute.0"),
+							Protocol:       kong.String("http"),
+							ReadTimeout:    kong.Int(60000),
+							Retries:        kong.Int(5),
+							WriteTimeout:   kong.Int(60000),
+						},
+						Namespace: "default",
+						Routes: []kongstate.Route{{
+							Route: kong.Route{
+								Name: kong.String("httproute.default.redirect-httproute.0.0"),
+								Paths: []*string{
+									kong.String("/old$"),
+								},
+								Hosts: []*string{
+									kong.String("konghq.com"),
+								},
+								PreserveHost: kong.Bool(true),
+								Protocols: []*string{
+									kong.String("http"),
+									kong.String("https"),
+								},
+							},
+							Ingress: util.K8sObjectInfo{
+								Name:             "redirect-httproute",
+								Namespace:        corev1.NamespaceDefault,
+								Annotations:      make(map[string]string),
+								GroupVersionKind: httprouteGVK,
+							},
+							Plugins: []kong.Plugin{
+								{
+									Name:   kong.String("request-termination"),
+									Config: kong.Configuration{"status_code": http.StatusFound},
+								},
+								{
+									Name:   kong.String("response-transformer"),
+									Config: kong.Configuration{"add": map[string]interface{}{"headers": []string{"Location://konghq.com/new"}}},
+								},
 							},
 						}},
 					},
@@ -255,7 +343,7 @@ func Test_ingressRulesFromHTTPRoutes(t *testing.T) {
 			},
 		},
 		{
-			msg: "an HTTPRoute with queryParam matches is not yet supported",
+			msg: "an HTTPRoute with regex queryParam matches is not supported",
 			routes: []*gatewayv1alpha2.HTTPRoute{{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "basic-httproute",
@@ -270,7 +358,7 @@ func Test_ingressRulesFromHTTPRoutes(t *testing.T) {
 					Rules: []gatewayv1alpha2.HTTPRouteRule{{
 						Matches: []gatewayv1alpha2.HTTPRouteMatch{{
 							QueryParams: []gatewayv1alpha2.HTTPQueryParamMatch{{
-								Type:  &queryMatchExact,
+								Type:  &queryMatchRegex,
 								Name:  "username",
 								Value: "kong",
 							}},
@@ -291,7 +379,7 @@ func Test_ingressRulesFromHTTPRoutes(t *testing.T) {
 				ServiceNameToServices: make(map[string]kongstate.Service),
 			},
 			errs: []error{
-				fmt.Errorf("query param matches are not yet supported"),
+				fmt.Errorf("RegularExpression query param matches are not supported"),
 			},
 		},
 		{
@@ -359,9 +447,10 @@ func Test_ingressRulesFromHTTPRoutes(t *testing.T) {
 								},
 							},
 							Ingress: util.K8sObjectInfo{
-								Name:        "basic-httproute",
-								Namespace:   corev1.NamespaceDefault,
-								Annotations: make(map[string]string),
+								Name:             "basic-httproute",
+								Namespace:        corev1.NamespaceDefault,
+								Annotations:      make(map[string]string),
+								GroupVersionKind: httprouteGVK,
 							},
 						}},
 					},
@@ -433,9 +522,10 @@ func Test_ingressRulesFromHTTPRoutes(t *testing.T) {
 								},
 							},
 							Ingress: util.K8sObjectInfo{
-								Name:        "basic-httproute",
-								Namespace:   corev1.NamespaceDefault,
-								Annotations: make(map[string]string),
+								Name:             "basic-httproute",
+								Namespace:        corev1.NamespaceDefault,
+								Annotations:      make(map[string]string),
+								GroupVersionKind: httprouteGVK,
 							},
 						}},
 					},
@@ -514,3 +604,468 @@ func Test_getHTTPRouteHostnamesAsSliceOfStringPointers(t *testing.T) {
 		})
 	}
 }
+
+func Test_generateKongConfigFromHTTPRouteRuleFilters(t *testing.T) {
+	backendRef := func(filters ...gatewayv1alpha2.HTTPRouteFilter) gatewayv1alpha2.HTTPBackendRef {
+		return gatewayv1alpha2.HTTPBackendRef{
+			BackendRef: gatewayv1alpha2.BackendRef{
+				BackendObjectReference: gatewayv1alpha2.BackendObjectReference{Name: "fake-service"},
+			},
+			Filters: filters,
+		}
+	}
+	headerModifier := gatewayv1alpha2.HTTPRouteFilter{
+		Type: gatewayv1alpha2.HTTPRouteFilterRequestHeaderModifier,
+		RequestHeaderModifier: &gatewayv1alpha2.HTTPRequestHeaderFilter{
+			Set:    []gatewayv1alpha2.HTTPHeader{{Name: "X-Set", Value: "set"}},
+			Add:    []gatewayv1alpha2.HTTPHeader{{Name: "X-Add", Value: "add:with:colons"}},
+			Remove: []string{"X-Remove"},
+		},
+	}
+	requestTransformer := kong.Plugin{
+		Name: kong.String("request-transformer"),
+		Config: kong.Configuration{
+			"remove":  map[string]interface{}{"headers": []string{"X-Remove"}},
+			"replace": map[string]interface{}{"headers": []string{"X-Set:set"}},
+			"add":     map[string]interface{}{"headers": []string{"X-Set:set"}},
+			"append":  map[string]interface{}{"headers": []string{"X-Add:add:with:colons"}},
+		},
+	}
+	redirect := func(redirect gatewayv1alpha2.HTTPRequestRedirectFilter) gatewayv1alpha2.HTTPRouteFilter {
+		return gatewayv1alpha2.HTTPRouteFilter{Type: gatewayv1alpha2.HTTPRouteFilterRequestRedirect, RequestRedirect: &redirect}
+	}
+	rewrite := func(rewrite gatewayv1alpha2.HTTPURLRewriteFilter) gatewayv1alpha2.HTTPRouteFilter {
+		return gatewayv1alpha2.HTTPRouteFilter{Type: gatewayv1alpha2.HTTPRouteFilterURLRewrite, URLRewrite: &rewrite}
+	}
+	redirectPlugins := func(statusCode int, location string) []kong.Plugin {
+		return []kong.Plugin{
+			{
+				Name:   kong.String("request-termination"),
+				Config: kong.Configuration{"status_code": statusCode},
+			},
+			{
+				Name:   kong.String("response-transformer"),
+				Config: kong.Configuration{"add": map[string]interface{}{"headers": []string{"Location:" + location}}},
+			},
+		}
+	}
+	https := "https"
+	hostname := gatewayv1alpha2.PreciseHostname("example.com")
+	rewrittenHostname := gatewayv1alpha2.Hostname("backend.example.com")
+	port := gatewayv1alpha2.PortNumber(8443)
+	httpsPort := gatewayv1alpha2.PortNumber(443)
+	statusCode := http.StatusMovedPermanently
+	pathMatchExact := gatewayv1alpha2.PathMatchExact
+	pathMatchPrefix := gatewayv1alpha2.PathMatchPathPrefix
+	pathMatch := func(matchType gatewayv1alpha2.PathMatchType, value string) gatewayv1alpha2.HTTPRouteMatch {
+		return gatewayv1alpha2.HTTPRouteMatch{Path: &gatewayv1alpha2.HTTPPathMatch{Type: &matchType, Value: &value}}
+	}
+	absolutePath := &gatewayv1alpha2.HTTPPathModifier{Type: gatewayv1alpha2.AbsoluteHTTPPathModifier, Substitution: "/new"}
+	prefixPath := &gatewayv1alpha2.HTTPPathModifier{Type: gatewayv1alpha2.PrefixMatchHTTPPathModifier, Substitution: "/new"}
+
+	for _, tt := range []struct {
+		msg       string
+		hostnames []gatewayv1alpha2.Hostname
+		rule      gatewayv1alpha2.HTTPRouteRule
+		expected  httpRouteRuleFilterConfig
+		err       error
+	}{
+		{
+			msg:  "a rule without filters produces no configuration",
+			rule: gatewayv1alpha2.HTTPRouteRule{BackendRefs: []gatewayv1alpha2.HTTPBackendRef{backendRef()}},
+		},
+		{
+			msg: "a RequestHeaderModifier filter of a rule produces a request-transformer plugin",
+			rule: gatewayv1alpha2.HTTPRouteRule{
+				Filters:     []gatewayv1alpha2.HTTPRouteFilter{headerModifier},
+				BackendRefs: []gatewayv1alpha2.HTTPBackendRef{backendRef(), backendRef()},
+			},
+			expected: httpRouteRuleFilterConfig{plugins: []kong.Plugin{requestTransformer}},
+		},
+		{
+			msg: "RequestHeaderModifier filters shared by all backendRefs produce a request-transformer plugin",
+			rule: gatewayv1alpha2.HTTPRouteRule{
+				BackendRefs: []gatewayv1alpha2.HTTPBackendRef{backendRef(headerModifier), backendRef(headerModifier)},
+			},
+			expected: httpRouteRuleFilterConfig{plugins: []kong.Plugin{requestTransformer}},
+		},
+		{
+			msg: "backendRefs with different filters can't be translated",
+			rule: gatewayv1alpha2.HTTPRouteRule{
+				BackendRefs: []gatewayv1alpha2.HTTPBackendRef{backendRef(headerModifier), backendRef()},
+			},
+			err: fmt.Errorf("backendRefs of the same rule with different filters are not supported"),
+		},
+		{
+			msg: "multiple RequestHeaderModifier filters can't be translated",
+			rule: gatewayv1alpha2.HTTPRouteRule{
+				Filters:     []gatewayv1alpha2.HTTPRouteFilter{headerModifier},
+				BackendRefs: []gatewayv1alpha2.HTTPBackendRef{backendRef(headerModifier)},
+			},
+			err: fmt.Errorf("only one RequestHeaderModifier filter is supported per rule, including the filters of its backendRefs"),
+		},
+		{
+			msg: "unsupported filters can't be translated",
+			rule: gatewayv1alpha2.HTTPRouteRule{
+				Filters: []gatewayv1alpha2.HTTPRouteFilter{{Type: gatewayv1alpha2.HTTPRouteFilterExtensionRef}},
+			},
+			err: fmt.Errorf("ExtensionRef filters are not yet supported"),
+		},
+		{
+			msg: "filters without configuration can't be translated",
+			rule: gatewayv1alpha2.HTTPRouteRule{
+				Filters: []gatewayv1alpha2.HTTPRouteFilter{{Type: gatewayv1alpha2.HTTPRouteFilterRequestRedirect}},
+			},
+			err: fmt.Errorf("RequestRedirect filter has no configuration"),
+		},
+		{
+			msg: "a RequestRedirect filter to HTTPS is made by the routes",
+			rule: gatewayv1alpha2.HTTPRouteRule{
+				Filters: []gatewayv1alpha2.HTTPRouteFilter{redirect(gatewayv1alpha2.HTTPRequestRedirectFilter{
+					Scheme: &https, Port: &httpsPort, StatusCode: &statusCode,
+				})},
+				BackendRefs: []gatewayv1alpha2.HTTPBackendRef{backendRef()},
+			},
+			expected: httpRouteRuleFilterConfig{httpsRedirectStatusCode: &statusCode},
+		},
+		{
+			msg: "a RequestRedirect filter to a full location produces request-termination and response-transformer plugins",
+			rule: gatewayv1alpha2.HTTPRouteRule{
+				Filters: []gatewayv1alpha2.HTTPRouteFilter{redirect(gatewayv1alpha2.HTTPRequestRedirectFilter{
+					Scheme: &https, Hostname: &hostname, Port: &port, Path: absolutePath,
+				})},
+			},
+			expected: httpRouteRuleFilterConfig{
+				plugins:   redirectPlugins(http.StatusFound, "https://example.com:8443/new"),
+				redirects: true,
+			},
+		},
+		{
+			msg:       "a RequestRedirect filter defaults to the hostname of the route and the path of its exact match",
+			hostnames: []gatewayv1alpha2.Hostname{"konghq.com"},
+			rule: gatewayv1alpha2.HTTPRouteRule{
+				Matches: []gatewayv1alpha2.HTTPRouteMatch{pathMatch(pathMatchExact, "/old")},
+				Filters: []gatewayv1alpha2.HTTPRouteFilter{redirect(gatewayv1alpha2.HTTPRequestRedirectFilter{
+					StatusCode: &statusCode,
+				})},
+			},
+			expected: httpRouteRuleFilterConfig{
+				plugins:   redirectPlugins(http.StatusMovedPermanently, "//konghq.com/old"),
+				redirects: true,
+			},
+		},
+		{
+			msg:       "a RequestRedirect filter can't default to a wildcard hostname",
+			hostnames: []gatewayv1alpha2.Hostname{"*.konghq.com"},
+			rule: gatewayv1alpha2.HTTPRouteRule{
+				Filters: []gatewayv1alpha2.HTTPRouteFilter{redirect(gatewayv1alpha2.HTTPRequestRedirectFilter{
+					Path: absolutePath,
+				})},
+			},
+			err: fmt.Errorf("RequestRedirect filters without a hostname are only supported for HTTPRoutes with a single hostname which is not a wildcard"),
+		},
+		{
+			msg: "a RequestRedirect filter can't default to the path of a prefix match",
+			rule: gatewayv1alpha2.HTTPRouteRule{
+				Matches: []gatewayv1alpha2.HTTPRouteMatch{pathMatch(pathMatchPrefix, "/old")},
+				Filters: []gatewayv1alpha2.HTTPRouteFilter{redirect(gatewayv1alpha2.HTTPRequestRedirectFilter{
+					Hostname: &hostname,
+				})},
+			},
+			err: fmt.Errorf("RequestRedirect filters without a path are only supported for rules with a single Exact path match"),
+		},
+		{
+			msg: "a RequestRedirect filter can't replace the path prefix",
+			rule: gatewayv1alpha2.HTTPRouteRule{
+				Filters: []gatewayv1alpha2.HTTPRouteFilter{redirect(gatewayv1alpha2.HTTPRequestRedirectFilter{
+					Hostname: &hostname, Path: prefixPath,
+				})},
+			},
+			err: fmt.Errorf("RequestRedirect filters replacing the path prefix are not supported"),
+		},
+		{
+			msg: "a URLRewrite filter of the hostname and full path is merged with the RequestHeaderModifier filter",
+			rule: gatewayv1alpha2.HTTPRouteRule{
+				Filters: []gatewayv1alpha2.HTTPRouteFilter{
+					headerModifier,
+					rewrite(gatewayv1alpha2.HTTPURLRewriteFilter{Hostname: &rewrittenHostname, Path: absolutePath}),
+				},
+				BackendRefs: []gatewayv1alpha2.HTTPBackendRef{backendRef()},
+			},
+			expected: httpRouteRuleFilterConfig{plugins: []kong.Plugin{{
+				Name: kong.String("request-transformer"),
+				Config: kong.Configuration{
+					"remove":  map[string]interface{}{"headers": []string{"X-Remove"}},
+					"replace": map[string]interface{}{"headers": []string{"X-Set:set", "Host:backend.example.com"}, "uri": "/new"},
+					"add":     map[string]interface{}{"headers": []string{"X-Set:set", "Host:backend.example.com"}},
+					"append":  map[string]interface{}{"headers": []string{"X-Add:add:with:colons"}},
+				},
+			}}},
+		},
+		{
+			msg: "a URLRewrite filter replacing the path prefix sets the service path",
+			rule: gatewayv1alpha2.HTTPRouteRule{
+				Matches:     []gatewayv1alpha2.HTTPRouteMatch{pathMatch(pathMatchPrefix, "/old"), {}},
+				Filters:     []gatewayv1alpha2.HTTPRouteFilter{rewrite(gatewayv1alpha2.HTTPURLRewriteFilter{Path: prefixPath})},
+				BackendRefs: []gatewayv1alpha2.HTTPBackendRef{backendRef()},
+			},
+			expected: httpRouteRuleFilterConfig{servicePath: kong.String("/new")},
+		},
+		{
+			msg: "a URLRewrite filter can't replace the path prefix of exact matches",
+			rule: gatewayv1alpha2.HTTPRouteRule{
+				Matches:     []gatewayv1alpha2.HTTPRouteMatch{pathMatch(pathMatchExact, "/old")},
+				Filters:     []gatewayv1alpha2.HTTPRouteFilter{rewrite(gatewayv1alpha2.HTTPURLRewriteFilter{Path: prefixPath})},
+				BackendRefs: []gatewayv1alpha2.HTTPBackendRef{backendRef()},
+			},
+			err: fmt.Errorf("URLRewrite filters replacing the path prefix are only supported for rules with PathPrefix matches"),
+		},
+		{
+			msg: "a URLRewrite filter of the hostname can't be combined with modifications of the Host header",
+			rule: gatewayv1alpha2.HTTPRouteRule{
+				Filters: []gatewayv1alpha2.HTTPRouteFilter{
+					{
+						Type: gatewayv1alpha2.HTTPRouteFilterRequestHeaderModifier,
+						RequestHeaderModifier: &gatewayv1alpha2.HTTPRequestHeaderFilter{
+							Set: []gatewayv1alpha2.HTTPHeader{{Name: "Host", Value: "other.example.com"}},
+						},
+					},
+					rewrite(gatewayv1alpha2.HTTPURLRewriteFilter{Hostname: &rewrittenHostname}),
+				},
+				BackendRefs: []gatewayv1alpha2.HTTPBackendRef{backendRef()},
+			},
+			err: fmt.Errorf("header Host can't be modified when the hostname is rewritten"),
+		},
+		{
+			msg: "a RequestMirror filter provides the mirrored backendRef",
+			rule: gatewayv1alpha2.HTTPRouteRule{
+				Filters: []gatewayv1alpha2.HTTPRouteFilter{{
+					Type:          gatewayv1alpha2.HTTPRouteFilterRequestMirror,
+					RequestMirror: &gatewayv1alpha2.HTTPRequestMirrorFilter{BackendRef: gatewayv1alpha2.BackendObjectReference{Name: "mirror", Port: &port}},
+				}},
+				BackendRefs: []gatewayv1alpha2.HTTPBackendRef{backendRef()},
+			},
+			expected: httpRouteRuleFilterConfig{mirrorBackendRef: &gatewayv1alpha2.BackendObjectReference{Name: "mirror", Port: &port}},
+		},
+		{
+			msg: "a RequestMirror filter without a port can't be translated",
+			rule: gatewayv1alpha2.HTTPRouteRule{
+				Filters: []gatewayv1alpha2.HTTPRouteFilter{{
+					Type:          gatewayv1alpha2.HTTPRouteFilterRequestMirror,
+					RequestMirror: &gatewayv1alpha2.HTTPRequestMirrorFilter{BackendRef: gatewayv1alpha2.BackendObjectReference{Name: "mirror"}},
+				}},
+				BackendRefs: []gatewayv1alpha2.HTTPBackendRef{backendRef()},
+			},
+			err: fmt.Errorf("backendRef of RequestMirror filter has no port"),
+		},
+		{
+			msg: "RequestRedirect and URLRewrite filters can't be combined",
+			rule: gatewayv1alpha2.HTTPRouteRule{
+				Filters: []gatewayv1alpha2.HTTPRouteFilter{
+					redirect(gatewayv1alpha2.HTTPRequestRedirectFilter{Scheme: &https}),
+					rewrite(gatewayv1alpha2.HTTPURLRewriteFilter{Hostname: &rewrittenHostname}),
+				},
+			},
+			err: fmt.Errorf("RequestRedirect and URLRewrite filters can't be combined"),
+		},
+	} {
+		t.Run(tt.msg, func(t *testing.T) {
+			httproute := &gatewayv1alpha2.HTTPRoute{Spec: gatewayv1alpha2.HTTPRouteSpec{Hostnames: tt.hostnames}}
+			config, err := generateKongConfigFromHTTPRouteRuleFilters(httproute, tt.rule)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.expected, config)
+		})
+	}
+}
+
+func Test_ingressRulesFromHTTPRouteWithRequestMirror(t *testing.T) {
+	group := gatewayv1alpha2.Group("")
+	kind := gatewayv1alpha2.Kind("Service")
+	port := gatewayv1alpha2.PortNumber(80)
+	mirrorNamespace := gatewayv1alpha2.Namespace("mirror")
+	httprouteWithMirror := func(name string, namespace *gatewayv1alpha2.Namespace) *gatewayv1alpha2.HTTPRoute {
+		httproute := &gatewayv1alpha2.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: corev1.NamespaceDefault},
+			Spec: gatewayv1alpha2.HTTPRouteSpec{
+				Hostnames: []gatewayv1alpha2.Hostname{"konghq.com"},
+				Rules: []gatewayv1alpha2.HTTPRouteRule{{
+					Filters: []gatewayv1alpha2.HTTPRouteFilter{{
+						Type: gatewayv1alpha2.HTTPRouteFilterRequestMirror,
+						RequestMirror: &gatewayv1alpha2.HTTPRequestMirrorFilter{
+							BackendRef: gatewayv1alpha2.BackendObjectReference{
+								Group: &group, Kind: &kind, Name: "mirror-service", Namespace: namespace, Port: &port,
+							},
+						},
+					}},
+					BackendRefs: []gatewayv1alpha2.HTTPBackendRef{{
+						BackendRef: gatewayv1alpha2.BackendRef{
+							BackendObjectReference: gatewayv1alpha2.BackendObjectReference{Name: "fake-service", Port: &port},
+						},
+					}},
+				}},
+			},
+		}
+		httproute.SetGroupVersionKind(httprouteGVK)
+		return httproute
+	}
+
+	fakestore, err := store.NewFakeStore(store.FakeObjects{
+		ReferencePolicies: []*gatewayv1alpha2.ReferencePolicy{{
+			ObjectMeta: metav1.ObjectMeta{Name: "allow-mirror", Namespace: string(mirrorNamespace)},
+			Spec: gatewayv1alpha2.ReferencePolicySpec{
+				From: []gatewayv1alpha2.ReferencePolicyFrom{{
+					Group:     gatewayv1alpha2.Group("gateway.networking.k8s.io"),
+					Kind:      gatewayv1alpha2.Kind("HTTPRoute"),
+					Namespace: gatewayv1alpha2.Namespace(corev1.NamespaceDefault),
+				}},
+				To: []gatewayv1alpha2.ReferencePolicyTo{{Group: group, Kind: kind}},
+			},
+		}},
+	})
+	require.NoError(t, err)
+	p := NewParser(logrus.New(), fakestore)
+
+	t.Log("verifying that a RequestMirror filter is rejected while the request-mirror plugin isn't available")
+	result := newIngressRules()
+	err = p.ingressRulesFromHTTPRoute(&result, httprouteWithMirror("local-mirror", nil))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "request-mirror plugin")
+	assert.Empty(t, result.ServiceNameToServices)
+
+	p.EnableRequestMirrorPlugin()
+
+	t.Log("verifying that a mirrored backendRef gets a Kong service wired to the routes of the rule")
+	result = newIngressRules()
+	require.NoError(t, p.ingressRulesFromHTTPRoute(&result, httprouteWithMirror("local-mirror", nil)))
+	mirror, ok := result.ServiceNameToServices["httproute.default.local-mirror.0.mirror"]
+	require.True(t, ok)
+	assert.Equal(t, []kongstate.ServiceBackend{{
+		Name:    "mirror-service",
+		PortDef: kongstate.PortDef{Mode: kongstate.PortModeByNumber, Number: 80},
+	}}, mirror.Backends)
+	assert.Empty(t, mirror.Routes)
+	service, ok := result.ServiceNameToServices["httproute.default.local-mirror.0"]
+	require.True(t, ok)
+	require.Len(t, service.Routes, 1)
+	assert.Equal(t, []kong.Plugin{{
+		Name:   kong.String("request-mirror"),
+		Config: kong.Configuration{"service": "httproute.default.local-mirror.0.mirror"},
+	}}, service.Routes[0].Plugins)
+
+	t.Log("verifying that a mirrored backendRef in another namespace is permitted by a ReferencePolicy")
+	result = newIngressRules()
+	require.NoError(t, p.ingressRulesFromHTTPRoute(&result, httprouteWithMirror("remote-mirror", &mirrorNamespace)))
+	mirror, ok = result.ServiceNameToServices["httproute.default.remote-mirror.0.mirror"]
+	require.True(t, ok)
+	assert.Equal(t, "mirror", mirror.Backends[0].Namespace)
+
+	t.Log("verifying that a mirrored backendRef in another namespace is rejected without a ReferencePolicy")
+	otherNamespace := gatewayv1alpha2.Namespace("other")
+	result = newIngressRules()
+	err = p.ingressRulesFromHTTPRoute(&result, httprouteWithMirror("forbidden-mirror", &otherNamespace))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "RequestMirror filter")
+	assert.Empty(t, result.ServiceNameToServices)
+}
+
+func Test_ingressRulesFromHTTPRoutesWithQueryParamMatchConflicts(t *testing.T) {
+	port := gatewayv1alpha2.PortNumber(80)
+	pathPrefix := gatewayv1alpha2.PathMatchPathPrefix
+	httproute := func(name string, parentName string, matches ...gatewayv1alpha2.HTTPRouteMatch) *gatewayv1alpha2.HTTPRoute {
+		rules := make([]gatewayv1alpha2.HTTPRouteRule, 0, len(matches))
+		for _, match := range matches {
+			rules = append(rules, gatewayv1alpha2.HTTPRouteRule{
+				Matches: []gatewayv1alpha2.HTTPRouteMatch{match},
+				BackendRefs: []gatewayv1alpha2.HTTPBackendRef{{
+					BackendRef: gatewayv1alpha2.BackendRef{
+						BackendObjectReference: gatewayv1alpha2.BackendObjectReference{Name: "fake-service", Port: &port},
+					},
+				}},
+			})
+		}
+		httproute := &gatewayv1alpha2.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: corev1.NamespaceDefault},
+			Spec: gatewayv1alpha2.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1alpha2.CommonRouteSpec{
+					ParentRefs: []gatewayv1alpha2.ParentReference{{Name: gatewayv1alpha2.ObjectName(parentName)}},
+				},
+				Hostnames: []gatewayv1alpha2.Hostname{"konghq.com"},
+				Rules:     rules,
+			},
+		}
+		httproute.SetGroupVersionKind(httprouteGVK)
+		return httproute
+	}
+	match := func(path string, query ...string) gatewayv1alpha2.HTTPRouteMatch {
+		match := gatewayv1alpha2.HTTPRouteMatch{Path: &gatewayv1alpha2.HTTPPathMatch{Type: &pathPrefix, Value: &path}}
+		for i := 0; i+1 < len(query); i += 2 {
+			match.QueryParams = append(match.QueryParams, gatewayv1alpha2.HTTPQueryParamMatch{Name: query[i], Value: query[i+1]})
+		}
+		return match
+	}
+
+	for _, tt := range []struct {
+		msg      string
+		routes   []*gatewayv1alpha2.HTTPRoute
+		rejected []string
+	}{
+		{
+			msg: "a query param match of a path which no other match routes is translated",
+			routes: []*gatewayv1alpha2.HTTPRoute{
+				httproute("versioned", "gateway", match("/foo", "v", "2")),
+				httproute("other-path", "gateway", match("/bar")),
+			},
+		},
+		{
+			msg: "a query param match of the same path as another rule of the route without query params is rejected",
+			routes: []*gatewayv1alpha2.HTTPRoute{
+				httproute("versioned", "gateway", match("/foo", "v", "2"), match("/foo")),
+			},
+			rejected: []string{"versioned"},
+		},
+		{
+			msg: "a query param match of the same path as an attached route without query params is rejected",
+			routes: []*gatewayv1alpha2.HTTPRoute{
+				httproute("versioned", "gateway", match("/foo", "v", "2")),
+				httproute("unversioned", "gateway", match("/foo")),
+			},
+			rejected: []string{"versioned"},
+		},
+		{
+			msg: "query param matches of the same path with other query params are both rejected",
+			routes: []*gatewayv1alpha2.HTTPRoute{
+				httproute("v1", "gateway", match("/foo", "v", "1")),
+				httproute("v2", "gateway", match("/foo", "v", "2")),
+			},
+			rejected: []string{"v1", "v2"},
+		},
+		{
+			msg: "a query param match of the same path as a route attached to another gateway is translated",
+			routes: []*gatewayv1alpha2.HTTPRoute{
+				httproute("versioned", "gateway", match("/foo", "v", "2")),
+				httproute("unversioned", "other-gateway", match("/foo")),
+			},
+		},
+	} {
+		t.Run(tt.msg, func(t *testing.T) {
+			fakestore, err := store.NewFakeStore(store.FakeObjects{HTTPRoutes: tt.routes})
+			require.NoError(t, err)
+			p := NewParser(logrus.New(), fakestore)
+
+			result := p.ingressRulesFromHTTPRoutes()
+
+			// other failures depend on the Kong version the tests run for.
+			var rejected []string
+			for _, failure := range p.PopTranslationFailures() {
+				if strings.Contains(failure.Reason, "can't be told apart by Kong") {
+					require.Len(t, failure.CausingObjects, 1)
+					rejected = append(rejected, failure.CausingObjects[0].GetName())
+				}
+			}
+			assert.ElementsMatch(t, tt.rejected, rejected)
+			for _, name := range rejected {
+				assert.NotContains(t, result.ServiceNameToServices, fmt.Sprintf("httproute.%s.%s.0", corev1.NamespaceDefault, name))
+			}
+		})
+	}
+}
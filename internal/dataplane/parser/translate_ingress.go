@@ -50,6 +50,7 @@ func (p *Parser) ingressRulesFromIngressV1beta1() ingressRules {
 
 				if strings.Contains(path, "//") {
 					log.Errorf("rule skipped: invalid path: '%v'", path)
+					p.registerTranslationFailure(fmt.Sprintf("rule skipped: invalid path: '%v'", path), ingress)
 					continue
 				}
 				if path == "" {
@@ -202,6 +203,7 @@ func (p *Parser) ingressRulesFromIngressV1() ingressRules {
 				for j, rulePath := range rule.HTTP.Paths {
 					if strings.Contains(rulePath.Path, "//") {
 						log.Errorf("rule skipped: invalid path: '%v'", rulePath.Path)
+						p.registerTranslationFailure(fmt.Sprintf("rule skipped: invalid path: '%v'", rulePath.Path), ingress)
 						continue
 					}
 
@@ -213,6 +215,7 @@ func (p *Parser) ingressRulesFromIngressV1() ingressRules {
 					paths, err := pathsFromK8s(rulePath.Path, pathType)
 					if err != nil {
 						log.WithError(err).Error("rule skipped: pathsFromK8s")
+						p.registerTranslationFailure(fmt.Sprintf("rule skipped: invalid path '%v': %v", rulePath.Path, err), ingress)
 						continue
 					}
 
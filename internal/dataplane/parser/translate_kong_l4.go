@@ -40,6 +40,7 @@ func (p *Parser) ingressRulesFromTCPIngressV1beta1() ingressRules {
 		for i, rule := range ingressSpec.Rules {
 			if !util.IsValidPort(rule.Port) {
 				log.Errorf("invalid TCPIngress: invalid port: %v", rule.Port)
+				p.registerTranslationFailure(fmt.Sprintf("rule %d skipped: invalid port: %v", i, rule.Port), ingress)
 				continue
 			}
 			r := kongstate.Route{
@@ -60,10 +61,12 @@ func (p *Parser) ingressRulesFromTCPIngressV1beta1() ingressRules {
 			}
 			if rule.Backend.ServiceName == "" {
 				log.Errorf("invalid TCPIngress: empty serviceName")
+				p.registerTranslationFailure(fmt.Sprintf("rule %d skipped: empty serviceName", i), ingress)
 				continue
 			}
 			if !util.IsValidPort(rule.Backend.ServicePort) {
 				log.Errorf("invalid TCPIngress: invalid servicePort: %v", rule.Backend.ServicePort)
+				p.registerTranslationFailure(fmt.Sprintf("rule %d skipped: invalid servicePort: %v", i, rule.Backend.ServicePort), ingress)
 				continue
 			}
 
@@ -128,14 +131,17 @@ func (p *Parser) ingressRulesFromUDPIngressV1beta1() ingressRules {
 			// validate the ports and servicenames for the rule
 			if !util.IsValidPort(rule.Port) {
 				log.Errorf("invalid UDPIngress: invalid port: %d", rule.Port)
+				p.registerTranslationFailure(fmt.Sprintf("rule %d skipped: invalid port: %d", i, rule.Port), ingress)
 				continue
 			}
 			if rule.Backend.ServiceName == "" {
 				log.Errorf("invalid UDPIngress: empty serviceName")
+				p.registerTranslationFailure(fmt.Sprintf("rule %d skipped: empty serviceName", i), ingress)
 				continue
 			}
 			if !util.IsValidPort(rule.Backend.ServicePort) {
 				log.Errorf("invalid UDPIngress: invalid servicePort: %d", rule.Backend.ServicePort)
+				p.registerTranslationFailure(fmt.Sprintf("rule %d skipped: invalid servicePort: %d", i, rule.Backend.ServicePort), ingress)
 				continue
 			}
 
@@ -25,11 +25,11 @@ func (p *Parser) ingressRulesFromTCPRoutes() ingressRules {
 		return result
 	}
 
-	var errs []error
 	for _, tcproute := range tcpRouteList {
 		if err := p.ingressRulesFromTCPRoute(&result, tcproute); err != nil {
 			err = fmt.Errorf("TCPRoute %s/%s can't be routed: %w", tcproute.Namespace, tcproute.Name, err)
-			errs = append(errs, err)
+			p.logger.Errorf(err.Error())
+			p.registerTranslationFailure(err.Error(), tcproute)
 		} else {
 			// at this point the object has been configured and can be
 			// reported as successfully parsed.
@@ -37,12 +37,6 @@ func (p *Parser) ingressRulesFromTCPRoutes() ingressRules {
 		}
 	}
 
-	if len(errs) > 0 {
-		for _, err := range errs {
-			p.logger.Errorf(err.Error())
-		}
-	}
-
 	return result
 }
 
@@ -58,6 +52,13 @@ func (p *Parser) ingressRulesFromTCPRoute(result *ingressRules, tcproute *gatewa
 		return fmt.Errorf("no rules provided")
 	}
 
+	// the plugins of the GatewayConfiguration of the gateways the route is
+	// attached to are configured on the generated routes.
+	config, err := p.getGatewayConfigurationForRoute(tcproute.Namespace, spec.ParentRefs)
+	if err != nil {
+		return err
+	}
+
 	// each rule may represent a different set of backend services that will be accepting
 	// traffic, so we make separate routes and Kong services for every present rule.
 	for ruleNumber, rule := range spec.Rules {
@@ -73,6 +74,7 @@ func (p *Parser) ingressRulesFromTCPRoute(result *ingressRules, tcproute *gatewa
 		if err != nil {
 			return err
 		}
+		applyGatewayConfigurationPlugins(routes, config)
 
 		// create a service and attach the routes to it
 		service, err := p.generateKongServiceFromBackendRef(result, tcproute, ruleNumber, "tcp", rule.BackendRefs...)
@@ -122,14 +124,14 @@ func generateKongRoutesFromTCPRouteRule(
 		0,
 	))
 
-	// for now, TCPRoutes provide no means of specifying a destination port other than the backend target port
-	// they will once https://gateway-api.sigs.k8s.io/geps/gep-957/ is stable. in the interim, this always uses the
-	var destinations []*kong.CIDRPort
-	for _, backendRef := range rule.BackendRefs {
-		destinations = append(destinations, &kong.CIDRPort{
-			Port: kong.Int(int(*backendRef.Port)),
-		})
+	// the backendRefs of the rule all share the port the route listens on, and
+	// the connections are split across them by weight by the upstream of the
+	// Kong service of the rule.
+	port, err := getL4RouteDestinationPort(rule.BackendRefs)
+	if err != nil {
+		return routes, err
 	}
+	destinations := []*kong.CIDRPort{{Port: kong.Int(port)}}
 
 	r := kongstate.Route{
 		Ingress: objectInfo,
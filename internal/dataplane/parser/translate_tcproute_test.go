@@ -0,0 +1,128 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+)
+
+func Test_ingressRulesFromTCPRouteWithWeightedBackends(t *testing.T) {
+	dbPort := gatewayv1alpha2.PortNumber(5432)
+	otherPort := gatewayv1alpha2.PortNumber(6432)
+	backendRef := func(name string, port *gatewayv1alpha2.PortNumber, weight int32) gatewayv1alpha2.BackendRef {
+		return gatewayv1alpha2.BackendRef{
+			BackendObjectReference: gatewayv1alpha2.BackendObjectReference{
+				Name: gatewayv1alpha2.ObjectName(name),
+				Port: port,
+			},
+			Weight: &weight,
+		}
+	}
+	tcproute := func(backendRefs ...gatewayv1alpha2.BackendRef) *gatewayv1alpha2.TCPRoute {
+		return &gatewayv1alpha2.TCPRoute{
+			TypeMeta: metav1.TypeMeta{Kind: "TCPRoute", APIVersion: gatewayv1alpha2.GroupVersion.String()},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "db",
+				Namespace: corev1.NamespaceDefault,
+			},
+			Spec: gatewayv1alpha2.TCPRouteSpec{
+				CommonRouteSpec: gatewayv1alpha2.CommonRouteSpec{
+					ParentRefs: []gatewayv1alpha2.ParentReference{{Name: "fake-gateway"}},
+				},
+				Rules: []gatewayv1alpha2.TCPRouteRule{{BackendRefs: backendRefs}},
+			},
+		}
+	}
+	service := func(name string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: corev1.NamespaceDefault},
+			Spec: corev1.ServiceSpec{
+				Ports: []corev1.ServicePort{{Name: "db", Port: 5432, Protocol: corev1.ProtocolTCP}},
+			},
+		}
+	}
+	endpoints := func(name string, ips ...string) *corev1.Endpoints {
+		addresses := make([]corev1.EndpointAddress, 0, len(ips))
+		for _, ip := range ips {
+			addresses = append(addresses, corev1.EndpointAddress{IP: ip})
+		}
+		return &corev1.Endpoints{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: corev1.NamespaceDefault},
+			Subsets: []corev1.EndpointSubset{{
+				Addresses: addresses,
+				Ports:     []corev1.EndpointPort{{Name: "db", Port: 5432, Protocol: corev1.ProtocolTCP}},
+			}},
+		}
+	}
+	build := func(route *gatewayv1alpha2.TCPRoute) (*Parser, map[string]int) {
+		fakestore, err := store.NewFakeStore(store.FakeObjects{
+			TCPRoutes: []*gatewayv1alpha2.TCPRoute{route},
+			Services:  []*corev1.Service{service("stable"), service("canary")},
+			Endpoints: []*corev1.Endpoints{
+				endpoints("stable", "10.0.0.1", "10.0.0.2"),
+				endpoints("canary", "10.0.1.1"),
+			},
+		})
+		require.NoError(t, err)
+		p := NewParser(logrus.New(), fakestore)
+		state, err := p.Build()
+		require.NoError(t, err)
+
+		weights := make(map[string]int)
+		for _, upstream := range state.Upstreams {
+			for _, target := range upstream.Targets {
+				weights[*target.Target.Target] = *target.Weight
+			}
+		}
+		return p, weights
+	}
+
+	t.Log("verifying that the connections are split across the backends by weight")
+	p, weights := build(tcproute(backendRef("stable", &dbPort, 80), backendRef("canary", &dbPort, 20)))
+	assert.Empty(t, p.PopTranslationFailures())
+	assert.Equal(t, map[string]int{
+		"10.0.0.1:5432": 40,
+		"10.0.0.2:5432": 40,
+		"10.0.1.1:5432": 20,
+	}, weights)
+
+	t.Log("verifying that a backend with a weight of 0 is drained")
+	p, weights = build(tcproute(backendRef("stable", &dbPort, 100), backendRef("canary", &dbPort, 0)))
+	assert.Empty(t, p.PopTranslationFailures())
+	assert.Equal(t, map[string]int{
+		"10.0.0.1:5432": 50,
+		"10.0.0.2:5432": 50,
+		"10.0.1.1:5432": 0,
+	}, weights)
+
+	t.Log("verifying that the route listens on the port shared by the backends")
+	result := newIngressRules()
+	require.NoError(t, p.ingressRulesFromTCPRoute(&result, tcproute(backendRef("stable", &dbPort, 80), backendRef("canary", &dbPort, 20))))
+	require.Len(t, result.ServiceNameToServices, 1)
+	for _, service := range result.ServiceNameToServices {
+		require.Len(t, service.Routes, 1)
+		assert.Equal(t, []*kong.CIDRPort{{Port: kong.Int(5432)}}, service.Routes[0].Destinations)
+		assert.Len(t, service.Backends, 2)
+	}
+
+	t.Log("verifying that backends with different ports are reported as a translation failure")
+	p, weights = build(tcproute(backendRef("stable", &dbPort, 80), backendRef("canary", &otherPort, 20)))
+	failures := p.PopTranslationFailures()
+	require.Len(t, failures, 1)
+	assert.Contains(t, failures[0].Reason, "have different ports (5432 and 6432)")
+	assert.Empty(t, weights)
+
+	t.Log("verifying that backends with a negative weight are reported as a translation failure")
+	p, _ = build(tcproute(backendRef("stable", &dbPort, 100), backendRef("canary", &dbPort, -1)))
+	failures = p.PopTranslationFailures()
+	require.Len(t, failures, 1)
+	assert.Contains(t, failures[0].Reason, "backendRef canary has negative weight -1")
+}
@@ -2,6 +2,7 @@ package parser
 
 import (
 	"fmt"
+	"reflect"
 
 	"github.com/kong/go-kong/kong"
 	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
@@ -25,11 +26,11 @@ func (p *Parser) ingressRulesFromTLSRoutes() ingressRules {
 		return result
 	}
 
-	var errs []error
 	for _, tlsroute := range tlsRouteList {
 		if err := p.ingressRulesFromTLSRoute(&result, tlsroute); err != nil {
 			err = fmt.Errorf("TLSRoute %s/%s can't be routed: %w", tlsroute.Namespace, tlsroute.Name, err)
-			errs = append(errs, err)
+			p.logger.Errorf(err.Error())
+			p.registerTranslationFailure(err.Error(), tlsroute)
 		} else {
 			// at this point the object has been configured and can be
 			// reported as successfully parsed.
@@ -37,12 +38,6 @@ func (p *Parser) ingressRulesFromTLSRoutes() ingressRules {
 		}
 	}
 
-	if len(errs) > 0 {
-		for _, err := range errs {
-			p.logger.Errorf(err.Error())
-		}
-	}
-
 	return result
 }
 
@@ -57,14 +52,39 @@ func (p *Parser) ingressRulesFromTLSRoute(result *ingressRules, tlsroute *gatewa
 		return fmt.Errorf("no rules provided")
 	}
 
+	// only the hostnames of the tlsroute which the listeners of its gateways
+	// accept are programmed into the generated routes.
+	hostnames, err := p.getRouteHostnamesForListeners(tlsroute, spec.ParentRefs, spec.Hostnames)
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(hostnames, spec.Hostnames) {
+		tlsroute = tlsroute.DeepCopy()
+		tlsroute.Spec.Hostnames = hostnames
+	}
+
+	// the plugins of the GatewayConfiguration of the gateways the route is
+	// attached to are configured on the generated routes.
+	config, err := p.getGatewayConfigurationForRoute(tlsroute.Namespace, spec.ParentRefs)
+	if err != nil {
+		return err
+	}
+
 	// each rule may represent a different set of backend services that will be accepting
 	// traffic, so we make separate routes and Kong services for every present rule.
 	for ruleNumber, rule := range spec.Rules {
+		// the connections are split across the backendRefs of the rule by weight
+		// by the upstream of the Kong service of the rule.
+		if err := validateL4RouteBackendRefs(rule.BackendRefs); err != nil {
+			return err
+		}
+
 		// determine the routes needed to route traffic to services for this rule
 		routes, err := generateKongRoutesFromTLSRouteRule(tlsroute, ruleNumber, rule)
 		if err != nil {
 			return err
 		}
+		applyGatewayConfigurationPlugins(routes, config)
 
 		// create a service and attach the routes to it
 		service, err := p.generateKongServiceFromBackendRef(result, tlsroute, ruleNumber, "tcp", rule.BackendRefs...)
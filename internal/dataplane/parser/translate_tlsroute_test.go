@@ -0,0 +1,130 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+)
+
+func Test_ingressRulesFromTLSRouteWithWeightedBackends(t *testing.T) {
+	tlsPort := gatewayv1alpha2.PortNumber(8443)
+	otherPort := gatewayv1alpha2.PortNumber(9443)
+	backendRef := func(name string, port *gatewayv1alpha2.PortNumber, weight int32) gatewayv1alpha2.BackendRef {
+		return gatewayv1alpha2.BackendRef{
+			BackendObjectReference: gatewayv1alpha2.BackendObjectReference{
+				Name: gatewayv1alpha2.ObjectName(name),
+				Port: port,
+			},
+			Weight: &weight,
+		}
+	}
+	tlsroute := func(backendRefs ...gatewayv1alpha2.BackendRef) *gatewayv1alpha2.TLSRoute {
+		return &gatewayv1alpha2.TLSRoute{
+			TypeMeta: metav1.TypeMeta{Kind: "TLSRoute", APIVersion: gatewayv1alpha2.GroupVersion.String()},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "api",
+				Namespace: corev1.NamespaceDefault,
+			},
+			Spec: gatewayv1alpha2.TLSRouteSpec{
+				CommonRouteSpec: gatewayv1alpha2.CommonRouteSpec{
+					ParentRefs: []gatewayv1alpha2.ParentReference{{Name: "fake-gateway"}},
+				},
+				Hostnames: []gatewayv1alpha2.Hostname{"api.konghq.com"},
+				Rules:     []gatewayv1alpha2.TLSRouteRule{{BackendRefs: backendRefs}},
+			},
+		}
+	}
+	service := func(name string, port int32) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: corev1.NamespaceDefault},
+			Spec: corev1.ServiceSpec{
+				Ports: []corev1.ServicePort{{Name: "tls", Port: port, Protocol: corev1.ProtocolTCP}},
+			},
+		}
+	}
+	endpoints := func(name string, port int32, ips ...string) *corev1.Endpoints {
+		addresses := make([]corev1.EndpointAddress, 0, len(ips))
+		for _, ip := range ips {
+			addresses = append(addresses, corev1.EndpointAddress{IP: ip})
+		}
+		return &corev1.Endpoints{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: corev1.NamespaceDefault},
+			Subsets: []corev1.EndpointSubset{{
+				Addresses: addresses,
+				Ports:     []corev1.EndpointPort{{Name: "tls", Port: port, Protocol: corev1.ProtocolTCP}},
+			}},
+		}
+	}
+	build := func(route *gatewayv1alpha2.TLSRoute) (*Parser, map[string]int) {
+		fakestore, err := store.NewFakeStore(store.FakeObjects{
+			TLSRoutes: []*gatewayv1alpha2.TLSRoute{route},
+			Services:  []*corev1.Service{service("stable", 8443), service("canary", 9443)},
+			Endpoints: []*corev1.Endpoints{
+				endpoints("stable", 8443, "10.0.0.1", "10.0.0.2"),
+				endpoints("canary", 9443, "10.0.1.1"),
+			},
+		})
+		require.NoError(t, err)
+		p := NewParser(logrus.New(), fakestore)
+		state, err := p.Build()
+		require.NoError(t, err)
+
+		weights := make(map[string]int)
+		for _, upstream := range state.Upstreams {
+			for _, target := range upstream.Targets {
+				weights[*target.Target.Target] = *target.Weight
+			}
+		}
+		return p, weights
+	}
+
+	t.Log("verifying that the connections are split across the backends by weight, on the port of each backend")
+	p, weights := build(tlsroute(backendRef("stable", &tlsPort, 80), backendRef("canary", &otherPort, 20)))
+	assert.Empty(t, p.PopTranslationFailures())
+	assert.Equal(t, map[string]int{
+		"10.0.0.1:8443": 40,
+		"10.0.0.2:8443": 40,
+		"10.0.1.1:9443": 20,
+	}, weights)
+
+	t.Log("verifying that a backend with a weight of 0 is drained")
+	p, weights = build(tlsroute(backendRef("stable", &tlsPort, 0), backendRef("canary", &otherPort, 100)))
+	assert.Empty(t, p.PopTranslationFailures())
+	assert.Equal(t, map[string]int{
+		"10.0.0.1:8443": 0,
+		"10.0.0.2:8443": 0,
+		"10.0.1.1:9443": 100,
+	}, weights)
+
+	t.Log("verifying that the route matches the hostnames of the tlsroute, as TLS connections are routed by SNI")
+	result := newIngressRules()
+	require.NoError(t, p.ingressRulesFromTLSRoute(&result, tlsroute(backendRef("stable", &tlsPort, 80), backendRef("canary", &otherPort, 20))))
+	require.Len(t, result.ServiceNameToServices, 1)
+	for _, service := range result.ServiceNameToServices {
+		require.Len(t, service.Routes, 1)
+		assert.Equal(t, kong.StringSlice("api.konghq.com"), service.Routes[0].SNIs)
+		assert.Empty(t, service.Routes[0].Destinations)
+		assert.Len(t, service.Backends, 2)
+	}
+
+	t.Log("verifying that backends without a port are reported as a translation failure")
+	p, weights = build(tlsroute(backendRef("stable", &tlsPort, 80), backendRef("canary", nil, 20)))
+	failures := p.PopTranslationFailures()
+	require.Len(t, failures, 1)
+	assert.Contains(t, failures[0].Reason, "backendRef canary has no port")
+	assert.Empty(t, weights)
+
+	t.Log("verifying that backends with a negative weight are reported as a translation failure")
+	p, _ = build(tlsroute(backendRef("stable", &tlsPort, 100), backendRef("canary", &otherPort, -1)))
+	failures = p.PopTranslationFailures()
+	require.Len(t, failures, 1)
+	assert.Contains(t, failures[0].Reason, "backendRef canary has negative weight -1")
+}
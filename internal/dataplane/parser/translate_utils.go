@@ -3,6 +3,8 @@ package parser
 import (
 	"fmt"
 	"reflect"
+	"regexp"
+	"strings"
 
 	"github.com/blang/semver/v4"
 	"github.com/kong/go-kong/kong"
@@ -20,6 +22,20 @@ const kongHeaderRegexPrefix = "~*"
 // MinRegexHeaderKongVersion is the minimum Kong version that supports regex header matches
 var MinRegexHeaderKongVersion = semver.MustParse("2.8.0")
 
+// kongPathRegexPrefix is a reserved prefix string that Kong 3.0 and later require to parse a path as a regex,
+// whereas earlier versions treat any path which contains regex characters as a regex
+const kongPathRegexPrefix = "~"
+
+// MinExplicitRegexPathKongVersion is the minimum Kong version that requires regex paths to be prefixed
+var MinExplicitRegexPathKongVersion = semver.MustParse("3.0.0")
+
+// MinVaultKongVersion is the minimum Kong version that supports vaults, which KongVaults are translated into
+var MinVaultKongVersion = semver.MustParse("2.8.0")
+
+// MinQueryParamMatchKongVersion is the minimum Kong version whose pre-function plugin can run code in the access
+// phase, which query param matches are translated into
+var MinQueryParamMatchKongVersion = semver.MustParse("2.3.0")
+
 // -----------------------------------------------------------------------------
 // Translate Utilities - Gateway
 // -----------------------------------------------------------------------------
@@ -51,6 +67,95 @@ func convertGatewayMatchHeadersToKongRouteMatchHeaders(headers []gatewayv1alpha2
 	return convertedHeaders, nil
 }
 
+// convertGatewayPathMatchToKongRoutePath takes a Gateway APIs HTTPPathMatch and converts it to the path of a Kong
+// route for the provided Kong version. Kong matches paths as a prefix by default, and regexes from the start of the
+// path, so exact matches are translated into a regex which terminates after the escaped value.
+func convertGatewayPathMatchToKongRoutePath(path gatewayv1alpha2.HTTPPathMatch, kongVersion semver.Version) (*string, error) {
+	if path.Value == nil {
+		return nil, fmt.Errorf("path match has no value")
+	}
+	regexPrefix := ""
+	if kongVersion.GTE(MinExplicitRegexPathKongVersion) {
+		regexPrefix = kongPathRegexPrefix
+	}
+
+	switch {
+	case path.Type == nil || *path.Type == gatewayv1alpha2.PathMatchPathPrefix:
+		return kong.String(*path.Value), nil
+	case *path.Type == gatewayv1alpha2.PathMatchExact:
+		return kong.String(regexPrefix + regexp.QuoteMeta(*path.Value) + "$"), nil
+	case *path.Type == gatewayv1alpha2.PathMatchRegularExpression:
+		if _, err := regexp.Compile(*path.Value); err != nil {
+			return nil, fmt.Errorf("invalid regex path match %s: %w", *path.Value, err)
+		}
+		return kong.String(regexPrefix + *path.Value), nil
+	default:
+		return nil, fmt.Errorf("unknown/unsupported path match type: %s", string(*path.Type))
+	}
+}
+
+// generateQueryParamMatchPlugin takes a list of Gateway APIs HTTPQueryParamMatch and converts it to a pre-function
+// plugin for the provided Kong version, as Kong routes can't match query params themselves. The plugin responds to
+// requests whose query params don't match as Kong does when no route matches. As a route is chosen before the plugin
+// runs, requests rejected by it would not be routed to other routes which only differ by their query param matches,
+// so such matches are rejected by findQueryParamMatchConflicts() instead.
+func generateQueryParamMatchPlugin(params []gatewayv1alpha2.HTTPQueryParamMatch, kongVersion semver.Version) (kong.Plugin, error) {
+	seen := make(map[string]struct{}, len(params))
+	conditions := make([]string, 0, len(params))
+	for _, param := range params {
+		if param.Type != nil && *param.Type != gatewayv1alpha2.QueryParamMatchExact {
+			return kong.Plugin{}, fmt.Errorf("%s query param matches are not supported", string(*param.Type))
+		}
+		// only the first match of each query param is considered.
+		if _, ok := seen[param.Name]; ok {
+			continue
+		}
+		seen[param.Name] = struct{}{}
+		conditions = append(conditions, fmt.Sprintf("first(query[%s]) ~= %s", luaString(param.Name), luaString(param.Value)))
+	}
+	if kongVersion.LT(MinQueryParamMatchKongVersion) {
+		return kong.Plugin{}, fmt.Errorf("Kong version %s does not support query param matches", kongVersion.String())
+	}
+
+	code := `local query = kong.request.get_query()
+local function first(value)
+  if type(value) == "table" then
+    return value[1]
+  end
+  return value
+end
+if ` + strings.Join(conditions, " or ") + ` then
+  return kong.response.exit(404, { message = "no Route matched with those values" })
+end
+`
+	return kong.Plugin{
+		Name:   kong.String("pre-function"),
+		Config: kong.Configuration{"access": []string{code}},
+	}, nil
+}
+
+// luaString quotes the provided string as a Lua string literal. Bytes other
+// than printable ASCII are escaped as decimal escape sequences, which are
+// understood by all Lua versions.
+func luaString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '"' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < 0x20 || c > 0x7e:
+			fmt.Fprintf(&b, "\\%03d", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
 // isRefAllowedByPolicy checks if backendRef is permitted by the provided namespace-indexed ReferencePolicyTo set,
 // allowed. allowed is assumed to contain Tos that only match the backendRef's parent's From, as returned by
 // getPermittedForReferencePolicyFrom
@@ -97,6 +202,50 @@ func getPermittedForReferencePolicyFrom(from gatewayv1alpha2.ReferencePolicyFrom
 	return allowed
 }
 
+// validateL4RouteBackendRefs checks that the backendRefs of a TCPRoute, UDPRoute
+// or TLSRoute rule can be combined into the single Kong service of the rule, whose
+// upstream splits the connections across the backends according to their weights.
+// Backends with a weight of 0 are drained and receive no new connections.
+func validateL4RouteBackendRefs(backendRefs []gatewayv1alpha2.BackendRef) error {
+	for _, backendRef := range backendRefs {
+		if backendRef.Group != nil && *backendRef.Group != "" && *backendRef.Group != "core" {
+			return fmt.Errorf("backendRef %s has unsupported group %s, only Services are supported", backendRef.Name, *backendRef.Group)
+		}
+		if backendRef.Kind != nil && *backendRef.Kind != "Service" {
+			return fmt.Errorf("backendRef %s has unsupported kind %s, only Services are supported", backendRef.Name, *backendRef.Kind)
+		}
+		if backendRef.Port == nil {
+			return fmt.Errorf("backendRef %s has no port", backendRef.Name)
+		}
+		if backendRef.Weight != nil && *backendRef.Weight < 0 {
+			return fmt.Errorf("backendRef %s has negative weight %d", backendRef.Name, *backendRef.Weight)
+		}
+	}
+	return nil
+}
+
+// getL4RouteDestinationPort returns the port which the Kong route generated for a
+// TCPRoute or UDPRoute rule listens on. Until routes can specify it (see
+// https://gateway-api.sigs.k8s.io/geps/gep-957/), this is the port of the backends,
+// so backendRefs with different ports can't be combined in a single rule.
+func getL4RouteDestinationPort(backendRefs []gatewayv1alpha2.BackendRef) (int, error) {
+	if err := validateL4RouteBackendRefs(backendRefs); err != nil {
+		return 0, err
+	}
+	if len(backendRefs) == 0 {
+		return 0, fmt.Errorf("no backendRefs present, cannot determine the destination port")
+	}
+	port := *backendRefs[0].Port
+	for _, backendRef := range backendRefs[1:] {
+		if *backendRef.Port != port {
+			return 0, fmt.Errorf("backendRefs %s and %s have different ports (%d and %d), "+
+				"which can't be combined as the port of the backends is the port the route listens on",
+				backendRefs[0].Name, backendRef.Name, port, *backendRef.Port)
+		}
+	}
+	return int(port), nil
+}
+
 // generateKongServiceFromBackendRef translates backendRefs for rule ruleNumber into a Kong service for use with the
 // rules generated from a Gateway APIs route
 func (p *Parser) generateKongServiceFromBackendRef(
@@ -105,6 +254,21 @@ func (p *Parser) generateKongServiceFromBackendRef(
 	ruleNumber int,
 	protocol string,
 	backendRefs ...gatewayv1alpha2.BackendRef,
+) (kongstate.Service, error) {
+	// the service name needs to uniquely identify this service given it's list of
+	// one or more backends.
+	serviceName := fmt.Sprintf("%s.%d", getUniqueKongServiceNameForObject(route), ruleNumber)
+	return p.generateNamedKongServiceFromBackendRef(rules, route, serviceName, protocol, backendRefs...)
+}
+
+// generateNamedKongServiceFromBackendRef translates backendRefs into a Kong service with the provided name, for
+// routes which need more than one Kong service per rule.
+func (p *Parser) generateNamedKongServiceFromBackendRef(
+	rules *ingressRules,
+	route client.Object,
+	serviceName string,
+	protocol string,
+	backendRefs ...gatewayv1alpha2.BackendRef,
 ) (kongstate.Service, error) {
 	objName := fmt.Sprintf("%s %s/%s",
 		route.GetObjectKind().GroupVersionKind().String(), route.GetNamespace(), route.GetName())
@@ -154,10 +318,6 @@ func (p *Parser) generateKongServiceFromBackendRef(
 		return kongstate.Service{}, fmt.Errorf("%s has no permissible backendRefs, cannot create a Kong service for it", objName)
 	}
 
-	// the service name needs to uniquely identify this service given it's list of
-	// one or more backends.
-	serviceName := fmt.Sprintf("%s.%d", getUniqueKongServiceNameForObject(route), ruleNumber)
-
 	// the service host needs to be a resolvable name due to legacy logic so we'll
 	// use the anchor backendRef as the basis for the name
 	serviceHost := serviceName
@@ -182,3 +342,23 @@ func (p *Parser) generateKongServiceFromBackendRef(
 
 	return service, nil
 }
+
+// generateKongServiceWithoutBackends creates a Kong service for a rule of a
+// route which doesn't route traffic to any backend, e.g. because its routes
+// respond to all requests themselves. It's named like the services generated
+// by generateKongServiceFromBackendRef().
+func generateKongServiceWithoutBackends(route client.Object, ruleNumber int) kongstate.Service {
+	serviceName := fmt.Sprintf("%s.%d", getUniqueKongServiceNameForObject(route), ruleNumber)
+	return kongstate.Service{
+		Service: kong.Service{
+			Name:           kong.String(serviceName),
+			Host:           kong.String(serviceName),
+			Protocol:       kong.String("http"),
+			ConnectTimeout: kong.Int(DefaultServiceTimeout),
+			ReadTimeout:    kong.Int(DefaultServiceTimeout),
+			WriteTimeout:   kong.Int(DefaultServiceTimeout),
+			Retries:        kong.Int(DefaultRetries),
+		},
+		Namespace: route.GetNamespace(),
+	}
+}
@@ -737,3 +737,142 @@ func Test_generateKongServiceFromBackendRef(t *testing.T) {
 		})
 	}
 }
+
+func Test_convertGatewayPathMatchToKongRoutePath(t *testing.T) {
+	prefixType := gatewayv1alpha2.PathMatchPathPrefix
+	exactType := gatewayv1alpha2.PathMatchExact
+	regexType := gatewayv1alpha2.PathMatchRegularExpression
+	kong2x := semver.MustParse("2.8.0")
+	kong3x := semver.MustParse("3.0.0")
+
+	for _, tt := range []struct {
+		msg         string
+		input       gatewayv1alpha2.HTTPPathMatch
+		kongVersion semver.Version
+		output      *string
+		err         error
+	}{
+		{
+			msg:         "prefix path matches are used as-is",
+			input:       gatewayv1alpha2.HTTPPathMatch{Type: &prefixType, Value: kong.String("/api")},
+			kongVersion: kong3x,
+			output:      kong.String("/api"),
+		},
+		{
+			msg:         "path matches default to prefix matches",
+			input:       gatewayv1alpha2.HTTPPathMatch{Value: kong.String("/api")},
+			kongVersion: kong2x,
+			output:      kong.String("/api"),
+		},
+		{
+			msg:         "exact path matches are escaped and terminated",
+			input:       gatewayv1alpha2.HTTPPathMatch{Type: &exactType, Value: kong.String("/api/v1.0")},
+			kongVersion: kong2x,
+			output:      kong.String(`/api/v1\.0$`),
+		},
+		{
+			msg:         "exact path matches are prefixed as regexes on Kong 3.0 and later",
+			input:       gatewayv1alpha2.HTTPPathMatch{Type: &exactType, Value: kong.String("/api")},
+			kongVersion: kong3x,
+			output:      kong.String("~/api$"),
+		},
+		{
+			msg:         "regex path matches are used as-is before Kong 3.0",
+			input:       gatewayv1alpha2.HTTPPathMatch{Type: &regexType, Value: kong.String(`/api/v\d+`)},
+			kongVersion: kong2x,
+			output:      kong.String(`/api/v\d+`),
+		},
+		{
+			msg:         "regex path matches are prefixed on Kong 3.0 and later",
+			input:       gatewayv1alpha2.HTTPPathMatch{Type: &regexType, Value: kong.String(`/api/v\d+`)},
+			kongVersion: kong3x,
+			output:      kong.String(`~/api/v\d+`),
+		},
+		{
+			msg:         "invalid regex path matches are rejected",
+			input:       gatewayv1alpha2.HTTPPathMatch{Type: &regexType, Value: kong.String("/api/(")},
+			kongVersion: kong3x,
+			err:         fmt.Errorf("invalid regex path match /api/(: error parsing regexp: missing closing ): `/api/(`"),
+		},
+	} {
+		t.Run(tt.msg, func(t *testing.T) {
+			output, err := convertGatewayPathMatchToKongRoutePath(tt.input, tt.kongVersion)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.output, output)
+		})
+	}
+}
+
+func Test_generateQueryParamMatchPlugin(t *testing.T) {
+	exactType := gatewayv1alpha2.QueryParamMatchExact
+	regexType := gatewayv1alpha2.QueryParamMatchRegularExpression
+
+	t.Log("verifying that query param matches are translated into a pre-function plugin")
+	plugin, err := generateQueryParamMatchPlugin([]gatewayv1alpha2.HTTPQueryParamMatch{
+		{Type: &exactType, Name: "user", Value: `kong "the" gorilla`},
+		{Name: "version", Value: "2"},
+		{Name: "user", Value: "ignored"},
+	}, semver.MustParse("2.8.0"))
+	assert.NoError(t, err)
+	assert.Equal(t, "pre-function", *plugin.Name)
+	code := plugin.Config["access"].([]string)[0]
+	assert.Contains(t, code, `if first(query["user"]) ~= "kong \"the\" gorilla" or first(query["version"]) ~= "2" then`)
+	assert.NotContains(t, code, "ignored")
+
+	t.Log("verifying that regex query param matches are rejected")
+	_, err = generateQueryParamMatchPlugin([]gatewayv1alpha2.HTTPQueryParamMatch{
+		{Type: &regexType, Name: "user", Value: ".*"},
+	}, semver.MustParse("2.8.0"))
+	assert.EqualError(t, err, "RegularExpression query param matches are not supported")
+
+	t.Log("verifying that query param matches are rejected on Kong versions which can't express them")
+	_, err = generateQueryParamMatchPlugin([]gatewayv1alpha2.HTTPQueryParamMatch{
+		{Name: "user", Value: "kong"},
+	}, semver.MustParse("2.2.0"))
+	assert.EqualError(t, err, "Kong version 2.2.0 does not support query param matches")
+}
+
+func Test_luaString(t *testing.T) {
+	assert.Equal(t, `"plain"`, luaString("plain"))
+	assert.Equal(t, `"\"quoted\" \\ back"`, luaString(`"quoted" \ back`))
+	assert.Equal(t, `"new\010line caf\195\169"`, luaString("new\nline café"))
+}
+
+func Test_getL4RouteDestinationPort(t *testing.T) {
+	port := gatewayv1alpha2.PortNumber(9000)
+	otherPort := gatewayv1alpha2.PortNumber(9001)
+	negativeWeight := int32(-1)
+	deploymentKind := gatewayv1alpha2.Kind("Deployment")
+	backendRef := func(name string, port *gatewayv1alpha2.PortNumber) gatewayv1alpha2.BackendRef {
+		return gatewayv1alpha2.BackendRef{
+			BackendObjectReference: gatewayv1alpha2.BackendObjectReference{
+				Name: gatewayv1alpha2.ObjectName(name),
+				Port: port,
+			},
+		}
+	}
+
+	destination, err := getL4RouteDestinationPort([]gatewayv1alpha2.BackendRef{backendRef("a", &port), backendRef("b", &port)})
+	assert.NoError(t, err)
+	assert.Equal(t, 9000, destination)
+
+	_, err = getL4RouteDestinationPort([]gatewayv1alpha2.BackendRef{backendRef("a", &port), backendRef("b", &otherPort)})
+	assert.Error(t, err)
+
+	_, err = getL4RouteDestinationPort([]gatewayv1alpha2.BackendRef{backendRef("a", nil)})
+	assert.Error(t, err)
+
+	negative := backendRef("a", &port)
+	negative.Weight = &negativeWeight
+	_, err = getL4RouteDestinationPort([]gatewayv1alpha2.BackendRef{negative})
+	assert.Error(t, err)
+
+	deployment := backendRef("a", &port)
+	deployment.Kind = &deploymentKind
+	_, err = getL4RouteDestinationPort([]gatewayv1alpha2.BackendRef{deployment})
+	assert.Error(t, err)
+}
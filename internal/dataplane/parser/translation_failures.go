@@ -0,0 +1,50 @@
+package parser
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// -----------------------------------------------------------------------------
+// Parser - Translation Failures - Public Types
+// -----------------------------------------------------------------------------
+
+// TranslationFailure describes a problem which prevented Kubernetes objects
+// (or parts of them) from being translated into Kong configuration.
+type TranslationFailure struct {
+	// CausingObjects are the Kubernetes objects which the problem was found
+	// with, and which should be notified about it.
+	CausingObjects []client.Object
+
+	// Reason describes the problem.
+	Reason string
+}
+
+// -----------------------------------------------------------------------------
+// Parser - Translation Failures - Public Methods
+// -----------------------------------------------------------------------------
+
+// PopTranslationFailures provides the translation failures which were found
+// during Build() calls so far. The failures are consumed: the parser's internal
+// list will be emptied once this method is called, until more builds are run.
+func (p *Parser) PopTranslationFailures() []TranslationFailure {
+	failures := p.translationFailures
+	p.translationFailures = nil
+	return failures
+}
+
+// -----------------------------------------------------------------------------
+// Parser - Translation Failures - Private Methods
+// -----------------------------------------------------------------------------
+
+// registerTranslationFailure records a problem found while translating the
+// provided objects. Failures without any causing objects are dropped, as there
+// is nothing they could be reported on.
+func (p *Parser) registerTranslationFailure(reason string, causingObjects ...client.Object) {
+	if len(causingObjects) == 0 {
+		return
+	}
+	p.translationFailures = append(p.translationFailures, TranslationFailure{
+		CausingObjects: causingObjects,
+		Reason:         reason,
+	})
+}
@@ -0,0 +1,89 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+	configurationv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
+)
+
+func TestParser_PopTranslationFailures(t *testing.T) {
+	objectMeta := func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			Annotations: map[string]string{
+				annotations.IngressClassKey: annotations.DefaultIngressClass,
+			},
+		}
+	}
+
+	tcpIngress := &configurationv1beta1.TCPIngress{
+		ObjectMeta: objectMeta("tcpingress"),
+		Spec: configurationv1beta1.TCPIngressSpec{
+			Rules: []configurationv1beta1.IngressRule{{
+				Port: 0,
+				Backend: configurationv1beta1.IngressBackend{
+					ServiceName: "svc",
+					ServicePort: 80,
+				},
+			}},
+		},
+	}
+	httproute := &gatewayv1alpha2.HTTPRoute{
+		ObjectMeta: objectMeta("httproute"),
+	}
+	certSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cert"},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       []byte("not a certificate"),
+			corev1.TLSPrivateKeyKey: []byte("not a key"),
+		},
+	}
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: objectMeta("ingress"),
+		Spec: networkingv1.IngressSpec{
+			TLS: []networkingv1.IngressTLS{{
+				Hosts:      []string{"example.com"},
+				SecretName: "cert",
+			}},
+		},
+	}
+
+	s, err := store.NewFakeStore(store.FakeObjects{
+		TCPIngresses: []*configurationv1beta1.TCPIngress{tcpIngress},
+		HTTPRoutes:   []*gatewayv1alpha2.HTTPRoute{httproute},
+		IngressesV1:  []*networkingv1.Ingress{ingress},
+		Secrets:      []*corev1.Secret{certSecret},
+	})
+	require.NoError(t, err)
+	p := NewParser(logrus.New(), s)
+
+	_, err = p.Build()
+	require.NoError(t, err)
+
+	failures := p.PopTranslationFailures()
+	causes := map[string]string{}
+	for _, failure := range failures {
+		for _, obj := range failure.CausingObjects {
+			causes[client.ObjectKeyFromObject(obj).String()] = failure.Reason
+		}
+	}
+	assert.Len(t, causes, 3)
+	assert.Equal(t, "rule 0 skipped: invalid port: 0", causes["default/tcpingress"])
+	assert.Equal(t, "HTTPRoute default/httproute can't be routed: no rules provided", causes["default/httproute"])
+	assert.Contains(t, causes["default/cert"], "failed to construct certificate from secret")
+
+	t.Log("verifying that translation failures are consumed")
+	assert.Empty(t, p.PopTranslationFailures())
+}
@@ -8,6 +8,8 @@ import (
 
 	"github.com/kong/go-kong/kong"
 	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
 
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
@@ -97,6 +99,8 @@ func (i *ingressTranslationIndex) add(ingress *networkingv1.Ingress) {
 				meta = &ingressTranslationMeta{
 					ingressNamespace: ingress.Namespace,
 					ingressName:      ingress.Name,
+					ingressUID:       ingress.UID,
+					ingressGVK:       ingress.GroupVersionKind(),
 					ingressHost:      ingressRule.Host,
 					serviceName:      serviceName,
 					servicePort:      servicePort,
@@ -146,6 +150,8 @@ type ingressTranslationMeta struct {
 	ingressAnnotations map[string]string
 	ingressNamespace   string
 	ingressName        string
+	ingressUID         k8stypes.UID
+	ingressGVK         schema.GroupVersionKind
 	ingressHost        string
 	serviceName        string
 	servicePort        int32
@@ -178,9 +184,11 @@ func (m *ingressTranslationMeta) translateIntoKongRoutes() *kongstate.Route {
 	routeName := fmt.Sprintf("%s.%s.%s.%s.%d", m.ingressNamespace, m.ingressName, m.serviceName, m.ingressHost, m.servicePort)
 	route := &kongstate.Route{
 		Ingress: util.K8sObjectInfo{
-			Namespace:   m.ingressNamespace,
-			Name:        m.ingressName,
-			Annotations: m.ingressAnnotations,
+			Namespace:        m.ingressNamespace,
+			Name:             m.ingressName,
+			Annotations:      m.ingressAnnotations,
+			GroupVersionKind: m.ingressGVK,
+			UID:              m.ingressUID,
 		},
 		Route: kong.Route{
 			Name:              kong.String(routeName),
@@ -0,0 +1,108 @@
+package sendconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// -----------------------------------------------------------------------------
+// Sendconfig - Config Errors
+// -----------------------------------------------------------------------------
+
+// ConfigError is the error produced when the Kong Admin API rejects a DB-less
+// configuration. When Kong supports reporting errors per entity (Kong 3.0+,
+// see the "flatten_errors" parameter of POST /config), the entities which
+// caused the configuration to be rejected are included.
+type ConfigError struct {
+	// Code is the HTTP status code of the response.
+	Code int
+
+	// Message is the error message provided by Kong.
+	Message string
+
+	// EntityErrors are the problems Kong found with individual entities of
+	// the configuration, if it reported any.
+	EntityErrors []EntityError
+}
+
+// EntityError describes the problems Kong found with a single entity of a
+// DB-less configuration.
+type EntityError struct {
+	// Type is the type of the entity, e.g. "route".
+	Type string `json:"entity_type"`
+
+	// Name is the name of the entity, if it has one.
+	Name string `json:"entity_name,omitempty"`
+
+	// ID is the ID of the entity, if it has one.
+	ID string `json:"entity_id,omitempty"`
+
+	// Tags are the tags of the entity.
+	Tags []string `json:"entity_tags,omitempty"`
+
+	// Entity is the entity as it was found in the configuration.
+	Entity map[string]interface{} `json:"entity,omitempty"`
+
+	// Errors are the individual problems with the entity.
+	Errors []EntityFieldError `json:"errors,omitempty"`
+}
+
+// EntityFieldError is a single problem with an entity, which may be specific
+// to one of its fields.
+type EntityFieldError struct {
+	// Field is the field of the entity which is invalid, if the problem
+	// concerns a single field.
+	Field string `json:"field,omitempty"`
+
+	// Message describes the problem.
+	Message string `json:"message"`
+
+	// Type is the kind of problem, e.g. "field" or "entity".
+	Type string `json:"type,omitempty"`
+}
+
+func (e *ConfigError) Error() string {
+	if len(e.EntityErrors) == 0 {
+		return fmt.Sprintf("HTTP status %d (message: %q)", e.Code, e.Message)
+	}
+	entities := make([]string, 0, len(e.EntityErrors))
+	for _, entityErr := range e.EntityErrors {
+		entities = append(entities, entityErr.String())
+	}
+	return fmt.Sprintf("HTTP status %d (message: %q, invalid entities: %s)", e.Code, e.Message, strings.Join(entities, "; "))
+}
+
+// String describes the entity and all its problems.
+func (e EntityError) String() string {
+	problems := make([]string, 0, len(e.Errors))
+	for _, fieldErr := range e.Errors {
+		problems = append(problems, fieldErr.String())
+	}
+	return fmt.Sprintf("%s %q: %s", e.Type, e.Name, strings.Join(problems, ", "))
+}
+
+// String describes the problem, including the field it concerns if any.
+func (e EntityFieldError) String() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// parseConfigError produces a ConfigError from the response body of a rejected
+// POST /config request. Bodies which can't be parsed are used as the message.
+func parseConfigError(code int, body []byte) *ConfigError {
+	var response struct {
+		Message         string        `json:"message"`
+		FlattenedErrors []EntityError `json:"flattened_errors"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil || response.Message == "" {
+		return &ConfigError{Code: code, Message: strings.TrimSpace(string(body))}
+	}
+	return &ConfigError{
+		Code:         code,
+		Message:      response.Message,
+		EntityErrors: response.FlattenedErrors,
+	}
+}
@@ -0,0 +1,280 @@
+package sendconfig
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/blang/semver/v4"
+	"github.com/kong/deck/crud"
+	"github.com/kong/deck/dump"
+	"github.com/kong/deck/file"
+	"github.com/kong/deck/state"
+	deckutils "github.com/kong/deck/utils"
+	"github.com/kong/go-kong/kong"
+	"github.com/pmezard/go-difflib/difflib"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/metrics"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+// -----------------------------------------------------------------------------
+// Sendconfig - Diff - Public Functions
+// -----------------------------------------------------------------------------
+
+// Diff computes the changes that applying `targetContent` to the Kong Admin API
+// specified by `kongConfig` would make, without applying them. DB-backed Kong
+// instances are compared with the entities they store, as PerformUpdate does,
+// and DB-less ones with the declarative configuration they currently run.
+// The provided content is not modified.
+func Diff(ctx context.Context,
+	kongConfig *Kong,
+	targetContent *file.Content,
+	selectorTags []string,
+	skipCACertificates bool,
+) ([]util.ConfigChange, error) {
+	dumpConfig := dump.Config{SelectorTags: selectorTags, SkipCACerts: skipCACertificates}
+
+	var (
+		rawState *deckutils.KongRawState
+		err      error
+	)
+	if kongConfig.InMemory {
+		rawState, err = getInMemoryRawState(ctx, kongConfig)
+	} else {
+		rawState, err = dump.Get(ctx, kongConfig.Client, dumpConfig)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loading configuration from kong: %w", err)
+	}
+	currentState, err := state.Get(rawState)
+	if err != nil {
+		return nil, err
+	}
+
+	// rendering the target content fills in the IDs and defaults of its
+	// entities, so a copy of it is rendered instead.
+	targetContent, err = copyContent(targetContent)
+	if err != nil {
+		return nil, err
+	}
+
+	return diffStates(ctx, kongConfig.Client, kongConfig.Version, currentState, targetContent, dumpConfig)
+}
+
+// -----------------------------------------------------------------------------
+// Sendconfig - Diff - Private Functions
+// -----------------------------------------------------------------------------
+
+// diffStates computes the changes which bring the provided current state to the
+// provided target content, as onUpdateDBMode does, without making them. The
+// client is only used to retrieve the defaults of the entities.
+func diffStates(ctx context.Context,
+	client *kong.Client,
+	kongVersion semver.Version,
+	currentState *state.KongState,
+	targetContent *file.Content,
+	dumpConfig dump.Config,
+) ([]util.ConfigChange, error) {
+	syncer, err := newSyncer(ctx, client, kongVersion, currentState, targetContent, dumpConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		lock    sync.Mutex
+		changes []util.ConfigChange
+	)
+	errs := syncer.Run(ctx, 1, func(e crud.Event) (crud.Arg, error) {
+		change, err := changeForEvent(e)
+		if err != nil {
+			return nil, err
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		changes = append(changes, change)
+		// the target entity is returned as if it had been applied, so that the
+		// entities depending on it are diffed against it.
+		return e.Obj, nil
+	})
+	if errs != nil {
+		return nil, deckutils.ErrArray{Errors: errs}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Kind != changes[j].Kind {
+			return changes[i].Kind < changes[j].Kind
+		}
+		return changes[i].Entity < changes[j].Entity
+	})
+	return changes, nil
+}
+
+// changeForEvent describes the change made by the provided syncer event.
+func changeForEvent(e crud.Event) (util.ConfigChange, error) {
+	change := util.ConfigChange{Kind: string(e.Kind)}
+	if obj, ok := e.Obj.(state.ConsoleString); ok {
+		change.Entity = obj.Console()
+	}
+	switch e.Op {
+	case crud.Create:
+		change.Action = metrics.ActionCreate
+	case crud.Delete:
+		change.Action = metrics.ActionDelete
+	case crud.Update:
+		change.Action = metrics.ActionUpdate
+		d, err := entityDiff(e.OldObj, e.Obj)
+		if err != nil {
+			return util.ConfigChange{}, fmt.Errorf("diffing %s %s: %w", change.Kind, change.Entity, err)
+		}
+		change.Diff = d
+	default:
+		op := e.Op
+		return util.ConfigChange{}, fmt.Errorf("unknown operation %s", op.String())
+	}
+	return change, nil
+}
+
+// entityDiff produces a unified diff of the JSON representations of the
+// provided entities, ignoring their timestamps.
+func entityDiff(oldObj, newObj interface{}) (string, error) {
+	lines := func(obj interface{}) ([]string, error) {
+		b, err := json.MarshalIndent(obj, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return difflib.SplitLines(string(b) + "\n"), nil
+	}
+
+	deckutils.ZeroOutTimestamps(oldObj)
+	deckutils.ZeroOutTimestamps(newObj)
+	a, err := lines(oldObj)
+	if err != nil {
+		return "", err
+	}
+	b, err := lines(newObj)
+	if err != nil {
+		return "", err
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        a,
+		B:        b,
+		FromFile: "current",
+		ToFile:   "target",
+		Context:  3,
+	})
+}
+
+// copyContent provides a deep copy of the provided content.
+func copyContent(content *file.Content) (*file.Content, error) {
+	b, err := json.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("copying configuration: %w", err)
+	}
+	var c file.Content
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("copying configuration: %w", err)
+	}
+	return &c, nil
+}
+
+// getInMemoryRawState retrieves the declarative configuration which a DB-less
+// Kong instance currently runs.
+func getInMemoryRawState(ctx context.Context, kongConfig *Kong) (*deckutils.KongRawState, error) {
+	req, err := http.NewRequest("GET", kongConfig.URL+"/config", nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating new HTTP request for /config: %w", err)
+	}
+	resp, err := kongConfig.Client.DoRAW(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("getting config from /config: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("getting config from /config: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("getting config from /config: HTTP status %d (message: %q)", resp.StatusCode, string(body))
+	}
+
+	var response struct {
+		Config string `json:"config"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("parsing response of /config: %w", err)
+	}
+	return parseConfigExport([]byte(response.Config))
+}
+
+// configExportForeignKeys are the fields of the entities of a configuration
+// exported by Kong which reference other entities.
+var configExportForeignKeys = []string{"service", "route", "consumer", "upstream", "certificate", "client_certificate"}
+
+// parseConfigExport produces a raw state from a declarative configuration
+// exported by Kong. Exported configurations list all entities at the top level
+// and reference related entities by ID instead of nesting them, as raw states
+// do, but represent references as plain IDs.
+func parseConfigExport(export []byte) (*deckutils.KongRawState, error) {
+	var config map[string]interface{}
+	if err := yaml.Unmarshal(export, &config); err != nil {
+		return nil, fmt.Errorf("parsing exported configuration: %w", err)
+	}
+	for _, entities := range config {
+		// top-level fields such as _format_version are not lists of entities.
+		list, ok := entities.([]interface{})
+		if !ok {
+			continue
+		}
+		for _, item := range list {
+			entity, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for _, key := range configExportForeignKeys {
+				if id, ok := entity[key].(string); ok {
+					entity[key] = map[string]interface{}{"id": id}
+				}
+			}
+		}
+	}
+
+	var rawState deckutils.KongRawState
+	for key, target := range map[string]interface{}{
+		"services":              &rawState.Services,
+		"routes":                &rawState.Routes,
+		"plugins":               &rawState.Plugins,
+		"upstreams":             &rawState.Upstreams,
+		"targets":               &rawState.Targets,
+		"certificates":          &rawState.Certificates,
+		"snis":                  &rawState.SNIs,
+		"ca_certificates":       &rawState.CACertificates,
+		"consumers":             &rawState.Consumers,
+		"keyauth_credentials":   &rawState.KeyAuths,
+		"hmacauth_credentials":  &rawState.HMACAuths,
+		"jwt_secrets":           &rawState.JWTAuths,
+		"basicauth_credentials": &rawState.BasicAuths,
+		"acls":                  &rawState.ACLGroups,
+		"oauth2_credentials":    &rawState.Oauth2Creds,
+		"mtls_auth_credentials": &rawState.MTLSAuths,
+	} {
+		entities, ok := config[key]
+		if !ok {
+			continue
+		}
+		b, err := json.Marshal(entities)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(b, target); err != nil {
+			return nil, fmt.Errorf("parsing %s of exported configuration: %w", key, err)
+		}
+	}
+	return &rawState, nil
+}
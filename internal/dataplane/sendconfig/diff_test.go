@@ -0,0 +1,107 @@
+package sendconfig
+
+import (
+	"context"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/kong/deck/dump"
+	"github.com/kong/deck/file"
+	"github.com/kong/deck/state"
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const configExport = `_format_version: "2.1"
+_transform: false
+services:
+- id: 0d8e2b1e-8c7a-4b1b-9a5e-111111111111
+  name: default.echo.80
+  host: echo.default.80.svc
+  port: 80
+  protocol: http
+routes:
+- id: 0d8e2b1e-8c7a-4b1b-9a5e-222222222222
+  name: default.echo.00
+  service: 0d8e2b1e-8c7a-4b1b-9a5e-111111111111
+  paths:
+  - /echo
+- id: 0d8e2b1e-8c7a-4b1b-9a5e-333333333333
+  name: default.old.00
+  service: 0d8e2b1e-8c7a-4b1b-9a5e-111111111111
+  paths:
+  - /old
+upstreams:
+- id: 0d8e2b1e-8c7a-4b1b-9a5e-444444444444
+  name: echo.default.80.svc
+targets:
+- id: 0d8e2b1e-8c7a-4b1b-9a5e-555555555555
+  target: 10.0.0.1:80
+  upstream: 0d8e2b1e-8c7a-4b1b-9a5e-444444444444
+`
+
+func Test_parseConfigExport(t *testing.T) {
+	rawState, err := parseConfigExport([]byte(configExport))
+	require.NoError(t, err)
+
+	require.Len(t, rawState.Services, 1)
+	assert.Equal(t, "default.echo.80", *rawState.Services[0].Name)
+	require.Len(t, rawState.Routes, 2)
+	assert.Equal(t, "0d8e2b1e-8c7a-4b1b-9a5e-111111111111", *rawState.Routes[0].Service.ID)
+	require.Len(t, rawState.Targets, 1)
+	assert.Equal(t, "0d8e2b1e-8c7a-4b1b-9a5e-444444444444", *rawState.Targets[0].Upstream.ID)
+
+	_, err = state.Get(rawState)
+	require.NoError(t, err)
+
+	_, err = parseConfigExport([]byte("services: {"))
+	assert.Error(t, err)
+}
+
+func Test_diffStates(t *testing.T) {
+	rawState, err := parseConfigExport([]byte(configExport))
+	require.NoError(t, err)
+	currentState, err := state.Get(rawState)
+	require.NoError(t, err)
+
+	targetContent := &file.Content{
+		FormatVersion: "1.1",
+		Services: []file.FService{{
+			Service: kong.Service{
+				Name:     kong.String("default.echo.80"),
+				Host:     kong.String("echo.default.80.svc"),
+				Port:     kong.Int(8080),
+				Protocol: kong.String("http"),
+			},
+			Routes: []*file.FRoute{
+				{Route: kong.Route{Name: kong.String("default.echo.00"), Paths: kong.StringSlice("/echo")}},
+				{Route: kong.Route{Name: kong.String("default.new.00"), Paths: kong.StringSlice("/new")}},
+			},
+		}},
+		Upstreams: []file.FUpstream{{
+			Upstream: kong.Upstream{Name: kong.String("echo.default.80.svc")},
+			Targets: []*file.FTarget{
+				{Target: kong.Target{Target: kong.String("10.0.0.1:80")}},
+			},
+		}},
+	}
+
+	changes, err := diffStates(context.Background(), nil, semver.MustParse("2.8.0"), currentState, targetContent, dump.Config{})
+	require.NoError(t, err)
+
+	actions := map[string]string{}
+	for _, change := range changes {
+		actions[change.Kind+" "+change.Entity] = change.Action
+	}
+	assert.Equal(t, "create", actions["route default.new.00"])
+	assert.Equal(t, "delete", actions["route default.old.00"])
+	assert.Equal(t, "update", actions["service default.echo.80"])
+
+	for _, change := range changes {
+		if change.Kind == "service" {
+			assert.Contains(t, change.Diff, `-  "port": 80,`)
+			assert.Contains(t, change.Diff, `+  "port": 8080,`)
+		}
+	}
+}
@@ -6,16 +6,19 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"reflect"
 	"sync"
 	"time"
 
+	"github.com/blang/semver/v4"
 	"github.com/kong/deck/diff"
 	"github.com/kong/deck/dump"
 	"github.com/kong/deck/file"
 	"github.com/kong/deck/state"
 	deckutils "github.com/kong/deck/utils"
+	"github.com/kong/go-kong/kong"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sirupsen/logrus"
 
@@ -166,12 +169,14 @@ func onUpdateInMemoryMode(ctx context.Context,
 	customEntities []byte,
 	kongConfig *Kong,
 ) error {
-	// Kong will error out if this is set
-	state.Info = nil
+	// Kong will error out if this is set. The same state may be sent to several
+	// proxies concurrently, so a copy is modified rather than the state itself.
+	stateCopy := *state
+	stateCopy.Info = nil
 	// Kong errors out if `null`s are present in `config` of plugins
-	deckgen.CleanUpNullsInPluginConfigs(state)
+	deckgen.CleanUpNullsInPluginConfigs(&stateCopy)
 
-	config, err := renderConfigWithCustomEntities(log, state, customEntities)
+	config, err := renderConfigWithCustomEntities(log, &stateCopy, customEntities)
 	if err != nil {
 		return fmt.Errorf("constructing kong configuration: %w", err)
 	}
@@ -185,15 +190,28 @@ func onUpdateInMemoryMode(ctx context.Context,
 
 	queryString := req.URL.Query()
 	queryString.Add("check_hash", "1")
+	// ask for errors to be reported per entity so that they can be traced back
+	// to the Kubernetes objects the entities were generated from. Kong versions
+	// which don't support this simply ignore the parameter.
+	queryString.Add("flatten_errors", "1")
 
 	req.URL.RawQuery = queryString.Encode()
 
-	_, err = kongConfig.Client.Do(ctx, req, nil)
+	resp, err := kongConfig.Client.DoRAW(ctx, req)
 	if err != nil {
 		return fmt.Errorf("posting new config to /config: %w", err)
 	}
+	defer resp.Body.Close()
 
-	return err
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("posting new config to /config: HTTP status %d (failed to read response: %v)", resp.StatusCode, err)
+		}
+		return fmt.Errorf("posting new config to /config: %w", parseConfigError(resp.StatusCode, body))
+	}
+
+	return nil
 }
 
 func onUpdateDBMode(ctx context.Context,
@@ -213,33 +231,49 @@ func onUpdateDBMode(ctx context.Context,
 		return err
 	}
 
+	syncer, err := newSyncer(ctx, kongConfig.Client, kongConfig.Version, currentState, targetContent, dumpConfig)
+	if err != nil {
+		return err
+	}
+	_, errs := syncer.Solve(ctx, kongConfig.Concurrency, false)
+	if errs != nil {
+		return deckutils.ErrArray{Errors: errs}
+	}
+	return nil
+}
+
+// newSyncer produces a syncer which brings the provided current state to the
+// provided target content.
+func newSyncer(ctx context.Context,
+	client *kong.Client,
+	kongVersion semver.Version,
+	currentState *state.KongState,
+	targetContent *file.Content,
+	dumpConfig dump.Config,
+) (*diff.Syncer, error) {
 	// read the target state
-	rawState, err = file.Get(ctx, targetContent, file.RenderConfig{
+	rawState, err := file.Get(ctx, targetContent, file.RenderConfig{
 		CurrentState: currentState,
-		KongVersion:  kongConfig.Version,
-	}, dumpConfig, kongConfig.Client)
+		KongVersion:  kongVersion,
+	}, dumpConfig, client)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	targetState, err := state.Get(rawState)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	syncer, err := diff.NewSyncer(diff.SyncerOpts{
 		CurrentState:    currentState,
 		TargetState:     targetState,
-		KongClient:      kongConfig.Client,
+		KongClient:      client,
 		SilenceWarnings: true,
 	})
 	if err != nil {
-		return fmt.Errorf("creating a new syncer: %w", err)
-	}
-	_, errs := syncer.Solve(ctx, kongConfig.Concurrency, false)
-	if errs != nil {
-		return deckutils.ErrArray{Errors: errs}
+		return nil, fmt.Errorf("creating a new syncer: %w", err)
 	}
-	return nil
+	return syncer, nil
 }
 
 func equalSHA(a, b []byte) bool {
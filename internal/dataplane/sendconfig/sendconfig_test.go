@@ -128,3 +128,61 @@ func Test_updateReportingUtilities(t *testing.T) {
 	assert.True(t, hasSHAUpdateAlreadyBeenReported([]byte("yet-another-fake-sha")))
 	assert.True(t, hasSHAUpdateAlreadyBeenReported([]byte("yet-another-fake-sha")))
 }
+
+func Test_parseConfigError(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		code int
+		body string
+		want *ConfigError
+	}{
+		{
+			name: "flattened entity errors are parsed",
+			code: 400,
+			body: `{
+				"code": 14,
+				"name": "invalid declarative configuration",
+				"message": "declarative config is invalid: {}",
+				"fields": {},
+				"flattened_errors": [{
+					"entity_type": "route",
+					"entity_name": "default.ingress.00",
+					"entity": {"name": "default.ingress.00", "paths": ["~/(bad"]},
+					"errors": [{"field": "paths.1", "message": "invalid regex: '/(bad'", "type": "field"}]
+				}]
+			}`,
+			want: &ConfigError{
+				Code:    400,
+				Message: "declarative config is invalid: {}",
+				EntityErrors: []EntityError{{
+					Type:   "route",
+					Name:   "default.ingress.00",
+					Entity: map[string]interface{}{"name": "default.ingress.00", "paths": []interface{}{"~/(bad"}},
+					Errors: []EntityFieldError{{Field: "paths.1", Message: "invalid regex: '/(bad'", Type: "field"}},
+				}},
+			},
+		},
+		{
+			name: "errors without entity details only have a message",
+			code: 400,
+			body: `{"code": 14, "message": "declarative config is invalid: {routes={[1]={paths=\"invalid\"}}}"}`,
+			want: &ConfigError{
+				Code:    400,
+				Message: `declarative config is invalid: {routes={[1]={paths="invalid"}}}`,
+			},
+		},
+		{
+			name: "unparseable bodies are used as the message",
+			code: 500,
+			body: "An unexpected error occurred\n",
+			want: &ConfigError{
+				Code:    500,
+				Message: "An unexpected error occurred",
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseConfigError(tt.code, []byte(tt.body)))
+		})
+	}
+}
@@ -2,6 +2,7 @@ package dataplane
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -9,6 +10,8 @@ import (
 	"github.com/bombsimon/logrusr/v2"
 	"github.com/go-logr/logr"
 	"github.com/sirupsen/logrus"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
 )
 
 // -----------------------------------------------------------------------------
@@ -26,14 +29,31 @@ const (
 	//
 	// See Also: https://github.com/Kong/kubernetes-ingress-controller/issues/1398
 	DefaultSyncSeconds float32 = 3.0
+
+	// DefaultSyncMinDelay is how long the Synchronizer waits for further changes
+	// after the configuration changed before updating the data-plane, so that
+	// bursts of changes (e.g. when applying many manifests) result in a single
+	// update.
+	DefaultSyncMinDelay = 100 * time.Millisecond
+
+	// DefaultSyncMaxDelay is the longest that further changes can delay an
+	// update of the data-plane after the configuration changed.
+	DefaultSyncMaxDelay = 10 * time.Second
+
+	// DefaultResyncPeriod is how often the data-plane is updated even if the
+	// configuration didn't change, e.g. to restore configuration which was
+	// lost by a proxy instance or changed by others.
+	DefaultResyncPeriod = time.Minute
 )
 
 // -----------------------------------------------------------------------------
 // Synchronizer - Public Types
 // -----------------------------------------------------------------------------
 
-// Synchronizer is a threadsafe object which starts a goroutine to updates
-// the data-plane at regular intervals.
+// Synchronizer is a threadsafe object which starts a goroutine to update
+// the data-plane. Clients which implement ChangeNotifier are updated after
+// their configuration changed, and periodically as a safety net. Other clients
+// are updated at regular intervals.
 type Synchronizer struct {
 	logger logr.Logger
 
@@ -42,7 +62,9 @@ type Synchronizer struct {
 
 	// server configuration, flow control, channels and utility attributes
 	stagger         time.Duration
-	syncTicker      *time.Ticker
+	minDelay        time.Duration
+	maxDelay        time.Duration
+	resyncPeriod    time.Duration
 	configApplied   bool
 	isServerRunning bool
 
@@ -65,10 +87,35 @@ func NewSynchronizer(logger logrus.FieldLogger, dataplaneClient Client) (*Synchr
 // background goroutines and the caller is resonsible for marking the provided
 // context.Context as "Done()" to shut down the background routines
 func NewSynchronizerWithStagger(logger logrus.FieldLogger, dataplaneClient Client, stagger time.Duration) (*Synchronizer, error) {
+	return NewSynchronizerWithDebounce(logger, dataplaneClient, stagger, DefaultSyncMinDelay, DefaultSyncMaxDelay, DefaultResyncPeriod)
+}
+
+// NewSynchronizerWithDebounce will provide a new Synchronizer object which
+// updates the data-plane at most once per the provided stagger time. After the
+// configuration changed it waits for minDelay without further changes, but no
+// longer than maxDelay, before updating the data-plane. The data-plane is also
+// updated every resyncPeriod regardless of changes. Note that this starts some
+// background goroutines and the caller is resonsible for marking the provided
+// context.Context as "Done()" to shut down the background routines
+func NewSynchronizerWithDebounce(
+	logger logrus.FieldLogger,
+	dataplaneClient Client,
+	stagger, minDelay, maxDelay, resyncPeriod time.Duration,
+) (*Synchronizer, error) {
+	if minDelay > maxDelay {
+		return nil, fmt.Errorf("minimum sync delay %s exceeds maximum sync delay %s", minDelay, maxDelay)
+	}
+	if resyncPeriod <= 0 {
+		return nil, fmt.Errorf("resync period must be positive, got %s", resyncPeriod)
+	}
+
 	synchronizer := &Synchronizer{
 		logger:          logrusr.New(logger),
 		dataplaneClient: dataplaneClient,
 		stagger:         stagger,
+		minDelay:        minDelay,
+		maxDelay:        maxDelay,
+		resyncPeriod:    resyncPeriod,
 		configApplied:   false,
 	}
 
@@ -80,8 +127,9 @@ func NewSynchronizerWithStagger(logger logrus.FieldLogger, dataplaneClient Clien
 // -----------------------------------------------------------------------------
 
 // Start starts the goroutine synchronization server that will perform an
-// Update() on the provided dataplane.Client according to the provided stagger
-// time, or using the DefaultSyncSeconds if not otherwise provided.
+// Update() on the provided dataplane.Client when its configuration changes, no
+// more often than the provided stagger time (DefaultSyncSeconds if not
+// otherwise provided).
 //
 // To stop the server, the provided context must be Done().
 func (p *Synchronizer) Start(ctx context.Context) error {
@@ -96,7 +144,6 @@ func (p *Synchronizer) Start(ctx context.Context) error {
 		return fmt.Errorf("server is already running")
 	}
 
-	p.syncTicker = time.NewTicker(p.stagger)
 	go p.startUpdateServer(ctx)
 	p.isServerRunning = true
 
@@ -140,9 +187,36 @@ func (p *Synchronizer) NeedLeaderElection() bool {
 // -----------------------------------------------------------------------------
 
 // startUpdateServer runs a server in a background goroutine that is responsible for
-// updating the kong proxy backend at regular intervals.
+// updating the kong proxy backend when its configuration changes.
 func (p *Synchronizer) startUpdateServer(ctx context.Context) {
 	var initialConfig sync.Once
+
+	// clients which don't signal changes are considered to always be changed,
+	// so that they're updated at regular intervals.
+	var changes <-chan struct{}
+	notifier, notifiesChanges := p.dataplaneClient.(ChangeNotifier)
+	if notifiesChanges {
+		changes = notifier.Changes()
+	}
+
+	// dirty indicates whether the configuration changed since the data-plane
+	// was last updated, and firstChange when the first of those changes was.
+	dirty, firstChange := true, time.Now()
+	var lastUpdate time.Time
+
+	syncTimer := time.NewTimer(0)
+	resyncTicker := time.NewTicker(p.resyncPeriod)
+	defer syncTimer.Stop()
+	defer resyncTicker.Stop()
+
+	markDirty := func() {
+		now := time.Now()
+		if !dirty {
+			dirty, firstChange = true, now
+		}
+		resetTimer(syncTimer, p.syncDelay(now, firstChange, lastUpdate))
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -150,7 +224,6 @@ func (p *Synchronizer) startUpdateServer(ctx context.Context) {
 			if err := ctx.Err(); err != nil {
 				p.logger.Error(err, "context completed with error")
 			}
-			p.syncTicker.Stop()
 
 			p.lock.Lock()
 			defer p.lock.Unlock()
@@ -158,12 +231,41 @@ func (p *Synchronizer) startUpdateServer(ctx context.Context) {
 			p.configApplied = false
 
 			return
-		case <-p.syncTicker.C:
-			if err := p.dataplaneClient.Update(ctx); err != nil {
-				p.logger.Error(err, "could not update kong admin")
+		case <-changes:
+			markDirty()
+		case <-resyncTicker.C:
+			if !dirty {
+				p.logger.V(util.DebugLevel).Info("resyncing the proxy configuration")
+				markDirty()
+			}
+		case <-syncTimer.C:
+			if !dirty {
 				break
 			}
-			initialConfig.Do(p.markConfigApplied)
+			lastUpdate = time.Now()
+			dirty = !notifiesChanges
+			retryDelay := p.stagger
+			if err := p.dataplaneClient.Update(ctx); err != nil {
+				var backoffErr *UpdateBackoffError
+				if errors.As(err, &backoffErr) {
+					p.logger.V(util.DebugLevel).Info("skipping update of kong admin", "reason", err.Error())
+					if delay := time.Until(backoffErr.RetryAfter); delay > retryDelay {
+						retryDelay = delay
+					}
+				} else {
+					p.logger.Error(err, "could not update kong admin")
+				}
+				// failed updates are retried as if the configuration changed
+				// again, without waiting for further changes.
+				if !dirty {
+					dirty, firstChange = true, lastUpdate
+				}
+			} else {
+				initialConfig.Do(p.markConfigApplied)
+			}
+			if dirty {
+				syncTimer.Reset(retryDelay)
+			}
 		}
 	}
 }
@@ -172,9 +274,40 @@ func (p *Synchronizer) startUpdateServer(ctx context.Context) {
 // Synchronizer - Private Methods - Helper
 // -----------------------------------------------------------------------------
 
+// syncDelay provides how long to wait before updating the data-plane, at the
+// provided time, for the configuration changes since firstChange. Updates are
+// delayed until the changes settled for minDelay, but no longer than maxDelay
+// after the first change, and are at least stagger apart.
+func (p *Synchronizer) syncDelay(now, firstChange, lastUpdate time.Time) time.Duration {
+	at := now.Add(p.minDelay)
+	if deadline := firstChange.Add(p.maxDelay); at.After(deadline) {
+		at = deadline
+	}
+	if earliest := lastUpdate.Add(p.stagger); at.Before(earliest) {
+		at = earliest
+	}
+	return at.Sub(now)
+}
+
 // markConfigApplied marks that config has been applied
 func (p *Synchronizer) markConfigApplied() {
 	p.lock.Lock()
 	defer p.lock.Unlock()
 	p.configApplied = true
 }
+
+// -----------------------------------------------------------------------------
+// Synchronizer - Private Functions
+// -----------------------------------------------------------------------------
+
+// resetTimer changes the provided timer to expire after the provided duration,
+// discarding any expiration which wasn't received yet.
+func resetTimer(t *time.Timer, d time.Duration) {
+	if !t.Stop() {
+		select {
+		case <-t.C:
+		default:
+		}
+	}
+	t.Reset(d)
+}
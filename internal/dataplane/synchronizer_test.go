@@ -1,6 +1,7 @@
 package dataplane
 
 import (
+	"fmt"
 	"sync"
 	"testing"
 	"time"
@@ -75,11 +76,97 @@ func TestSynchronizer(t *testing.T) {
 	assert.Eventually(t, func() bool { return !sync.IsReady() }, time.Second, time.Millisecond*200)
 }
 
+func TestSynchronizerDebounce(t *testing.T) {
+	c := &fakeNotifyingDataplaneClient{
+		fakeDataplaneClient: fakeDataplaneClient{dbmode: "off"},
+		changes:             make(chan struct{}, 1),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stagger, minDelay, maxDelay, resyncPeriod := time.Millisecond*50, time.Millisecond*100, time.Millisecond*400, time.Second*2
+	sync, err := NewSynchronizerWithDebounce(logrus.New(), c, stagger, minDelay, maxDelay, resyncPeriod)
+	assert.NoError(t, err)
+	go sync.startUpdateServer(ctx)
+
+	t.Log("verifying that the dataplane is updated once on start")
+	assert.Eventually(t, func() bool { return c.totalUpdates() == 1 }, time.Second, time.Millisecond*10)
+	assert.Eventually(t, func() bool { return sync.IsReady() }, time.Second, time.Millisecond*10)
+
+	t.Log("verifying that the dataplane is not updated while nothing changes")
+	time.Sleep(minDelay * 3)
+	assert.Equal(t, 1, c.totalUpdates())
+
+	t.Log("verifying that a burst of changes results in a single update")
+	for i := 0; i < 5; i++ {
+		c.notifyChange()
+		time.Sleep(minDelay / 5)
+	}
+	assert.Eventually(t, func() bool { return c.totalUpdates() == 2 }, time.Second, time.Millisecond*10)
+	time.Sleep(minDelay * 2)
+	assert.Equal(t, 2, c.totalUpdates())
+
+	t.Log("verifying that continuous changes don't delay updates past the maximum delay")
+	start := time.Now()
+	for time.Since(start) < maxDelay*2 {
+		c.notifyChange()
+		time.Sleep(minDelay / 4)
+	}
+	assert.GreaterOrEqual(t, c.totalUpdates(), 3)
+
+	t.Log("verifying that failed updates are retried without further changes")
+	updates := c.totalUpdates()
+	time.Sleep(minDelay * 2)
+	c.setUpdateError(fmt.Errorf("rejected"))
+	c.notifyChange()
+	assert.Eventually(t, func() bool { return c.totalUpdates() >= updates+3 }, time.Second, time.Millisecond*10)
+	c.setUpdateError(nil)
+
+	t.Log("verifying that the dataplane is eventually resynced without changes")
+	time.Sleep(stagger * 2)
+	updates = c.totalUpdates()
+	assert.Eventually(t, func() bool { return c.totalUpdates() == updates+1 }, resyncPeriod*2, time.Millisecond*50)
+
+	cancel()
+	assert.Eventually(t, func() bool { return !sync.IsReady() }, time.Second, time.Millisecond*10)
+}
+
+func TestSynchronizerBackoff(t *testing.T) {
+	c := &fakeNotifyingDataplaneClient{
+		fakeDataplaneClient: fakeDataplaneClient{dbmode: "off"},
+		changes:             make(chan struct{}, 1),
+	}
+	retryAfter := time.Now().Add(time.Millisecond * 500)
+	c.setUpdateError(&UpdateBackoffError{RetryAfter: retryAfter, ConsecutiveFailures: 1, Err: fmt.Errorf("rejected")})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sync, err := NewSynchronizerWithDebounce(logrus.New(), c, time.Millisecond*20, time.Millisecond*10, time.Millisecond*100, time.Minute)
+	assert.NoError(t, err)
+	go sync.startUpdateServer(ctx)
+
+	t.Log("verifying that updates which are backed off are only retried after the backoff delay")
+	assert.Eventually(t, func() bool { return c.totalUpdates() == 1 }, time.Second, time.Millisecond*10)
+	time.Sleep(time.Until(retryAfter) - time.Millisecond*100)
+	assert.Equal(t, 1, c.totalUpdates())
+	c.setUpdateError(nil)
+	assert.Eventually(t, func() bool { return c.totalUpdates() == 2 }, time.Second, time.Millisecond*10)
+	assert.True(t, sync.IsReady())
+}
+
+func TestNewSynchronizerWithDebounce(t *testing.T) {
+	_, err := NewSynchronizerWithDebounce(logrus.New(), &fakeDataplaneClient{}, time.Second, time.Second*2, time.Second, time.Minute)
+	assert.Error(t, err)
+	_, err = NewSynchronizerWithDebounce(logrus.New(), &fakeDataplaneClient{}, time.Second, time.Second, time.Second, 0)
+	assert.Error(t, err)
+}
+
 // fakeDataplaneClient fakes the dataplane.Client interface so that we can
 // unit test the dataplane.Synchronizer.
 type fakeDataplaneClient struct {
 	dbmode      string
 	updateCount int
+	updateError error
 	lock        sync.RWMutex
 }
 
@@ -93,7 +180,13 @@ func (c *fakeDataplaneClient) Update(ctx context.Context) error {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 	c.updateCount++
-	return nil
+	return c.updateError
+}
+
+func (c *fakeDataplaneClient) setUpdateError(err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.updateError = err
 }
 
 func (c *fakeDataplaneClient) totalUpdates() int {
@@ -101,3 +194,21 @@ func (c *fakeDataplaneClient) totalUpdates() int {
 	defer c.lock.RUnlock()
 	return c.updateCount
 }
+
+// fakeNotifyingDataplaneClient additionally fakes the dataplane.ChangeNotifier
+// interface.
+type fakeNotifyingDataplaneClient struct {
+	fakeDataplaneClient
+	changes chan struct{}
+}
+
+func (c *fakeNotifyingDataplaneClient) Changes() <-chan struct{} {
+	return c.changes
+}
+
+func (c *fakeNotifyingDataplaneClient) notifyChange() {
+	select {
+	case c.changes <- struct{}{}:
+	default:
+	}
+}
@@ -25,6 +25,8 @@ type Server struct {
 
 var successfulConfigDump file.Content
 var failedConfigDump file.Content
+var dryRunReport util.DryRunReport
+var circuitBreakerStatus = util.CircuitBreakerStatus{State: util.CircuitBreakerClosed}
 
 // Listen starts up the HTTP server and blocks until ctx expires.
 func (s *Server) Listen(ctx context.Context, port int) error {
@@ -77,6 +79,14 @@ func (s *Server) receiveConfig(ctx context.Context) {
 				successfulConfigDump = dump.Config
 			}
 			s.ConfigLock.Unlock()
+		case report := <-s.ConfigDumps.DryRunReports:
+			s.ConfigLock.Lock()
+			dryRunReport = report
+			s.ConfigLock.Unlock()
+		case status := <-s.ConfigDumps.CircuitBreaker:
+			s.ConfigLock.Lock()
+			circuitBreakerStatus = status
+			s.ConfigLock.Unlock()
 		case <-ctx.Done():
 			if err := ctx.Err(); err != nil {
 				s.Logger.Error(err, "shutting down diagnostic config collection: context completed with error")
@@ -104,8 +114,16 @@ func installProfilingHandlers(mux *http.ServeMux) {
 
 // installDumpHandlers adds the config dump webservice to the given mux.
 func (s *Server) installDumpHandlers(mux *http.ServeMux) {
-	mux.HandleFunc("/debug/config/successful", s.lastConfig(&successfulConfigDump))
-	mux.HandleFunc("/debug/config/failed", s.lastConfig(&failedConfigDump))
+	if s.ConfigDumps.Configs != nil {
+		mux.HandleFunc("/debug/config/successful", s.lastConfig(&successfulConfigDump))
+		mux.HandleFunc("/debug/config/failed", s.lastConfig(&failedConfigDump))
+	}
+	if s.ConfigDumps.DryRunReports != nil {
+		mux.HandleFunc("/debug/config/dry-run", s.lastDryRunReport)
+	}
+	if s.ConfigDumps.CircuitBreaker != nil {
+		mux.HandleFunc("/debug/circuit-breaker", s.lastCircuitBreakerStatus)
+	}
 }
 
 // redirectTo redirects request to a certain destination.
@@ -125,3 +143,25 @@ func (s *Server) lastConfig(config *file.Content) func(rw http.ResponseWriter, r
 		s.ConfigLock.RUnlock()
 	}
 }
+
+// lastDryRunReport serves the configuration which was most recently built in
+// dry-run mode, along with the changes it would make to the data-plane.
+func (s *Server) lastDryRunReport(rw http.ResponseWriter, req *http.Request) {
+	rw.Header().Set("Content-Type", "application/json")
+	s.ConfigLock.RLock()
+	if err := json.NewEncoder(rw).Encode(dryRunReport); err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+	}
+	s.ConfigLock.RUnlock()
+}
+
+// lastCircuitBreakerStatus serves the most recent status of the circuit breaker
+// which stops updates of the data-plane after consecutive failures.
+func (s *Server) lastCircuitBreakerStatus(rw http.ResponseWriter, req *http.Request) {
+	rw.Header().Set("Content-Type", "application/json")
+	s.ConfigLock.RLock()
+	if err := json.NewEncoder(rw).Encode(circuitBreakerStatus); err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+	}
+	s.ConfigLock.RUnlock()
+}
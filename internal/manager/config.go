@@ -51,9 +51,16 @@ type Config struct {
 	MetricsAddr              string
 	ProbeAddr                string
 	KongAdminURL             string
+	KongAdminSvc             string
+	KongAdminSvcPortNames    []string
+	KongAdminValidationURL   string
 	ProxySyncSeconds         float32
+	ProxySyncMinDelay        time.Duration
+	ProxySyncMaxDelay        time.Duration
+	ProxyResyncPeriod        time.Duration
 	ProxyTimeoutSeconds      float32
 	KongCustomEntitiesSecret string
+	CircuitBreakerReadiness  bool
 
 	// Kubernetes configurations
 	KubeconfigPath          string
@@ -71,19 +78,23 @@ type Config struct {
 	UpdateStatus         bool
 
 	// Kubernetes API toggling
-	IngressExtV1beta1Enabled bool
-	IngressNetV1beta1Enabled bool
-	IngressNetV1Enabled      bool
-	IngressClassNetV1Enabled bool
-	UDPIngressEnabled        bool
-	TCPIngressEnabled        bool
-	KongIngressEnabled       bool
-	KnativeIngressEnabled    bool
-	KongClusterPluginEnabled bool
-	KongPluginEnabled        bool
-	KongConsumerEnabled      bool
-	ServiceEnabled           bool
-	UseBeta1IngressClass     bool
+	IngressExtV1beta1Enabled  bool
+	IngressNetV1beta1Enabled  bool
+	IngressNetV1Enabled       bool
+	IngressClassNetV1Enabled  bool
+	UDPIngressEnabled         bool
+	TCPIngressEnabled         bool
+	KongIngressEnabled        bool
+	KnativeIngressEnabled     bool
+	KongClusterPluginEnabled  bool
+	KongPluginEnabled         bool
+	KongConsumerEnabled       bool
+	KongConsumerGroupEnabled  bool
+	KongUpstreamPolicyEnabled bool
+	KongServicePolicyEnabled  bool
+	KongVaultEnabled          bool
+	ServiceEnabled            bool
+	UseBeta1IngressClass      bool
 
 	// Admission Webhook server config
 	AdmissionServer admission.ServerConfig
@@ -93,6 +104,10 @@ type Config struct {
 	EnableConfigDumps   bool
 	DumpSensitiveConfig bool
 
+	// DryRun runs the controller in a mode where configuration is built and
+	// reported but never applied, and Kubernetes objects are not updated.
+	DryRun bool
+
 	// Feature Gates
 	FeatureGates map[string]bool
 
@@ -146,13 +161,30 @@ func (c *Config) FlagSet() *pflag.FlagSet {
 	flagSet.StringVar(&c.MetricsAddr, "metrics-bind-address", fmt.Sprintf(":%v", MetricsPort), "The address the metric endpoint binds to.")
 	flagSet.StringVar(&c.ProbeAddr, "health-probe-bind-address", fmt.Sprintf(":%v", HealthzPort), "The address the probe endpoint binds to.")
 	flagSet.StringVar(&c.KongAdminURL, "kong-admin-url", "http://localhost:8001", `The Kong Admin URL to connect to in the format "protocol://address:port".`)
+	flagSet.StringVar(&c.KongAdminSvc, "kong-admin-svc", "", `Kong Admin API Service in "namespace/name" format. When set, the Admin API endpoints of all DB-less proxy instances backing this Service are discovered and configured, and --kong-admin-url is ignored.`)
+	flagSet.StringSliceVar(&c.KongAdminSvcPortNames, "kong-admin-svc-port-names", adminapi.DefaultAdminAPIServicePortNames, "Names of the ports of the Service provided with --kong-admin-svc which serve the Kong Admin API. Ports with names including \"tls\" are expected to serve HTTPS.")
+	flagSet.StringVar(&c.KongAdminValidationURL, "kong-admin-validation-url", "", `The Admin URL of a DB-less Kong instance which doesn't serve traffic, in the format "protocol://address:port". `+
+		`When DB-less Kong rejects a configuration without reporting which entities are invalid, configurations are probed on this instance to find the Kubernetes objects to exclude.`)
 	flagSet.Float32Var(&c.ProxySyncSeconds, "proxy-sync-seconds", dataplane.DefaultSyncSeconds,
-		"Define the rate (in seconds) in which configuration updates will be applied to the Kong Admin API.",
+		"Define the minimum interval (in seconds) between configuration updates applied to the Kong Admin API.",
+	)
+	flagSet.DurationVar(&c.ProxySyncMinDelay, "proxy-sync-min-delay", dataplane.DefaultSyncMinDelay,
+		"Time to wait for further changes to Kubernetes objects after a change before updating the Kong Admin API.",
+	)
+	flagSet.DurationVar(&c.ProxySyncMaxDelay, "proxy-sync-max-delay", dataplane.DefaultSyncMaxDelay,
+		"Maximum time that further changes to Kubernetes objects can delay updating the Kong Admin API after a change.",
+	)
+	flagSet.DurationVar(&c.ProxyResyncPeriod, "proxy-resync-period", dataplane.DefaultResyncPeriod,
+		"Period at which the configuration is applied to the Kong Admin API even if no Kubernetes objects changed.",
 	)
 	flagSet.Float32Var(&c.ProxyTimeoutSeconds, "proxy-timeout-seconds", dataplane.DefaultTimeoutSeconds,
 		"Sets the timeout (in seconds) for all requests to Kong's Admin API.",
 	)
-	flagSet.StringVar(&c.KongCustomEntitiesSecret, "kong-custom-entities-secret", "", `A Secret containing custom entities for DB-less mode, in "namespace/name" format`)
+	flagSet.BoolVar(&c.CircuitBreakerReadiness, "circuit-breaker-readiness", false,
+		"Fail the readiness check while updates of the Kong Admin API are backed off after consecutive failures. "+
+			"This takes the Kong proxy out of its Service when it shares the controller's Pod, so it should only be enabled when the controller runs in its own Pod.",
+	)
+	flagSet.StringVar(&c.KongCustomEntitiesSecret, "kong-custom-entities-secret", "", `A Secret containing custom entities (as JSON or YAML under the "config" key) to merge into the configuration in DB-less mode, in "namespace/name" format`)
 
 	// Kubernetes configurations
 	flagSet.StringVar(&c.KubeconfigPath, "kubeconfig", "", "Path to the kubeconfig file.")
@@ -187,6 +219,10 @@ func (c *Config) FlagSet() *pflag.FlagSet {
 	flagSet.BoolVar(&c.KongClusterPluginEnabled, "enable-controller-kongclusterplugin", true, "Enable the KongClusterPlugin controller.")
 	flagSet.BoolVar(&c.KongPluginEnabled, "enable-controller-kongplugin", true, "Enable the KongPlugin controller.")
 	flagSet.BoolVar(&c.KongConsumerEnabled, "enable-controller-kongconsumer", true, "Enable the KongConsumer controller. ")
+	flagSet.BoolVar(&c.KongConsumerGroupEnabled, "enable-controller-kongconsumergroup", true, "Enable the KongConsumerGroup controller.")
+	flagSet.BoolVar(&c.KongUpstreamPolicyEnabled, "enable-controller-kongupstreampolicy", true, "Enable the KongUpstreamPolicy controller.")
+	flagSet.BoolVar(&c.KongServicePolicyEnabled, "enable-controller-kongservicepolicy", true, "Enable the KongServicePolicy controller.")
+	flagSet.BoolVar(&c.KongVaultEnabled, "enable-controller-kongvault", true, "Enable the KongVault controller.")
 	flagSet.BoolVar(&c.ServiceEnabled, "enable-controller-service", true, "Enable the Service controller.")
 	flagSet.BoolVar(&c.UseBeta1IngressClass, "use-v1beta1-ingress-class", false, "Use older networking.k8s.io/v1beta1 IngressClass")
 
@@ -208,6 +244,8 @@ func (c *Config) FlagSet() *pflag.FlagSet {
 	flagSet.BoolVar(&c.EnableProfiling, "profiling", false, fmt.Sprintf("Enable profiling via web interface host:%v/debug/pprof/", DiagnosticsPort))
 	flagSet.BoolVar(&c.EnableConfigDumps, "dump-config", false, fmt.Sprintf("Enable config dumps via web interface host:%v/debug/config", DiagnosticsPort))
 	flagSet.BoolVar(&c.DumpSensitiveConfig, "dump-sensitive-config", false, "Include credentials and TLS secrets in configs exposed with --dump-config")
+	flagSet.BoolVar(&c.DryRun, "dry-run", false, fmt.Sprintf(`Build the Kong configuration without applying it, and expose it along with the changes it would make to Kong `+
+		`via web interface host:%v/debug/config/dry-run and metrics. Kubernetes objects are not updated in this mode.`, DiagnosticsPort))
 
 	// Feature Gates (see FEATURE_GATES.md)
 	flagSet.Var(cliflag.NewMapStringBool(&c.FeatureGates), "feature-gates", "A set of key=value pairs that describe feature gates for alpha/beta/experimental features. "+
@@ -227,15 +265,22 @@ func (c *Config) FlagSet() *pflag.FlagSet {
 }
 
 func (c *Config) GetKongClient(ctx context.Context) (*kong.Client, error) {
+	return c.GetKongClientForURL(ctx, c.KongAdminURL)
+}
+
+// GetKongClientForURL provides a Kong Admin API client for the Admin API at the
+// provided URL, configured with the Admin API options of this Config.
+func (c *Config) GetKongClientForURL(ctx context.Context, url string) (*kong.Client, error) {
+	opts := c.KongAdminAPIConfig
 	if c.KongAdminToken != "" {
-		c.KongAdminAPIConfig.Headers = append(c.KongAdminAPIConfig.Headers, "kong-admin-token:"+c.KongAdminToken)
+		opts.Headers = append(append([]string{}, opts.Headers...), "kong-admin-token:"+c.KongAdminToken)
 	}
-	httpclient, err := adminapi.MakeHTTPClient(&c.KongAdminAPIConfig)
+	httpclient, err := adminapi.MakeHTTPClient(&opts)
 	if err != nil {
 		return nil, err
 	}
 
-	return adminapi.GetKongClientForWorkspace(ctx, c.KongAdminURL, c.KongWorkspace, httpclient)
+	return adminapi.GetKongClientForWorkspace(ctx, url, c.KongWorkspace, httpclient)
 }
 
 func (c *Config) GetKubeconfig() (*rest.Config, error) {
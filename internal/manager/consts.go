@@ -19,3 +19,7 @@ const MetricsPort = 10255
 
 // DiagnosticsPort is the default port of the manager's diagnostics service listens on.
 const DiagnosticsPort = 10256
+
+// KongClientEventRecorderComponentName is the component name used for the Kubernetes
+// Events which the data-plane client emits for objects whose configuration was rejected.
+const KongClientEventRecorderComponentName = "kong-client"
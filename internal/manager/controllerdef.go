@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"reflect"
 
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
 	knativev1alpha1 "knative.dev/networking/pkg/apis/networking/v1alpha1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -15,8 +17,10 @@ import (
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/controllers/gateway"
 	ctrlutils "github.com/kong/kubernetes-ingress-controller/v2/internal/controllers/utils"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util/kubernetes/object/status"
 	konghqcomv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+	konghqcomv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
 )
 
 // -----------------------------------------------------------------------------
@@ -67,6 +71,7 @@ func setupControllers(
 	dataplaneClient *dataplane.KongClient,
 	dataplaneAddressFinder *dataplane.AddressFinder,
 	kubernetesStatusQueue *status.Queue,
+	controllerPodPeer *networkingv1.NetworkPolicyPeer,
 	c *Config,
 	featureGates map[string]bool,
 ) ([]ControllerDef, error) {
@@ -76,6 +81,15 @@ func setupControllers(
 		return nil, fmt.Errorf("ingress version picker failed: %w", err)
 	}
 
+	var kongAdminSvcNN k8stypes.NamespacedName
+	if c.KongAdminSvc != "" {
+		namespace, name, err := util.ParseNameNS(c.KongAdminSvc)
+		if err != nil {
+			return nil, fmt.Errorf("--kong-admin-svc is invalid: %w", err)
+		}
+		kongAdminSvcNN = k8stypes.NamespacedName{Namespace: namespace, Name: name}
+	}
+
 	controllers := []ControllerDef{
 		// ---------------------------------------------------------------------------
 		// Core API Controllers
@@ -159,6 +173,27 @@ func setupControllers(
 				DataplaneClient: dataplaneClient,
 			},
 		},
+		{
+			// the labels of namespaces are only needed to check which routes the
+			// listeners of Gateways allow.
+			Enabled: featureGates[GatewayFeature],
+			Controller: &configuration.CoreV1NamespaceReconciler{
+				Client:          mgr.GetClient(),
+				Log:             ctrl.Log.WithName("controllers").WithName("Namespaces"),
+				Scheme:          mgr.GetScheme(),
+				DataplaneClient: dataplaneClient,
+			},
+		},
+		{
+			Enabled: c.KongAdminSvc != "",
+			Controller: &configuration.KongAdminAPIServiceReconciler{
+				Client:          mgr.GetClient(),
+				Log:             ctrl.Log.WithName("controllers").WithName("KongAdminAPIService"),
+				DataplaneClient: dataplaneClient,
+				ServiceNN:       kongAdminSvcNN,
+				PortNames:       c.KongAdminSvcPortNames,
+			},
+		},
 		// ---------------------------------------------------------------------------
 		// Kong API Controllers
 		// ---------------------------------------------------------------------------
@@ -203,6 +238,7 @@ func setupControllers(
 				Log:             ctrl.Log.WithName("controllers").WithName("KongPlugin"),
 				Scheme:          mgr.GetScheme(),
 				DataplaneClient: dataplaneClient,
+				StatusQueue:     kubernetesStatusQueue,
 			},
 		},
 		{
@@ -212,6 +248,69 @@ func setupControllers(
 				Log:              ctrl.Log.WithName("controllers").WithName("KongConsumer"),
 				Scheme:           mgr.GetScheme(),
 				DataplaneClient:  dataplaneClient,
+				StatusQueue:      kubernetesStatusQueue,
+				IngressClassName: c.IngressClassName,
+				IngressClassType: c.GetIngressClassObject(),
+			},
+		},
+		{
+			Enabled: c.KongConsumerGroupEnabled,
+			AutoHandler: crdExistsChecker{GVR: schema.GroupVersionResource{
+				Group:    konghqcomv1beta1.SchemeGroupVersion.Group,
+				Version:  konghqcomv1beta1.SchemeGroupVersion.Version,
+				Resource: "kongconsumergroups",
+			}}.CRDExists,
+			Controller: &configuration.KongV1Beta1KongConsumerGroupReconciler{
+				Client:           mgr.GetClient(),
+				Log:              ctrl.Log.WithName("controllers").WithName("KongConsumerGroup"),
+				Scheme:           mgr.GetScheme(),
+				DataplaneClient:  dataplaneClient,
+				IngressClassName: c.IngressClassName,
+				IngressClassType: c.GetIngressClassObject(),
+			},
+		},
+		{
+			Enabled: c.KongUpstreamPolicyEnabled,
+			AutoHandler: crdExistsChecker{GVR: schema.GroupVersionResource{
+				Group:    konghqcomv1beta1.SchemeGroupVersion.Group,
+				Version:  konghqcomv1beta1.SchemeGroupVersion.Version,
+				Resource: "kongupstreampolicies",
+			}}.CRDExists,
+			Controller: &configuration.KongV1Beta1KongUpstreamPolicyReconciler{
+				Client:          mgr.GetClient(),
+				Log:             ctrl.Log.WithName("controllers").WithName("KongUpstreamPolicy"),
+				Scheme:          mgr.GetScheme(),
+				DataplaneClient: dataplaneClient,
+				StatusQueue:     kubernetesStatusQueue,
+			},
+		},
+		{
+			Enabled: c.KongServicePolicyEnabled,
+			AutoHandler: crdExistsChecker{GVR: schema.GroupVersionResource{
+				Group:    konghqcomv1beta1.SchemeGroupVersion.Group,
+				Version:  konghqcomv1beta1.SchemeGroupVersion.Version,
+				Resource: "kongservicepolicies",
+			}}.CRDExists,
+			Controller: &configuration.KongV1Beta1KongServicePolicyReconciler{
+				Client:          mgr.GetClient(),
+				Log:             ctrl.Log.WithName("controllers").WithName("KongServicePolicy"),
+				Scheme:          mgr.GetScheme(),
+				DataplaneClient: dataplaneClient,
+				StatusQueue:     kubernetesStatusQueue,
+			},
+		},
+		{
+			Enabled: c.KongVaultEnabled,
+			AutoHandler: crdExistsChecker{GVR: schema.GroupVersionResource{
+				Group:    konghqcomv1beta1.SchemeGroupVersion.Group,
+				Version:  konghqcomv1beta1.SchemeGroupVersion.Version,
+				Resource: "kongvaults",
+			}}.CRDExists,
+			Controller: &configuration.KongV1Beta1KongVaultReconciler{
+				Client:           mgr.GetClient(),
+				Log:              ctrl.Log.WithName("controllers").WithName("KongVault"),
+				Scheme:           mgr.GetScheme(),
+				DataplaneClient:  dataplaneClient,
 				IngressClassName: c.IngressClassName,
 				IngressClassType: c.GetIngressClassObject(),
 			},
@@ -228,6 +327,7 @@ func setupControllers(
 				Log:              ctrl.Log.WithName("controllers").WithName("KongClusterPlugin"),
 				Scheme:           mgr.GetScheme(),
 				DataplaneClient:  dataplaneClient,
+				StatusQueue:      kubernetesStatusQueue,
 				IngressClassName: c.IngressClassName,
 				IngressClassType: c.GetIngressClassObject(),
 			},
@@ -239,7 +339,7 @@ func setupControllers(
 			// knative is a special case because it existed before we added feature gates functionality
 			// for this controller (only) the existing --enable-controller-knativeingress flag overrides
 			// any feature gate configuration. See FEATURE_GATES.md for more information.
-			Enabled: featureGates[gatewayFeature] || c.KnativeIngressEnabled,
+			Enabled: featureGates[GatewayFeature] || c.KnativeIngressEnabled,
 			AutoHandler: crdExistsChecker{GVR: schema.GroupVersionResource{
 				Group:    knativev1alpha1.SchemeGroupVersion.Group,
 				Version:  knativev1alpha1.SchemeGroupVersion.Version,
@@ -260,7 +360,7 @@ func setupControllers(
 		// GatewayAPI Controllers
 		// ---------------------------------------------------------------------------
 		{
-			Enabled: featureGates[gatewayFeature],
+			Enabled: featureGates[GatewayFeature],
 			AutoHandler: crdExistsChecker{
 				GVR: schema.GroupVersionResource{
 					Group:    gatewayv1alpha2.SchemeGroupVersion.Group,
@@ -268,16 +368,17 @@ func setupControllers(
 					Resource: "gateways",
 				}}.CRDExists,
 			Controller: &gateway.GatewayReconciler{
-				Client:          mgr.GetClient(),
-				Log:             ctrl.Log.WithName("controllers").WithName(gatewayFeature),
-				Scheme:          mgr.GetScheme(),
-				DataplaneClient: dataplaneClient,
-				PublishService:  c.PublishService,
-				WatchNamespaces: c.WatchNamespaces,
+				Client:            mgr.GetClient(),
+				Log:               ctrl.Log.WithName("controllers").WithName(GatewayFeature),
+				Scheme:            mgr.GetScheme(),
+				DataplaneClient:   dataplaneClient,
+				PublishService:    c.PublishService,
+				WatchNamespaces:   c.WatchNamespaces,
+				ControllerPodPeer: controllerPodPeer,
 			},
 		},
 		{
-			Enabled: featureGates[gatewayFeature],
+			Enabled: featureGates[GatewayFeature],
 			AutoHandler: crdExistsChecker{
 				GVR: schema.GroupVersionResource{
 					Group:    gatewayv1alpha2.SchemeGroupVersion.Group,
@@ -292,7 +393,22 @@ func setupControllers(
 			},
 		},
 		{
-			Enabled: featureGates[gatewayFeature],
+			Enabled: featureGates[GatewayFeature],
+			AutoHandler: crdExistsChecker{
+				GVR: schema.GroupVersionResource{
+					Group:    konghqcomv1beta1.SchemeGroupVersion.Group,
+					Version:  konghqcomv1beta1.SchemeGroupVersion.Version,
+					Resource: "gatewayconfigurations",
+				}}.CRDExists,
+			Controller: &gateway.GatewayConfigurationReconciler{
+				Client:          mgr.GetClient(),
+				Log:             ctrl.Log.WithName("controllers").WithName("GatewayConfiguration"),
+				Scheme:          mgr.GetScheme(),
+				DataplaneClient: dataplaneClient,
+			},
+		},
+		{
+			Enabled: featureGates[GatewayFeature],
 			AutoHandler: crdExistsChecker{
 				GVR: schema.GroupVersionResource{
 					Group:    gatewayv1alpha2.SchemeGroupVersion.Group,
@@ -307,7 +423,7 @@ func setupControllers(
 			},
 		},
 		{
-			Enabled: featureGates[gatewayFeature],
+			Enabled: featureGates[GatewayFeature],
 			AutoHandler: crdExistsChecker{
 				GVR: schema.GroupVersionResource{
 					Group:    gatewayv1alpha2.SchemeGroupVersion.Group,
@@ -322,7 +438,7 @@ func setupControllers(
 			},
 		},
 		{
-			Enabled: featureGates[gatewayFeature],
+			Enabled: featureGates[GatewayFeature],
 			AutoHandler: crdExistsChecker{
 				GVR: schema.GroupVersionResource{
 					Group:    gatewayv1alpha2.SchemeGroupVersion.Group,
@@ -337,7 +453,7 @@ func setupControllers(
 			},
 		},
 		{
-			Enabled: featureGates[gatewayFeature],
+			Enabled: featureGates[GatewayFeature],
 			AutoHandler: crdExistsChecker{
 				GVR: schema.GroupVersionResource{
 					Group:    gatewayv1alpha2.SchemeGroupVersion.Group,
@@ -11,23 +11,23 @@ import (
 // -----------------------------------------------------------------------------
 
 const (
-	// knativeFeature is the name of the feature-gate for enabling/disabling Knative
-	knativeFeature = "Knative"
+	// KnativeFeature is the name of the feature-gate for enabling/disabling Knative
+	KnativeFeature = "Knative"
 
-	// gatewayFeature is the name of the feature-gate for enabling/disabling Gateway APIs
-	gatewayFeature = "Gateway"
+	// GatewayFeature is the name of the feature-gate for enabling/disabling Gateway APIs
+	GatewayFeature = "Gateway"
 
-	// combinedRoutesFeature is the name of the feature-gate for the newer object
+	// CombinedRoutesFeature is the name of the feature-gate for the newer object
 	// translation logic that will combine routes for kong services when translating
 	// objects like Ingress instead of creating a route per path.
-	combinedRoutesFeature = "CombinedRoutes"
+	CombinedRoutesFeature = "CombinedRoutes"
 
 	// featureGatesDocsURL provides a link to the documentation for feature gates in the KIC repository
 	featureGatesDocsURL = "https://github.com/Kong/kubernetes-ingress-controller/blob/main/FEATURE_GATES.md"
 )
 
-// setupFeatureGates converts feature gates to controller enablement
-func setupFeatureGates(setupLog logr.Logger, c *Config) (map[string]bool, error) {
+// SetupFeatureGates converts feature gates to controller enablement
+func SetupFeatureGates(setupLog logr.Logger, c *Config) (map[string]bool, error) {
 	// generate a map of feature gates by string names to their controller enablement
 	ctrlMap := getFeatureGatesDefaults()
 
@@ -51,8 +51,8 @@ func setupFeatureGates(setupLog logr.Logger, c *Config) (map[string]bool, error)
 // NOTE: if you're adding a new feature gate, it needs to be added here.
 func getFeatureGatesDefaults() map[string]bool {
 	return map[string]bool{
-		knativeFeature:        false,
-		gatewayFeature:        false,
-		combinedRoutesFeature: false,
+		KnativeFeature:        false,
+		GatewayFeature:        false,
+		CombinedRoutesFeature: false,
 	}
 }
@@ -19,21 +19,21 @@ func TestFeatureGates(t *testing.T) {
 	config := new(Config)
 
 	t.Log("verifying feature gates setup defaults when no feature gates are configured")
-	fgs, err := setupFeatureGates(setupLog, config)
+	fgs, err := SetupFeatureGates(setupLog, config)
 	assert.NoError(t, err)
 	assert.Len(t, fgs, len(getFeatureGatesDefaults()))
 
 	t.Log("verifying feature gates setup results when valid feature gates options are present")
-	config.FeatureGates = map[string]bool{knativeFeature: true}
-	fgs, err = setupFeatureGates(setupLog, config)
+	config.FeatureGates = map[string]bool{KnativeFeature: true}
+	fgs, err = SetupFeatureGates(setupLog, config)
 	assert.NoError(t, err)
-	assert.True(t, fgs[knativeFeature])
+	assert.True(t, fgs[KnativeFeature])
 
 	t.Log("configuring several invalid feature gates options")
 	config.FeatureGates = map[string]bool{"invalidGateway": true}
 
 	t.Log("verifying feature gates setup results when invalid feature gates options are present")
-	_, err = setupFeatureGates(setupLog, config)
+	_, err = SetupFeatureGates(setupLog, config)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "invalidGateway is not a valid feature")
 }
@@ -11,8 +11,10 @@ import (
 	"github.com/avast/retry-go/v4"
 	"github.com/kong/go-kong/kong"
 	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	knativev1alpha1 "knative.dev/networking/pkg/apis/networking/v1alpha1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
@@ -53,7 +55,7 @@ func Run(ctx context.Context, c *Config, diagnostic util.ConfigDumpDiagnostic) e
 	}
 
 	setupLog.Info("getting enabled options and features")
-	featureGates, err := setupFeatureGates(setupLog, c)
+	featureGates, err := SetupFeatureGates(setupLog, c)
 	if err != nil {
 		return fmt.Errorf("failed to configure feature gates: %w", err)
 	}
@@ -64,6 +66,18 @@ func Run(ctx context.Context, c *Config, diagnostic util.ConfigDumpDiagnostic) e
 		return fmt.Errorf("get kubeconfig from file %q: %w", c.KubeconfigPath, err)
 	}
 
+	var kongAdminURLs []string
+	if c.KongAdminSvc != "" {
+		setupLog.Info("discovering kong admin api endpoints", "service", c.KongAdminSvc)
+		kongAdminURLs, err = setupKongAdminAPIDiscovery(ctx, setupLog, c)
+		if err != nil {
+			return err
+		}
+		// the first discovered proxy instance is used to determine the Kong
+		// version and configuration, all of them are expected to be identical.
+		c.KongAdminURL = kongAdminURLs[0]
+	}
+
 	setupLog.Info("getting the kong admin api client configuration")
 	adminClient, err := c.GetKongClient(ctx)
 	if err != nil {
@@ -107,6 +121,9 @@ func Run(ctx context.Context, c *Config, diagnostic util.ConfigDumpDiagnostic) e
 	if dbmode == "off" && c.SkipCACertificates {
 		return fmt.Errorf("--skip-ca-certificates is not available for use with DB-less Kong instances")
 	}
+	if dbmode != "off" && c.KongAdminSvc != "" {
+		return fmt.Errorf("--kong-admin-svc is only available for use with DB-less Kong instances")
+	}
 
 	setupLog.Info("configuring and building the controller manager")
 	controllerOpts, err := setupControllerOptions(setupLog, c, scheme, dbmode)
@@ -119,7 +136,7 @@ func Run(ctx context.Context, c *Config, diagnostic util.ConfigDumpDiagnostic) e
 	}
 
 	setupLog.Info("Starting Admission Server")
-	if err := setupAdmissionServer(ctx, c, mgr.GetClient()); err != nil {
+	if err := setupAdmissionServer(ctx, c, mgr.GetClient(), dbmode); err != nil {
 		return err
 	}
 
@@ -128,10 +145,21 @@ func Run(ctx context.Context, c *Config, diagnostic util.ConfigDumpDiagnostic) e
 	if err != nil {
 		return fmt.Errorf("%f is not a valid number of seconds to the timeout config for the kong client: %w", c.ProxyTimeoutSeconds, err)
 	}
-	dataplaneClient, err := dataplane.NewKongClient(deprecatedLogger, timeoutDuration, c.IngressClassName, c.EnableReverseSync, c.SkipCACertificates, diagnostic, kongConfig)
+	// no Events are emitted in dry-run mode, as they would be indistinguishable
+	// from the ones of the controller which actually configures Kong.
+	var eventRecorder record.EventRecorder
+	if !c.DryRun {
+		eventRecorder = mgr.GetEventRecorderFor(KongClientEventRecorderComponentName)
+	}
+	dataplaneClient, err := dataplane.NewKongClient(deprecatedLogger, timeoutDuration, c.IngressClassName, c.EnableReverseSync, c.SkipCACertificates, diagnostic, kongConfig,
+		eventRecorder)
 	if err != nil {
 		return fmt.Errorf("failed to initialize kong data-plane client: %w", err)
 	}
+	if c.DryRun {
+		dataplaneClient.EnableDryRun()
+		setupLog.Info("dry-run mode has been enabled, configuration will not be applied to kong")
+	}
 
 	setupLog.Info("Initializing Dataplane Synchronizer")
 	synchronizer, err := setupDataplaneSynchronizer(setupLog, deprecatedLogger, mgr, dataplaneClient, c)
@@ -139,12 +167,49 @@ func Run(ctx context.Context, c *Config, diagnostic util.ConfigDumpDiagnostic) e
 		return fmt.Errorf("unable to initialize dataplane synchronizer: %w", err)
 	}
 
-	if enabled, ok := featureGates[combinedRoutesFeature]; ok && enabled {
+	if enabled, ok := featureGates[CombinedRoutesFeature]; ok && enabled {
 		dataplaneClient.EnableCombinedServiceRoutes()
 		setupLog.Info("combined routes mode has been enabled")
 	}
 
+	if c.KongCustomEntitiesSecret != "" {
+		namespace, name, err := util.ParseNameNS(c.KongCustomEntitiesSecret)
+		if err != nil {
+			return fmt.Errorf("--kong-custom-entities-secret is invalid: %w", err)
+		}
+		if dbmode != "off" {
+			setupLog.Info("WARNING: --kong-custom-entities-secret is only supported for DB-less Kong instances and will be ignored")
+		} else {
+			dataplaneClient.EnableCustomEntities(k8stypes.NamespacedName{Namespace: namespace, Name: name})
+			setupLog.Info("custom entities have been enabled", "secret", c.KongCustomEntitiesSecret)
+		}
+	}
+
+	if c.KongAdminSvc != "" {
+		dataplaneClient.EnableProxyDiscovery(c.GetKongClientForURL)
+		if err := dataplaneClient.UpdateProxies(ctx, kongAdminURLs); err != nil {
+			return fmt.Errorf("failed to configure discovered kong proxy instances: %w", err)
+		}
+		setupLog.Info("kong admin api discovery has been enabled", "service", c.KongAdminSvc, "urls", kongAdminURLs)
+	}
+
+	if c.KongAdminValidationURL != "" {
+		if dbmode != "off" {
+			setupLog.Info("WARNING: --kong-admin-validation-url is only supported for DB-less Kong instances and will be ignored")
+		} else {
+			validationClient, err := c.GetKongClientForURL(ctx, c.KongAdminValidationURL)
+			if err != nil {
+				return fmt.Errorf("unable to build kong api client for the validation instance: %w", err)
+			}
+			dataplaneClient.EnableValidationProxy(c.KongAdminValidationURL, validationClient)
+			setupLog.Info("configuration validation instance has been enabled", "url", c.KongAdminValidationURL)
+		}
+	}
+
 	var kubernetesStatusQueue *status.Queue
+	if c.DryRun {
+		c.UpdateStatus = false
+	}
 	if c.UpdateStatus {
 		setupLog.Info("Starting Status Updater")
 		kubernetesStatusQueue = status.NewQueue()
@@ -159,8 +224,13 @@ func Run(ctx context.Context, c *Config, diagnostic util.ConfigDumpDiagnostic) e
 		return err
 	}
 
+	controllerPodPeer, err := setupControllerPodPeer(ctx, setupLog, mgr.GetAPIReader())
+	if err != nil {
+		return err
+	}
+
 	setupLog.Info("Starting Enabled Controllers")
-	controllers, err := setupControllers(mgr, dataplaneClient, dataplaneAddressFinder, kubernetesStatusQueue, c, featureGates)
+	controllers, err := setupControllers(mgr, dataplaneClient, dataplaneAddressFinder, kubernetesStatusQueue, controllerPodPeer, c, featureGates)
 	if err != nil {
 		return fmt.Errorf("unable to setup controller as expected %w", err)
 	}
@@ -186,6 +256,25 @@ func Run(ctx context.Context, c *Config, diagnostic util.ConfigDumpDiagnostic) e
 	}); err != nil {
 		return fmt.Errorf("unable to setup readyz: %w", err)
 	}
+	// Kong keeps serving its last configuration while updates are backed off,
+	// so the readiness of the Pod, which Kong usually shares, only reflects the
+	// circuit breaker when explicitly requested.
+	if c.CircuitBreakerReadiness {
+		if err := mgr.AddReadyzCheck("circuit-breaker", func(_ *http.Request) error {
+			if status := dataplaneClient.CircuitBreakerStatus(); status.State == util.CircuitBreakerOpen {
+				return fmt.Errorf("updates of the data-plane are backed off after %d consecutive failures: %s",
+					status.ConsecutiveFailures, status.LastError)
+			}
+			return nil
+		}); err != nil {
+			return fmt.Errorf("unable to setup readyz: %w", err)
+		}
+	}
+	if !c.DryRun {
+		if err := setupCircuitBreakerCondition(setupLog, deprecatedLogger, mgr, dataplaneClient); err != nil {
+			return fmt.Errorf("unable to setup the circuit breaker condition: %w", err)
+		}
+	}
 
 	if c.AnonymousReports {
 		setupLog.Info("Starting anonymous reports")
@@ -7,19 +7,25 @@ import (
 	"strings"
 	"time"
 
+	"github.com/avast/retry-go/v4"
 	"github.com/bombsimon/logrusr/v2"
 	"github.com/go-logr/logr"
 	"github.com/kong/deck/cprint"
 	"github.com/kong/go-kong/kong"
 	"github.com/sirupsen/logrus"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/admission"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/sendconfig"
@@ -70,8 +76,29 @@ func setupControllerOptions(logger logr.Logger, c *Config, scheme *runtime.Schem
 		requiredCacheNamespaces = append(requiredCacheNamespaces, publishServiceSplit[0])
 	}
 
+	// if a custom entities secret has been provided the namespace for it should
+	// be watched so that the secret is available to the dataplane client.
+	if c.KongCustomEntitiesSecret != "" {
+		namespace, _, err := util.ParseNameNS(c.KongCustomEntitiesSecret)
+		if err != nil {
+			return ctrl.Options{}, fmt.Errorf("--kong-custom-entities-secret was expected to be in format <namespace>/<name> but got %s", c.KongCustomEntitiesSecret)
+		}
+		requiredCacheNamespaces = append(requiredCacheNamespaces, namespace)
+	}
+
+	if c.KongAdminSvc != "" {
+		namespace, _, err := util.ParseNameNS(c.KongAdminSvc)
+		if err != nil {
+			return ctrl.Options{}, fmt.Errorf("--kong-admin-svc was expected to be in format <namespace>/<name> but got %s", c.KongAdminSvc)
+		}
+		requiredCacheNamespaces = append(requiredCacheNamespaces, namespace)
+	}
+
 	var leaderElection bool
-	if dbmode == "off" {
+	if c.DryRun {
+		logger.Info("dry-run mode enabled, disabling leader election")
+		leaderElection = false
+	} else if dbmode == "off" {
 		logger.Info("DB-less mode detected, disabling leader election")
 		leaderElection = false
 	} else {
@@ -88,6 +115,9 @@ func setupControllerOptions(logger logr.Logger, c *Config, scheme *runtime.Schem
 		LeaderElection:         leaderElection,
 		LeaderElectionID:       c.LeaderElectionID,
 		SyncPeriod:             &c.SyncPeriod,
+		// in dry-run mode the changes controllers make to Kubernetes objects are
+		// only validated by the API server and not persisted.
+		DryRunClient: c.DryRun,
 	}
 
 	// configure the controller caching options
@@ -150,10 +180,13 @@ func setupDataplaneSynchronizer(
 		return nil, err
 	}
 
-	dataplaneSynchronizer, err := dataplane.NewSynchronizerWithStagger(
+	dataplaneSynchronizer, err := dataplane.NewSynchronizerWithDebounce(
 		fieldLogger.WithField("subsystem", "dataplane-synchronizer"),
 		dataplaneClient,
 		syncTickDuration,
+		c.ProxySyncMinDelay,
+		c.ProxySyncMaxDelay,
+		c.ProxyResyncPeriod,
 	)
 	if err != nil {
 		return nil, err
@@ -167,7 +200,58 @@ func setupDataplaneSynchronizer(
 	return dataplaneSynchronizer, nil
 }
 
-func setupAdmissionServer(ctx context.Context, managerConfig *Config, managerClient client.Client) error {
+//+kubebuilder:rbac:groups="",resources=pods/status,verbs=patch
+
+// setupCircuitBreakerCondition reports the state of the circuit breaker of
+// data-plane updates as a condition of the controller's Pod, when the Pod is
+// known from the POD_NAME and POD_NAMESPACE environment variables.
+func setupCircuitBreakerCondition(
+	logger logr.Logger,
+	fieldLogger logrus.FieldLogger,
+	mgr manager.Manager,
+	dataplaneClient *dataplane.KongClient,
+) error {
+	name, namespace := os.Getenv("POD_NAME"), os.Getenv("POD_NAMESPACE")
+	if name == "" || namespace == "" {
+		logger.Info("POD_NAME or POD_NAMESPACE are not set, the circuit breaker state will not be reported on the controller pod")
+		return nil
+	}
+	return mgr.Add(dataplane.NewCircuitBreakerConditionReporter(
+		fieldLogger.WithField("subsystem", "circuit-breaker-condition"),
+		mgr.GetClient(),
+		types.NamespacedName{Namespace: namespace, Name: name},
+		dataplaneClient.CircuitBreakerStatus,
+		dataplane.DefaultCircuitBreakerConditionInterval,
+	))
+}
+
+// setupControllerPodPeer provides the NetworkPolicy peer which selects the pods of the controller, by
+// the namespace and labels of the controller's Pod known from the POD_NAME and POD_NAMESPACE environment
+// variables. The label of the revision of the Deployment is left out, so that the peer keeps selecting
+// the controller across rollouts. No peer is provided when the Pod is unknown.
+func setupControllerPodPeer(ctx context.Context, logger logr.Logger, reader client.Reader) (*networkingv1.NetworkPolicyPeer, error) {
+	name, namespace := os.Getenv("POD_NAME"), os.Getenv("POD_NAMESPACE")
+	if name == "" || namespace == "" {
+		logger.Info("POD_NAME or POD_NAMESPACE are not set, the admin api of managed gateways will not be restricted to the controller pods")
+		return nil, nil
+	}
+	pod := &corev1.Pod{}
+	if err := reader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, pod); err != nil {
+		return nil, fmt.Errorf("could not retrieve the controller pod %s/%s: %w", namespace, name, err)
+	}
+	labels := make(map[string]string, len(pod.Labels))
+	for k, v := range pod.Labels {
+		if k != appsv1.DefaultDeploymentUniqueLabelKey {
+			labels[k] = v
+		}
+	}
+	return &networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{corev1.LabelMetadataName: namespace}},
+		PodSelector:       &metav1.LabelSelector{MatchLabels: labels},
+	}, nil
+}
+
+func setupAdmissionServer(ctx context.Context, managerConfig *Config, managerClient client.Client, dbmode string) error {
 	log, err := util.MakeLogger(managerConfig.LogLevel, managerConfig.LogFormat)
 	if err != nil {
 		return err
@@ -191,6 +275,7 @@ func setupAdmissionServer(ctx context.Context, managerConfig *Config, managerCli
 			log,
 			managerClient,
 			managerConfig.IngressClassName,
+			dbmode,
 		),
 		Logger: logger,
 	}, log)
@@ -252,3 +337,46 @@ func setupDataplaneAddressFinder(ctx context.Context, mgrc client.Client, c *Con
 
 	return dataplaneAddressFinder, nil
 }
+
+// setupKongAdminAPIDiscovery retrieves the Admin API URLs of the proxy instances
+// which currently back the Service provided with --kong-admin-svc. The Endpoints
+// are retried until at least one ready Admin API is available, as the proxies
+// may start at the same time as the controller.
+func setupKongAdminAPIDiscovery(ctx context.Context, logger logr.Logger, c *Config) ([]string, error) {
+	namespace, name, err := util.ParseNameNS(c.KongAdminSvc)
+	if err != nil {
+		return nil, fmt.Errorf("--kong-admin-svc was expected to be in format <namespace>/<name> but got %s", c.KongAdminSvc)
+	}
+
+	kubeClient, err := c.GetKubeClient()
+	if err != nil {
+		return nil, fmt.Errorf("unable to build kubernetes client: %w", err)
+	}
+
+	var urls []string
+	err = retry.Do(
+		func() error {
+			endpoints := new(corev1.Endpoints)
+			if err := kubeClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, endpoints); err != nil {
+				return err
+			}
+			urls = adminapi.GetURLsForEndpoints(endpoints, sets.NewString(c.KongAdminSvcPortNames...))
+			if len(urls) == 0 {
+				return fmt.Errorf("no ready kong admin api endpoints found for service %s", c.KongAdminSvc)
+			}
+			return nil
+		},
+		retry.Context(ctx),
+		retry.Attempts(c.KongAdminInitializationRetries),
+		retry.Delay(c.KongAdminInitializationRetryDelay),
+		retry.DelayType(retry.FixedDelay),
+		retry.OnRetry(func(n uint, err error) {
+			logger.Info("retrying kong admin api discovery", "attempt", n, "error", err.Error())
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not discover kong admin api endpoints: %w", err)
+	}
+
+	return urls, nil
+}
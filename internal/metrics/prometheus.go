@@ -14,6 +14,18 @@ type CtrlFuncMetrics struct {
 
 	// ConfigPushDuration is a Prometheus metric with semantics defined by its help string in NewCtrlFuncMetrics().
 	ConfigPushDuration *prometheus.HistogramVec
+
+	// QuarantinedObjects is a Prometheus metric with semantics defined by its help string in NewCtrlFuncMetrics().
+	QuarantinedObjects *prometheus.GaugeVec
+
+	// TranslationFailures is a Prometheus metric with semantics defined by its help string in NewCtrlFuncMetrics().
+	TranslationFailures *prometheus.GaugeVec
+
+	// DryRunConfigChanges is a Prometheus metric with semantics defined by its help string in NewCtrlFuncMetrics().
+	DryRunConfigChanges *prometheus.GaugeVec
+
+	// CircuitBreakerState is a Prometheus metric with semantics defined by its help string in NewCtrlFuncMetrics().
+	CircuitBreakerState *prometheus.GaugeVec
 }
 
 const (
@@ -37,9 +49,35 @@ const (
 )
 
 const (
-	MetricNameConfigPushCount    = "ingress_controller_configuration_push_count"
-	MetricNameTranslationCount   = "ingress_controller_translation_count"
-	MetricNameConfigPushDuration = "ingress_controller_configuration_push_duration_milliseconds"
+	// KindKey defines the key of the metric label indicating the kind of a Kubernetes object.
+	KindKey string = "kind"
+)
+
+const (
+	// ActionCreate indicates that an entity would be created in Kong.
+	ActionCreate string = "create"
+	// ActionUpdate indicates that an entity would be updated in Kong.
+	ActionUpdate string = "update"
+	// ActionDelete indicates that an entity would be deleted from Kong.
+	ActionDelete string = "delete"
+
+	// ActionKey defines the key of the metric label indicating the action taken on Kong entities.
+	ActionKey string = "action"
+)
+
+const (
+	// StateKey defines the key of the metric label indicating a state.
+	StateKey string = "state"
+)
+
+const (
+	MetricNameConfigPushCount     = "ingress_controller_configuration_push_count"
+	MetricNameTranslationCount    = "ingress_controller_translation_count"
+	MetricNameConfigPushDuration  = "ingress_controller_configuration_push_duration_milliseconds"
+	MetricNameQuarantinedObjects  = "ingress_controller_configuration_quarantined_objects"
+	MetricNameTranslationFailures = "ingress_controller_translation_failures"
+	MetricNameDryRunConfigChanges = "ingress_controller_dry_run_configuration_changes"
+	MetricNameCircuitBreakerState = "ingress_controller_configuration_push_circuit_breaker_state"
 )
 
 func NewCtrlFuncMetrics() *CtrlFuncMetrics {
@@ -83,7 +121,58 @@ func NewCtrlFuncMetrics() *CtrlFuncMetrics {
 			[]string{SuccessKey, ProtocolKey},
 		)
 
-	metrics.Registry.MustRegister(controllerMetrics.ConfigPushCount, controllerMetrics.TranslationCount, controllerMetrics.ConfigPushDuration)
+	controllerMetrics.QuarantinedObjects =
+		prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: MetricNameQuarantinedObjects,
+				Help: "Number of Kubernetes objects excluded from the configuration pushed to Kong because " +
+					"Kong rejected the configuration generated from them. `" +
+					KindKey + "` describes the kind of the objects.",
+			},
+			[]string{KindKey},
+		)
+
+	controllerMetrics.TranslationFailures =
+		prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: MetricNameTranslationFailures,
+				Help: "Number of Kubernetes objects which could not be (fully) translated into Kong configuration " +
+					"by the most recent translation. `" + KindKey + "` describes the kind of the objects.",
+			},
+			[]string{KindKey},
+		)
+
+	controllerMetrics.DryRunConfigChanges =
+		prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: MetricNameDryRunConfigChanges,
+				Help: "Number of Kong entities which the most recent configuration would change if the controller " +
+					"was not running in dry-run mode. `" + ActionKey + "` describes the change (" +
+					ActionCreate + ", " + ActionUpdate + " or " + ActionDelete + ").",
+			},
+			[]string{ActionKey},
+		)
+
+	controllerMetrics.CircuitBreakerState =
+		prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: MetricNameCircuitBreakerState,
+				Help: "State of the circuit breaker which stops configuration pushes to Kong after consecutive " +
+					"failures. The gauge labeled with the current `" + StateKey + "` (closed, open or half-open) " +
+					"is 1, the others are 0.",
+			},
+			[]string{StateKey},
+		)
+
+	metrics.Registry.MustRegister(
+		controllerMetrics.ConfigPushCount,
+		controllerMetrics.TranslationCount,
+		controllerMetrics.ConfigPushDuration,
+		controllerMetrics.QuarantinedObjects,
+		controllerMetrics.TranslationFailures,
+		controllerMetrics.DryRunConfigChanges,
+		controllerMetrics.CircuitBreakerState,
+	)
 
 	return controllerMetrics
 }
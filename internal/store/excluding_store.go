@@ -0,0 +1,333 @@
+package store
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	knative "knative.dev/networking/pkg/apis/networking/v1alpha1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	kongv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+	kongv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
+)
+
+// ExcludeFunc indicates whether the object of the provided kind with the
+// provided namespace and name should be excluded.
+type ExcludeFunc func(gk schema.GroupKind, namespace, name string) bool
+
+// excludingStore is a Storer which hides the objects that an ExcludeFunc
+// excludes from an underlying Storer, as if those objects didn't exist.
+//
+// Only the kinds of objects which configuration is generated for (as opposed
+// to supporting objects like Secrets and IngressClasses) can be excluded.
+type excludingStore struct {
+	Storer
+
+	exclude ExcludeFunc
+}
+
+// NewExcludingStorer provides a Storer which hides the objects excluded by the
+// provided function from the provided Storer.
+func NewExcludingStorer(s Storer, exclude ExcludeFunc) Storer {
+	return excludingStore{Storer: s, exclude: exclude}
+}
+
+var (
+	serviceGK            = corev1.SchemeGroupVersion.WithKind("Service").GroupKind()
+	ingressGK            = networkingv1.SchemeGroupVersion.WithKind("Ingress").GroupKind()
+	extensionsIngressGK  = schema.GroupKind{Group: "extensions", Kind: "Ingress"}
+	gatewayGK            = gatewayv1alpha2.SchemeGroupVersion.WithKind("Gateway").GroupKind()
+	httpRouteGK          = gatewayv1alpha2.SchemeGroupVersion.WithKind("HTTPRoute").GroupKind()
+	udpRouteGK           = gatewayv1alpha2.SchemeGroupVersion.WithKind("UDPRoute").GroupKind()
+	tcpRouteGK           = gatewayv1alpha2.SchemeGroupVersion.WithKind("TCPRoute").GroupKind()
+	tlsRouteGK           = gatewayv1alpha2.SchemeGroupVersion.WithKind("TLSRoute").GroupKind()
+	tcpIngressGK         = kongv1beta1.SchemeGroupVersion.WithKind("TCPIngress").GroupKind()
+	udpIngressGK         = kongv1beta1.SchemeGroupVersion.WithKind("UDPIngress").GroupKind()
+	knativeIngressGK     = knative.SchemeGroupVersion.WithKind("Ingress").GroupKind()
+	kongPluginGK         = kongv1.SchemeGroupVersion.WithKind("KongPlugin").GroupKind()
+	kongClusterPluginGK  = kongv1.SchemeGroupVersion.WithKind("KongClusterPlugin").GroupKind()
+	kongConsumerGK       = kongv1.SchemeGroupVersion.WithKind("KongConsumer").GroupKind()
+	kongConsumerGroupGK  = kongv1beta1.SchemeGroupVersion.WithKind("KongConsumerGroup").GroupKind()
+	kongUpstreamPolicyGK = kongv1beta1.SchemeGroupVersion.WithKind("KongUpstreamPolicy").GroupKind()
+	kongServicePolicyGK  = kongv1beta1.SchemeGroupVersion.WithKind("KongServicePolicy").GroupKind()
+	kongVaultGK          = kongv1beta1.SchemeGroupVersion.WithKind("KongVault").GroupKind()
+)
+
+// GetService returns the named Service unless it's excluded.
+func (s excludingStore) GetService(namespace, name string) (*corev1.Service, error) {
+	if s.exclude(serviceGK, namespace, name) {
+		return nil, ErrNotFound{fmt.Sprintf("Service %v/%v is excluded", namespace, name)}
+	}
+	return s.Storer.GetService(namespace, name)
+}
+
+// GetKongPlugin returns the named KongPlugin unless it's excluded.
+func (s excludingStore) GetKongPlugin(namespace, name string) (*kongv1.KongPlugin, error) {
+	if s.exclude(kongPluginGK, namespace, name) {
+		return nil, ErrNotFound{fmt.Sprintf("KongPlugin %v/%v is excluded", namespace, name)}
+	}
+	return s.Storer.GetKongPlugin(namespace, name)
+}
+
+// GetKongClusterPlugin returns the named KongClusterPlugin unless it's excluded.
+func (s excludingStore) GetKongClusterPlugin(name string) (*kongv1.KongClusterPlugin, error) {
+	if s.exclude(kongClusterPluginGK, "", name) {
+		return nil, ErrNotFound{fmt.Sprintf("KongClusterPlugin %v is excluded", name)}
+	}
+	return s.Storer.GetKongClusterPlugin(name)
+}
+
+// GetKongConsumer returns the named KongConsumer unless it's excluded.
+func (s excludingStore) GetKongConsumer(namespace, name string) (*kongv1.KongConsumer, error) {
+	if s.exclude(kongConsumerGK, namespace, name) {
+		return nil, ErrNotFound{fmt.Sprintf("KongConsumer %v/%v is excluded", namespace, name)}
+	}
+	return s.Storer.GetKongConsumer(namespace, name)
+}
+
+// ListIngressesV1beta1 returns the Ingresses of the underlying Storer which
+// are not excluded.
+func (s excludingStore) ListIngressesV1beta1() []*networkingv1beta1.Ingress {
+	var res []*networkingv1beta1.Ingress
+	for _, obj := range s.Storer.ListIngressesV1beta1() {
+		if !s.exclude(ingressGK, obj.Namespace, obj.Name) && !s.exclude(extensionsIngressGK, obj.Namespace, obj.Name) {
+			res = append(res, obj)
+		}
+	}
+	return res
+}
+
+// ListIngressesV1 returns the Ingresses of the underlying Storer which are not
+// excluded.
+func (s excludingStore) ListIngressesV1() []*networkingv1.Ingress {
+	var res []*networkingv1.Ingress
+	for _, obj := range s.Storer.ListIngressesV1() {
+		if !s.exclude(ingressGK, obj.Namespace, obj.Name) {
+			res = append(res, obj)
+		}
+	}
+	return res
+}
+
+// ListGateways returns the Gateways of the underlying Storer which are not
+// excluded.
+func (s excludingStore) ListGateways() ([]*gatewayv1alpha2.Gateway, error) {
+	objs, err := s.Storer.ListGateways()
+	if err != nil {
+		return nil, err
+	}
+	var res []*gatewayv1alpha2.Gateway
+	for _, obj := range objs {
+		if !s.exclude(gatewayGK, obj.Namespace, obj.Name) {
+			res = append(res, obj)
+		}
+	}
+	return res, nil
+}
+
+// ListHTTPRoutes returns the HTTPRoutes of the underlying Storer which are not
+// excluded.
+func (s excludingStore) ListHTTPRoutes() ([]*gatewayv1alpha2.HTTPRoute, error) {
+	objs, err := s.Storer.ListHTTPRoutes()
+	if err != nil {
+		return nil, err
+	}
+	var res []*gatewayv1alpha2.HTTPRoute
+	for _, obj := range objs {
+		if !s.exclude(httpRouteGK, obj.Namespace, obj.Name) {
+			res = append(res, obj)
+		}
+	}
+	return res, nil
+}
+
+// ListUDPRoutes returns the UDPRoutes of the underlying Storer which are not
+// excluded.
+func (s excludingStore) ListUDPRoutes() ([]*gatewayv1alpha2.UDPRoute, error) {
+	objs, err := s.Storer.ListUDPRoutes()
+	if err != nil {
+		return nil, err
+	}
+	var res []*gatewayv1alpha2.UDPRoute
+	for _, obj := range objs {
+		if !s.exclude(udpRouteGK, obj.Namespace, obj.Name) {
+			res = append(res, obj)
+		}
+	}
+	return res, nil
+}
+
+// ListTCPRoutes returns the TCPRoutes of the underlying Storer which are not
+// excluded.
+func (s excludingStore) ListTCPRoutes() ([]*gatewayv1alpha2.TCPRoute, error) {
+	objs, err := s.Storer.ListTCPRoutes()
+	if err != nil {
+		return nil, err
+	}
+	var res []*gatewayv1alpha2.TCPRoute
+	for _, obj := range objs {
+		if !s.exclude(tcpRouteGK, obj.Namespace, obj.Name) {
+			res = append(res, obj)
+		}
+	}
+	return res, nil
+}
+
+// ListTLSRoutes returns the TLSRoutes of the underlying Storer which are not
+// excluded.
+func (s excludingStore) ListTLSRoutes() ([]*gatewayv1alpha2.TLSRoute, error) {
+	objs, err := s.Storer.ListTLSRoutes()
+	if err != nil {
+		return nil, err
+	}
+	var res []*gatewayv1alpha2.TLSRoute
+	for _, obj := range objs {
+		if !s.exclude(tlsRouteGK, obj.Namespace, obj.Name) {
+			res = append(res, obj)
+		}
+	}
+	return res, nil
+}
+
+// ListTCPIngresses returns the TCPIngresses of the underlying Storer which are
+// not excluded.
+func (s excludingStore) ListTCPIngresses() ([]*kongv1beta1.TCPIngress, error) {
+	objs, err := s.Storer.ListTCPIngresses()
+	if err != nil {
+		return nil, err
+	}
+	var res []*kongv1beta1.TCPIngress
+	for _, obj := range objs {
+		if !s.exclude(tcpIngressGK, obj.Namespace, obj.Name) {
+			res = append(res, obj)
+		}
+	}
+	return res, nil
+}
+
+// ListUDPIngresses returns the UDPIngresses of the underlying Storer which are
+// not excluded.
+func (s excludingStore) ListUDPIngresses() ([]*kongv1beta1.UDPIngress, error) {
+	objs, err := s.Storer.ListUDPIngresses()
+	if err != nil {
+		return nil, err
+	}
+	var res []*kongv1beta1.UDPIngress
+	for _, obj := range objs {
+		if !s.exclude(udpIngressGK, obj.Namespace, obj.Name) {
+			res = append(res, obj)
+		}
+	}
+	return res, nil
+}
+
+// ListKnativeIngresses returns the Knative Ingresses of the underlying Storer
+// which are not excluded.
+func (s excludingStore) ListKnativeIngresses() ([]*knative.Ingress, error) {
+	objs, err := s.Storer.ListKnativeIngresses()
+	if err != nil {
+		return nil, err
+	}
+	var res []*knative.Ingress
+	for _, obj := range objs {
+		if !s.exclude(knativeIngressGK, obj.Namespace, obj.Name) {
+			res = append(res, obj)
+		}
+	}
+	return res, nil
+}
+
+// ListGlobalKongPlugins returns the global KongPlugins of the underlying Storer
+// which are not excluded.
+func (s excludingStore) ListGlobalKongPlugins() ([]*kongv1.KongPlugin, error) {
+	objs, err := s.Storer.ListGlobalKongPlugins()
+	if err != nil {
+		return nil, err
+	}
+	var res []*kongv1.KongPlugin
+	for _, obj := range objs {
+		if !s.exclude(kongPluginGK, obj.Namespace, obj.Name) {
+			res = append(res, obj)
+		}
+	}
+	return res, nil
+}
+
+// ListGlobalKongClusterPlugins returns the global KongClusterPlugins of the
+// underlying Storer which are not excluded.
+func (s excludingStore) ListGlobalKongClusterPlugins() ([]*kongv1.KongClusterPlugin, error) {
+	objs, err := s.Storer.ListGlobalKongClusterPlugins()
+	if err != nil {
+		return nil, err
+	}
+	var res []*kongv1.KongClusterPlugin
+	for _, obj := range objs {
+		if !s.exclude(kongClusterPluginGK, obj.Namespace, obj.Name) {
+			res = append(res, obj)
+		}
+	}
+	return res, nil
+}
+
+// ListKongConsumers returns the KongConsumers of the underlying Storer which
+// are not excluded.
+func (s excludingStore) ListKongConsumers() []*kongv1.KongConsumer {
+	var res []*kongv1.KongConsumer
+	for _, obj := range s.Storer.ListKongConsumers() {
+		if !s.exclude(kongConsumerGK, obj.Namespace, obj.Name) {
+			res = append(res, obj)
+		}
+	}
+	return res
+}
+
+// ListKongConsumerGroups returns the KongConsumerGroups of the underlying
+// Storer which are not excluded.
+func (s excludingStore) ListKongConsumerGroups() []*kongv1beta1.KongConsumerGroup {
+	var res []*kongv1beta1.KongConsumerGroup
+	for _, obj := range s.Storer.ListKongConsumerGroups() {
+		if !s.exclude(kongConsumerGroupGK, obj.Namespace, obj.Name) {
+			res = append(res, obj)
+		}
+	}
+	return res
+}
+
+// ListKongUpstreamPolicies returns the KongUpstreamPolicies of the underlying
+// Storer which are not excluded.
+func (s excludingStore) ListKongUpstreamPolicies() []*kongv1beta1.KongUpstreamPolicy {
+	var res []*kongv1beta1.KongUpstreamPolicy
+	for _, obj := range s.Storer.ListKongUpstreamPolicies() {
+		if !s.exclude(kongUpstreamPolicyGK, obj.Namespace, obj.Name) {
+			res = append(res, obj)
+		}
+	}
+	return res
+}
+
+// ListKongServicePolicies returns the KongServicePolicies of the underlying
+// Storer which are not excluded.
+func (s excludingStore) ListKongServicePolicies() []*kongv1beta1.KongServicePolicy {
+	var res []*kongv1beta1.KongServicePolicy
+	for _, obj := range s.Storer.ListKongServicePolicies() {
+		if !s.exclude(kongServicePolicyGK, obj.Namespace, obj.Name) {
+			res = append(res, obj)
+		}
+	}
+	return res
+}
+
+// ListKongVaults returns the KongVaults of the underlying Storer which are not
+// excluded.
+func (s excludingStore) ListKongVaults() []*kongv1beta1.KongVault {
+	var res []*kongv1beta1.KongVault
+	for _, obj := range s.Storer.ListKongVaults() {
+		if !s.exclude(kongVaultGK, "", obj.Name) {
+			res = append(res, obj)
+		}
+	}
+	return res
+}
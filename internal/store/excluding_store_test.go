@@ -0,0 +1,113 @@
+package store
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
+	configurationv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+	configurationv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
+)
+
+func TestExcludingStore(t *testing.T) {
+	objectMeta := func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			Annotations: map[string]string{
+				annotations.IngressClassKey: annotations.DefaultIngressClass,
+			},
+		}
+	}
+	clusterObjectMeta := func(name string) metav1.ObjectMeta {
+		meta := objectMeta(name)
+		meta.Namespace = ""
+		return meta
+	}
+	s, err := NewFakeStore(FakeObjects{
+		IngressesV1: []*networkingv1.Ingress{
+			{ObjectMeta: objectMeta("good")},
+			{ObjectMeta: objectMeta("broken")},
+		},
+		HTTPRoutes: []*gatewayv1alpha2.HTTPRoute{
+			{ObjectMeta: objectMeta("good")},
+			{ObjectMeta: objectMeta("broken")},
+		},
+		Services: []*apiv1.Service{
+			{ObjectMeta: objectMeta("broken")},
+		},
+		KongPlugins: []*configurationv1.KongPlugin{
+			{ObjectMeta: objectMeta("broken")},
+		},
+		KongConsumerGroups: []*configurationv1beta1.KongConsumerGroup{
+			{ObjectMeta: objectMeta("good")},
+			{ObjectMeta: objectMeta("broken")},
+		},
+		KongUpstreamPolicies: []*configurationv1beta1.KongUpstreamPolicy{
+			{ObjectMeta: objectMeta("good")},
+			{ObjectMeta: objectMeta("broken")},
+		},
+		KongServicePolicies: []*configurationv1beta1.KongServicePolicy{
+			{ObjectMeta: objectMeta("good")},
+			{ObjectMeta: objectMeta("broken")},
+		},
+		KongVaults: []*configurationv1beta1.KongVault{
+			{ObjectMeta: clusterObjectMeta("good")},
+			{ObjectMeta: clusterObjectMeta("broken")},
+		},
+	})
+	require.NoError(t, err)
+
+	excluded := map[schema.GroupKind]string{
+		ingressGK:            "broken",
+		httpRouteGK:          "broken",
+		kongPluginGK:         "broken",
+		kongConsumerGroupGK:  "broken",
+		kongUpstreamPolicyGK: "broken",
+		kongServicePolicyGK:  "broken",
+		kongVaultGK:          "broken",
+	}
+	s = NewExcludingStorer(s, func(gk schema.GroupKind, namespace, name string) bool {
+		return (namespace == "default" || gk == kongVaultGK) && excluded[gk] == name
+	})
+
+	ingresses := s.ListIngressesV1()
+	require.Len(t, ingresses, 1)
+	assert.Equal(t, "good", ingresses[0].Name)
+
+	httproutes, err := s.ListHTTPRoutes()
+	require.NoError(t, err)
+	require.Len(t, httproutes, 1)
+	assert.Equal(t, "good", httproutes[0].Name)
+
+	consumerGroups := s.ListKongConsumerGroups()
+	require.Len(t, consumerGroups, 1)
+	assert.Equal(t, "good", consumerGroups[0].Name)
+
+	upstreamPolicies := s.ListKongUpstreamPolicies()
+	require.Len(t, upstreamPolicies, 1)
+	assert.Equal(t, "good", upstreamPolicies[0].Name)
+
+	servicePolicies := s.ListKongServicePolicies()
+	require.Len(t, servicePolicies, 1)
+	assert.Equal(t, "good", servicePolicies[0].Name)
+
+	vaults := s.ListKongVaults()
+	require.Len(t, vaults, 1)
+	assert.Equal(t, "good", vaults[0].Name)
+
+	_, err = s.GetKongPlugin("default", "broken")
+	assert.True(t, errors.As(err, &ErrNotFound{}))
+
+	t.Log("verifying that objects of kinds which are not excluded are still available")
+	_, err = s.GetService("default", "broken")
+	assert.NoError(t, err)
+}
@@ -33,6 +33,8 @@ type FakeObjects struct {
 	IngressesV1beta1   []*networkingv1beta1.Ingress
 	IngressesV1        []*networkingv1.Ingress
 	IngressClassesV1   []*networkingv1.IngressClass
+	GatewayClasses     []*gatewayv1alpha2.GatewayClass
+	Gateways           []*gatewayv1alpha2.Gateway
 	HTTPRoutes         []*gatewayv1alpha2.HTTPRoute
 	UDPRoutes          []*gatewayv1alpha2.UDPRoute
 	TCPRoutes          []*gatewayv1alpha2.TCPRoute
@@ -40,13 +42,20 @@ type FakeObjects struct {
 	ReferencePolicies  []*gatewayv1alpha2.ReferencePolicy
 	TCPIngresses       []*configurationv1beta1.TCPIngress
 	UDPIngresses       []*configurationv1beta1.UDPIngress
+	GatewayConfigs     []*configurationv1beta1.GatewayConfiguration
 	Services           []*apiv1.Service
 	Endpoints          []*apiv1.Endpoints
 	Secrets            []*apiv1.Secret
+	Namespaces         []*apiv1.Namespace
 	KongPlugins        []*configurationv1.KongPlugin
 	KongClusterPlugins []*configurationv1.KongClusterPlugin
 	KongIngresses      []*configurationv1.KongIngress
 	KongConsumers      []*configurationv1.KongConsumer
+	KongConsumerGroups []*configurationv1beta1.KongConsumerGroup
+
+	KongUpstreamPolicies []*configurationv1beta1.KongUpstreamPolicy
+	KongServicePolicies  []*configurationv1beta1.KongServicePolicy
+	KongVaults           []*configurationv1beta1.KongVault
 
 	KnativeIngresses []*knative.Ingress
 }
@@ -78,6 +87,24 @@ func NewFakeStore(
 			return nil, err
 		}
 	}
+	namespaceStore := cache.NewStore(clusterResourceKeyFunc)
+	for _, namespace := range objects.Namespaces {
+		if err := namespaceStore.Add(namespace); err != nil {
+			return nil, err
+		}
+	}
+	gatewayClassStore := cache.NewStore(clusterResourceKeyFunc)
+	for _, gatewayClass := range objects.GatewayClasses {
+		if err := gatewayClassStore.Add(gatewayClass); err != nil {
+			return nil, err
+		}
+	}
+	gatewayStore := cache.NewStore(keyFunc)
+	for _, gateway := range objects.Gateways {
+		if err := gatewayStore.Add(gateway); err != nil {
+			return nil, err
+		}
+	}
 	httprouteStore := cache.NewStore(keyFunc)
 	for _, httproute := range objects.HTTPRoutes {
 		if err := httprouteStore.Add(httproute); err != nil {
@@ -156,6 +183,31 @@ func NewFakeStore(
 			return nil, err
 		}
 	}
+	consumerGroupStore := cache.NewStore(keyFunc)
+	for _, g := range objects.KongConsumerGroups {
+		err := consumerGroupStore.Add(g)
+		if err != nil {
+			return nil, err
+		}
+	}
+	upstreamPolicyStore := cache.NewStore(keyFunc)
+	for _, p := range objects.KongUpstreamPolicies {
+		if err := upstreamPolicyStore.Add(p); err != nil {
+			return nil, err
+		}
+	}
+	servicePolicyStore := cache.NewStore(keyFunc)
+	for _, p := range objects.KongServicePolicies {
+		if err := servicePolicyStore.Add(p); err != nil {
+			return nil, err
+		}
+	}
+	vaultStore := cache.NewStore(clusterResourceKeyFunc)
+	for _, v := range objects.KongVaults {
+		if err := vaultStore.Add(v); err != nil {
+			return nil, err
+		}
+	}
 	kongPluginsStore := cache.NewStore(keyFunc)
 	for _, p := range objects.KongPlugins {
 		err := kongPluginsStore.Add(p)
@@ -171,6 +223,13 @@ func NewFakeStore(
 		}
 	}
 
+	gatewayConfigurationStore := cache.NewStore(keyFunc)
+	for _, gatewayConfiguration := range objects.GatewayConfigs {
+		if err := gatewayConfigurationStore.Add(gatewayConfiguration); err != nil {
+			return nil, err
+		}
+	}
+
 	knativeIngressStore := cache.NewStore(keyFunc)
 	for _, ingress := range objects.KnativeIngresses {
 		err := knativeIngressStore.Add(ingress)
@@ -183,6 +242,8 @@ func NewFakeStore(
 			IngressV1beta1:  ingressV1beta1Store,
 			IngressV1:       ingressV1Store,
 			IngressClassV1:  ingressClassV1Store,
+			GatewayClass:    gatewayClassStore,
+			Gateway:         gatewayStore,
 			HTTPRoute:       httprouteStore,
 			UDPRoute:        udprouteStore,
 			TCPRoute:        tcprouteStore,
@@ -193,11 +254,18 @@ func NewFakeStore(
 			Service:         serviceStore,
 			Endpoint:        endpointStore,
 			Secret:          secretsStore,
+			Namespace:       namespaceStore,
+
+			Plugin:         kongPluginsStore,
+			ClusterPlugin:  kongClusterPluginsStore,
+			Consumer:       consumerStore,
+			ConsumerGroup:  consumerGroupStore,
+			KongIngress:    kongIngressStore,
+			UpstreamPolicy: upstreamPolicyStore,
+			ServicePolicy:  servicePolicyStore,
+			Vault:          vaultStore,
 
-			Plugin:        kongPluginsStore,
-			ClusterPlugin: kongClusterPluginsStore,
-			Consumer:      consumerStore,
-			KongIngress:   kongIngressStore,
+			GatewayConfiguration: gatewayConfigurationStore,
 
 			KnativeIngress: knativeIngressStore,
 		},
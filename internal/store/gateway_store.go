@@ -0,0 +1,287 @@
+package store
+
+import (
+	"fmt"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	knative "knative.dev/networking/pkg/apis/networking/v1alpha1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	kongv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+	kongv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
+)
+
+// gatewayFilteringStore is a Storer which hides the Gateways that aren't kept
+// from an underlying Storer, along with the Gateway API routes which are only
+// attached to such Gateways. This is used to configure the routes of Gateways
+// on separate data-planes, which may also be restricted to the consumers and
+// global plugins of a single namespace.
+type gatewayFilteringStore struct {
+	Storer
+
+	// keep indicates whether the Gateway with the provided namespace and name
+	// is kept.
+	keep func(gateway k8stypes.NamespacedName) bool
+
+	// keepUnattached indicates whether routing objects which aren't attached
+	// to any Gateway (e.g. Ingresses, or routes without Gateway parentRefs)
+	// are kept.
+	keepUnattached bool
+
+	// namespace, when set, is the only namespace whose KongConsumers,
+	// KongConsumerGroups and global KongPlugins are kept. Global
+	// KongClusterPlugins are hidden then, as they apply to all namespaces.
+	namespace string
+}
+
+// NewGatewayScopedStorer provides a Storer which only provides the provided
+// Gateway and the Gateway API routes attached to it from the provided Storer.
+// Other routing objects, such as Ingresses, are hidden. So that the data-plane
+// of the Gateway doesn't hold the credentials of other tenants, only the
+// KongConsumers, KongConsumerGroups and global KongPlugins in the namespace of
+// the Gateway are provided, and global KongClusterPlugins are hidden.
+func NewGatewayScopedStorer(s Storer, gateway k8stypes.NamespacedName) Storer {
+	return gatewayFilteringStore{
+		Storer: s,
+		keep: func(other k8stypes.NamespacedName) bool {
+			return other == gateway
+		},
+		namespace: gateway.Namespace,
+	}
+}
+
+// NewExcludingGatewaysStorer provides a Storer which hides the provided
+// Gateways and the Gateway API routes which are only attached to them from the
+// provided Storer.
+func NewExcludingGatewaysStorer(s Storer, gateways []k8stypes.NamespacedName) Storer {
+	excluded := make(map[k8stypes.NamespacedName]struct{}, len(gateways))
+	for _, gateway := range gateways {
+		excluded[gateway] = struct{}{}
+	}
+	return gatewayFilteringStore{
+		Storer: s,
+		keep: func(gateway k8stypes.NamespacedName) bool {
+			_, ok := excluded[gateway]
+			return !ok
+		},
+		keepUnattached: true,
+	}
+}
+
+// keepsRoute indicates whether a route in the provided namespace with the
+// provided parentRefs is kept.
+func (s gatewayFilteringStore) keepsRoute(namespace string, parentRefs []gatewayv1alpha2.ParentReference) bool {
+	attached := false
+	for _, parentRef := range parentRefs {
+		if parentRef.Group != nil && *parentRef.Group != gatewayv1alpha2.GroupName {
+			continue
+		}
+		if parentRef.Kind != nil && *parentRef.Kind != "Gateway" {
+			continue
+		}
+		attached = true
+		gateway := k8stypes.NamespacedName{Namespace: namespace, Name: string(parentRef.Name)}
+		if parentRef.Namespace != nil {
+			gateway.Namespace = string(*parentRef.Namespace)
+		}
+		if s.keep(gateway) {
+			return true
+		}
+	}
+	return !attached && s.keepUnattached
+}
+
+// ListIngressesV1beta1 returns the Ingresses of the underlying Storer if
+// routing objects which aren't attached to Gateways are kept.
+func (s gatewayFilteringStore) ListIngressesV1beta1() []*networkingv1beta1.Ingress {
+	if !s.keepUnattached {
+		return nil
+	}
+	return s.Storer.ListIngressesV1beta1()
+}
+
+// ListIngressesV1 returns the Ingresses of the underlying Storer if routing
+// objects which aren't attached to Gateways are kept.
+func (s gatewayFilteringStore) ListIngressesV1() []*networkingv1.Ingress {
+	if !s.keepUnattached {
+		return nil
+	}
+	return s.Storer.ListIngressesV1()
+}
+
+// ListTCPIngresses returns the TCPIngresses of the underlying Storer if
+// routing objects which aren't attached to Gateways are kept.
+func (s gatewayFilteringStore) ListTCPIngresses() ([]*kongv1beta1.TCPIngress, error) {
+	if !s.keepUnattached {
+		return nil, nil
+	}
+	return s.Storer.ListTCPIngresses()
+}
+
+// ListUDPIngresses returns the UDPIngresses of the underlying Storer if
+// routing objects which aren't attached to Gateways are kept.
+func (s gatewayFilteringStore) ListUDPIngresses() ([]*kongv1beta1.UDPIngress, error) {
+	if !s.keepUnattached {
+		return nil, nil
+	}
+	return s.Storer.ListUDPIngresses()
+}
+
+// ListKnativeIngresses returns the Knative Ingresses of the underlying Storer
+// if routing objects which aren't attached to Gateways are kept.
+func (s gatewayFilteringStore) ListKnativeIngresses() ([]*knative.Ingress, error) {
+	if !s.keepUnattached {
+		return nil, nil
+	}
+	return s.Storer.ListKnativeIngresses()
+}
+
+// ListGateways returns the Gateways of the underlying Storer which are kept.
+func (s gatewayFilteringStore) ListGateways() ([]*gatewayv1alpha2.Gateway, error) {
+	objs, err := s.Storer.ListGateways()
+	if err != nil {
+		return nil, err
+	}
+	var res []*gatewayv1alpha2.Gateway
+	for _, obj := range objs {
+		if s.keep(k8stypes.NamespacedName{Namespace: obj.Namespace, Name: obj.Name}) {
+			res = append(res, obj)
+		}
+	}
+	return res, nil
+}
+
+// GetGateway returns the Gateway of the underlying Storer if it's kept.
+func (s gatewayFilteringStore) GetGateway(namespace, name string) (*gatewayv1alpha2.Gateway, error) {
+	if !s.keep(k8stypes.NamespacedName{Namespace: namespace, Name: name}) {
+		return nil, ErrNotFound{fmt.Sprintf("Gateway %v/%v not found", namespace, name)}
+	}
+	return s.Storer.GetGateway(namespace, name)
+}
+
+// ListHTTPRoutes returns the HTTPRoutes of the underlying Storer which are
+// kept.
+func (s gatewayFilteringStore) ListHTTPRoutes() ([]*gatewayv1alpha2.HTTPRoute, error) {
+	objs, err := s.Storer.ListHTTPRoutes()
+	if err != nil {
+		return nil, err
+	}
+	var res []*gatewayv1alpha2.HTTPRoute
+	for _, obj := range objs {
+		if s.keepsRoute(obj.Namespace, obj.Spec.ParentRefs) {
+			res = append(res, obj)
+		}
+	}
+	return res, nil
+}
+
+// ListUDPRoutes returns the UDPRoutes of the underlying Storer which are kept.
+func (s gatewayFilteringStore) ListUDPRoutes() ([]*gatewayv1alpha2.UDPRoute, error) {
+	objs, err := s.Storer.ListUDPRoutes()
+	if err != nil {
+		return nil, err
+	}
+	var res []*gatewayv1alpha2.UDPRoute
+	for _, obj := range objs {
+		if s.keepsRoute(obj.Namespace, obj.Spec.ParentRefs) {
+			res = append(res, obj)
+		}
+	}
+	return res, nil
+}
+
+// ListTCPRoutes returns the TCPRoutes of the underlying Storer which are kept.
+func (s gatewayFilteringStore) ListTCPRoutes() ([]*gatewayv1alpha2.TCPRoute, error) {
+	objs, err := s.Storer.ListTCPRoutes()
+	if err != nil {
+		return nil, err
+	}
+	var res []*gatewayv1alpha2.TCPRoute
+	for _, obj := range objs {
+		if s.keepsRoute(obj.Namespace, obj.Spec.ParentRefs) {
+			res = append(res, obj)
+		}
+	}
+	return res, nil
+}
+
+// ListTLSRoutes returns the TLSRoutes of the underlying Storer which are kept.
+func (s gatewayFilteringStore) ListTLSRoutes() ([]*gatewayv1alpha2.TLSRoute, error) {
+	objs, err := s.Storer.ListTLSRoutes()
+	if err != nil {
+		return nil, err
+	}
+	var res []*gatewayv1alpha2.TLSRoute
+	for _, obj := range objs {
+		if s.keepsRoute(obj.Namespace, obj.Spec.ParentRefs) {
+			res = append(res, obj)
+		}
+	}
+	return res, nil
+}
+
+// keepsNamespace indicates whether the namespaced consumers and global plugins
+// of the provided namespace are kept.
+func (s gatewayFilteringStore) keepsNamespace(namespace string) bool {
+	return s.namespace == "" || s.namespace == namespace
+}
+
+// GetKongConsumer returns the KongConsumer of the underlying Storer if its
+// namespace is kept.
+func (s gatewayFilteringStore) GetKongConsumer(namespace, name string) (*kongv1.KongConsumer, error) {
+	if !s.keepsNamespace(namespace) {
+		return nil, ErrNotFound{fmt.Sprintf("KongConsumer %v/%v not found", namespace, name)}
+	}
+	return s.Storer.GetKongConsumer(namespace, name)
+}
+
+// ListKongConsumers returns the KongConsumers of the underlying Storer whose
+// namespace is kept.
+func (s gatewayFilteringStore) ListKongConsumers() []*kongv1.KongConsumer {
+	var res []*kongv1.KongConsumer
+	for _, obj := range s.Storer.ListKongConsumers() {
+		if s.keepsNamespace(obj.Namespace) {
+			res = append(res, obj)
+		}
+	}
+	return res
+}
+
+// ListKongConsumerGroups returns the KongConsumerGroups of the underlying
+// Storer whose namespace is kept.
+func (s gatewayFilteringStore) ListKongConsumerGroups() []*kongv1beta1.KongConsumerGroup {
+	var res []*kongv1beta1.KongConsumerGroup
+	for _, obj := range s.Storer.ListKongConsumerGroups() {
+		if s.keepsNamespace(obj.Namespace) {
+			res = append(res, obj)
+		}
+	}
+	return res
+}
+
+// ListGlobalKongPlugins returns the global KongPlugins of the underlying Storer
+// whose namespace is kept.
+func (s gatewayFilteringStore) ListGlobalKongPlugins() ([]*kongv1.KongPlugin, error) {
+	objs, err := s.Storer.ListGlobalKongPlugins()
+	if err != nil {
+		return nil, err
+	}
+	var res []*kongv1.KongPlugin
+	for _, obj := range objs {
+		if s.keepsNamespace(obj.Namespace) {
+			res = append(res, obj)
+		}
+	}
+	return res, nil
+}
+
+// ListGlobalKongClusterPlugins returns the global KongClusterPlugins of the
+// underlying Storer, unless the store is restricted to a namespace.
+func (s gatewayFilteringStore) ListGlobalKongClusterPlugins() ([]*kongv1.KongClusterPlugin, error) {
+	if s.namespace != "" {
+		return nil, nil
+	}
+	return s.Storer.ListGlobalKongClusterPlugins()
+}
@@ -0,0 +1,120 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
+	kongv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+)
+
+func TestGatewayFilteringStore(t *testing.T) {
+	objectMeta := func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			Annotations: map[string]string{
+				annotations.IngressClassKey: annotations.DefaultIngressClass,
+			},
+		}
+	}
+	otherNamespace := gatewayv1alpha2.Namespace("other")
+	globalPlugin := func(namespace, name string) *kongv1.KongPlugin {
+		meta := objectMeta(name)
+		meta.Namespace = namespace
+		meta.Labels = map[string]string{"global": "true"}
+		return &kongv1.KongPlugin{ObjectMeta: meta, PluginName: "cors"}
+	}
+	consumer := func(namespace, name string) *kongv1.KongConsumer {
+		meta := objectMeta(name)
+		meta.Namespace = namespace
+		return &kongv1.KongConsumer{ObjectMeta: meta, Username: name}
+	}
+	route := func(name string, parentRefs ...gatewayv1alpha2.ParentReference) *gatewayv1alpha2.HTTPRoute {
+		return &gatewayv1alpha2.HTTPRoute{
+			ObjectMeta: objectMeta(name),
+			Spec: gatewayv1alpha2.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1alpha2.CommonRouteSpec{ParentRefs: parentRefs},
+			},
+		}
+	}
+	s, err := NewFakeStore(FakeObjects{
+		IngressesV1: []*networkingv1.Ingress{{ObjectMeta: objectMeta("ingress")}},
+		Gateways: []*gatewayv1alpha2.Gateway{
+			{ObjectMeta: objectMeta("managed")},
+			{ObjectMeta: objectMeta("unmanaged")},
+		},
+		HTTPRoutes: []*gatewayv1alpha2.HTTPRoute{
+			route("managed", gatewayv1alpha2.ParentReference{Name: "managed"}),
+			route("unmanaged", gatewayv1alpha2.ParentReference{Name: "unmanaged"}),
+			route("both", gatewayv1alpha2.ParentReference{Name: "managed"}, gatewayv1alpha2.ParentReference{Name: "unmanaged"}),
+			route("other-namespace", gatewayv1alpha2.ParentReference{Name: "managed", Namespace: &otherNamespace}),
+			route("unattached"),
+		},
+		KongConsumers: []*kongv1.KongConsumer{consumer("default", "alice"), consumer("other", "bob")},
+		KongPlugins:   []*kongv1.KongPlugin{globalPlugin("default", "local"), globalPlugin("other", "remote")},
+		KongClusterPlugins: []*kongv1.KongClusterPlugin{{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "cluster",
+				Labels:      map[string]string{"global": "true"},
+				Annotations: map[string]string{annotations.IngressClassKey: annotations.DefaultIngressClass},
+			},
+			PluginName: "cors",
+		}},
+	})
+	require.NoError(t, err)
+	managed := k8stypes.NamespacedName{Namespace: "default", Name: "managed"}
+
+	names := func(routes []*gatewayv1alpha2.HTTPRoute) (res []string) {
+		for _, route := range routes {
+			res = append(res, route.Name)
+		}
+		return
+	}
+
+	t.Log("verifying that a gateway scoped store only provides the gateway and its routes")
+	scoped := NewGatewayScopedStorer(s, managed)
+	gateways, err := scoped.ListGateways()
+	require.NoError(t, err)
+	require.Len(t, gateways, 1)
+	assert.Equal(t, "managed", gateways[0].Name)
+	routes, err := scoped.ListHTTPRoutes()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"managed", "both"}, names(routes))
+	assert.Empty(t, scoped.ListIngressesV1())
+
+	t.Log("verifying that a gateway scoped store only provides the consumers and global plugins of the gateway namespace")
+	consumers := scoped.ListKongConsumers()
+	require.Len(t, consumers, 1)
+	assert.Equal(t, "alice", consumers[0].Name)
+	_, err = scoped.GetKongConsumer("other", "bob")
+	assert.ErrorAs(t, err, &ErrNotFound{})
+	plugins, err := scoped.ListGlobalKongPlugins()
+	require.NoError(t, err)
+	require.Len(t, plugins, 1)
+	assert.Equal(t, "local", plugins[0].Name)
+	clusterPlugins, err := scoped.ListGlobalKongClusterPlugins()
+	require.NoError(t, err)
+	assert.Empty(t, clusterPlugins)
+
+	t.Log("verifying that an excluding store hides the gateway and the routes only attached to it")
+	excluding := NewExcludingGatewaysStorer(s, []k8stypes.NamespacedName{managed})
+	gateways, err = excluding.ListGateways()
+	require.NoError(t, err)
+	require.Len(t, gateways, 1)
+	assert.Equal(t, "unmanaged", gateways[0].Name)
+	routes, err = excluding.ListHTTPRoutes()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"unmanaged", "both", "other-namespace", "unattached"}, names(routes))
+	assert.Len(t, excluding.ListIngressesV1(), 1)
+	assert.Len(t, excluding.ListKongConsumers(), 2)
+	clusterPlugins, err = excluding.ListGlobalKongClusterPlugins()
+	require.NoError(t, err)
+	assert.Len(t, clusterPlugins, 1)
+}
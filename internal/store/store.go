@@ -78,10 +78,15 @@ type Storer interface {
 	GetKongClusterPlugin(name string) (*kongv1.KongClusterPlugin, error)
 	GetKongConsumer(namespace, name string) (*kongv1.KongConsumer, error)
 	GetIngressClassV1(name string) (*networkingv1.IngressClass, error)
+	GetNamespace(name string) (*corev1.Namespace, error)
+	GetGateway(namespace, name string) (*gatewayv1alpha2.Gateway, error)
+	GetGatewayClass(name string) (*gatewayv1alpha2.GatewayClass, error)
+	GetGatewayConfiguration(namespace, name string) (*kongv1beta1.GatewayConfiguration, error)
 
 	ListIngressesV1beta1() []*networkingv1beta1.Ingress
 	ListIngressesV1() []*networkingv1.Ingress
 	ListIngressClassesV1() []*networkingv1.IngressClass
+	ListGateways() ([]*gatewayv1alpha2.Gateway, error)
 	ListHTTPRoutes() ([]*gatewayv1alpha2.HTTPRoute, error)
 	ListUDPRoutes() ([]*gatewayv1alpha2.UDPRoute, error)
 	ListTCPRoutes() ([]*gatewayv1alpha2.TCPRoute, error)
@@ -93,6 +98,10 @@ type Storer interface {
 	ListGlobalKongPlugins() ([]*kongv1.KongPlugin, error)
 	ListGlobalKongClusterPlugins() ([]*kongv1.KongClusterPlugin, error)
 	ListKongConsumers() []*kongv1.KongConsumer
+	ListKongConsumerGroups() []*kongv1beta1.KongConsumerGroup
+	ListKongUpstreamPolicies() []*kongv1beta1.KongUpstreamPolicy
+	ListKongServicePolicies() []*kongv1beta1.KongServicePolicy
+	ListKongVaults() []*kongv1beta1.KongVault
 	ListCACerts() ([]*corev1.Secret, error)
 }
 
@@ -125,8 +134,11 @@ type CacheStores struct {
 	Service        cache.Store
 	Secret         cache.Store
 	Endpoint       cache.Store
+	Namespace      cache.Store
 
 	// Gateway API Stores
+	GatewayClass    cache.Store
+	Gateway         cache.Store
 	HTTPRoute       cache.Store
 	UDPRoute        cache.Store
 	TCPRoute        cache.Store
@@ -134,12 +146,18 @@ type CacheStores struct {
 	ReferencePolicy cache.Store
 
 	// Kong Stores
-	Plugin        cache.Store
-	ClusterPlugin cache.Store
-	Consumer      cache.Store
-	KongIngress   cache.Store
-	TCPIngress    cache.Store
-	UDPIngress    cache.Store
+	Plugin         cache.Store
+	ClusterPlugin  cache.Store
+	Consumer       cache.Store
+	ConsumerGroup  cache.Store
+	KongIngress    cache.Store
+	UpstreamPolicy cache.Store
+	ServicePolicy  cache.Store
+	Vault          cache.Store
+	TCPIngress     cache.Store
+	UDPIngress     cache.Store
+
+	GatewayConfiguration cache.Store
 
 	// Knative Stores
 	KnativeIngress cache.Store
@@ -153,9 +171,12 @@ func NewCacheStores() CacheStores {
 		IngressV1beta1:  cache.NewStore(keyFunc),
 		IngressV1:       cache.NewStore(keyFunc),
 		IngressClassV1:  cache.NewStore(clusterResourceKeyFunc),
+		Namespace:       cache.NewStore(clusterResourceKeyFunc),
 		Service:         cache.NewStore(keyFunc),
 		Secret:          cache.NewStore(keyFunc),
 		Endpoint:        cache.NewStore(keyFunc),
+		GatewayClass:    cache.NewStore(clusterResourceKeyFunc),
+		Gateway:         cache.NewStore(keyFunc),
 		HTTPRoute:       cache.NewStore(keyFunc),
 		UDPRoute:        cache.NewStore(keyFunc),
 		TCPRoute:        cache.NewStore(keyFunc),
@@ -164,11 +185,18 @@ func NewCacheStores() CacheStores {
 		Plugin:          cache.NewStore(keyFunc),
 		ClusterPlugin:   cache.NewStore(clusterResourceKeyFunc),
 		Consumer:        cache.NewStore(keyFunc),
+		ConsumerGroup:   cache.NewStore(keyFunc),
 		KongIngress:     cache.NewStore(keyFunc),
+		UpstreamPolicy:  cache.NewStore(keyFunc),
+		ServicePolicy:   cache.NewStore(keyFunc),
+		Vault:           cache.NewStore(clusterResourceKeyFunc),
 		TCPIngress:      cache.NewStore(keyFunc),
 		UDPIngress:      cache.NewStore(keyFunc),
 		KnativeIngress:  cache.NewStore(keyFunc),
-		l:               &sync.RWMutex{},
+
+		GatewayConfiguration: cache.NewStore(keyFunc),
+
+		l: &sync.RWMutex{},
 	}
 }
 
@@ -235,11 +263,17 @@ func (c CacheStores) Get(obj runtime.Object) (item interface{}, exists bool, err
 		return c.Service.Get(obj)
 	case *corev1.Secret:
 		return c.Secret.Get(obj)
+	case *corev1.Namespace:
+		return c.Namespace.Get(obj)
 	case *corev1.Endpoints:
 		return c.Endpoint.Get(obj)
 	// ----------------------------------------------------------------------------
 	// Kubernetes Gateway API Support
 	// ----------------------------------------------------------------------------
+	case *gatewayv1alpha2.GatewayClass:
+		return c.GatewayClass.Get(obj)
+	case *gatewayv1alpha2.Gateway:
+		return c.Gateway.Get(obj)
 	case *gatewayv1alpha2.HTTPRoute:
 		return c.HTTPRoute.Get(obj)
 	case *gatewayv1alpha2.UDPRoute:
@@ -259,12 +293,22 @@ func (c CacheStores) Get(obj runtime.Object) (item interface{}, exists bool, err
 		return c.ClusterPlugin.Get(obj)
 	case *kongv1.KongConsumer:
 		return c.Consumer.Get(obj)
+	case *kongv1beta1.KongConsumerGroup:
+		return c.ConsumerGroup.Get(obj)
 	case *kongv1.KongIngress:
 		return c.KongIngress.Get(obj)
+	case *kongv1beta1.KongUpstreamPolicy:
+		return c.UpstreamPolicy.Get(obj)
+	case *kongv1beta1.KongServicePolicy:
+		return c.ServicePolicy.Get(obj)
+	case *kongv1beta1.KongVault:
+		return c.Vault.Get(obj)
 	case *kongv1beta1.TCPIngress:
 		return c.TCPIngress.Get(obj)
 	case *kongv1beta1.UDPIngress:
 		return c.UDPIngress.Get(obj)
+	case *kongv1beta1.GatewayConfiguration:
+		return c.GatewayConfiguration.Get(obj)
 	// ----------------------------------------------------------------------------
 	// 3rd Party API Support
 	// ----------------------------------------------------------------------------
@@ -296,11 +340,17 @@ func (c CacheStores) Add(obj runtime.Object) error {
 		return c.Service.Add(obj)
 	case *corev1.Secret:
 		return c.Secret.Add(obj)
+	case *corev1.Namespace:
+		return c.Namespace.Add(obj)
 	case *corev1.Endpoints:
 		return c.Endpoint.Add(obj)
 	// ----------------------------------------------------------------------------
 	// Kubernetes Gateway API Support
 	// ----------------------------------------------------------------------------
+	case *gatewayv1alpha2.GatewayClass:
+		return c.GatewayClass.Add(obj)
+	case *gatewayv1alpha2.Gateway:
+		return c.Gateway.Add(obj)
 	case *gatewayv1alpha2.HTTPRoute:
 		return c.HTTPRoute.Add(obj)
 	case *gatewayv1alpha2.UDPRoute:
@@ -320,12 +370,22 @@ func (c CacheStores) Add(obj runtime.Object) error {
 		return c.ClusterPlugin.Add(obj)
 	case *kongv1.KongConsumer:
 		return c.Consumer.Add(obj)
+	case *kongv1beta1.KongConsumerGroup:
+		return c.ConsumerGroup.Add(obj)
 	case *kongv1.KongIngress:
 		return c.KongIngress.Add(obj)
+	case *kongv1beta1.KongUpstreamPolicy:
+		return c.UpstreamPolicy.Add(obj)
+	case *kongv1beta1.KongServicePolicy:
+		return c.ServicePolicy.Add(obj)
+	case *kongv1beta1.KongVault:
+		return c.Vault.Add(obj)
 	case *kongv1beta1.TCPIngress:
 		return c.TCPIngress.Add(obj)
 	case *kongv1beta1.UDPIngress:
 		return c.UDPIngress.Add(obj)
+	case *kongv1beta1.GatewayConfiguration:
+		return c.GatewayConfiguration.Add(obj)
 	// ----------------------------------------------------------------------------
 	// 3rd Party API Support
 	// ----------------------------------------------------------------------------
@@ -358,11 +418,17 @@ func (c CacheStores) Delete(obj runtime.Object) error {
 		return c.Service.Delete(obj)
 	case *corev1.Secret:
 		return c.Secret.Delete(obj)
+	case *corev1.Namespace:
+		return c.Namespace.Delete(obj)
 	case *corev1.Endpoints:
 		return c.Endpoint.Delete(obj)
 	// ----------------------------------------------------------------------------
 	// Kubernetes Gateway API Support
 	// ----------------------------------------------------------------------------
+	case *gatewayv1alpha2.GatewayClass:
+		return c.GatewayClass.Delete(obj)
+	case *gatewayv1alpha2.Gateway:
+		return c.Gateway.Delete(obj)
 	case *gatewayv1alpha2.HTTPRoute:
 		return c.HTTPRoute.Delete(obj)
 	case *gatewayv1alpha2.UDPRoute:
@@ -382,12 +448,22 @@ func (c CacheStores) Delete(obj runtime.Object) error {
 		return c.ClusterPlugin.Delete(obj)
 	case *kongv1.KongConsumer:
 		return c.Consumer.Delete(obj)
+	case *kongv1beta1.KongConsumerGroup:
+		return c.ConsumerGroup.Delete(obj)
 	case *kongv1.KongIngress:
 		return c.KongIngress.Delete(obj)
+	case *kongv1beta1.KongUpstreamPolicy:
+		return c.UpstreamPolicy.Delete(obj)
+	case *kongv1beta1.KongServicePolicy:
+		return c.ServicePolicy.Delete(obj)
+	case *kongv1beta1.KongVault:
+		return c.Vault.Delete(obj)
 	case *kongv1beta1.TCPIngress:
 		return c.TCPIngress.Delete(obj)
 	case *kongv1beta1.UDPIngress:
 		return c.UDPIngress.Delete(obj)
+	case *kongv1beta1.GatewayConfiguration:
+		return c.GatewayConfiguration.Delete(obj)
 	// ----------------------------------------------------------------------------
 	// 3rd Party API Support
 	// ----------------------------------------------------------------------------
@@ -537,6 +613,22 @@ func (s Store) ListIngressesV1beta1() []*networkingv1beta1.Ingress {
 	return ingresses
 }
 
+// ListGateways returns the list of Gateways in the Gateway cache store.
+func (s Store) ListGateways() ([]*gatewayv1alpha2.Gateway, error) {
+	var gateways []*gatewayv1alpha2.Gateway
+	if err := cache.ListAll(s.stores.Gateway, labels.NewSelector(),
+		func(ob interface{}) {
+			gateway, ok := ob.(*gatewayv1alpha2.Gateway)
+			if ok {
+				gateways = append(gateways, gateway)
+			}
+		},
+	); err != nil {
+		return nil, err
+	}
+	return gateways, nil
+}
+
 // ListHTTPRoutes returns the list of HTTPRoutes in the HTTPRoute cache store.
 func (s Store) ListHTTPRoutes() ([]*gatewayv1alpha2.HTTPRoute, error) {
 	var httproutes []*gatewayv1alpha2.HTTPRoute
@@ -731,6 +823,56 @@ func (s Store) GetKongClusterPlugin(name string) (*kongv1.KongClusterPlugin, err
 	return p.(*kongv1.KongClusterPlugin), nil
 }
 
+// GetGateway returns the 'name' Gateway resource in namespace.
+func (s Store) GetGateway(namespace, name string) (*gatewayv1alpha2.Gateway, error) {
+	key := fmt.Sprintf("%v/%v", namespace, name)
+	p, exists, err := s.stores.Gateway.GetByKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrNotFound{fmt.Sprintf("Gateway %v not found", key)}
+	}
+	return p.(*gatewayv1alpha2.Gateway), nil
+}
+
+// GetNamespace returns the 'name' Namespace resource.
+func (s Store) GetNamespace(name string) (*corev1.Namespace, error) {
+	p, exists, err := s.stores.Namespace.GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrNotFound{fmt.Sprintf("Namespace %v not found", name)}
+	}
+	return p.(*corev1.Namespace), nil
+}
+
+// GetGatewayClass returns the 'name' GatewayClass resource.
+func (s Store) GetGatewayClass(name string) (*gatewayv1alpha2.GatewayClass, error) {
+	p, exists, err := s.stores.GatewayClass.GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrNotFound{fmt.Sprintf("GatewayClass %v not found", name)}
+	}
+	return p.(*gatewayv1alpha2.GatewayClass), nil
+}
+
+// GetGatewayConfiguration returns the 'name' GatewayConfiguration resource in namespace.
+func (s Store) GetGatewayConfiguration(namespace, name string) (*kongv1beta1.GatewayConfiguration, error) {
+	key := fmt.Sprintf("%v/%v", namespace, name)
+	p, exists, err := s.stores.GatewayConfiguration.GetByKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrNotFound{fmt.Sprintf("GatewayConfiguration %v not found", key)}
+	}
+	return p.(*kongv1beta1.GatewayConfiguration), nil
+}
+
 // GetKongIngress returns the 'name' KongIngress resource in namespace.
 func (s Store) GetKongIngress(namespace, name string) (*kongv1.KongIngress, error) {
 	key := fmt.Sprintf("%v/%v", namespace, name)
@@ -783,6 +925,60 @@ func (s Store) ListKongConsumers() []*kongv1.KongConsumer {
 	return consumers
 }
 
+// ListKongConsumerGroups returns all KongConsumerGroups filtered by the
+// ingress.class annotation.
+func (s Store) ListKongConsumerGroups() []*kongv1beta1.KongConsumerGroup {
+	var groups []*kongv1beta1.KongConsumerGroup
+	for _, item := range s.stores.ConsumerGroup.List() {
+		g, ok := item.(*kongv1beta1.KongConsumerGroup)
+		if ok && s.isValidIngressClass(&g.ObjectMeta, annotations.IngressClassKey, s.getIngressClassHandling()) {
+			groups = append(groups, g)
+		}
+	}
+
+	return groups
+}
+
+// ListKongUpstreamPolicies returns all KongUpstreamPolicies. Like
+// KongIngresses, they apply to the Services attaching them regardless of the
+// ingress class.
+func (s Store) ListKongUpstreamPolicies() []*kongv1beta1.KongUpstreamPolicy {
+	var policies []*kongv1beta1.KongUpstreamPolicy
+	for _, item := range s.stores.UpstreamPolicy.List() {
+		if p, ok := item.(*kongv1beta1.KongUpstreamPolicy); ok {
+			policies = append(policies, p)
+		}
+	}
+	return policies
+}
+
+// ListKongServicePolicies returns all KongServicePolicies. Like
+// KongIngresses, they apply to the Services attaching them regardless of the
+// ingress class.
+func (s Store) ListKongServicePolicies() []*kongv1beta1.KongServicePolicy {
+	var policies []*kongv1beta1.KongServicePolicy
+	for _, item := range s.stores.ServicePolicy.List() {
+		if p, ok := item.(*kongv1beta1.KongServicePolicy); ok {
+			policies = append(policies, p)
+		}
+	}
+	return policies
+}
+
+// ListKongVaults returns all KongVaults filtered by the ingress.class
+// annotation.
+func (s Store) ListKongVaults() []*kongv1beta1.KongVault {
+	var vaults []*kongv1beta1.KongVault
+	for _, item := range s.stores.Vault.List() {
+		v, ok := item.(*kongv1beta1.KongVault)
+		if ok && s.isValidIngressClass(&v.ObjectMeta, annotations.IngressClassKey, s.getIngressClassHandling()) {
+			vaults = append(vaults, v)
+		}
+	}
+
+	return vaults
+}
+
 // ListGlobalKongPlugins returns all KongPlugin resources
 // filtered by the ingress.class annotation and with the
 // label global:"true".
@@ -920,6 +1116,13 @@ func convUnstructuredObj(from, to runtime.Object) error {
 	return yaml.Unmarshal(b, to)
 }
 
+// IsObjectSupported indicates whether objects of the provided kind can be
+// stored in CacheStores, e.g. by NewCacheStoresFromObjYAML.
+func IsObjectSupported(gvk schema.GroupVersionKind) bool {
+	_, err := mkObjFromGVK(gvk)
+	return err == nil
+}
+
 // mkObjFromGVK is a factory function that returns a concrete implementation runtime.Object
 // for the given GVK. Callers can then use `convert()` to convert an unstructured
 // runtime.Object into a concrete one.
@@ -930,8 +1133,12 @@ func mkObjFromGVK(gvk schema.GroupVersionKind) (runtime.Object, error) {
 	// ----------------------------------------------------------------------------
 	case extensions.SchemeGroupVersion.WithKind("Ingress"):
 		return &extensions.Ingress{}, nil
+	case networkingv1beta1.SchemeGroupVersion.WithKind("Ingress"):
+		return &networkingv1beta1.Ingress{}, nil
 	case networkingv1.SchemeGroupVersion.WithKind("Ingress"):
 		return &networkingv1.Ingress{}, nil
+	case networkingv1.SchemeGroupVersion.WithKind("IngressClass"):
+		return &networkingv1.IngressClass{}, nil
 	case kongv1beta1.SchemeGroupVersion.WithKind("TCPIngress"):
 		return &kongv1beta1.TCPIngress{}, nil
 	case corev1.SchemeGroupVersion.WithKind("Service"):
@@ -940,11 +1147,25 @@ func mkObjFromGVK(gvk schema.GroupVersionKind) (runtime.Object, error) {
 		return &corev1.Secret{}, nil
 	case corev1.SchemeGroupVersion.WithKind("Endpoints"):
 		return &corev1.Endpoints{}, nil
+	case corev1.SchemeGroupVersion.WithKind("Namespace"):
+		return &corev1.Namespace{}, nil
 	// ----------------------------------------------------------------------------
 	// Kubernetes Gateway APIs
 	// ----------------------------------------------------------------------------
-	case gatewayv1alpha2.SchemeGroupVersion.WithKind("HTTPRoutes"):
+	case gatewayv1alpha2.SchemeGroupVersion.WithKind("GatewayClass"):
+		return &gatewayv1alpha2.GatewayClass{}, nil
+	case gatewayv1alpha2.SchemeGroupVersion.WithKind("Gateway"):
+		return &gatewayv1alpha2.Gateway{}, nil
+	case gatewayv1alpha2.SchemeGroupVersion.WithKind("HTTPRoute"):
 		return &gatewayv1alpha2.HTTPRoute{}, nil
+	case gatewayv1alpha2.SchemeGroupVersion.WithKind("UDPRoute"):
+		return &gatewayv1alpha2.UDPRoute{}, nil
+	case gatewayv1alpha2.SchemeGroupVersion.WithKind("TCPRoute"):
+		return &gatewayv1alpha2.TCPRoute{}, nil
+	case gatewayv1alpha2.SchemeGroupVersion.WithKind("TLSRoute"):
+		return &gatewayv1alpha2.TLSRoute{}, nil
+	case gatewayv1alpha2.SchemeGroupVersion.WithKind("ReferencePolicy"):
+		return &gatewayv1alpha2.ReferencePolicy{}, nil
 	// ----------------------------------------------------------------------------
 	// Kong APIs
 	// ----------------------------------------------------------------------------
@@ -952,12 +1173,22 @@ func mkObjFromGVK(gvk schema.GroupVersionKind) (runtime.Object, error) {
 		return &kongv1.KongIngress{}, nil
 	case kongv1beta1.SchemeGroupVersion.WithKind("UDPIngress"):
 		return &kongv1beta1.UDPIngress{}, nil
+	case kongv1beta1.SchemeGroupVersion.WithKind("GatewayConfiguration"):
+		return &kongv1beta1.GatewayConfiguration{}, nil
 	case kongv1.SchemeGroupVersion.WithKind("KongPlugin"):
 		return &kongv1.KongPlugin{}, nil
 	case kongv1.SchemeGroupVersion.WithKind("KongClusterPlugin"):
 		return &kongv1.KongClusterPlugin{}, nil
 	case kongv1.SchemeGroupVersion.WithKind("KongConsumer"):
 		return &kongv1.KongConsumer{}, nil
+	case kongv1beta1.SchemeGroupVersion.WithKind("KongConsumerGroup"):
+		return &kongv1beta1.KongConsumerGroup{}, nil
+	case kongv1beta1.SchemeGroupVersion.WithKind("KongUpstreamPolicy"):
+		return &kongv1beta1.KongUpstreamPolicy{}, nil
+	case kongv1beta1.SchemeGroupVersion.WithKind("KongServicePolicy"):
+		return &kongv1beta1.KongServicePolicy{}, nil
+	case kongv1beta1.SchemeGroupVersion.WithKind("KongVault"):
+		return &kongv1beta1.KongVault{}, nil
 	// ----------------------------------------------------------------------------
 	// Knative APIs
 	// ----------------------------------------------------------------------------
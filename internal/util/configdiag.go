@@ -1,6 +1,10 @@
 package util
 
-import "github.com/kong/deck/file"
+import (
+	"time"
+
+	"github.com/kong/deck/file"
+)
 
 // ConfigDump contains a config dump and a flag indicating that the config was not successfully applid
 type ConfigDump struct {
@@ -12,4 +16,84 @@ type ConfigDump struct {
 type ConfigDumpDiagnostic struct {
 	DumpsIncludeSensitive bool
 	Configs               chan ConfigDump
+	DryRunReports         chan DryRunReport
+	CircuitBreaker        chan CircuitBreakerStatus
+}
+
+// ConfigChange is a change that applying a configuration would make to an
+// entity of the configuration of Kong.
+type ConfigChange struct {
+	// Action is the action taken on the entity, one of "create", "update" or
+	// "delete".
+	Action string `json:"action"`
+
+	// Kind is the type of the entity, e.g. "service".
+	Kind string `json:"kind"`
+
+	// Entity identifies the entity, by name when it has one.
+	Entity string `json:"entity"`
+
+	// Diff is a unified diff of the entity for updates.
+	Diff string `json:"diff,omitempty"`
+}
+
+// TranslationFailureReport describes a Kubernetes object which couldn't be
+// (fully) translated into Kong configuration.
+type TranslationFailureReport struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Reason    string `json:"reason"`
+}
+
+// DryRunReport describes the configuration which would have been applied to
+// Kong by a controller running in dry-run mode.
+type DryRunReport struct {
+	// Config is the configuration which would have been applied.
+	Config file.Content `json:"config"`
+
+	// Changes are the changes applying the configuration would make to the
+	// configuration of Kong.
+	Changes []ConfigChange `json:"changes"`
+
+	// DiffError describes why the changes couldn't be determined, if they
+	// couldn't.
+	DiffError string `json:"diffError,omitempty"`
+
+	// TranslationFailures are the objects which couldn't be (fully) translated.
+	TranslationFailures []TranslationFailureReport `json:"translationFailures"`
+}
+
+// CircuitBreakerState is the state of the circuit breaker which stops updates
+// of the data-plane after consecutive failures.
+type CircuitBreakerState string
+
+const (
+	// CircuitBreakerClosed indicates that the data-plane is updated normally.
+	CircuitBreakerClosed CircuitBreakerState = "closed"
+
+	// CircuitBreakerOpen indicates that updates of the data-plane are stopped
+	// until a backoff delay elapsed or the configuration changed.
+	CircuitBreakerOpen CircuitBreakerState = "open"
+
+	// CircuitBreakerHalfOpen indicates that the backoff delay elapsed, and the
+	// next update of the data-plane decides whether the circuit breaker closes.
+	CircuitBreakerHalfOpen CircuitBreakerState = "half-open"
+)
+
+// CircuitBreakerStatus describes whether updates of the data-plane are backed
+// off after consecutive failures.
+type CircuitBreakerStatus struct {
+	State CircuitBreakerState `json:"state"`
+
+	// ConsecutiveFailures is the number of consecutive failed updates of the
+	// configuration which is currently backed off.
+	ConsecutiveFailures int `json:"consecutiveFailures"`
+
+	// RetryAfter is when the configuration is sent to the data-plane again,
+	// unless it changes before.
+	RetryAfter *time.Time `json:"retryAfter,omitempty"`
+
+	// LastError is the error of the most recent failed update.
+	LastError string `json:"lastError,omitempty"`
 }
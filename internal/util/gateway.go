@@ -0,0 +1,93 @@
+package util
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// IsRouteKindAllowedByListener indicates whether the kind of a route is allowed
+// by the AllowedRoutes of a listener. Listeners which don't restrict the kinds
+// of routes allow the routes which can be used with their protocol.
+func IsRouteKindAllowedByListener(obj client.Object, listener gatewayv1alpha2.Listener) bool {
+	var kind gatewayv1alpha2.Kind
+	var protocols []gatewayv1alpha2.ProtocolType
+	switch obj.(type) {
+	case *gatewayv1alpha2.HTTPRoute:
+		kind, protocols = "HTTPRoute", []gatewayv1alpha2.ProtocolType{gatewayv1alpha2.HTTPProtocolType, gatewayv1alpha2.HTTPSProtocolType}
+	case *gatewayv1alpha2.TCPRoute:
+		kind, protocols = "TCPRoute", []gatewayv1alpha2.ProtocolType{gatewayv1alpha2.TCPProtocolType}
+	case *gatewayv1alpha2.UDPRoute:
+		kind, protocols = "UDPRoute", []gatewayv1alpha2.ProtocolType{gatewayv1alpha2.UDPProtocolType}
+	case *gatewayv1alpha2.TLSRoute:
+		kind, protocols = "TLSRoute", []gatewayv1alpha2.ProtocolType{gatewayv1alpha2.TLSProtocolType}
+	default:
+		return false
+	}
+
+	// routes can't be used with listeners of other protocols, regardless of
+	// the kinds allowed by the listener.
+	supportedProtocol := false
+	for _, protocol := range protocols {
+		if listener.Protocol == protocol {
+			supportedProtocol = true
+		}
+	}
+	if !supportedProtocol {
+		return false
+	}
+
+	if listener.AllowedRoutes == nil || len(listener.AllowedRoutes.Kinds) == 0 {
+		return true
+	}
+	for _, allowedKind := range listener.AllowedRoutes.Kinds {
+		if allowedKind.Group != nil && string(*allowedKind.Group) != gatewayv1alpha2.GroupName {
+			continue
+		}
+		if allowedKind.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRouteNamespaceAllowedByListener indicates whether the namespace of a route is
+// allowed by the AllowedRoutes of a listener, which defaults to the namespace of
+// the Gateway. The namespace of the route is only retrieved for selectors.
+func IsRouteNamespaceAllowedByListener(
+	obj client.Object,
+	gateway *gatewayv1alpha2.Gateway,
+	listener gatewayv1alpha2.Listener,
+	getRouteNamespace func() (*corev1.Namespace, error),
+) (bool, error) {
+	from := gatewayv1alpha2.NamespacesFromSame
+	if listener.AllowedRoutes != nil && listener.AllowedRoutes.Namespaces != nil && listener.AllowedRoutes.Namespaces.From != nil {
+		from = *listener.AllowedRoutes.Namespaces.From
+	}
+
+	switch from {
+	case gatewayv1alpha2.NamespacesFromAll:
+		return true, nil
+	case gatewayv1alpha2.NamespacesFromSame:
+		return obj.GetNamespace() == gateway.Namespace, nil
+	case gatewayv1alpha2.NamespacesFromSelector:
+		if listener.AllowedRoutes.Namespaces.Selector == nil {
+			return false, nil
+		}
+		selector, err := metav1.LabelSelectorAsSelector(listener.AllowedRoutes.Namespaces.Selector)
+		if err != nil {
+			return false, fmt.Errorf("failed to convert LabelSelector to Selector for gateway %s", gateway.Name)
+		}
+		namespace, err := getRouteNamespace()
+		if err != nil {
+			return false, err
+		}
+		return selector.Matches(labels.Set(namespace.Labels)), nil
+	default:
+		return false, nil
+	}
+}
@@ -0,0 +1,63 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+func TestIsRouteKindAllowedByListener(t *testing.T) {
+	httproute := &gatewayv1alpha2.HTTPRoute{}
+	tcproute := &gatewayv1alpha2.TCPRoute{}
+	otherGroup := gatewayv1alpha2.Group("example.com")
+
+	httpListener := gatewayv1alpha2.Listener{Protocol: gatewayv1alpha2.HTTPProtocolType}
+	assert.True(t, IsRouteKindAllowedByListener(httproute, httpListener))
+	assert.False(t, IsRouteKindAllowedByListener(tcproute, httpListener), "routes can't be used with listeners of other protocols")
+
+	httpListener.AllowedRoutes = &gatewayv1alpha2.AllowedRoutes{
+		Kinds: []gatewayv1alpha2.RouteGroupKind{{Group: &otherGroup, Kind: "HTTPRoute"}},
+	}
+	assert.False(t, IsRouteKindAllowedByListener(httproute, httpListener), "kinds of other groups don't allow the route")
+
+	httpListener.AllowedRoutes.Kinds = append(httpListener.AllowedRoutes.Kinds, gatewayv1alpha2.RouteGroupKind{Kind: "HTTPRoute"})
+	assert.True(t, IsRouteKindAllowedByListener(httproute, httpListener))
+}
+
+func TestIsRouteNamespaceAllowedByListener(t *testing.T) {
+	gateway := &gatewayv1alpha2.Gateway{ObjectMeta: metav1.ObjectMeta{Name: "kong", Namespace: "kong"}}
+	route := &gatewayv1alpha2.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "default"}}
+	getRouteNamespace := func() (*corev1.Namespace, error) {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default", Labels: map[string]string{"team": "kong"}}}, nil
+	}
+	from := func(from gatewayv1alpha2.FromNamespaces, labels map[string]string) gatewayv1alpha2.Listener {
+		namespaces := &gatewayv1alpha2.RouteNamespaces{From: &from}
+		if labels != nil {
+			namespaces.Selector = &metav1.LabelSelector{MatchLabels: labels}
+		}
+		return gatewayv1alpha2.Listener{AllowedRoutes: &gatewayv1alpha2.AllowedRoutes{Namespaces: namespaces}}
+	}
+
+	for _, tt := range []struct {
+		msg      string
+		listener gatewayv1alpha2.Listener
+		allowed  bool
+	}{
+		{msg: "listeners default to the namespace of the gateway", listener: gatewayv1alpha2.Listener{}, allowed: false},
+		{msg: "same namespace", listener: from(gatewayv1alpha2.NamespacesFromSame, nil), allowed: false},
+		{msg: "all namespaces", listener: from(gatewayv1alpha2.NamespacesFromAll, nil), allowed: true},
+		{msg: "matching selector", listener: from(gatewayv1alpha2.NamespacesFromSelector, map[string]string{"team": "kong"}), allowed: true},
+		{msg: "other selector", listener: from(gatewayv1alpha2.NamespacesFromSelector, map[string]string{"team": "other"}), allowed: false},
+		{msg: "missing selector", listener: from(gatewayv1alpha2.NamespacesFromSelector, nil), allowed: false},
+	} {
+		t.Run(tt.msg, func(t *testing.T) {
+			allowed, err := IsRouteNamespaceAllowedByListener(route, gateway, tt.listener, getRouteNamespace)
+			require.NoError(t, err)
+			assert.Equal(t, tt.allowed, allowed)
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package util
 
+import "strings"
+
 const (
 	// minPort is the minimum networking port number.
 	minPort = 1
@@ -16,3 +18,29 @@ func IsValidPort(port int) bool {
 	}
 	return false
 }
+
+// IntersectHostnames provides the hostname matched by both of the given
+// hostnames, which may be wildcard hostnames (e.g. "*.example.com") matching
+// any subdomain of their suffix. The more specific of the hostnames is the
+// intersection, and the boolean is false if they don't have any intersection.
+func IntersectHostnames(hostname, otherHostname string) (string, bool) {
+	switch {
+	case hostname == otherHostname:
+		return hostname, true
+	case isHostnameInWildcard(hostname, otherHostname):
+		return hostname, true
+	case isHostnameInWildcard(otherHostname, hostname):
+		return otherHostname, true
+	}
+	return "", false
+}
+
+// isHostnameInWildcard indicates whether a hostname, which may be a wildcard
+// hostname itself, is a subdomain of the suffix of a wildcard hostname.
+func isHostnameInWildcard(hostname, wildcard string) bool {
+	if !strings.HasPrefix(wildcard, "*.") {
+		return false
+	}
+	suffix := strings.TrimPrefix(wildcard, "*")
+	return len(hostname) > len(suffix) && strings.HasSuffix(hostname, suffix) && hostname != wildcard
+}
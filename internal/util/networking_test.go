@@ -15,3 +15,24 @@ func TestIsValidPort(t *testing.T) {
 	assert.False(t, IsValidPort(65536))
 	assert.False(t, IsValidPort(9999999))
 }
+
+func TestIntersectHostnames(t *testing.T) {
+	for _, tt := range []struct {
+		hostname, otherHostname string
+		intersection            string
+		ok                      bool
+	}{
+		{"konghq.com", "konghq.com", "konghq.com", true},
+		{"docs.konghq.com", "*.konghq.com", "docs.konghq.com", true},
+		{"*.konghq.com", "api.docs.konghq.com", "api.docs.konghq.com", true},
+		{"*.docs.konghq.com", "*.konghq.com", "*.docs.konghq.com", true},
+		{"*.konghq.com", "*.konghq.com", "*.konghq.com", true},
+		{"konghq.com", "*.konghq.com", "", false},
+		{"docs.konghq.com", "api.konghq.com", "", false},
+		{"*.konghq.com", "*.example.com", "", false},
+	} {
+		intersection, ok := IntersectHostnames(tt.hostname, tt.otherHostname)
+		assert.Equal(t, tt.ok, ok, "%s and %s", tt.hostname, tt.otherHostname)
+		assert.Equal(t, tt.intersection, intersection, "%s and %s", tt.hostname, tt.otherHostname)
+	}
+}
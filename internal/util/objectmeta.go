@@ -2,6 +2,9 @@ package util
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
 )
 
 // K8sObjectInfo describes a Kubernetes object.
@@ -9,6 +12,14 @@ type K8sObjectInfo struct {
 	Name        string
 	Namespace   string
 	Annotations map[string]string
+
+	// GroupVersionKind is the kind of the object, if it was known when the
+	// object info was generated.
+	GroupVersionKind schema.GroupVersionKind
+
+	// UID is the unique ID of the object, if it was known when the object info
+	// was generated.
+	UID k8stypes.UID
 }
 
 func deepCopy(m map[string]string) map[string]string {
@@ -20,9 +31,31 @@ func deepCopy(m map[string]string) map[string]string {
 }
 
 func FromK8sObject(obj metav1.Object) K8sObjectInfo {
-	return K8sObjectInfo{
+	info := K8sObjectInfo{
 		Name:        obj.GetName(),
 		Namespace:   obj.GetNamespace(),
 		Annotations: deepCopy(obj.GetAnnotations()),
+		UID:         obj.GetUID(),
+	}
+	if runtimeObj, ok := obj.(runtime.Object); ok {
+		info.GroupVersionKind = runtimeObj.GetObjectKind().GroupVersionKind()
+	}
+	return info
+}
+
+// ToPartialObjectMetadata provides a minimal Kubernetes object representing
+// the described object, e.g. for use as the subject of Kubernetes Events.
+func (i K8sObjectInfo) ToPartialObjectMetadata() *metav1.PartialObjectMetadata {
+	apiVersion, kind := i.GroupVersionKind.ToAPIVersionAndKind()
+	return &metav1.PartialObjectMetadata{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: apiVersion,
+			Kind:       kind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: i.Namespace,
+			Name:      i.Name,
+			UID:       i.UID,
+		},
 	}
 }
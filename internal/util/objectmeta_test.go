@@ -43,6 +43,27 @@ func TestFromK8sObject(t *testing.T) {
 				Annotations: map[string]string{"a": "1", "b": "2"},
 			},
 		},
+		{
+			name: "has kind and uid",
+			in: &networkingv1beta1.Ingress{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "networking.k8s.io/v1beta1",
+					Kind:       "Ingress",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "name",
+					Namespace: "namespace",
+					UID:       "2d3e4f5a",
+				},
+			},
+			want: K8sObjectInfo{
+				Name:             "name",
+				Namespace:        "namespace",
+				Annotations:      map[string]string{},
+				GroupVersionKind: networkingv1beta1.SchemeGroupVersion.WithKind("Ingress"),
+				UID:              "2d3e4f5a",
+			},
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			got := FromK8sObject(tt.in)
@@ -45,3 +45,15 @@ func (p *PluginSchemaStore) Schema(ctx context.Context, pluginName string) (map[
 	p.schemas[pluginName] = schema
 	return schema, nil
 }
+
+// IsAvailable tells whether a plugin is available in Kong, that is whether Kong
+// provides a schema for it.
+func (p *PluginSchemaStore) IsAvailable(ctx context.Context, pluginName string) (bool, error) {
+	if _, err := p.Schema(ctx, pluginName); err != nil {
+		if kong.IsNotFoundErr(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
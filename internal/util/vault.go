@@ -0,0 +1,17 @@
+package util
+
+import (
+	"strings"
+)
+
+const (
+	vaultReferenceStart = "{vault://"
+	vaultReferenceEnd   = "}"
+)
+
+// IsVaultReference indicates whether the provided value is a reference to a
+// secret stored in a vault, e.g. {vault://env/my-secret}. Kong resolves such
+// references itself when it uses the value, so they must be passed along as-is.
+func IsVaultReference(value string) bool {
+	return strings.HasPrefix(value, vaultReferenceStart) && strings.HasSuffix(value, vaultReferenceEnd)
+}
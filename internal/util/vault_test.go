@@ -0,0 +1,24 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsVaultReference(t *testing.T) {
+	for _, tt := range []struct {
+		value string
+		want  bool
+	}{
+		{"{vault://env/my-secret}", true},
+		{"{vault://my-vault/credentials/password?ttl=60}", true},
+		{"vault://env/my-secret", false},
+		{"{vault://env/my-secret", false},
+		{"{env://my-secret}", false},
+		{"my-secret", false},
+		{"", false},
+	} {
+		assert.Equal(t, tt.want, IsVaultReference(tt.value), tt.value)
+	}
+}
@@ -0,0 +1,45 @@
+package gateway
+
+import (
+	"fmt"
+	"strings"
+
+	configurationv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
+)
+
+// -----------------------------------------------------------------------------
+// Validation - GatewayConfiguration - Public Functions
+// -----------------------------------------------------------------------------
+
+// ValidateGatewayConfiguration validates the settings of a GatewayConfiguration.
+// The returned boolean indicates whether the configuration is valid, and the
+// message explains why it is not.
+func ValidateGatewayConfiguration(config *configurationv1beta1.GatewayConfiguration) (bool, string) {
+	if publishService := config.Spec.PublishService; publishService != "" {
+		parts := strings.Split(publishService, "/")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return false, fmt.Sprintf("publishService %q is not in the namespace/name format", publishService)
+		}
+	}
+
+	for _, plugin := range config.Spec.Plugins {
+		// plugins are configured through the konghq.com/plugins annotation of
+		// the routes, whose values are separated by commas.
+		if strings.TrimSpace(plugin) == "" || strings.Contains(plugin, ",") {
+			return false, fmt.Sprintf("plugin name %q is invalid", plugin)
+		}
+	}
+
+	seen := make(map[string]struct{}, len(config.Spec.RouteProtocols))
+	for _, protocol := range config.Spec.RouteProtocols {
+		if protocol != "http" && protocol != "https" {
+			return false, fmt.Sprintf("route protocol %q is not supported, must be http or https", protocol)
+		}
+		if _, ok := seen[protocol]; ok {
+			return false, fmt.Sprintf("route protocol %q is listed more than once", protocol)
+		}
+		seen[protocol] = struct{}{}
+	}
+
+	return true, ""
+}
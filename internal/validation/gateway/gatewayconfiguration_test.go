@@ -0,0 +1,65 @@
+package gateway
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configurationv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
+)
+
+func TestValidateGatewayConfiguration(t *testing.T) {
+	for _, tt := range []struct {
+		msg   string
+		spec  configurationv1beta1.GatewayConfigurationSpec
+		valid bool
+	}{
+		{
+			msg:   "an empty configuration is valid",
+			valid: true,
+		},
+		{
+			msg: "a complete configuration is valid",
+			spec: configurationv1beta1.GatewayConfigurationSpec{
+				PublishService: "kong/kong-proxy",
+				Plugins:        []string{"rate-limit", "auth"},
+				RouteProtocols: []string{"https", "http"},
+			},
+			valid: true,
+		},
+		{
+			msg:  "a publish service without namespace is invalid",
+			spec: configurationv1beta1.GatewayConfigurationSpec{PublishService: "kong-proxy"},
+		},
+		{
+			msg:  "a publish service with an empty name is invalid",
+			spec: configurationv1beta1.GatewayConfigurationSpec{PublishService: "kong/"},
+		},
+		{
+			msg:  "an empty plugin name is invalid",
+			spec: configurationv1beta1.GatewayConfigurationSpec{Plugins: []string{" "}},
+		},
+		{
+			msg:  "a plugin name with a comma is invalid",
+			spec: configurationv1beta1.GatewayConfigurationSpec{Plugins: []string{"auth,rate-limit"}},
+		},
+		{
+			msg:  "an unsupported route protocol is invalid",
+			spec: configurationv1beta1.GatewayConfigurationSpec{RouteProtocols: []string{"grpc"}},
+		},
+		{
+			msg:  "a duplicated route protocol is invalid",
+			spec: configurationv1beta1.GatewayConfigurationSpec{RouteProtocols: []string{"https", "https"}},
+		},
+	} {
+		t.Run(tt.msg, func(t *testing.T) {
+			valid, message := ValidateGatewayConfiguration(&configurationv1beta1.GatewayConfiguration{Spec: tt.spec})
+			assert.Equal(t, tt.valid, valid)
+			if tt.valid {
+				assert.Empty(t, message)
+			} else {
+				assert.NotEmpty(t, message)
+			}
+		})
+	}
+}
@@ -2,6 +2,9 @@ package gateway
 
 import (
 	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
 
 	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 )
@@ -80,16 +83,18 @@ func validateHTTPRouteListener(listener *gatewayv1alpha2.Listener) error {
 func validateHTTPRouteFeatures(httproute *gatewayv1alpha2.HTTPRoute) error {
 	for _, rule := range httproute.Spec.Rules {
 		for _, match := range rule.Matches {
-			// we don't support queryparam matching rules
-			// See: https://github.com/Kong/kubernetes-ingress-controller/issues/2152
-			if len(match.QueryParams) != 0 {
-				return fmt.Errorf("queryparam matching is not yet supported for httproute")
+			// query params are matched by a plugin which can only compare values
+			for _, param := range match.QueryParams {
+				if param.Type != nil && *param.Type != gatewayv1alpha2.QueryParamMatchExact {
+					return fmt.Errorf("%s query param matching is not supported for httproute", *param.Type)
+				}
 			}
 
-			// we don't support regex path matching rules
-			// See: https://github.com/Kong/kubernetes-ingress-controller/issues/2153
-			if match.Path != nil && match.Path.Type != nil && *match.Path.Type == gatewayv1alpha2.PathMatchRegularExpression {
-				return fmt.Errorf("regex path matching is not yet supported for httproute")
+			// regex paths are passed on to Kong, so they need to be valid
+			if match.Path != nil && match.Path.Type != nil && *match.Path.Type == gatewayv1alpha2.PathMatchRegularExpression && match.Path.Value != nil {
+				if _, err := regexp.Compile(*match.Path.Value); err != nil {
+					return fmt.Errorf("invalid regex path match for httproute: %w", err)
+				}
 			}
 
 			// we don't support regex header matching rules
@@ -103,12 +108,142 @@ func validateHTTPRouteFeatures(httproute *gatewayv1alpha2.HTTPRoute) error {
 
 		// we don't support any backendRef types except Kubernetes Services
 		for _, ref := range rule.BackendRefs {
-			if ref.BackendRef.Group != nil && *ref.BackendRef.Group != "core" && *ref.BackendRef.Group != "" {
-				return fmt.Errorf("%s is not a supported group for httproute backendRefs, only core is supported", *ref.BackendRef.Group)
+			if err := validateHTTPRouteBackendRef(ref.BackendRef.BackendObjectReference); err != nil {
+				return err
+			}
+		}
+
+		if err := validateHTTPRouteFilters(rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateHTTPRouteBackendRef verifies that a backendRef of an HTTPRoute, or
+// of one of its filters, refers to a Kubernetes Service.
+func validateHTTPRouteBackendRef(ref gatewayv1alpha2.BackendObjectReference) error {
+	if ref.Group != nil && *ref.Group != "core" && *ref.Group != "" {
+		return fmt.Errorf("%s is not a supported group for httproute backendRefs, only core is supported", *ref.Group)
+	}
+	if ref.Kind != nil && *ref.Kind != "Service" {
+		return fmt.Errorf("%s is not a supported kind for httproute backendRefs, only Service is supported", *ref.Kind)
+	}
+	return nil
+}
+
+// validateHTTPRouteFilters verifies that the filters of a given HTTPRoute rule,
+// including the filters of its backendRefs, can be translated into plugins of
+// the Kong routes generated for the rule.
+func validateHTTPRouteFilters(rule gatewayv1alpha2.HTTPRouteRule) error {
+	// all the backendRefs of a rule are served by the same Kong service, so
+	// their filters can't differ.
+	filters := rule.Filters
+	for i, ref := range rule.BackendRefs {
+		if i > 0 && !reflect.DeepEqual(ref.Filters, rule.BackendRefs[0].Filters) {
+			return fmt.Errorf("backendRefs of the same httproute rule with different filters are not supported")
+		}
+	}
+	if len(rule.BackendRefs) > 0 {
+		filters = append(append([]gatewayv1alpha2.HTTPRouteFilter{}, filters...), rule.BackendRefs[0].Filters...)
+	}
+
+	seen := map[gatewayv1alpha2.HTTPRouteFilterType]struct{}{}
+	for _, filter := range filters {
+		if _, ok := seen[filter.Type]; ok {
+			return fmt.Errorf("only one %s filter is supported per httproute rule, including the filters of its backendRefs", filter.Type)
+		}
+		seen[filter.Type] = struct{}{}
+
+		switch filter.Type { //nolint:exhaustive
+		case gatewayv1alpha2.HTTPRouteFilterRequestHeaderModifier:
+			if err := validateHTTPRequestHeaderFilter(filter.RequestHeaderModifier); err != nil {
+				return err
+			}
+		case gatewayv1alpha2.HTTPRouteFilterRequestRedirect:
+			if err := validateHTTPRequestRedirectFilter(filter.RequestRedirect); err != nil {
+				return err
+			}
+		case gatewayv1alpha2.HTTPRouteFilterURLRewrite:
+			if err := validateHTTPURLRewriteFilter(rule, filter.URLRewrite); err != nil {
+				return err
+			}
+		case gatewayv1alpha2.HTTPRouteFilterRequestMirror:
+			if filter.RequestMirror == nil {
+				return fmt.Errorf("%s filter has no configuration", filter.Type)
 			}
-			if ref.BackendRef.Kind != nil && *ref.BackendRef.Kind != "Service" {
-				return fmt.Errorf("%s is not a supported kind for httproute backendRefs, only Service is supported", *ref.BackendRef.Kind)
+			if err := validateHTTPRouteBackendRef(filter.RequestMirror.BackendRef); err != nil {
+				return err
 			}
+			if filter.RequestMirror.BackendRef.Port == nil {
+				return fmt.Errorf("backendRef of %s filter has no port", filter.Type)
+			}
+		default:
+			return fmt.Errorf("%s filters are not yet supported for httproute", filter.Type)
+		}
+	}
+
+	// a redirect is a response, so there's no proxied request to rewrite.
+	_, redirects := seen[gatewayv1alpha2.HTTPRouteFilterRequestRedirect]
+	if _, rewrites := seen[gatewayv1alpha2.HTTPRouteFilterURLRewrite]; redirects && rewrites {
+		return fmt.Errorf("%s and %s filters can't be combined in an httproute rule",
+			gatewayv1alpha2.HTTPRouteFilterRequestRedirect, gatewayv1alpha2.HTTPRouteFilterURLRewrite)
+	}
+	return nil
+}
+
+// validateHTTPRequestRedirectFilter verifies that a RequestRedirect filter
+// can be expressed by Kong. As the location of the redirect can't depend on
+// the request, replacing the prefix of the request path isn't supported.
+func validateHTTPRequestRedirectFilter(redirect *gatewayv1alpha2.HTTPRequestRedirectFilter) error {
+	if redirect == nil {
+		return fmt.Errorf("%s filter has no configuration", gatewayv1alpha2.HTTPRouteFilterRequestRedirect)
+	}
+	if redirect.Path != nil && redirect.Path.Type == gatewayv1alpha2.PrefixMatchHTTPPathModifier {
+		return fmt.Errorf("%s filters replacing the path prefix are not supported for httproute", gatewayv1alpha2.HTTPRouteFilterRequestRedirect)
+	}
+	return nil
+}
+
+// validateHTTPURLRewriteFilter verifies that a URLRewrite filter can be
+// expressed by Kong. The path prefix is replaced by stripping it from the
+// request, so it can only be replaced if all the matches of the rule match a
+// path prefix.
+func validateHTTPURLRewriteFilter(rule gatewayv1alpha2.HTTPRouteRule, rewrite *gatewayv1alpha2.HTTPURLRewriteFilter) error {
+	if rewrite == nil {
+		return fmt.Errorf("%s filter has no configuration", gatewayv1alpha2.HTTPRouteFilterURLRewrite)
+	}
+	if rewrite.Path == nil || rewrite.Path.Type != gatewayv1alpha2.PrefixMatchHTTPPathModifier {
+		return nil
+	}
+	for _, match := range rule.Matches {
+		if match.Path != nil && match.Path.Type != nil && *match.Path.Type != gatewayv1alpha2.PathMatchPathPrefix {
+			return fmt.Errorf("%s filters replacing the path prefix are only supported for httproute rules with PathPrefix matches",
+				gatewayv1alpha2.HTTPRouteFilterURLRewrite)
+		}
+	}
+	return nil
+}
+
+// validateHTTPRequestHeaderFilter verifies that a RequestHeaderModifier filter
+// can be expressed by a request-transformer plugin.
+func validateHTTPRequestHeaderFilter(modifier *gatewayv1alpha2.HTTPRequestHeaderFilter) error {
+	if modifier == nil {
+		return fmt.Errorf("%s filter has no configuration", gatewayv1alpha2.HTTPRouteFilterRequestHeaderModifier)
+	}
+
+	// the plugin removes headers before setting or adding any, which doesn't
+	// necessarily match the intent of removing and setting the same header.
+	removed := make(map[string]struct{}, len(modifier.Remove))
+	for _, name := range modifier.Remove {
+		removed[strings.ToLower(name)] = struct{}{}
+	}
+	for _, header := range append(append([]gatewayv1alpha2.HTTPHeader{}, modifier.Set...), modifier.Add...) {
+		if _, ok := removed[strings.ToLower(string(header.Name))]; ok {
+			return fmt.Errorf("header %s can't be both removed and set or added", header.Name)
+		}
+		if strings.Contains(header.Value, "$(") {
+			return fmt.Errorf("value of header %s can't contain \"$(\"", header.Name)
 		}
 	}
 	return nil
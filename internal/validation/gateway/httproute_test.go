@@ -2,6 +2,7 @@ package gateway
 
 import (
 	"fmt"
+	"regexp/syntax"
 	"testing"
 
 	"github.com/kong/go-kong/kong"
@@ -16,6 +17,7 @@ func TestValidateHTTPRoute(t *testing.T) {
 	group := gatewayv1alpha2.Group("gateway.networking.k8s.io")
 	defaultGWNamespace := gatewayv1alpha2.Namespace(corev1.NamespaceDefault)
 	pathMatchRegex := gatewayv1alpha2.PathMatchRegularExpression
+	queryParamMatchRegex := gatewayv1alpha2.QueryParamMatchRegularExpression
 	headerMatchRegex := gatewayv1alpha2.HeaderMatchRegularExpression
 	exampleGroup := gatewayv1alpha2.Group("example")
 	podKind := gatewayv1alpha2.Kind("Pod")
@@ -201,7 +203,7 @@ func TestValidateHTTPRoute(t *testing.T) {
 			err:           fmt.Errorf("HTTPRoute not supported by listener http-alternate"),
 		},
 		{
-			msg: "if an HTTPRoute is using queryparams matching it fails validation due to lack of support",
+			msg: "if an HTTPRoute is using regex queryparams matching it fails validation due to lack of support",
 			route: &gatewayv1alpha2.HTTPRoute{
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace: corev1.NamespaceDefault,
@@ -216,6 +218,7 @@ func TestValidateHTTPRoute(t *testing.T) {
 					Rules: []gatewayv1alpha2.HTTPRouteRule{{
 						Matches: []gatewayv1alpha2.HTTPRouteMatch{{
 							QueryParams: []gatewayv1alpha2.HTTPQueryParamMatch{{
+								Type:  &queryParamMatchRegex,
 								Name:  "user-agent",
 								Value: "netscape navigator",
 							}},
@@ -251,10 +254,10 @@ func TestValidateHTTPRoute(t *testing.T) {
 			}},
 			valid:         false,
 			validationMsg: "httproute spec did not pass validation",
-			err:           fmt.Errorf("queryparam matching is not yet supported for httproute"),
+			err:           fmt.Errorf("RegularExpression query param matching is not supported for httproute"),
 		},
 		{
-			msg: "if an HTTPRoute is using regex path matching it fails validation due to lack of support",
+			msg: "if an HTTPRoute is using an invalid regex path matching it fails validation",
 			route: &gatewayv1alpha2.HTTPRoute{
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace: corev1.NamespaceDefault,
@@ -270,7 +273,7 @@ func TestValidateHTTPRoute(t *testing.T) {
 						Matches: []gatewayv1alpha2.HTTPRouteMatch{{
 							Path: &gatewayv1alpha2.HTTPPathMatch{
 								Type:  &pathMatchRegex,
-								Value: kong.String("^path/to/stuff/*$"),
+								Value: kong.String("^path/to/(stuff/*$"),
 							},
 						}},
 						BackendRefs: []gatewayv1alpha2.HTTPBackendRef{{
@@ -304,7 +307,7 @@ func TestValidateHTTPRoute(t *testing.T) {
 			}},
 			valid:         false,
 			validationMsg: "httproute spec did not pass validation",
-			err:           fmt.Errorf("regex path matching is not yet supported for httproute"),
+			err:           fmt.Errorf("invalid regex path match for httproute: %w", &syntax.Error{Code: syntax.ErrMissingParen, Expr: "^path/to/(stuff/*$"}),
 		},
 		{
 			msg: "if an HTTPRoute is using regex header matching it fails validation due to lack of support",
@@ -482,3 +485,170 @@ func TestValidateHTTPRoute(t *testing.T) {
 		assert.Equal(t, tt.err, err, tt.msg)
 	}
 }
+
+func TestValidateHTTPRouteFilters(t *testing.T) {
+	headerModifier := func(modifier gatewayv1alpha2.HTTPRequestHeaderFilter) gatewayv1alpha2.HTTPRouteFilter {
+		return gatewayv1alpha2.HTTPRouteFilter{
+			Type:                  gatewayv1alpha2.HTTPRouteFilterRequestHeaderModifier,
+			RequestHeaderModifier: &modifier,
+		}
+	}
+	setFoo := headerModifier(gatewayv1alpha2.HTTPRequestHeaderFilter{
+		Set: []gatewayv1alpha2.HTTPHeader{{Name: "X-Foo", Value: "foo"}},
+	})
+	backendRef := func(filters ...gatewayv1alpha2.HTTPRouteFilter) gatewayv1alpha2.HTTPBackendRef {
+		return gatewayv1alpha2.HTTPBackendRef{
+			BackendRef: gatewayv1alpha2.BackendRef{
+				BackendObjectReference: gatewayv1alpha2.BackendObjectReference{Name: "service"},
+			},
+			Filters: filters,
+		}
+	}
+	absolutePath := &gatewayv1alpha2.HTTPPathModifier{Type: gatewayv1alpha2.AbsoluteHTTPPathModifier, Substitution: "/new"}
+	prefixPath := &gatewayv1alpha2.HTTPPathModifier{Type: gatewayv1alpha2.PrefixMatchHTTPPathModifier, Substitution: "/new"}
+	pathMatchPrefix := gatewayv1alpha2.PathMatchPathPrefix
+	pathMatchExact := gatewayv1alpha2.PathMatchExact
+	port := gatewayv1alpha2.PortNumber(80)
+	unsupportedKind := gatewayv1alpha2.Kind("Pod")
+
+	for _, tt := range []struct {
+		msg  string
+		rule gatewayv1alpha2.HTTPRouteRule
+		err  error
+	}{
+		{
+			msg: "a rule with a RequestHeaderModifier filter passes validation",
+			rule: gatewayv1alpha2.HTTPRouteRule{
+				Filters:     []gatewayv1alpha2.HTTPRouteFilter{setFoo},
+				BackendRefs: []gatewayv1alpha2.HTTPBackendRef{backendRef(), backendRef()},
+			},
+		},
+		{
+			msg: "backendRefs with the same RequestHeaderModifier filter pass validation",
+			rule: gatewayv1alpha2.HTTPRouteRule{
+				BackendRefs: []gatewayv1alpha2.HTTPBackendRef{backendRef(setFoo), backendRef(setFoo)},
+			},
+		},
+		{
+			msg: "backendRefs with different filters fail validation",
+			rule: gatewayv1alpha2.HTTPRouteRule{
+				BackendRefs: []gatewayv1alpha2.HTTPBackendRef{backendRef(setFoo), backendRef()},
+			},
+			err: fmt.Errorf("backendRefs of the same httproute rule with different filters are not supported"),
+		},
+		{
+			msg: "a RequestHeaderModifier filter on both the rule and its backendRefs fails validation",
+			rule: gatewayv1alpha2.HTTPRouteRule{
+				Filters:     []gatewayv1alpha2.HTTPRouteFilter{setFoo},
+				BackendRefs: []gatewayv1alpha2.HTTPBackendRef{backendRef(setFoo)},
+			},
+			err: fmt.Errorf("only one RequestHeaderModifier filter is supported per httproute rule, including the filters of its backendRefs"),
+		},
+		{
+			msg: "a header which is both removed and set fails validation",
+			rule: gatewayv1alpha2.HTTPRouteRule{
+				Filters: []gatewayv1alpha2.HTTPRouteFilter{headerModifier(gatewayv1alpha2.HTTPRequestHeaderFilter{
+					Set:    []gatewayv1alpha2.HTTPHeader{{Name: "X-Foo", Value: "foo"}},
+					Remove: []string{"x-foo"},
+				})},
+			},
+			err: fmt.Errorf("header X-Foo can't be both removed and set or added"),
+		},
+		{
+			msg: "a header value which would be evaluated as a template fails validation",
+			rule: gatewayv1alpha2.HTTPRouteRule{
+				Filters: []gatewayv1alpha2.HTTPRouteFilter{headerModifier(gatewayv1alpha2.HTTPRequestHeaderFilter{
+					Add: []gatewayv1alpha2.HTTPHeader{{Name: "X-Foo", Value: "$(uri_captures[1])"}},
+				})},
+			},
+			err: fmt.Errorf(`value of header X-Foo can't contain "$("`),
+		},
+		{
+			msg: "a rule with a RequestRedirect filter passes validation",
+			rule: gatewayv1alpha2.HTTPRouteRule{
+				Filters: []gatewayv1alpha2.HTTPRouteFilter{{
+					Type:            gatewayv1alpha2.HTTPRouteFilterRequestRedirect,
+					RequestRedirect: &gatewayv1alpha2.HTTPRequestRedirectFilter{Path: absolutePath},
+				}},
+			},
+		},
+		{
+			msg: "a RequestRedirect filter replacing the path prefix fails validation",
+			rule: gatewayv1alpha2.HTTPRouteRule{
+				Filters: []gatewayv1alpha2.HTTPRouteFilter{{
+					Type:            gatewayv1alpha2.HTTPRouteFilterRequestRedirect,
+					RequestRedirect: &gatewayv1alpha2.HTTPRequestRedirectFilter{Path: prefixPath},
+				}},
+			},
+			err: fmt.Errorf("RequestRedirect filters replacing the path prefix are not supported for httproute"),
+		},
+		{
+			msg: "a URLRewrite filter replacing the path prefix of prefix matches passes validation",
+			rule: gatewayv1alpha2.HTTPRouteRule{
+				Matches:     []gatewayv1alpha2.HTTPRouteMatch{{Path: &gatewayv1alpha2.HTTPPathMatch{Type: &pathMatchPrefix}}},
+				Filters:     []gatewayv1alpha2.HTTPRouteFilter{{Type: gatewayv1alpha2.HTTPRouteFilterURLRewrite, URLRewrite: &gatewayv1alpha2.HTTPURLRewriteFilter{Path: prefixPath}}},
+				BackendRefs: []gatewayv1alpha2.HTTPBackendRef{backendRef()},
+			},
+		},
+		{
+			msg: "a URLRewrite filter replacing the path prefix of exact matches fails validation",
+			rule: gatewayv1alpha2.HTTPRouteRule{
+				Matches:     []gatewayv1alpha2.HTTPRouteMatch{{Path: &gatewayv1alpha2.HTTPPathMatch{Type: &pathMatchExact}}},
+				Filters:     []gatewayv1alpha2.HTTPRouteFilter{{Type: gatewayv1alpha2.HTTPRouteFilterURLRewrite, URLRewrite: &gatewayv1alpha2.HTTPURLRewriteFilter{Path: prefixPath}}},
+				BackendRefs: []gatewayv1alpha2.HTTPBackendRef{backendRef()},
+			},
+			err: fmt.Errorf("URLRewrite filters replacing the path prefix are only supported for httproute rules with PathPrefix matches"),
+		},
+		{
+			msg: "RequestRedirect and URLRewrite filters combined fail validation",
+			rule: gatewayv1alpha2.HTTPRouteRule{
+				Filters: []gatewayv1alpha2.HTTPRouteFilter{
+					{Type: gatewayv1alpha2.HTTPRouteFilterRequestRedirect, RequestRedirect: &gatewayv1alpha2.HTTPRequestRedirectFilter{}},
+					{Type: gatewayv1alpha2.HTTPRouteFilterURLRewrite, URLRewrite: &gatewayv1alpha2.HTTPURLRewriteFilter{}},
+				},
+			},
+			err: fmt.Errorf("RequestRedirect and URLRewrite filters can't be combined in an httproute rule"),
+		},
+		{
+			msg: "a rule with a RequestMirror filter passes validation",
+			rule: gatewayv1alpha2.HTTPRouteRule{
+				Filters: []gatewayv1alpha2.HTTPRouteFilter{{
+					Type:          gatewayv1alpha2.HTTPRouteFilterRequestMirror,
+					RequestMirror: &gatewayv1alpha2.HTTPRequestMirrorFilter{BackendRef: gatewayv1alpha2.BackendObjectReference{Name: "mirror", Port: &port}},
+				}},
+				BackendRefs: []gatewayv1alpha2.HTTPBackendRef{backendRef()},
+			},
+		},
+		{
+			msg: "a RequestMirror filter which doesn't refer to a Service fails validation",
+			rule: gatewayv1alpha2.HTTPRouteRule{
+				Filters: []gatewayv1alpha2.HTTPRouteFilter{{
+					Type:          gatewayv1alpha2.HTTPRouteFilterRequestMirror,
+					RequestMirror: &gatewayv1alpha2.HTTPRequestMirrorFilter{BackendRef: gatewayv1alpha2.BackendObjectReference{Name: "mirror", Kind: &unsupportedKind, Port: &port}},
+				}},
+				BackendRefs: []gatewayv1alpha2.HTTPBackendRef{backendRef()},
+			},
+			err: fmt.Errorf("Pod is not a supported kind for httproute backendRefs, only Service is supported"),
+		},
+		{
+			msg: "a RequestMirror filter without a port fails validation",
+			rule: gatewayv1alpha2.HTTPRouteRule{
+				Filters: []gatewayv1alpha2.HTTPRouteFilter{{
+					Type:          gatewayv1alpha2.HTTPRouteFilterRequestMirror,
+					RequestMirror: &gatewayv1alpha2.HTTPRequestMirrorFilter{BackendRef: gatewayv1alpha2.BackendObjectReference{Name: "mirror"}},
+				}},
+				BackendRefs: []gatewayv1alpha2.HTTPBackendRef{backendRef()},
+			},
+			err: fmt.Errorf("backendRef of RequestMirror filter has no port"),
+		},
+		{
+			msg: "unsupported filters fail validation",
+			rule: gatewayv1alpha2.HTTPRouteRule{
+				Filters: []gatewayv1alpha2.HTTPRouteFilter{{Type: gatewayv1alpha2.HTTPRouteFilterExtensionRef}},
+			},
+			err: fmt.Errorf("ExtensionRef filters are not yet supported for httproute"),
+		},
+	} {
+		assert.Equal(t, tt.err, validateHTTPRouteFilters(tt.rule), tt.msg)
+	}
+}
@@ -0,0 +1,87 @@
+package policies
+
+import (
+	"fmt"
+
+	configurationv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
+)
+
+// -----------------------------------------------------------------------------
+// Validation - Policies - Public Functions
+// -----------------------------------------------------------------------------
+
+// ValidateKongUpstreamPolicy validates the settings of a KongUpstreamPolicy
+// which its schema can't express. The returned boolean indicates whether the
+// policy is valid, and the message explains why it is not.
+func ValidateKongUpstreamPolicy(policy *configurationv1beta1.KongUpstreamPolicy) (bool, string) {
+	spec := policy.Spec
+	if spec.HashOn == nil && spec.HashOnFallback == nil {
+		return true, ""
+	}
+
+	if spec.Algorithm == nil || *spec.Algorithm != "consistent-hashing" {
+		return false, "hashOn and hashOnFallback require the consistent-hashing algorithm"
+	}
+	if spec.HashOn == nil {
+		return false, "hashOnFallback requires hashOn"
+	}
+	if ok, msg := validateUpstreamHash("hashOn", spec.HashOn); !ok {
+		return false, msg
+	}
+	if spec.HashOnFallback == nil {
+		return true, ""
+	}
+	if ok, msg := validateUpstreamHash("hashOnFallback", spec.HashOnFallback); !ok {
+		return false, msg
+	}
+
+	// Kong has a single setting for the cookie of both hashing inputs, and
+	// generates the cookie when it's missing, so there is nothing to fall
+	// back from or to.
+	if spec.HashOn.Cookie != nil || spec.HashOnFallback.Cookie != nil {
+		return false, "hashOnFallback can't be used along with a cookie"
+	}
+	if spec.HashOn.Input != nil && spec.HashOnFallback.Input != nil && *spec.HashOn.Input == *spec.HashOnFallback.Input {
+		return false, fmt.Sprintf("hashOnFallback can't use the same input %q as hashOn", *spec.HashOn.Input)
+	}
+	if spec.HashOn.Header != nil && spec.HashOnFallback.Header != nil && *spec.HashOn.Header == *spec.HashOnFallback.Header {
+		return false, fmt.Sprintf("hashOnFallback can't use the same header %q as hashOn", *spec.HashOn.Header)
+	}
+	return true, ""
+}
+
+// ValidateKongServicePolicy validates the settings of a KongServicePolicy
+// which its schema can't express. The returned boolean indicates whether the
+// policy is valid, and the message explains why it is not.
+func ValidateKongServicePolicy(policy *configurationv1beta1.KongServicePolicy) (bool, string) {
+	spec := policy.Spec
+	if spec.Path != nil && spec.Protocol != nil {
+		switch *spec.Protocol {
+		case "tcp", "tls", "udp":
+			return false, fmt.Sprintf("path can't be set for the %s protocol", *spec.Protocol)
+		}
+	}
+	return true, ""
+}
+
+// -----------------------------------------------------------------------------
+// Validation - Policies - Private Functions
+// -----------------------------------------------------------------------------
+
+// validateUpstreamHash checks that exactly one hashing input is set by the
+// provided field of a KongUpstreamPolicy.
+func validateUpstreamHash(field string, hash *configurationv1beta1.KongUpstreamHash) (bool, string) {
+	set := 0
+	for _, v := range []*string{hash.Input, hash.Header, hash.Cookie} {
+		if v != nil {
+			set++
+		}
+	}
+	if set != 1 {
+		return false, fmt.Sprintf("%s must set exactly one of input, header and cookie", field)
+	}
+	if hash.CookiePath != nil && hash.Cookie == nil {
+		return false, fmt.Sprintf("%s.cookiePath requires %s.cookie", field, field)
+	}
+	return true, ""
+}
@@ -0,0 +1,147 @@
+package policies
+
+import (
+	"testing"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/assert"
+
+	configurationv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
+)
+
+func TestValidateKongUpstreamPolicy(t *testing.T) {
+	for _, tt := range []struct {
+		msg   string
+		spec  configurationv1beta1.KongUpstreamPolicySpec
+		valid bool
+	}{
+		{
+			msg:   "an empty policy is valid",
+			valid: true,
+		},
+		{
+			msg: "a policy without hashing is valid",
+			spec: configurationv1beta1.KongUpstreamPolicySpec{
+				Algorithm: kong.String("least-connections"),
+				Slots:     kong.Int(100),
+			},
+			valid: true,
+		},
+		{
+			msg: "a header with an input fallback is valid",
+			spec: configurationv1beta1.KongUpstreamPolicySpec{
+				Algorithm:      kong.String("consistent-hashing"),
+				HashOn:         &configurationv1beta1.KongUpstreamHash{Header: kong.String("x-user")},
+				HashOnFallback: &configurationv1beta1.KongUpstreamHash{Input: kong.String("ip")},
+			},
+			valid: true,
+		},
+		{
+			msg: "a cookie with a path is valid",
+			spec: configurationv1beta1.KongUpstreamPolicySpec{
+				Algorithm: kong.String("consistent-hashing"),
+				HashOn:    &configurationv1beta1.KongUpstreamHash{Cookie: kong.String("session"), CookiePath: kong.String("/")},
+			},
+			valid: true,
+		},
+		{
+			msg: "hashing without the consistent-hashing algorithm is invalid",
+			spec: configurationv1beta1.KongUpstreamPolicySpec{
+				HashOn: &configurationv1beta1.KongUpstreamHash{Input: kong.String("ip")},
+			},
+		},
+		{
+			msg: "a fallback without hashOn is invalid",
+			spec: configurationv1beta1.KongUpstreamPolicySpec{
+				Algorithm:      kong.String("consistent-hashing"),
+				HashOnFallback: &configurationv1beta1.KongUpstreamHash{Input: kong.String("ip")},
+			},
+		},
+		{
+			msg: "a hash without input is invalid",
+			spec: configurationv1beta1.KongUpstreamPolicySpec{
+				Algorithm: kong.String("consistent-hashing"),
+				HashOn:    &configurationv1beta1.KongUpstreamHash{},
+			},
+		},
+		{
+			msg: "a hash with several inputs is invalid",
+			spec: configurationv1beta1.KongUpstreamPolicySpec{
+				Algorithm: kong.String("consistent-hashing"),
+				HashOn:    &configurationv1beta1.KongUpstreamHash{Input: kong.String("ip"), Header: kong.String("x-user")},
+			},
+		},
+		{
+			msg: "a cookie path without cookie is invalid",
+			spec: configurationv1beta1.KongUpstreamPolicySpec{
+				Algorithm: kong.String("consistent-hashing"),
+				HashOn:    &configurationv1beta1.KongUpstreamHash{Input: kong.String("ip"), CookiePath: kong.String("/")},
+			},
+		},
+		{
+			msg: "a fallback along with a cookie is invalid",
+			spec: configurationv1beta1.KongUpstreamPolicySpec{
+				Algorithm:      kong.String("consistent-hashing"),
+				HashOn:         &configurationv1beta1.KongUpstreamHash{Cookie: kong.String("session")},
+				HashOnFallback: &configurationv1beta1.KongUpstreamHash{Input: kong.String("ip")},
+			},
+		},
+		{
+			msg: "a fallback on the same input is invalid",
+			spec: configurationv1beta1.KongUpstreamPolicySpec{
+				Algorithm:      kong.String("consistent-hashing"),
+				HashOn:         &configurationv1beta1.KongUpstreamHash{Input: kong.String("consumer")},
+				HashOnFallback: &configurationv1beta1.KongUpstreamHash{Input: kong.String("consumer")},
+			},
+		},
+	} {
+		t.Run(tt.msg, func(t *testing.T) {
+			valid, msg := ValidateKongUpstreamPolicy(&configurationv1beta1.KongUpstreamPolicy{Spec: tt.spec})
+			assert.Equal(t, tt.valid, valid)
+			if tt.valid {
+				assert.Empty(t, msg)
+			} else {
+				assert.NotEmpty(t, msg)
+			}
+		})
+	}
+}
+
+func TestValidateKongServicePolicy(t *testing.T) {
+	for _, tt := range []struct {
+		msg   string
+		spec  configurationv1beta1.KongServicePolicySpec
+		valid bool
+	}{
+		{
+			msg:   "an empty policy is valid",
+			valid: true,
+		},
+		{
+			msg: "a path with the http protocol is valid",
+			spec: configurationv1beta1.KongServicePolicySpec{
+				Protocol: kong.String("http"),
+				Path:     kong.String("/api"),
+				Retries:  kong.Int(3),
+			},
+			valid: true,
+		},
+		{
+			msg: "a path with the tcp protocol is invalid",
+			spec: configurationv1beta1.KongServicePolicySpec{
+				Protocol: kong.String("tcp"),
+				Path:     kong.String("/api"),
+			},
+		},
+	} {
+		t.Run(tt.msg, func(t *testing.T) {
+			valid, msg := ValidateKongServicePolicy(&configurationv1beta1.KongServicePolicy{Spec: tt.spec})
+			assert.Equal(t, tt.valid, valid)
+			if tt.valid {
+				assert.Empty(t, msg)
+			} else {
+				assert.NotEmpty(t, msg)
+			}
+		})
+	}
+}
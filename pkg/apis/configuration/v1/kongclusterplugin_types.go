@@ -33,6 +33,7 @@ import (
 //+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Age"
 //+kubebuilder:printcolumn:name="Disabled",type=boolean,JSONPath=`.disabled`,description="Indicates if the plugin is disabled",priority=1
 //+kubebuilder:printcolumn:name="Config",type=string,JSONPath=`.config`,description="Configuration of the plugin",priority=1
+//+kubebuilder:printcolumn:name="Programmed",type=string,JSONPath=`.status.conditions[?(@.type=="Programmed")].status`,description="Indicates if the plugin is configured on the data-plane"
 
 // KongClusterPlugin is the Schema for the kongclusterplugins API
 type KongClusterPlugin struct {
@@ -64,6 +65,9 @@ type KongClusterPlugin struct {
 	// Protocols configures plugin to run on requests received on specific
 	// protocols.
 	Protocols []KongProtocol `json:"protocols,omitempty"`
+
+	// Status represents the current status of the plugin.
+	Status KongPluginStatus `json:"status,omitempty"`
 }
 
 //+kubebuilder:object:root=true
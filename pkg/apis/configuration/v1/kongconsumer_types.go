@@ -29,6 +29,7 @@ import (
 //+kubebuilder:validation:Optional
 //+kubebuilder:printcolumn:name="Username",type=string,JSONPath=`.username`,description="Username of a Kong Consumer"
 //+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Age"
+//+kubebuilder:printcolumn:name="Programmed",type=string,JSONPath=`.status.conditions[?(@.type=="Programmed")].status`,description="Indicates if the consumer is configured on the data-plane"
 
 // KongConsumer is the Schema for the kongconsumers API
 type KongConsumer struct {
@@ -45,8 +46,105 @@ type KongConsumer struct {
 	// Credentials are references to secrets containing a credential to be
 	// provisioned in Kong.
 	Credentials []string `json:"credentials,omitempty"`
+
+	// ConsumerGroups are the names of the KongConsumerGroups in the namespace
+	// of the consumer which the consumer is a member of.
+	ConsumerGroups []string `json:"consumerGroups,omitempty"`
+
+	// Status represents the current status of the consumer.
+	Status KongConsumerStatus `json:"status,omitempty"`
+}
+
+// KongConsumerStatus represents the current status of a KongConsumer.
+type KongConsumerStatus struct {
+	// Conditions describe the current conditions of the consumer.
+	//
+	// Known condition types are:
+	//
+	// * "Programmed", which indicates whether the consumer is configured on
+	//   the data-plane.
+	//
+	//+listType=map
+	//+listMapKey=type
+	//+kubebuilder:validation:MaxItems=8
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the generation of the consumer which the status
+	// was last updated for.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// ConsumerID is the ID of the consumer in Kong.
+	ConsumerID string `json:"consumerID,omitempty"`
+
+	// Credentials are the results of resolving the credential Secrets of the
+	// consumer, in the order of the credentials of the consumer.
+	Credentials []ConsumerCredentialStatus `json:"credentials,omitempty"`
+}
+
+// ConsumerCredentialStatus describes the result of resolving a credential
+// Secret of a KongConsumer.
+type ConsumerCredentialStatus struct {
+	// SecretName is the name of the credential Secret.
+	SecretName string `json:"secretName"`
+
+	// Type is the type of the credential, as set in the kongCredType key of
+	// the Secret.
+	Type string `json:"type,omitempty"`
+
+	// Reason indicates whether the credential was found, or why it was not
+	// configured. One of "Found", "SecretNotFound", "InvalidType",
+	// "MissingFields", "Duplicate" or "Invalid".
+	Reason string `json:"reason"`
+
+	// Message describes the problem with the credential, if any.
+	Message string `json:"message,omitempty"`
 }
 
+const (
+	// ConsumerConditionProgrammed is the type of the condition which indicates
+	// whether a consumer is configured on the data-plane.
+	ConsumerConditionProgrammed = "Programmed"
+
+	// ConsumerReasonProgrammed is the reason of the Programmed condition of
+	// consumers configured on the data-plane.
+	ConsumerReasonProgrammed = "Programmed"
+
+	// ConsumerReasonInvalid is the reason of the Programmed condition of
+	// consumers which Kong configuration couldn't be generated from.
+	ConsumerReasonInvalid = "Invalid"
+
+	// ConsumerReasonConfigurationRejected is the reason of the Programmed
+	// condition of consumers whose configuration was rejected by the
+	// data-plane.
+	ConsumerReasonConfigurationRejected = "ConfigurationRejected"
+)
+
+const (
+	// CredentialReasonFound indicates that a credential Secret was found and
+	// that the credential is configured for the consumer.
+	CredentialReasonFound = "Found"
+
+	// CredentialReasonSecretNotFound indicates that a credential Secret
+	// doesn't exist.
+	CredentialReasonSecretNotFound = "SecretNotFound"
+
+	// CredentialReasonInvalidType indicates that a credential Secret has no
+	// kongCredType key, or a type which isn't supported.
+	CredentialReasonInvalidType = "InvalidType"
+
+	// CredentialReasonMissingFields indicates that a credential Secret lacks
+	// fields required for its type.
+	CredentialReasonMissingFields = "MissingFields"
+
+	// CredentialReasonDuplicate indicates that a credential Secret is
+	// referenced more than once by the consumer.
+	CredentialReasonDuplicate = "Duplicate"
+
+	// CredentialReasonInvalid indicates that a credential couldn't be
+	// generated from the data of the Secret.
+	CredentialReasonInvalid = "Invalid"
+)
+
 //+kubebuilder:object:root=true
 
 // KongConsumerList contains a list of KongConsumer
@@ -32,6 +32,7 @@ import (
 //+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Age"
 //+kubebuilder:printcolumn:name="Disabled",type=boolean,JSONPath=`.disabled`,description="Indicates if the plugin is disabled",priority=1
 //+kubebuilder:printcolumn:name="Config",type=string,JSONPath=`.config`,description="Configuration of the plugin",priority=1
+//+kubebuilder:printcolumn:name="Programmed",type=string,JSONPath=`.status.conditions[?(@.type=="Programmed")].status`,description="Indicates if the plugin is configured on the data-plane"
 
 // KongPlugin is the Schema for the kongplugins API
 type KongPlugin struct {
@@ -63,8 +64,84 @@ type KongPlugin struct {
 	// Protocols configures plugin to run on requests received on specific
 	// protocols.
 	Protocols []KongProtocol `json:"protocols,omitempty"`
+
+	// Status represents the current status of the plugin.
+	Status KongPluginStatus `json:"status,omitempty"`
+}
+
+// KongPluginStatus represents the current status of a KongPlugin or of a
+// KongClusterPlugin.
+type KongPluginStatus struct {
+	// Conditions describe the current conditions of the plugin.
+	//
+	// Known condition types are:
+	//
+	// * "Accepted", which indicates whether Kong configuration could be
+	//   generated from the plugin.
+	// * "Programmed", which indicates whether the data-plane accepted the
+	//   configuration generated from the plugin.
+	//
+	//+listType=map
+	//+listMapKey=type
+	//+kubebuilder:validation:MaxItems=8
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the generation of the plugin which the status was
+	// last updated for.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Parents are the objects which the plugin is attached to, e.g. the
+	// Ingresses, routes, Services and KongConsumers referring to it.
+	Parents []PluginParentReference `json:"parents,omitempty"`
+}
+
+// PluginParentReference identifies an object which a plugin is attached to.
+type PluginParentReference struct {
+	// Group is the API group of the object, empty for the core API group.
+	Group string `json:"group"`
+
+	// Kind is the kind of the object.
+	Kind string `json:"kind"`
+
+	// Namespace is the namespace of the object.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name is the name of the object.
+	Name string `json:"name"`
 }
 
+const (
+	// PluginConditionAccepted is the type of the condition which indicates
+	// whether Kong configuration could be generated from a plugin.
+	PluginConditionAccepted = "Accepted"
+
+	// PluginConditionProgrammed is the type of the condition which indicates
+	// whether the configuration generated from a plugin was accepted by the
+	// data-plane.
+	PluginConditionProgrammed = "Programmed"
+
+	// PluginReasonAccepted is the reason of the Accepted condition of plugins
+	// which Kong configuration could be generated from.
+	PluginReasonAccepted = "Accepted"
+
+	// PluginReasonInvalid is the reason of the Accepted condition of plugins
+	// which Kong configuration couldn't be generated from.
+	PluginReasonInvalid = "Invalid"
+
+	// PluginReasonProgrammed is the reason of the Programmed condition of
+	// plugins configured on the data-plane.
+	PluginReasonProgrammed = "Programmed"
+
+	// PluginReasonConfigurationRejected is the reason of the Programmed
+	// condition of plugins whose configuration was rejected by the data-plane.
+	PluginReasonConfigurationRejected = "ConfigurationRejected"
+
+	// PluginReasonNoParents is the reason of the Programmed condition of
+	// plugins which no object refers to, and which therefore aren't configured
+	// on the data-plane.
+	PluginReasonNoParents = "NoParents"
+)
+
 //+kubebuilder:object:root=true
 
 // KongPluginList contains a list of KongPlugin
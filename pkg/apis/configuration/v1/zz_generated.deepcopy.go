@@ -23,6 +23,7 @@ package v1
 
 import (
 	"github.com/kong/go-kong/kong"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -42,6 +43,21 @@ func (in *ConfigSource) DeepCopy() *ConfigSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerCredentialStatus) DeepCopyInto(out *ConsumerCredentialStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerCredentialStatus.
+func (in *ConsumerCredentialStatus) DeepCopy() *ConsumerCredentialStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerCredentialStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KongClusterPlugin) DeepCopyInto(out *KongClusterPlugin) {
 	*out = *in
@@ -58,6 +74,7 @@ func (in *KongClusterPlugin) DeepCopyInto(out *KongClusterPlugin) {
 		*out = make([]KongProtocol, len(*in))
 		copy(*out, *in)
 	}
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KongClusterPlugin.
@@ -120,6 +137,12 @@ func (in *KongConsumer) DeepCopyInto(out *KongConsumer) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ConsumerGroups != nil {
+		in, out := &in.ConsumerGroups, &out.ConsumerGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KongConsumer.
@@ -172,6 +195,33 @@ func (in *KongConsumerList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KongConsumerStatus) DeepCopyInto(out *KongConsumerStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = make([]ConsumerCredentialStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KongConsumerStatus.
+func (in *KongConsumerStatus) DeepCopy() *KongConsumerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KongConsumerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KongIngress) DeepCopyInto(out *KongIngress) {
 	*out = *in
@@ -468,6 +518,7 @@ func (in *KongPlugin) DeepCopyInto(out *KongPlugin) {
 		*out = make([]KongProtocol, len(*in))
 		copy(*out, *in)
 	}
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KongPlugin.
@@ -520,6 +571,33 @@ func (in *KongPluginList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KongPluginStatus) DeepCopyInto(out *KongPluginStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Parents != nil {
+		in, out := &in.Parents, &out.Parents
+		*out = make([]PluginParentReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KongPluginStatus.
+func (in *KongPluginStatus) DeepCopy() *KongPluginStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KongPluginStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NamespacedConfigSource) DeepCopyInto(out *NamespacedConfigSource) {
 	*out = *in
@@ -551,6 +629,21 @@ func (in *NamespacedSecretValueFromSource) DeepCopy() *NamespacedSecretValueFrom
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginParentReference) DeepCopyInto(out *PluginParentReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginParentReference.
+func (in *PluginParentReference) DeepCopy() *PluginParentReference {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginParentReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretValueFromSource) DeepCopyInto(out *SecretValueFromSource) {
 	*out = *in
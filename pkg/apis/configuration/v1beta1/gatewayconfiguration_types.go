@@ -0,0 +1,106 @@
+/*
+Copyright 2022 Kong, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GatewayConfigurationKind is the kind which GatewayClasses use in their
+// parametersRef to refer to a GatewayConfiguration.
+const GatewayConfigurationKind = "GatewayConfiguration"
+
+func init() {
+	SchemeBuilder.Register(&GatewayConfiguration{}, &GatewayConfigurationList{})
+}
+
+//+kubebuilder:object:root=true
+
+// GatewayConfigurationList contains a list of GatewayConfiguration
+type GatewayConfigurationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GatewayConfiguration `json:"items"`
+}
+
+//+genclient
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:categories=kong-ingress-controller
+//+kubebuilder:storageversion
+//+kubebuilder:validation:Optional
+//+kubebuilder:printcolumn:name="Publish Service",type=string,JSONPath=`.spec.publishService`,description="Proxy Service of the unmanaged Gateways"
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Age"
+
+// GatewayConfiguration is the Schema for the gatewayconfigurations API. It is
+// referenced by the parametersRef of a GatewayClass to configure how the
+// Gateways of that class, and the routes attached to them, are handled.
+type GatewayConfiguration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec GatewayConfigurationSpec `json:"spec,omitempty"`
+}
+
+// GatewayConfigurationSpec defines the desired state of GatewayConfiguration
+type GatewayConfigurationSpec struct {
+	// PublishService is the "namespace/name" of the proxy Service which the
+	// unmanaged Gateways of the class are attached to. The Service must select
+	// the proxies configured by the controller. Defaults to the Service set by
+	// the --publish-service flag of the controller.
+	PublishService string `json:"publishService,omitempty"`
+
+	// Plugins is a list of the names of plugins configured on all the routes
+	// attached to the Gateways of the class, in addition to the plugins set by
+	// the konghq.com/plugins annotation of the routes. Names refer to
+	// KongPlugins in the namespace of the route, or to KongClusterPlugins.
+	Plugins []string `json:"plugins,omitempty"`
+
+	// RouteProtocols is the list of protocols of the Kong routes generated
+	// for the HTTPRoutes attached to the Gateways of the class. Defaults to
+	// both http and https.
+	//+kubebuilder:validation:Items:Enum=http;https
+	RouteProtocols []string `json:"routeProtocols,omitempty"`
+
+	// CombinedRoutes, when enabled, combines the matches of an HTTPRoute rule
+	// which only differ by their path into a single Kong route, rather than
+	// generating a Kong route for each match. Disabled by default, regardless
+	// of the CombinedRoutes feature gate of the controller.
+	CombinedRoutes *bool `json:"combinedRoutes,omitempty"`
+
+	// Managed, when set, makes the controller provision a Kong proxy for each
+	// Gateway of the class which doesn't have the konghq.com/gateway-unmanaged
+	// annotation, rather than attaching it to the proxies of the controller.
+	Managed *ManagedGatewayProxy `json:"managed,omitempty"`
+}
+
+// ManagedGatewayProxy defines the Kong proxy provisioned for each managed
+// Gateway.
+type ManagedGatewayProxy struct {
+	// Image is the Kong image of the proxy. Defaults to kong:2.8.
+	Image string `json:"image,omitempty"`
+
+	// Replicas is the number of replicas of the proxy. Defaults to 1.
+	//+kubebuilder:validation:Minimum=0
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// ServiceType is the type of the Service of the proxy. Defaults to
+	// LoadBalancer.
+	//+kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer
+	ServiceType corev1.ServiceType `json:"serviceType,omitempty"`
+}
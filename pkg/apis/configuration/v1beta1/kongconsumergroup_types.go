@@ -0,0 +1,71 @@
+/*
+Copyright 2022 Kong, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func init() {
+	SchemeBuilder.Register(&KongConsumerGroup{}, &KongConsumerGroupList{})
+}
+
+//+kubebuilder:object:root=true
+
+// KongConsumerGroupList contains a list of KongConsumerGroup
+type KongConsumerGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KongConsumerGroup `json:"items"`
+}
+
+//+genclient
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:shortName=kcg,categories=kong-ingress-controller
+//+kubebuilder:storageversion
+//+kubebuilder:validation:Optional
+//+kubebuilder:printcolumn:name="Name",type=string,JSONPath=`.spec.name`,description="Name of the Kong consumer group"
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Age"
+
+// KongConsumerGroup is the Schema for the kongconsumergroups API. It declares
+// a consumer group in Kong Enterprise, which KongConsumers of the same
+// namespace become members of by listing it in their consumerGroups. The
+// plugins set by the konghq.com/plugins annotation of the KongConsumerGroup
+// are scoped to the group, e.g. to configure a rate limiting tier.
+type KongConsumerGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec KongConsumerGroupSpec `json:"spec,omitempty"`
+}
+
+// KongConsumerGroupSpec defines the desired state of KongConsumerGroup
+type KongConsumerGroupSpec struct {
+	// Name is the name of the consumer group in Kong, which must be unique
+	// across all namespaces. Defaults to the name of the KongConsumerGroup.
+	//+kubebuilder:validation:MaxLength=253
+	Name string `json:"name,omitempty"`
+}
+
+// KongName provides the name of the consumer group in Kong.
+func (in *KongConsumerGroup) KongName() string {
+	if in.Spec.Name != "" {
+		return in.Spec.Name
+	}
+	return in.Name
+}
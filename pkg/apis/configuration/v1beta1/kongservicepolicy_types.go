@@ -0,0 +1,99 @@
+/*
+Copyright 2022 Kong, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func init() {
+	SchemeBuilder.Register(&KongServicePolicy{}, &KongServicePolicyList{})
+}
+
+//+kubebuilder:object:root=true
+
+// KongServicePolicyList contains a list of KongServicePolicy
+type KongServicePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KongServicePolicy `json:"items"`
+}
+
+//+genclient
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:shortName=ksp,categories=kong-ingress-controller
+//+kubebuilder:storageversion
+//+kubebuilder:validation:Optional
+//+kubebuilder:printcolumn:name="Protocol",type=string,JSONPath=`.spec.protocol`,description="Protocol used to communicate with the upstream"
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Age"
+//+kubebuilder:printcolumn:name="Accepted",type=string,JSONPath=`.status.conditions[?(@.type=="Accepted")].status`
+
+// KongServicePolicy is the Schema for the kongservicepolicies API. It
+// configures how Kong proxies requests to the Services it is attached to,
+// either by the konghq.com/service-policy annotation of a Service or by its
+// targetRef. It supersedes the proxy section of KongIngress.
+type KongServicePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec KongServicePolicySpec `json:"spec,omitempty"`
+
+	// Status represents the current status of the policy.
+	Status KongPolicyStatus `json:"status,omitempty"`
+}
+
+// KongServicePolicySpec defines the desired state of KongServicePolicy
+type KongServicePolicySpec struct {
+	// TargetRef is the Service in the namespace of the policy which the
+	// policy applies to, in addition to the Services attaching it with the
+	// konghq.com/service-policy annotation.
+	TargetRef *PolicyTargetReference `json:"targetRef,omitempty"`
+
+	// Protocol is the protocol used to communicate with the upstream.
+	//+kubebuilder:validation:Enum=http;https;grpc;grpcs;tcp;tls;udp
+	Protocol *string `json:"protocol,omitempty"`
+
+	// Path is the path to be used in requests to the upstream server. It is
+	// ignored for the grpc and grpcs protocols.
+	//+kubebuilder:validation:Pattern=^/.*$
+	Path *string `json:"path,omitempty"`
+
+	// Retries is the number of retries to execute upon failure to proxy.
+	//+kubebuilder:validation:Minimum=0
+	//+kubebuilder:validation:Maximum=32767
+	Retries *int `json:"retries,omitempty"`
+
+	// ConnectTimeout is the timeout in milliseconds for establishing a
+	// connection to the upstream server.
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=2147483646
+	ConnectTimeout *int `json:"connectTimeout,omitempty"`
+
+	// ReadTimeout is the timeout in milliseconds between two successive read
+	// operations for transmitting a request to the upstream server.
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=2147483646
+	ReadTimeout *int `json:"readTimeout,omitempty"`
+
+	// WriteTimeout is the timeout in milliseconds between two successive write
+	// operations for transmitting a request to the upstream server.
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=2147483646
+	WriteTimeout *int `json:"writeTimeout,omitempty"`
+}
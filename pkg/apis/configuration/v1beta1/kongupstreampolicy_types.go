@@ -0,0 +1,111 @@
+/*
+Copyright 2022 Kong, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/kong/go-kong/kong"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func init() {
+	SchemeBuilder.Register(&KongUpstreamPolicy{}, &KongUpstreamPolicyList{})
+}
+
+//+kubebuilder:object:root=true
+
+// KongUpstreamPolicyList contains a list of KongUpstreamPolicy
+type KongUpstreamPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KongUpstreamPolicy `json:"items"`
+}
+
+//+genclient
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:shortName=kup,categories=kong-ingress-controller
+//+kubebuilder:storageversion
+//+kubebuilder:validation:Optional
+//+kubebuilder:printcolumn:name="Algorithm",type=string,JSONPath=`.spec.algorithm`,description="Load balancing algorithm"
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Age"
+//+kubebuilder:printcolumn:name="Accepted",type=string,JSONPath=`.status.conditions[?(@.type=="Accepted")].status`
+
+// KongUpstreamPolicy is the Schema for the kongupstreampolicies API. It
+// configures the Kong upstreams generated for the Services it is attached to,
+// either by the konghq.com/upstream-policy annotation of a Service or by its
+// targetRef. It supersedes the upstream section of KongIngress.
+type KongUpstreamPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec KongUpstreamPolicySpec `json:"spec,omitempty"`
+
+	// Status represents the current status of the policy.
+	Status KongPolicyStatus `json:"status,omitempty"`
+}
+
+// KongUpstreamPolicySpec defines the desired state of KongUpstreamPolicy
+type KongUpstreamPolicySpec struct {
+	// TargetRef is the Service in the namespace of the policy which the
+	// policy applies to, in addition to the Services attaching it with the
+	// konghq.com/upstream-policy annotation.
+	TargetRef *PolicyTargetReference `json:"targetRef,omitempty"`
+
+	// Algorithm is the load balancing algorithm of the upstream.
+	//+kubebuilder:validation:Enum=round-robin;consistent-hashing;least-connections
+	Algorithm *string `json:"algorithm,omitempty"`
+
+	// Slots is the number of slots in the load balancer of the upstream.
+	//+kubebuilder:validation:Minimum=10
+	//+kubebuilder:validation:Maximum=65536
+	Slots *int `json:"slots,omitempty"`
+
+	// HashOn defines what to use as hashing input with the
+	// consistent-hashing algorithm.
+	HashOn *KongUpstreamHash `json:"hashOn,omitempty"`
+
+	// HashOnFallback defines what to use as hashing input if HashOn does not
+	// return a hash, e.g. because the header is missing. Requires HashOn.
+	HashOnFallback *KongUpstreamHash `json:"hashOnFallback,omitempty"`
+
+	// Healthchecks defines the active and passive health checks of the
+	// targets of the upstream.
+	Healthchecks *kong.Healthcheck `json:"healthchecks,omitempty"`
+}
+
+// KongUpstreamHash defines what to use as hashing input. Exactly one of
+// Input, Header and Cookie must be set.
+type KongUpstreamHash struct {
+	// Input is the client attribute to use as hashing input.
+	//+kubebuilder:validation:Enum=ip;consumer
+	Input *string `json:"input,omitempty"`
+
+	// Header is the name of the request header to use as hashing input.
+	//+kubebuilder:validation:MinLength=1
+	Header *string `json:"header,omitempty"`
+
+	// Cookie is the name of the cookie to use as hashing input. Kong sets the
+	// cookie when the request doesn't include it.
+	//+kubebuilder:validation:MinLength=1
+	Cookie *string `json:"cookie,omitempty"`
+
+	// CookiePath is the path of the cookie set by Kong. Only valid along with
+	// Cookie.
+	//+kubebuilder:validation:Pattern=^/.*$
+	CookiePath *string `json:"cookiePath,omitempty"`
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2022 Kong, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func init() {
+	SchemeBuilder.Register(&KongVault{}, &KongVaultList{})
+}
+
+//+kubebuilder:object:root=true
+
+// KongVaultList contains a list of KongVault
+type KongVaultList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KongVault `json:"items"`
+}
+
+//+genclient
+//+genclient:nonNamespaced
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster,shortName=kv,categories=kong-ingress-controller
+//+kubebuilder:storageversion
+//+kubebuilder:validation:Optional
+//+kubebuilder:printcolumn:name="Backend",type=string,JSONPath=`.spec.backend`,description="Name of the vault backend"
+//+kubebuilder:printcolumn:name="Prefix",type=string,JSONPath=`.spec.prefix`,description="Prefix of the vault references"
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Age"
+
+// KongVault is the Schema for the kongvaults API. It declares a vault entity
+// in Kong, which lets plugin configurations refer to secrets stored outside of
+// Kubernetes with references like {vault://<prefix>/<secret>}. The secrets are
+// resolved by Kong, and are never read by the controller.
+type KongVault struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec KongVaultSpec `json:"spec,omitempty"`
+}
+
+// KongVaultSpec defines the desired state of KongVault
+type KongVaultSpec struct {
+	// Backend is the name of the vault backend of Kong, e.g. "env" to read
+	// secrets from environment variables of the Kong proxy.
+	//+kubebuilder:validation:Required
+	//+kubebuilder:validation:MinLength=1
+	Backend string `json:"backend"`
+
+	// Prefix is the prefix which vault references use to refer to the vault,
+	// which must be unique across all KongVaults.
+	//+kubebuilder:validation:Required
+	//+kubebuilder:validation:Pattern=`^[a-z][a-z0-9-]*$`
+	//+kubebuilder:validation:MaxLength=253
+	Prefix string `json:"prefix"`
+
+	// Description is the description of the vault in Kong.
+	Description string `json:"description,omitempty"`
+
+	// Config contains the configuration of the vault backend.
+	//+kubebuilder:validation:Type=object
+	Config apiextensionsv1.JSON `json:"config,omitempty"`
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2022 Kong, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PolicyTargetReference identifies the object which a KongUpstreamPolicy or a
+// KongServicePolicy applies to. Only Services in the namespace of the policy
+// can be targeted.
+type PolicyTargetReference struct {
+	// Kind is the kind of the target, a Service of the core API group.
+	//+kubebuilder:validation:Required
+	//+kubebuilder:validation:Enum=Service
+	Kind string `json:"kind"`
+
+	// Name is the name of the target.
+	//+kubebuilder:validation:Required
+	//+kubebuilder:validation:MinLength=1
+	//+kubebuilder:validation:MaxLength=253
+	Name string `json:"name"`
+}
+
+// KongPolicyStatus represents the current status of a KongUpstreamPolicy or of
+// a KongServicePolicy.
+type KongPolicyStatus struct {
+	// Conditions describe the current conditions of the policy.
+	//
+	// Known condition types are:
+	//
+	// * "Accepted", which indicates whether Kong configuration could be
+	//   generated from the policy.
+	// * "Programmed", which indicates whether the data-plane accepted the
+	//   configuration generated from the policy.
+	//
+	//+listType=map
+	//+listMapKey=type
+	//+kubebuilder:validation:MaxItems=8
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the generation of the policy which the status was
+	// last updated for.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+const (
+	// PolicyConditionAccepted is the type of the condition which indicates
+	// whether Kong configuration could be generated from a policy.
+	PolicyConditionAccepted = "Accepted"
+
+	// PolicyConditionProgrammed is the type of the condition which indicates
+	// whether the configuration generated from a policy was accepted by the
+	// data-plane.
+	PolicyConditionProgrammed = "Programmed"
+
+	// PolicyReasonAccepted is the reason of the Accepted condition of policies
+	// which Kong configuration could be generated from.
+	PolicyReasonAccepted = "Accepted"
+
+	// PolicyReasonInvalid is the reason of the Accepted condition of policies
+	// which couldn't be applied, e.g. because of conflicting policies.
+	PolicyReasonInvalid = "Invalid"
+
+	// PolicyReasonProgrammed is the reason of the Programmed condition of
+	// policies configured on the data-plane.
+	PolicyReasonProgrammed = "Programmed"
+
+	// PolicyReasonConfigurationRejected is the reason of the Programmed
+	// condition of policies whose configuration was rejected by the data-plane.
+	PolicyReasonConfigurationRejected = "ConfigurationRejected"
+)
@@ -96,6 +96,17 @@ func (c *FakeKongClusterPlugins) Update(ctx context.Context, kongClusterPlugin *
 	return obj.(*configurationv1.KongClusterPlugin), err
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeKongClusterPlugins) UpdateStatus(ctx context.Context, kongClusterPlugin *configurationv1.KongClusterPlugin, opts v1.UpdateOptions) (*configurationv1.KongClusterPlugin, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(kongclusterpluginsResource, "status", kongClusterPlugin), &configurationv1.KongClusterPlugin{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*configurationv1.KongClusterPlugin), err
+}
+
 // Delete takes name of the kongClusterPlugin and deletes it. Returns an error if one occurs.
 func (c *FakeKongClusterPlugins) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	_, err := c.Fake.
@@ -102,6 +102,18 @@ func (c *FakeKongConsumers) Update(ctx context.Context, kongConsumer *configurat
 	return obj.(*configurationv1.KongConsumer), err
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeKongConsumers) UpdateStatus(ctx context.Context, kongConsumer *configurationv1.KongConsumer, opts v1.UpdateOptions) (*configurationv1.KongConsumer, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(kongconsumersResource, "status", c.ns, kongConsumer), &configurationv1.KongConsumer{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*configurationv1.KongConsumer), err
+}
+
 // Delete takes name of the kongConsumer and deletes it. Returns an error if one occurs.
 func (c *FakeKongConsumers) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	_, err := c.Fake.
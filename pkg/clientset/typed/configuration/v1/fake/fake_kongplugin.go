@@ -102,6 +102,18 @@ func (c *FakeKongPlugins) Update(ctx context.Context, kongPlugin *configurationv
 	return obj.(*configurationv1.KongPlugin), err
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeKongPlugins) UpdateStatus(ctx context.Context, kongPlugin *configurationv1.KongPlugin, opts v1.UpdateOptions) (*configurationv1.KongPlugin, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(kongpluginsResource, "status", c.ns, kongPlugin), &configurationv1.KongPlugin{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*configurationv1.KongPlugin), err
+}
+
 // Delete takes name of the kongPlugin and deletes it. Returns an error if one occurs.
 func (c *FakeKongPlugins) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	_, err := c.Fake.
@@ -40,6 +40,7 @@ type KongClusterPluginsGetter interface {
 type KongClusterPluginInterface interface {
 	Create(ctx context.Context, kongClusterPlugin *v1.KongClusterPlugin, opts metav1.CreateOptions) (*v1.KongClusterPlugin, error)
 	Update(ctx context.Context, kongClusterPlugin *v1.KongClusterPlugin, opts metav1.UpdateOptions) (*v1.KongClusterPlugin, error)
+	UpdateStatus(ctx context.Context, kongClusterPlugin *v1.KongClusterPlugin, opts metav1.UpdateOptions) (*v1.KongClusterPlugin, error)
 	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
 	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
 	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.KongClusterPlugin, error)
@@ -128,6 +129,21 @@ func (c *kongClusterPlugins) Update(ctx context.Context, kongClusterPlugin *v1.K
 	return
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *kongClusterPlugins) UpdateStatus(ctx context.Context, kongClusterPlugin *v1.KongClusterPlugin, opts metav1.UpdateOptions) (result *v1.KongClusterPlugin, err error) {
+	result = &v1.KongClusterPlugin{}
+	err = c.client.Put().
+		Resource("kongclusterplugins").
+		Name(kongClusterPlugin.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(kongClusterPlugin).
+		Do(ctx).
+		Into(result)
+	return
+}
+
 // Delete takes name of the kongClusterPlugin and deletes it. Returns an error if one occurs.
 func (c *kongClusterPlugins) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
 	return c.client.Delete().
@@ -26,6 +26,11 @@ import (
 
 type ConfigurationV1beta1Interface interface {
 	RESTClient() rest.Interface
+	GatewayConfigurationsGetter
+	KongConsumerGroupsGetter
+	KongServicePoliciesGetter
+	KongUpstreamPoliciesGetter
+	KongVaultsGetter
 	TCPIngressesGetter
 	UDPIngressesGetter
 }
@@ -35,6 +40,26 @@ type ConfigurationV1beta1Client struct {
 	restClient rest.Interface
 }
 
+func (c *ConfigurationV1beta1Client) GatewayConfigurations(namespace string) GatewayConfigurationInterface {
+	return newGatewayConfigurations(c, namespace)
+}
+
+func (c *ConfigurationV1beta1Client) KongConsumerGroups(namespace string) KongConsumerGroupInterface {
+	return newKongConsumerGroups(c, namespace)
+}
+
+func (c *ConfigurationV1beta1Client) KongServicePolicies(namespace string) KongServicePolicyInterface {
+	return newKongServicePolicies(c, namespace)
+}
+
+func (c *ConfigurationV1beta1Client) KongUpstreamPolicies(namespace string) KongUpstreamPolicyInterface {
+	return newKongUpstreamPolicies(c, namespace)
+}
+
+func (c *ConfigurationV1beta1Client) KongVaults() KongVaultInterface {
+	return newKongVaults(c)
+}
+
 func (c *ConfigurationV1beta1Client) TCPIngresses(namespace string) TCPIngressInterface {
 	return newTCPIngresses(c, namespace)
 }
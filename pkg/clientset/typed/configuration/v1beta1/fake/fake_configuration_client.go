@@ -28,6 +28,26 @@ type FakeConfigurationV1beta1 struct {
 	*testing.Fake
 }
 
+func (c *FakeConfigurationV1beta1) GatewayConfigurations(namespace string) v1beta1.GatewayConfigurationInterface {
+	return &FakeGatewayConfigurations{c, namespace}
+}
+
+func (c *FakeConfigurationV1beta1) KongConsumerGroups(namespace string) v1beta1.KongConsumerGroupInterface {
+	return &FakeKongConsumerGroups{c, namespace}
+}
+
+func (c *FakeConfigurationV1beta1) KongServicePolicies(namespace string) v1beta1.KongServicePolicyInterface {
+	return &FakeKongServicePolicies{c, namespace}
+}
+
+func (c *FakeConfigurationV1beta1) KongUpstreamPolicies(namespace string) v1beta1.KongUpstreamPolicyInterface {
+	return &FakeKongUpstreamPolicies{c, namespace}
+}
+
+func (c *FakeConfigurationV1beta1) KongVaults() v1beta1.KongVaultInterface {
+	return &FakeKongVaults{c}
+}
+
 func (c *FakeConfigurationV1beta1) TCPIngresses(namespace string) v1beta1.TCPIngressInterface {
 	return &FakeTCPIngresses{c, namespace}
 }